@@ -0,0 +1,110 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var errBlocksNoFile = errors.New("please provide a blocks file")
+
+// exportBlocksAction writes finalised blocks from the local database to the given file.
+func exportBlocksAction(ctx *cli.Context) (err error) {
+	arguments := ctx.Args()
+	if arguments.Len() != 1 {
+		return errBlocksNoFile
+	}
+
+	format := dot.BlocksFileFormat(ctx.String(BlocksFormatFlag.Name))
+	if !format.IsValid() {
+		return fmt.Errorf("--%s must be %s or %s", BlocksFormatFlag.Name, dot.BlocksFileBinary, dot.BlocksFileJSON)
+	}
+
+	cfg, err := createBlocksConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+
+	file, err := os.Create(filepath.Clean(arguments.Get(0)))
+	if err != nil {
+		return fmt.Errorf("creating blocks file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing blocks file: %w", closeErr)
+		}
+	}()
+
+	exportCfg := dot.ExportBlocksConfig{
+		BasePath: utils.ExpandDir(cfg.Global.BasePath),
+		From:     ctx.Uint(BlocksFromFlag.Name),
+		To:       ctx.Uint(BlocksToFlag.Name),
+		Format:   format,
+	}
+
+	blocks, err := dot.ExportBlocks(exportCfg, file)
+	if err != nil {
+		return fmt.Errorf("exporting blocks: %w", err)
+	}
+
+	logger.Infof("exported %d blocks to %s", blocks, file.Name())
+	return nil
+}
+
+// importBlocksAction imports the blocks of the given file into the local database.
+func importBlocksAction(ctx *cli.Context) (err error) {
+	arguments := ctx.Args()
+	if arguments.Len() != 1 {
+		return errBlocksNoFile
+	}
+
+	format := dot.BlocksFileFormat(ctx.String(BlocksFormatFlag.Name))
+	if !format.IsValid() {
+		return fmt.Errorf("--%s must be %s or %s", BlocksFormatFlag.Name, dot.BlocksFileBinary, dot.BlocksFileJSON)
+	}
+
+	cfg, err := createBlocksConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+	cfg.Global.BasePath = utils.ExpandDir(cfg.Global.BasePath)
+
+	file, err := os.Open(filepath.Clean(arguments.Get(0)))
+	if err != nil {
+		return fmt.Errorf("opening blocks file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing blocks file: %w", closeErr)
+		}
+	}()
+
+	report, err := dot.ImportBlocks(cfg, file, format)
+	if report != nil {
+		logger.Infof("imported %d blocks and skipped %d blocks already in the database",
+			report.Imported, report.Skipped)
+	}
+	if err != nil {
+		return fmt.Errorf("importing blocks: %w", err)
+	}
+
+	logger.Infof("highest finalised block number is %d and best block number is %d",
+		report.FinalisedNumber, report.BestNumber)
+	if report.BestNumber > report.FinalisedNumber {
+		logger.Warn(fmt.Sprintf("blocks after block number %d are not finalised and are not persisted",
+			report.FinalisedNumber))
+	}
+	return nil
+}
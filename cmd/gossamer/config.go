@@ -15,6 +15,7 @@
 	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -142,7 +143,10 @@ func createDotConfig(ctx *cli.Context) (*dot.Config, error) {
 	}
 	setDotRPCConfig(ctx, tomlCfg.RPC, &cfg.RPC)
 	setDotPprofConfig(ctx, tomlCfg.Pprof, &cfg.Pprof)
-	setStateConfig(ctx, tomlCfg.State, &cfg.State)
+	err = setStateConfig(ctx, tomlCfg.State, &cfg.State)
+	if err != nil {
+		return nil, err
+	}
 
 	// set system info
 	setSystemInfoConfig(ctx, cfg)
@@ -166,7 +170,7 @@ func createInitConfig(ctx *cli.Context) (*dot.Config, error) {
 	}
 
 	if !cfg.Global.Pruning.IsValid() {
-		return nil, fmt.Errorf("--%s must be %s", PruningFlag.Name, pruner.Archive)
+		return nil, fmt.Errorf("--%s must be %s or %s", PruningFlag.Name, pruner.Archive, pruner.Full)
 	}
 
 	const minRetainBlocks = uint32(512)
@@ -185,6 +189,10 @@ func createInitConfig(ctx *cli.Context) (*dot.Config, error) {
 	// set init configuration values
 	setDotInitConfig(ctx, tomlCfg.Init, &cfg.Init)
 
+	if cfg.Init.DatabaseBackend != "" && !cfg.Init.DatabaseBackend.IsValid() {
+		return nil, fmt.Errorf("--%s must be %s or %s", DatabaseBackendFlag.Name, database.Badger, database.LevelDB)
+	}
+
 	// set system info
 	setSystemInfoConfig(ctx, cfg)
 
@@ -219,6 +227,68 @@ func createImportStateConfig(ctx *cli.Context) (*dot.Config, error) {
 	return cfg, nil
 }
 
+func createDBConfig(ctx *cli.Context) (*dot.Config, error) {
+	tomlCfg, cfg, err := setupConfigFromChain(ctx)
+	if err != nil {
+		logger.Errorf("failed to set chain configuration: %s", err)
+		return nil, err
+	}
+
+	if err := setDotGlobalConfig(ctx, tomlCfg, &cfg.Global); err != nil {
+		logger.Errorf("failed to set global node configuration: %s", err)
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func createTryRuntimeConfig(ctx *cli.Context) (*dot.Config, error) {
+	tomlCfg, cfg, err := setupConfigFromChain(ctx)
+	if err != nil {
+		logger.Errorf("failed to set chain configuration: %s", err)
+		return nil, err
+	}
+
+	if err := setDotGlobalConfig(ctx, tomlCfg, &cfg.Global); err != nil {
+		logger.Errorf("failed to set global node configuration: %s", err)
+		return nil, err
+	}
+
+	if err := setLogConfig(ctx, tomlCfg, &cfg.Global, &cfg.Log); err != nil {
+		logger.Errorf("failed to set log configuration: %s", err)
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// createBlocksConfig creates the configuration to export and import blocks
+func createBlocksConfig(ctx *cli.Context) (*dot.Config, error) {
+	tomlCfg, cfg, err := setupConfigFromChain(ctx)
+	if err != nil {
+		logger.Errorf("failed to set chain configuration: %s", err)
+		return nil, err
+	}
+
+	if err := setDotGlobalConfig(ctx, tomlCfg, &cfg.Global); err != nil {
+		logger.Errorf("failed to set global node configuration: %s", err)
+		return nil, err
+	}
+
+	if err := setLogConfig(ctx, tomlCfg, &cfg.Global, &cfg.Log); err != nil {
+		logger.Errorf("failed to set log configuration: %s", err)
+		return nil, err
+	}
+
+	setDotCoreConfig(ctx, tomlCfg.Core, &cfg.Core)
+	err = setStateConfig(ctx, tomlCfg.State, &cfg.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
 func createBuildSpecConfig(ctx *cli.Context) (*dot.Config, error) {
 	tomlCfg := new(ctoml.Config)
 	err := loadConfigFile(ctx, tomlCfg)
@@ -286,6 +356,26 @@ type stringKVStore interface {
 	String(key string) (value string)
 }
 
+// parseLogTargets parses a comma separated list of `target=level`
+// directives into a map of log levels by target.
+// It returns a nil map if the string given is empty.
+func parseLogTargets(s string) (levels map[string]log.Level, err error) {
+	directives, err := log.ParseDirectives(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(directives) == 0 {
+		return nil, nil
+	}
+
+	levels = make(map[string]log.Level, len(directives))
+	for _, directive := range directives {
+		levels[directive.Target] = directive.Level
+	}
+	return levels, nil
+}
+
 // getLogLevel obtains the log level in the following order:
 // 1. Try to obtain it from the flag value corresponding to flagName.
 // 2. Try to obtain it from the TOML value given, if step 1. failed.
@@ -413,6 +503,15 @@ func setLogConfig(flagsKVStore stringKVStore, tomlConfig *ctoml.Config,
 		*levelData.levelPtr = level
 	}
 
+	targets := tomlConfig.Log.Targets
+	if flagValue := flagsKVStore.String(LogTargetFlag.Name); flagValue != "" {
+		targets = flagValue
+	}
+	logCfg.TargetLvls, err = parseLogTargets(targets)
+	if err != nil {
+		return fmt.Errorf("cannot get log target levels: %w", err)
+	}
+
 	logger.Debugf("set log configuration: --log %s global %s", flagsKVStore.String(LogFlag.Name), globalCfg.LogLvl)
 	return nil
 }
@@ -428,6 +527,15 @@ func setDotInitConfig(ctx *cli.Context, tomlCfg ctoml.InitConfig, cfg *dot.InitC
 		cfg.Genesis = genesis
 	}
 
+	if tomlCfg.DatabaseBackend != "" {
+		cfg.DatabaseBackend = database.Backend(tomlCfg.DatabaseBackend)
+	}
+
+	// check --db-backend flag and update init configuration
+	if backend := ctx.String(DatabaseBackendFlag.Name); backend != "" {
+		cfg.DatabaseBackend = database.Backend(backend)
+	}
+
 	logger.Debug("init configuration with genesis " + cfg.Genesis)
 }
 
@@ -670,10 +778,16 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	cfg.NoMDNS = tomlCfg.NoMDNS
 	cfg.MinPeers = tomlCfg.MinPeers
 	cfg.MaxPeers = tomlCfg.MaxPeers
+	cfg.MaxLightPeers = tomlCfg.MaxLightPeers
+	cfg.MaxAuthorityPeers = tomlCfg.MaxAuthorityPeers
 	cfg.PersistentPeers = tomlCfg.PersistentPeers
 	cfg.DiscoveryInterval = time.Second * time.Duration(tomlCfg.DiscoveryInterval)
 	cfg.NodeKey = tomlCfg.NodeKey
 	cfg.ListenAddress = tomlCfg.ListenAddress
+	cfg.IPFSServer = tomlCfg.IPFSServer
+	cfg.AllowNonGlobalsInDHT = tomlCfg.AllowNonGlobalsInDHT
+	cfg.MaxInboundRequestsPerSecond = tomlCfg.MaxInboundRequestsPerSecond
+	cfg.MaxConcurrentInboundRequests = tomlCfg.MaxConcurrentInboundRequests
 
 	// check --port flag and update node configuration
 	if port := ctx.Uint(PortFlag.Name); port != 0 {
@@ -725,17 +839,30 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 		cfg.ListenAddress = listenAddress
 	}
 
+	// check --ipfs-server flag and update node configuration
+	if ipfsServer := ctx.Bool(IPFSServerFlag.Name); ipfsServer {
+		cfg.IPFSServer = true
+	}
+
+	// check --allow-non-globals-in-dht flag and update node configuration
+	if ctx.Bool(AllowNonGlobalsInDHTFlag.Name) {
+		cfg.AllowNonGlobalsInDHT = true
+	}
+
 	if len(cfg.PersistentPeers) == 0 {
 		cfg.PersistentPeers = []string(nil)
 	}
 
 	logger.Debugf(
 		"network configuration: port=%d bootnodes=%s protocol=%s nobootstrap=%t "+
-			"nomdns=%t minpeers=%d maxpeers=%d persistent-peers=%s "+
-			"discovery-interval=%s",
+			"nomdns=%t minpeers=%d maxpeers=%d maxlightpeers=%d maxauthoritypeers=%d persistent-peers=%s "+
+			"discovery-interval=%s max-inbound-requests-per-second=%d max-concurrent-inbound-requests=%d "+
+			"ipfs-server=%t allow-non-globals-in-dht=%t",
 		cfg.Port, strings.Join(cfg.Bootnodes, ","), cfg.ProtocolID, cfg.NoBootstrap,
-		cfg.NoMDNS, cfg.MinPeers, cfg.MaxPeers, strings.Join(cfg.PersistentPeers, ","),
-		cfg.DiscoveryInterval,
+		cfg.NoMDNS, cfg.MinPeers, cfg.MaxPeers, cfg.MaxLightPeers, cfg.MaxAuthorityPeers,
+		strings.Join(cfg.PersistentPeers, ","), cfg.DiscoveryInterval,
+		cfg.MaxInboundRequestsPerSecond, cfg.MaxConcurrentInboundRequests,
+		cfg.IPFSServer, cfg.AllowNonGlobalsInDHT,
 	)
 	return nil
 }
@@ -976,10 +1103,35 @@ func setDotPprofConfig(ctx *cli.Context, tomlCfg ctoml.PprofConfig, cfg *dot.Ppr
 	logger.Debug("pprof configuration: " + cfg.String())
 }
 
-func setStateConfig(ctx *cli.Context, tomlCfg ctoml.StateConfig, cfg *dot.StateConfig) {
+func setStateConfig(ctx *cli.Context, tomlCfg ctoml.StateConfig, cfg *dot.StateConfig) (err error) {
 	if ctx.IsSet(RewindFlag.Name) {
 		cfg.Rewind = ctx.Uint(RewindFlag.Name)
 	} else if tomlCfg.Rewind > 0 {
 		cfg.Rewind = tomlCfg.Rewind
 	}
+
+	if ctx.IsSet(TransactionStoragePeriodFlag.Name) {
+		cfg.TransactionStoragePeriod = ctx.Uint(TransactionStoragePeriodFlag.Name)
+	} else if tomlCfg.TransactionStoragePeriod > 0 {
+		cfg.TransactionStoragePeriod = tomlCfg.TransactionStoragePeriod
+	}
+
+	if ctx.IsSet(TrieCacheSizeFlag.Name) {
+		cfg.TrieCacheSize = ctx.Uint(TrieCacheSizeFlag.Name)
+	} else if tomlCfg.TrieCacheSize > 0 {
+		cfg.TrieCacheSize = tomlCfg.TrieCacheSize
+	}
+
+	blocksPruning := tomlCfg.BlocksPruning
+	if ctx.IsSet(BlocksPruningFlag.Name) {
+		blocksPruning = ctx.String(BlocksPruningFlag.Name)
+	}
+	if blocksPruning != "" {
+		cfg.BlocksPruning, err = state.ParseBlocksPruning(blocksPruning)
+		if err != nil {
+			return fmt.Errorf("parsing --%s: %w", BlocksPruningFlag.Name, err)
+		}
+	}
+
+	return nil
 }
@@ -19,6 +19,7 @@
 	ctoml "github.com/ChainSafe/gossamer/dot/config/toml"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -97,6 +98,15 @@ func TestInitConfigFromFlags(t *testing.T) {
 				Genesis: "test_genesis",
 			},
 		},
+		{
+			"Test gossamer --db-backend",
+			[]string{"config", "genesis", "db-backend", "pruning", "retain-blocks"},
+			[]interface{}{testCfgFile, "test_genesis", "leveldb", "archive", uint32(512)},
+			dot.InitConfig{
+				Genesis:         "test_genesis",
+				DatabaseBackend: database.LevelDB,
+			},
+		},
 	}
 
 	for _, c := range testcases {
@@ -470,6 +480,20 @@ func TestNetworkConfigFromFlags(t *testing.T) {
 				ListenAddress: "/ip4/0.0.0.0/tcp/1234/ws",
 			},
 		},
+		"Test_gossamer_--ipfs-server": {
+			[]string{"app", "--ipfs-server"},
+			dot.NetworkConfig{
+				Port:       westendDevConfig.Network.Port,
+				IPFSServer: true,
+			},
+		},
+		"Test_gossamer_--allow-non-globals-in-dht": {
+			[]string{"app", "--allow-non-globals-in-dht"},
+			dot.NetworkConfig{
+				Port:                 westendDevConfig.Network.Port,
+				AllowNonGlobalsInDHT: true,
+			},
+		},
 	}
 
 	for key, c := range testcases {
@@ -1201,6 +1225,74 @@ func Test_setLogConfig(t *testing.T) {
 				FinalityGadgetLvl: log.Info,
 			},
 		},
+		"targets_flag_overrides_toml": {
+			ctx: newMockGetStringer(map[string]string{
+				LogTargetFlag.Name: "runtime::system=trace,debug",
+			}),
+			initialCfg: ctoml.Config{
+				Log: ctoml.LogConfig{
+					Targets: "sync=warn",
+				},
+			},
+			expectedCfg: ctoml.Config{
+				Global: ctoml.GlobalConfig{
+					LogLvl: log.Info.String(),
+				},
+				Log: ctoml.LogConfig{
+					Targets: "sync=warn",
+				},
+			},
+			expectedGlobalCfg: dot.GlobalConfig{
+				LogLvl: log.Info,
+			},
+			expectedLogCfg: dot.LogConfig{
+				CoreLvl:           log.Info,
+				DigestLvl:         log.Info,
+				SyncLvl:           log.Info,
+				NetworkLvl:        log.Info,
+				RPCLvl:            log.Info,
+				StateLvl:          log.Info,
+				RuntimeLvl:        log.Info,
+				BlockProducerLvl:  log.Info,
+				FinalityGadgetLvl: log.Info,
+				TargetLvls: map[string]log.Level{
+					"runtime::system": log.Trace,
+					"":                log.Debug,
+				},
+			},
+		},
+		"malformed_targets": {
+			ctx: newMockGetStringer(map[string]string{}),
+			initialCfg: ctoml.Config{
+				Log: ctoml.LogConfig{
+					Targets: "sync=loud",
+				},
+			},
+			expectedCfg: ctoml.Config{
+				Global: ctoml.GlobalConfig{
+					LogLvl: log.Info.String(),
+				},
+				Log: ctoml.LogConfig{
+					Targets: "sync=loud",
+				},
+			},
+			expectedGlobalCfg: dot.GlobalConfig{
+				LogLvl: log.Info,
+			},
+			expectedLogCfg: dot.LogConfig{
+				CoreLvl:           log.Info,
+				DigestLvl:         log.Info,
+				SyncLvl:           log.Info,
+				NetworkLvl:        log.Info,
+				RPCLvl:            log.Info,
+				StateLvl:          log.Info,
+				RuntimeLvl:        log.Info,
+				BlockProducerLvl:  log.Info,
+				FinalityGadgetLvl: log.Info,
+			},
+			err: errors.New("cannot get log target levels: " +
+				`log directive is malformed: "sync=loud": level is not recognised: loud`),
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -0,0 +1,108 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var errDBInconsistent = errors.New("database is inconsistent")
+
+// dbMigrateAction copies the node database to a new database using the
+// backend given, and replaces the node database with the new database.
+func dbMigrateAction(ctx *cli.Context) error {
+	backend := database.Backend(ctx.String(DatabaseBackendFlag.Name))
+	if !backend.IsValid() {
+		return fmt.Errorf("--%s must be %s or %s", DatabaseBackendFlag.Name, database.Badger, database.LevelDB)
+	}
+
+	cfg, err := createDBConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+	basepath := utils.ExpandDir(cfg.Global.BasePath)
+	databasePath := filepath.Join(basepath, utils.DefaultDatabaseDir)
+
+	logger.Infof("migrating database at %s to the %s backend...", databasePath, backend)
+
+	backupPath, keys, err := database.Migrate(databasePath, backend)
+	if err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+
+	logger.Infof("migrated %d keys to the %s database at %s", keys, backend, databasePath)
+	logger.Infof("the previous database is kept at %s and can be removed", backupPath)
+	return nil
+}
+
+// dbCheckAction checks the integrity of the node database, and rewinds
+// the chain to the last consistent finalised block if the repair flag
+// is set and inconsistencies are found.
+func dbCheckAction(ctx *cli.Context) error {
+	cfg, err := createDBConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+	basepath := utils.ExpandDir(cfg.Global.BasePath)
+
+	report, repaired, err := dot.CheckDatabase(basepath, ctx.Bool(DBRepairFlag.Name))
+	if report != nil {
+		writeDBCheckReport(os.Stdout, report, repaired)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(report.Issues) > 0 && !repaired {
+		return fmt.Errorf("%w: %d issues found", errDBInconsistent, len(report.Issues))
+	}
+	return nil
+}
+
+func writeDBCheckReport(w io.Writer, report *state.CheckReport, repaired bool) {
+	_, _ = fmt.Fprintf(w, "finalised block: #%d (%s)\n",
+		report.FinalisedHeader.Number, report.FinalisedHeader.Hash())
+	_, _ = fmt.Fprintf(w, "checked %d headers and %d states\n",
+		report.HeadersChecked, report.StatesChecked)
+
+	if report.LastConsistentHeader == nil {
+		_, _ = fmt.Fprintln(w, "last consistent block: none")
+	} else {
+		_, _ = fmt.Fprintf(w, "last consistent block: #%d (%s)\n",
+			report.LastConsistentHeader.Number, report.LastConsistentHeader.Hash())
+	}
+
+	if len(report.Issues) == 0 {
+		_, _ = fmt.Fprintln(w, "no issues found")
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%d issues found:\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		_, _ = fmt.Fprintf(w, "- %s\n", issue)
+	}
+
+	switch {
+	case repaired:
+		_, _ = fmt.Fprintf(w, "repaired: chain rewound to block #%d\n",
+			report.LastConsistentHeader.Number)
+	case report.Repairable:
+		_, _ = fmt.Fprintf(w, "repairable: the chain can be rewound to block #%d with --%s\n",
+			report.LastConsistentHeader.Number, DBRepairFlag.Name)
+	default:
+		_, _ = fmt.Fprintln(w, "not repairable: the issues cannot be repaired by rewinding the chain")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeDBCheckReport(t *testing.T) {
+	t.Parallel()
+
+	finalisedHeader := &types.Header{Number: 10}
+	consistentHeader := &types.Header{Number: 8}
+
+	testCases := map[string]struct {
+		report   *state.CheckReport
+		repaired bool
+		output   string
+	}{
+		"no_issues": {
+			report: &state.CheckReport{
+				FinalisedHeader:      finalisedHeader,
+				HeadersChecked:       11,
+				StatesChecked:        11,
+				LastConsistentHeader: finalisedHeader,
+			},
+			output: "finalised block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"checked 11 headers and 11 states\n" +
+				"last consistent block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"no issues found\n",
+		},
+		"no_consistent_block": {
+			report: &state.CheckReport{
+				FinalisedHeader: finalisedHeader,
+				HeadersChecked:  11,
+				StatesChecked:   11,
+				Issues:          []error{errors.New("state trie does not fully resolve")},
+			},
+			output: "finalised block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"checked 11 headers and 11 states\n" +
+				"last consistent block: none\n" +
+				"1 issues found:\n" +
+				"- state trie does not fully resolve\n" +
+				"not repairable: the issues cannot be repaired by rewinding the chain\n",
+		},
+		"metadata_issues": {
+			report: &state.CheckReport{
+				FinalisedHeader:      finalisedHeader,
+				HeadersChecked:       11,
+				StatesChecked:        11,
+				LastConsistentHeader: finalisedHeader,
+				Issues:               []error{errors.New("grandpa metadata is inconsistent")},
+			},
+			output: "finalised block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"checked 11 headers and 11 states\n" +
+				"last consistent block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"1 issues found:\n" +
+				"- grandpa metadata is inconsistent\n" +
+				"not repairable: the issues cannot be repaired by rewinding the chain\n",
+		},
+		"repairable": {
+			report: &state.CheckReport{
+				FinalisedHeader:      finalisedHeader,
+				HeadersChecked:       9,
+				StatesChecked:        9,
+				LastConsistentHeader: consistentHeader,
+				Repairable:           true,
+				Issues:               []error{errors.New("header not found")},
+			},
+			output: "finalised block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"checked 9 headers and 9 states\n" +
+				"last consistent block: #8 (" + consistentHeader.Hash().String() + ")\n" +
+				"1 issues found:\n" +
+				"- header not found\n" +
+				"repairable: the chain can be rewound to block #8 with --repair\n",
+		},
+		"repaired": {
+			report: &state.CheckReport{
+				FinalisedHeader:      finalisedHeader,
+				HeadersChecked:       9,
+				StatesChecked:        9,
+				LastConsistentHeader: consistentHeader,
+				Repairable:           true,
+				Issues:               []error{errors.New("header not found")},
+			},
+			repaired: true,
+			output: "finalised block: #10 (" + finalisedHeader.Hash().String() + ")\n" +
+				"checked 9 headers and 9 states\n" +
+				"last consistent block: #8 (" + consistentHeader.Hash().String() + ")\n" +
+				"1 issues found:\n" +
+				"- header not found\n" +
+				"repaired: chain rewound to block #8\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+
+			writeDBCheckReport(buffer, testCase.report, testCase.repaired)
+
+			assert.Equal(t, testCase.output, buffer.String())
+		})
+	}
+}
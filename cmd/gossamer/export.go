@@ -89,10 +89,12 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		RuntimeLvl:        dcfg.Log.RuntimeLvl.String(),
 		BlockProducerLvl:  dcfg.Log.BlockProducerLvl.String(),
 		FinalityGadgetLvl: dcfg.Log.FinalityGadgetLvl.String(),
+		Targets:           dcfg.Log.Targets(),
 	}
 
 	cfg.Init = ctoml.InitConfig{
-		Genesis: dcfg.Init.Genesis,
+		Genesis:         dcfg.Init.Genesis,
+		DatabaseBackend: string(dcfg.Init.DatabaseBackend),
 	}
 
 	cfg.Account = ctoml.AccountConfig{
@@ -116,6 +118,13 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		DiscoveryInterval: int(dcfg.Network.DiscoveryInterval / time.Second),
 		MinPeers:          dcfg.Network.MinPeers,
 		MaxPeers:          dcfg.Network.MaxPeers,
+		MaxLightPeers:     dcfg.Network.MaxLightPeers,
+		MaxAuthorityPeers: dcfg.Network.MaxAuthorityPeers,
+		IPFSServer:        dcfg.Network.IPFSServer,
+
+		AllowNonGlobalsInDHT:         dcfg.Network.AllowNonGlobalsInDHT,
+		MaxInboundRequestsPerSecond:  dcfg.Network.MaxInboundRequestsPerSecond,
+		MaxConcurrentInboundRequests: dcfg.Network.MaxConcurrentInboundRequests,
 	}
 
 	cfg.RPC = ctoml.RPCConfig{
@@ -36,6 +36,22 @@
 		Name:  "rewind",
 		Usage: "Rewind head of chain to the given block number",
 	}
+	// TransactionStoragePeriodFlag sets the number of finalised blocks for which indexed transaction data is kept
+	TransactionStoragePeriodFlag = cli.UintFlag{
+		Name:  "transaction-storage-period",
+		Usage: "Number of finalised blocks for which indexed transaction data is kept, 0 keeps it forever",
+	}
+	// TrieCacheSizeFlag sets the number of trie nodes cached when loading state tries lazily
+	TrieCacheSizeFlag = cli.UintFlag{
+		Name:  "trie-cache-size",
+		Usage: "Number of trie nodes cached to load state tries lazily, 0 loads state tries fully in memory",
+	}
+	// BlocksPruningFlag sets the pruning of the bodies, receipts, message queues and justifications of blocks
+	BlocksPruningFlag = cli.StringFlag{
+		Name: "blocks-pruning",
+		Usage: `Blocks pruning ("archive", "archive-canonical" or the number of finalised blocks ` +
+			`for which bodies, receipts, message queues and justifications are kept)`,
+	}
 )
 
 // Global node configuration flags
@@ -81,6 +97,12 @@
 		Name:  "log-grandpa",
 		Usage: "Grandpa package log level. Supports levels critical (silent), error, warn, info, debug and trace",
 	}
+	LogTargetFlag = cli.StringFlag{
+		Name:    "log-target",
+		Aliases: []string{"l"}, // -l is argument used by polkadot node
+		Usage: "Comma separated log levels by target, such as runtime::system=trace,sync=debug. " +
+			"Targets are package names or runtime log targets",
+	}
 
 	// NameFlag node implementation name
 	NameFlag = cli.StringFlag{
@@ -163,6 +185,11 @@
 		Name:  "genesis",
 		Usage: "Path to genesis JSON file",
 	}
+	// DatabaseBackendFlag is the database backend used to store the node state
+	DatabaseBackendFlag = cli.StringFlag{
+		Name:  "db-backend",
+		Usage: `Database backend used to store the node state ("badger", "leveldb")`,
+	}
 )
 
 // ImportState-only flags
@@ -181,6 +208,61 @@
 	}
 )
 
+// TryRuntime-only flags
+var (
+	TryRuntimeBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Hash of the block to try the runtime on the state of, defaults to the best block",
+	}
+	TryRuntimeBlocksFlag = cli.UintFlag{
+		Name:  "blocks",
+		Usage: "Number of blocks following the block to re-execute with the runtime",
+	}
+	TryRuntimeChecksFlag = cli.BoolFlag{
+		Name:  "checks",
+		Usage: "Run the pre and post runtime upgrade checks",
+	}
+)
+
+// ExportSnapshot-only flags
+var (
+	// SnapshotBlockFlag is the hash of the block to export the snapshot of
+	SnapshotBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Hash of the finalised block to export the snapshot of, defaults to the highest finalised block",
+	}
+)
+
+// ExportBlocks and ImportBlocks flags
+var (
+	// BlocksFromFlag is the number of the first block to export
+	BlocksFromFlag = cli.UintFlag{
+		Name:  "from",
+		Usage: "Number of the first block to export",
+		Value: 1,
+	}
+	// BlocksToFlag is the number of the last block to export
+	BlocksToFlag = cli.UintFlag{
+		Name:  "to",
+		Usage: "Number of the last block to export, defaults to the highest finalised block",
+	}
+	// BlocksFormatFlag is the format of the blocks file
+	BlocksFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: `Format of the blocks file ("binary", "json")`,
+		Value: "binary",
+	}
+)
+
+// DBCheck-only flags
+var (
+	// DBRepairFlag rewinds the chain to the last consistent block if inconsistencies are found
+	DBRepairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Rewind the chain to the last consistent finalised block if inconsistencies are found",
+	}
+)
+
 // BuildSpec-only flags
 var (
 	RawFlag = cli.BoolFlag{
@@ -245,6 +327,16 @@
 		Name:  "listen-addr",
 		Usage: "Listen on this multiaddress",
 	}
+	// IPFSServerFlag enables the Bitswap server for the indexed transaction data
+	IPFSServerFlag = cli.BoolFlag{
+		Name:  "ipfs-server",
+		Usage: "Serves the transaction data indexed by the runtime over the IPFS Bitswap protocol",
+	}
+	// AllowNonGlobalsInDHTFlag allows loopback and private authority addresses in the DHT
+	AllowNonGlobalsInDHTFlag = cli.BoolFlag{
+		Name:  "allow-non-globals-in-dht",
+		Usage: "Publishes and resolves loopback and private authority addresses in the DHT, for local test networks",
+	}
 )
 
 // RPC service configuration flags
@@ -365,10 +457,40 @@
 	}
 )
 
+// Key management flags
+var (
+	// KeyFileFlag is the file to write the generated node key to, or to read the node key from
+	KeyFileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "File to write the generated node key to, or to read the node key from",
+	}
+	// KeySchemeFlag is the cryptography scheme of the key
+	KeySchemeFlag = cli.StringFlag{
+		Name:  "scheme",
+		Usage: `Cryptography scheme of the key ("sr25519", "ed25519", "ecdsa")`,
+	}
+	// KeyNetworkFlag is the network prefix of the SS58 addresses
+	KeyNetworkFlag = cli.UintFlag{
+		Name:  "network",
+		Usage: "Network prefix of the SS58 addresses",
+		Value: 42,
+	}
+	// KeyPublicFlag specifies the key to inspect is a public key
+	KeyPublicFlag = cli.BoolFlag{
+		Name:  "public",
+		Usage: "Inspect a hex encoded public key instead of a secret",
+	}
+	// KeySecretFlag is the secret of the key to insert
+	KeySecretFlag = cli.StringFlag{
+		Name:  "suri",
+		Usage: "Secret of the key to insert, as a 0x prefixed hex encoded seed or a BIP39 mnemonic",
+	}
+)
+
 // State Prune flags
 var (
 	// RetainBlockNumberFlag retain number of block from latest block while pruning,
-	// valid for the use with prune-state subcommand
+	// valid for the use with prune-state subcommand and the full pruning mode
 	RetainBlockNumberFlag = cli.Uint64Flag{
 		Name:  "retain-blocks",
 		Usage: "Retain number of block from latest block while pruning",
@@ -378,7 +500,7 @@
 	// PruningFlag triggers the online pruning of historical state tries.
 	PruningFlag = cli.StringFlag{
 		Name:  "pruning",
-		Usage: `State trie online pruning ("archive")`,
+		Usage: `State trie online pruning ("archive", "full")`,
 		Value: "archive",
 	}
 )
@@ -404,6 +526,7 @@
 		&LogRuntimeLevelFlag,
 		&LogBabeLevelFlag,
 		&LogGrandpaLevelFlag,
+		&LogTargetFlag,
 		&NameFlag,
 		&ChainFlag,
 		&ConfigFlag,
@@ -413,6 +536,9 @@
 		&PprofBlockRateFlag,
 		&PprofMutexRateFlag,
 		&RewindFlag,
+		&TransactionStoragePeriodFlag,
+		&TrieCacheSizeFlag,
+		&BlocksPruningFlag,
 	}
 
 	// StartupFlags are flags that are valid for use with the root command and the export subcommand
@@ -432,6 +558,8 @@
 		&PublicDNSFlag,
 		&NodeKeyFlag,
 		&ListenAddressFlag,
+		&IPFSServerFlag,
+		&AllowNonGlobalsInDHTFlag,
 
 		// rpc flags
 		&RPCEnabledFlag,
@@ -464,12 +592,13 @@
 // local flag sets for the root gossamer command and all subcommands
 var (
 	// RootFlags are the flags that are valid for use with the root gossamer command
-	RootFlags = append(append(GlobalFlags, StartupFlags...), &GenesisFlag)
+	RootFlags = append(append(GlobalFlags, StartupFlags...), &GenesisFlag, &DatabaseBackendFlag)
 
 	// InitFlags are flags that are valid for use with the init subcommand
 	InitFlags = append([]cli.Flag{
 		&ForceFlag,
 		&GenesisFlag,
+		&DatabaseBackendFlag,
 		&PruningFlag,
 		&RetainBlockNumberFlag,
 	}, GlobalFlags...)
@@ -507,6 +636,65 @@
 		&FirstSlotFlag,
 	}
 
+	TryRuntimeFlags = append([]cli.Flag{
+		&TryRuntimeBlockFlag,
+		&TryRuntimeBlocksFlag,
+		&TryRuntimeChecksFlag,
+	}, GlobalFlags...)
+
+	ExportBlocksFlags = append([]cli.Flag{
+		&BlocksFromFlag,
+		&BlocksToFlag,
+		&BlocksFormatFlag,
+	}, GlobalFlags...)
+
+	ImportBlocksFlags = append([]cli.Flag{
+		&BlocksFormatFlag,
+	}, GlobalFlags...)
+
+	ExportSnapshotFlags = []cli.Flag{
+		&BasePathFlag,
+		&ChainFlag,
+		&ConfigFlag,
+		&SnapshotBlockFlag,
+	}
+
+	ImportSnapshotFlags = []cli.Flag{
+		&BasePathFlag,
+		&ChainFlag,
+		&ConfigFlag,
+	}
+
+	DBMigrateFlags = []cli.Flag{
+		&BasePathFlag,
+		&ChainFlag,
+		&ConfigFlag,
+		&DatabaseBackendFlag,
+	}
+
+	DBCheckFlags = []cli.Flag{
+		&BasePathFlag,
+		&ChainFlag,
+		&ConfigFlag,
+		&DBRepairFlag,
+	}
+
+	KeyNodeKeyFlags = []cli.Flag{
+		&KeyFileFlag,
+	}
+
+	KeyInspectFlags = []cli.Flag{
+		&KeySchemeFlag,
+		&KeyNetworkFlag,
+		&KeyPublicFlag,
+	}
+
+	KeyInsertFlags = append([]cli.Flag{
+		&KeySecretFlag,
+		&KeySchemeFlag,
+		&PasswordFlag,
+	}, GlobalFlags...)
+
 	PruningFlags = []cli.Flag{
 		&ChainFlag,
 		&ConfigFlag,
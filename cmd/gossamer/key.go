@@ -0,0 +1,364 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/urfave/cli/v2"
+)
+
+// ecdsaScheme is the name of the secp256k1 key scheme used by substrate tooling.
+const ecdsaScheme = "ecdsa"
+
+var (
+	errNodeKeyMissing   = errors.New("node key must be given as argument or with --file")
+	errNodeKeyLength    = errors.New("node key must be 32 or 64 bytes long")
+	errKeyMissing       = errors.New("key must be given as argument")
+	errSecretDerivation = errors.New("secret key derivation paths are not supported")
+	errInvalidKeyScheme = errors.New("invalid key scheme")
+	errSecretMissing    = errors.New("--suri must be set")
+)
+
+// keyGenerateNodeKeyAction generates a new ed25519 node key. The secret seed of the key,
+// as accepted by --node-key, is written to stdout, and the peer ID of the key to stderr.
+// If the file flag is set, the key is written to the file in the format of the node key
+// file of the base path instead.
+func keyGenerateNodeKeyAction(ctx *cli.Context) error {
+	privateKey, _, err := libp2pcrypto.GenerateEd25519Key(crand.Reader)
+	if err != nil {
+		return fmt.Errorf("generating node key: %w", err)
+	}
+
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("getting peer id from node key: %w", err)
+	}
+
+	raw, err := privateKey.Raw()
+	if err != nil {
+		return fmt.Errorf("getting raw node key: %w", err)
+	}
+
+	if file := ctx.String(KeyFileFlag.Name); file != "" {
+		err = os.WriteFile(filepath.Clean(file), []byte(hex.EncodeToString(raw)), 0600)
+		if err != nil {
+			return fmt.Errorf("writing node key file: %w", err)
+		}
+	} else {
+		_, _ = fmt.Fprintln(os.Stdout, hex.EncodeToString(raw[:ed25519.SeedLength]))
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, peerID)
+	return nil
+}
+
+// keyInspectNodeKeyAction prints the peer ID of the node key given
+// as argument, or read from the file given with the file flag.
+func keyInspectNodeKeyAction(ctx *cli.Context) error {
+	encodedKey := ctx.Args().First()
+	if file := ctx.String(KeyFileFlag.Name); file != "" {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return fmt.Errorf("reading node key file: %w", err)
+		}
+		encodedKey = string(data)
+	}
+
+	if encodedKey == "" {
+		return errNodeKeyMissing
+	}
+
+	peerID, err := nodeKeyPeerID(encodedKey)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, peerID)
+	return nil
+}
+
+// nodeKeyPeerID returns the peer ID of the hex encoded ed25519 node key given, which is
+// either a 32 bytes seed as given to --node-key, or a 64 bytes private key as stored
+// in the node key file of the base path.
+func nodeKeyPeerID(encodedKey string) (peer.ID, error) {
+	encodedKey = strings.TrimPrefix(strings.TrimSpace(encodedKey), "0x")
+	keyBytes, err := hex.DecodeString(encodedKey)
+	if err != nil {
+		return "", fmt.Errorf("decoding hex node key: %w", err)
+	}
+
+	switch len(keyBytes) {
+	case ed25519.SeedLength:
+		keypair, err := ed25519.NewKeypairFromSeed(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("creating keypair from node key seed: %w", err)
+		}
+		keyBytes = keypair.Private().Encode()
+	case ed25519.PrivateKeyLength:
+	default:
+		return "", fmt.Errorf("%w: got %d bytes", errNodeKeyLength, len(keyBytes))
+	}
+
+	privateKey, err := libp2pcrypto.UnmarshalEd25519PrivateKey(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("decoding node key: %w", err)
+	}
+
+	return peer.IDFromPrivateKey(privateKey)
+}
+
+// keyInspectAction prints the public key, account ID and SS58 addresses of the
+// secret seed or mnemonic given as argument, or of the public key given as argument
+// if the public flag is set.
+func keyInspectAction(ctx *cli.Context) error {
+	key := ctx.Args().First()
+	if key == "" {
+		return errKeyMissing
+	}
+
+	scheme := ctx.String(KeySchemeFlag.Name)
+	if scheme == "" {
+		scheme = crypto.Sr25519Type
+	}
+
+	keyType, err := keyTypeFromScheme(scheme)
+	if err != nil {
+		return err
+	}
+
+	var inspection *keyInspection
+	if ctx.Bool(KeyPublicFlag.Name) {
+		inspection, err = inspectPublicKey(keyType, key, uint16(ctx.Uint(KeyNetworkFlag.Name)))
+	} else {
+		inspection, err = inspectSecret(keyType, key, uint16(ctx.Uint(KeyNetworkFlag.Name)))
+	}
+	if err != nil {
+		return err
+	}
+
+	writeKeyInspection(os.Stdout, inspection)
+	return nil
+}
+
+// keyInsertAction inserts the key of the secret seed or mnemonic given into
+// the keystore directory of the base path, encrypted with the keystore password.
+// The key type is not stored with the key, so the key is only loaded at startup
+// if it is unlocked with --unlock, like the keys of the account command.
+func keyInsertAction(ctx *cli.Context) error {
+	secret := ctx.String(KeySecretFlag.Name)
+	if secret == "" {
+		return errSecretMissing
+	}
+
+	scheme := ctx.String(KeySchemeFlag.Name)
+	if scheme == "" {
+		scheme = crypto.Sr25519Type
+	}
+
+	keyType, err := keyTypeFromScheme(scheme)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := createDBConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+	basepath := utils.ExpandDir(cfg.Global.BasePath)
+	if err = os.MkdirAll(basepath, os.ModePerm); err != nil {
+		return fmt.Errorf("creating base path: %w", err)
+	}
+
+	keypair, err := keypairFromSecret(keyType, secret)
+	if err != nil {
+		return err
+	}
+
+	file, err := keystore.GenerateKeypair(keyType, keypair, basepath, getKeystorePassword(ctx))
+	if err != nil {
+		return fmt.Errorf("inserting key in keystore: %w", err)
+	}
+
+	index, err := keystoreFileIndex(basepath, file)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("inserted %s key %s in keystore file %s, unlock it at startup with --unlock %d",
+		keyType, keypair.Public().Hex(), file, index)
+	return nil
+}
+
+// keystoreFileIndex returns the index of the keystore file given
+// in the keystore directory, as expected by the --unlock flag.
+func keystoreFileIndex(basepath, file string) (index int, err error) {
+	files, err := utils.KeystoreFiles(basepath)
+	if err != nil {
+		return 0, fmt.Errorf("listing keystore files: %w", err)
+	}
+
+	for i, keystoreFile := range files {
+		if keystoreFile == filepath.Base(file) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("keystore file %s not found", file)
+}
+
+// keyTypeFromScheme returns the key type of the substrate key scheme given.
+func keyTypeFromScheme(scheme string) (crypto.KeyType, error) {
+	switch scheme {
+	case crypto.Sr25519Type, crypto.Ed25519Type:
+		return scheme, nil
+	case ecdsaScheme:
+		return crypto.Secp256k1Type, nil
+	default:
+		return "", fmt.Errorf("%w: %q, must be %s, %s or %s",
+			errInvalidKeyScheme, scheme, crypto.Sr25519Type, crypto.Ed25519Type, ecdsaScheme)
+	}
+}
+
+// keypairFromSecret returns the keypair of the key type given from its
+// secret, being either a 0x prefixed hex encoded seed or a BIP39 mnemonic.
+func keypairFromSecret(keyType crypto.KeyType, secret string) (keystore.PublicPrivater, error) {
+	if strings.Contains(secret, "/") {
+		return nil, errSecretDerivation
+	}
+
+	if strings.HasPrefix(secret, "0x") {
+		seed, err := common.HexToBytes(secret)
+		if err != nil {
+			return nil, fmt.Errorf("decoding hex secret seed: %w", err)
+		}
+
+		switch keyType {
+		case crypto.Sr25519Type:
+			return sr25519.NewKeypairFromSeed(seed)
+		case crypto.Ed25519Type:
+			return ed25519.NewKeypairFromSeed(seed)
+		default:
+			return secp256k1.NewKeypairFromPrivateKeyString(secret)
+		}
+	}
+
+	switch keyType {
+	case crypto.Sr25519Type:
+		return sr25519.NewKeypairFromMnenomic(secret, "")
+	case crypto.Ed25519Type:
+		return ed25519.NewKeypairFromMnenomic(secret, "")
+	default:
+		return secp256k1.NewKeypairFromMnemonic(secret, "")
+	}
+}
+
+// keyInspection is the description of a key printed by the key inspect command.
+type keyInspection struct {
+	secret        string
+	mnemonic      bool
+	networkID     uint16
+	publicKey     []byte
+	accountID     []byte
+	publicKeySS58 common.Address
+	ss58Address   common.Address
+}
+
+func inspectSecret(keyType crypto.KeyType, secret string, networkID uint16) (*keyInspection, error) {
+	keypair, err := keypairFromSecret(keyType, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection, err := inspectPublicKeyBytes(keyType, keypair.Public().Encode(), networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection.secret = secret
+	inspection.mnemonic = !strings.HasPrefix(secret, "0x")
+	return inspection, nil
+}
+
+func inspectPublicKey(keyType crypto.KeyType, publicKeyHex string, networkID uint16) (*keyInspection, error) {
+	publicKey, err := common.HexToBytes(publicKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex public key: %w", err)
+	}
+
+	switch keyType {
+	case crypto.Sr25519Type:
+		_, err = sr25519.NewPublicKey(publicKey)
+	case crypto.Ed25519Type:
+		_, err = ed25519.NewPublicKey(publicKey)
+	default:
+		err = new(secp256k1.PublicKey).Decode(publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s public key: %w", keyType, err)
+	}
+
+	return inspectPublicKeyBytes(keyType, publicKey, networkID)
+}
+
+func inspectPublicKeyBytes(keyType crypto.KeyType, publicKey []byte, networkID uint16) (
+	inspection *keyInspection, err error) {
+	// the account ID of ecdsa keys is the hash of their compressed public key
+	accountID := publicKey
+	if keyType == crypto.Secp256k1Type {
+		hash, err := common.Blake2bHash(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("hashing public key: %w", err)
+		}
+		accountID = hash.ToBytes()
+	}
+
+	publicKeySS58, err := crypto.SS58Address(networkID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ss58Address, err := crypto.SS58Address(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyInspection{
+		networkID:     networkID,
+		publicKey:     publicKey,
+		accountID:     accountID,
+		publicKeySS58: publicKeySS58,
+		ss58Address:   ss58Address,
+	}, nil
+}
+
+func writeKeyInspection(w io.Writer, inspection *keyInspection) {
+	if inspection.mnemonic {
+		_, _ = fmt.Fprintf(w, "Secret phrase:       %s\n", inspection.secret)
+	} else if inspection.secret != "" {
+		_, _ = fmt.Fprintf(w, "Secret seed:         %s\n", inspection.secret)
+	}
+	_, _ = fmt.Fprintf(w, "  Network ID:        %d\n", inspection.networkID)
+	_, _ = fmt.Fprintf(w, "  Public key (hex):  %s\n", common.BytesToHex(inspection.publicKey))
+	_, _ = fmt.Fprintf(w, "  Account ID:        %s\n", common.BytesToHex(inspection.accountID))
+	_, _ = fmt.Fprintf(w, "  Public key (SS58): %s\n", inspection.publicKeySS58)
+	_, _ = fmt.Fprintf(w, "  SS58 Address:      %s\n", inspection.ss58Address)
+}
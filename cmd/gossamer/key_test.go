@@ -0,0 +1,227 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nodeKeyPeerID(t *testing.T) {
+	t.Parallel()
+
+	const seed = "93ce444331ced4d2f7bfb8296267544e20c2591dbf310c7ea3af672f2879cf8f"
+	expectedPeerID, err := peer.Decode("12D3KooWMER5iow67nScpWeVqEiRRx59PJ3xMMAYPTACYPRQbbWU")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		encodedKey string
+		peerID     peer.ID
+		errWrapped error
+		errMessage string
+	}{
+		"seed": {
+			encodedKey: seed,
+			peerID:     expectedPeerID,
+		},
+		"prefixed_seed_with_new_line": {
+			encodedKey: "0x" + seed + "\n",
+			peerID:     expectedPeerID,
+		},
+		"node_key_file": {
+			encodedKey: seed + "a999ca3052b725123c2939085e3158dba26624fcfd05c361b1adcc8b27785e35",
+			peerID:     expectedPeerID,
+		},
+		"invalid_hex": {
+			encodedKey: "zz",
+			errMessage: "decoding hex node key: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		"invalid_length": {
+			encodedKey: "0102",
+			errWrapped: errNodeKeyLength,
+			errMessage: "node key must be 32 or 64 bytes long: got 2 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			peerID, err := nodeKeyPeerID(testCase.encodedKey)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			assert.Equal(t, testCase.peerID, peerID)
+		})
+	}
+}
+
+func Test_keyTypeFromScheme(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		scheme     string
+		keyType    crypto.KeyType
+		errWrapped error
+	}{
+		"sr25519": {
+			scheme:  "sr25519",
+			keyType: crypto.Sr25519Type,
+		},
+		"ed25519": {
+			scheme:  "ed25519",
+			keyType: crypto.Ed25519Type,
+		},
+		"ecdsa": {
+			scheme:  "ecdsa",
+			keyType: crypto.Secp256k1Type,
+		},
+		"invalid": {
+			scheme:     "secp256k1",
+			errWrapped: errInvalidKeyScheme,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			keyType, err := keyTypeFromScheme(testCase.scheme)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.keyType, keyType)
+		})
+	}
+}
+
+func Test_inspectSecret(t *testing.T) {
+	t.Parallel()
+
+	// alice's secret seed and mnemonic from substrate
+	const aliceSeed = "0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a"
+	const mnemonic = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+	testCases := map[string]struct {
+		keyType    crypto.KeyType
+		secret     string
+		networkID  uint16
+		inspection *keyInspection
+		errWrapped error
+	}{
+		"sr25519_seed": {
+			keyType:   crypto.Sr25519Type,
+			secret:    aliceSeed,
+			networkID: 42,
+			inspection: &keyInspection{
+				secret:        aliceSeed,
+				networkID:     42,
+				publicKey:     common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"),
+				accountID:     common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"),
+				publicKeySS58: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+				ss58Address:   "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+			},
+		},
+		"ecdsa_mnemonic": {
+			keyType: crypto.Secp256k1Type,
+			secret:  mnemonic,
+			inspection: &keyInspection{
+				secret:        mnemonic,
+				mnemonic:      true,
+				publicKey:     common.MustHexToBytes("0x035b26108e8b97479c547da4860d862dc08ab2c29ada449c74d5a9a58a6c46a8c4"),
+				accountID:     common.MustHexToBytes("0xbc9539b36a87a586b1aa20fbe23a1db3ef3edcd65b44a2dc4444cc552687633f"),
+				publicKeySS58: "1LRVSYx1U9pXLtxpW5ubc9qAa447ovsaDZW2TefNfYAeBd3G",
+				ss58Address:   "15GGLEFfp6jVHGi5oxsMs6iTajnkRcAffp8z3qpTcJDuq8vN",
+			},
+		},
+		"derivation_path": {
+			keyType:    crypto.Sr25519Type,
+			secret:     mnemonic + "//Alice",
+			errWrapped: errSecretDerivation,
+		},
+		"network_id_too_high": {
+			keyType:    crypto.Ed25519Type,
+			secret:     aliceSeed,
+			networkID:  1 << 14,
+			errWrapped: crypto.ErrInvalidSS58Prefix,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inspection, err := inspectSecret(testCase.keyType, testCase.secret, testCase.networkID)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.inspection, inspection)
+		})
+	}
+}
+
+func Test_writeKeyInspection(t *testing.T) {
+	t.Parallel()
+
+	inspection, err := inspectPublicKey(crypto.Ed25519Type,
+		"0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", 0)
+	require.NoError(t, err)
+
+	buffer := bytes.NewBuffer(nil)
+	writeKeyInspection(buffer, inspection)
+
+	const expected = "  Network ID:        0\n" +
+		"  Public key (hex):  0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee\n" +
+		"  Account ID:        0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee\n" +
+		"  Public key (SS58): 146SvjUZXoMaemdeiecyxgALeYMm8ZWh1yrGo8RtpoPfe7WL\n" +
+		"  SS58 Address:      146SvjUZXoMaemdeiecyxgALeYMm8ZWh1yrGo8RtpoPfe7WL\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+// TestKeyInsert test "gossamer key insert"
+func TestKeyInsert(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "node")
+
+	const seed = "0xabe8a0a4bd8ad23d2a6a4aeca5c8e5e8a8a4b8c33e9ddd1d0e7c4b79cf5c5b1e"
+	const publicKey = "91df4b0741d620a351cddacc20428f5254cf5edfdb695e3282ec6de5717b02b6"
+
+	err := app.Run([]string{"irrelevant", "key", "insert", "--basepath", basePath,
+		"--scheme", "ed25519", "--suri", seed, "--password", "password"})
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(basePath, "keystore", publicKey+".key")
+	require.FileExists(t, keyFile)
+
+	privateKey, err := keystore.ReadFromFileAndDecrypt(keyFile, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, seed, privateKey.Hex()[:len(seed)])
+
+	index, err := keystoreFileIndex(basePath, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, 0, index)
+
+	err = app.Run([]string{"irrelevant", "key", "insert", "--basepath", basePath,
+		"--scheme", "xxxx", "--suri", seed, "--password", "password"})
+	require.ErrorIs(t, err, errInvalidKeyScheme)
+
+	entries, err := os.ReadDir(filepath.Join(basePath, "keystore"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -21,13 +21,26 @@
 )
 
 const (
-	accountCommandName       = "account"
-	exportCommandName        = "export"
-	initCommandName          = "init"
-	buildSpecCommandName     = "build-spec"
-	importRuntimeCommandName = "import-runtime"
-	importStateCommandName   = "import-state"
-	pruningStateCommandName  = "prune-state"
+	accountCommandName        = "account"
+	exportCommandName         = "export"
+	initCommandName           = "init"
+	buildSpecCommandName      = "build-spec"
+	importRuntimeCommandName  = "import-runtime"
+	importStateCommandName    = "import-state"
+	pruningStateCommandName   = "prune-state"
+	tryRuntimeCommandName     = "try-runtime"
+	exportBlocksCommandName   = "export-blocks"
+	importBlocksCommandName   = "import-blocks"
+	exportSnapshotCommandName = "export-snapshot"
+	importSnapshotCommandName = "import-snapshot"
+	dbCommandName             = "db"
+	dbMigrateCommandName      = "migrate"
+	dbCheckCommandName        = "check"
+	keyCommandName            = "key"
+	keyGenerateNodeKeyName    = "generate-node-key"
+	keyInspectNodeKeyName     = "inspect-node-key"
+	keyInspectName            = "inspect"
+	keyInsertName             = "insert"
 )
 
 // app is the cli application
@@ -114,6 +127,173 @@
 			"\tUsage: gossamer import-state --state state.json --header header.json --first-slot <first slot of network>\n",
 	}
 
+	tryRuntimeCommand = cli.Command{
+		Action:    FixFlagOrder(tryRuntimeAction),
+		Name:      tryRuntimeCommandName,
+		Usage:     "Dry-run a runtime upgrade against the state of a block from the local database",
+		ArgsUsage: "<runtime.wasm>",
+		Flags:     TryRuntimeFlags,
+		Category:  "TRY-RUNTIME",
+		Description: "The try-runtime command loads the state of a block from the local database, " +
+			"replaces its runtime code with the given .wasm runtime binary, and calls Core_version, " +
+			"Metadata_metadata and TryRuntime_on_runtime_upgrade on it.\n" +
+			"The runtime must be built with the try-runtime feature for the upgrade to be tried.\n" +
+			"Using --blocks, the blocks following the block are re-executed on top of its state with the runtime.\n" +
+			"\tUsage: gossamer try-runtime --chain westend --block 0x... --blocks 10 --checks runtime.wasm\n",
+	}
+
+	exportBlocksCommand = cli.Command{
+		Action:    FixFlagOrder(exportBlocksAction),
+		Name:      exportBlocksCommandName,
+		Usage:     "Export finalised blocks from the local database to a file",
+		ArgsUsage: "<file>",
+		Flags:     ExportBlocksFlags,
+		Category:  "BLOCKS",
+		Description: "The export-blocks command writes the finalised blocks in the given range, " +
+			"with their justifications, from the local database to the given file.\n" +
+			"The file format is either binary, with each block SCALE encoded, or json.\n" +
+			"\tUsage: gossamer export-blocks --chain westend --from 1 --to 1000 --format binary blocks.bin\n",
+	}
+
+	importBlocksCommand = cli.Command{
+		Action:    FixFlagOrder(importBlocksAction),
+		Name:      importBlocksCommandName,
+		Usage:     "Import blocks from a file into the local database",
+		ArgsUsage: "<file>",
+		Flags:     ImportBlocksFlags,
+		Category:  "BLOCKS",
+		Description: "The import-blocks command reads blocks written by export-blocks from the given file, " +
+			"and verifies, executes and imports them as if they were synced from the network.\n" +
+			"Blocks are only persisted once finalised by a justification.\n" +
+			"\tUsage: gossamer import-blocks --chain westend --format binary blocks.bin\n",
+	}
+
+	exportSnapshotCommand = cli.Command{
+		Action:    FixFlagOrder(exportSnapshotAction),
+		Name:      exportSnapshotCommandName,
+		Usage:     "Export a snapshot of a finalised block state to a file",
+		ArgsUsage: "<file>",
+		Flags:     ExportSnapshotFlags,
+		Category:  "SNAPSHOT",
+		Description: "The export-snapshot command writes a compressed snapshot of a finalised block to the given file.\n" +
+			"The snapshot contains the block state trie nodes, header and justification, " +
+			"and the BABE epoch and GRANDPA authority set data required to continue the chain from the block.\n" +
+			"\tUsage: gossamer export-snapshot --chain westend --block <hash> snapshot.gz\n",
+	}
+
+	importSnapshotCommand = cli.Command{
+		Action:    FixFlagOrder(importSnapshotAction),
+		Name:      importSnapshotCommandName,
+		Usage:     "Import a snapshot written by export-snapshot into the local database",
+		ArgsUsage: "<file>",
+		Flags:     ImportSnapshotFlags,
+		Category:  "SNAPSHOT",
+		Description: "The import-snapshot command imports a snapshot written by export-snapshot " +
+			"into the database of a node initialised with the same chain.\n" +
+			"The snapshot block becomes the highest finalised block of the node, " +
+			"which then produces and finalises blocks from it.\n" +
+			"\tUsage: gossamer import-snapshot --chain westend snapshot.gz\n",
+	}
+
+	dbCommand = cli.Command{
+		Name:     dbCommandName,
+		Usage:    "Manage the node database",
+		Category: "DB",
+		Subcommands: []*cli.Command{
+			&dbMigrateCommand,
+			&dbCheckCommand,
+		},
+	}
+
+	dbMigrateCommand = cli.Command{
+		Action:    FixFlagOrder(dbMigrateAction),
+		Name:      dbMigrateCommandName,
+		Usage:     "Copy the node database to another database backend",
+		ArgsUsage: "",
+		Flags:     DBMigrateFlags,
+		Description: "The db migrate command copies all the keys of the node database to a new database " +
+			"using the given backend, and replaces the node database with it.\n" +
+			"The previous database is kept next to the node database directory.\n" +
+			"\tUsage: gossamer db migrate --chain westend --db-backend leveldb\n",
+	}
+
+	dbCheckCommand = cli.Command{
+		Action:    FixFlagOrder(dbCheckAction),
+		Name:      dbCheckCommandName,
+		Usage:     "Check the integrity of the node database",
+		ArgsUsage: "",
+		Flags:     DBCheckFlags,
+		Description: "The db check command walks the finalised headers verifying each header hash and " +
+			"parent hash, verifies the state tries of the retained finalised blocks fully resolve, " +
+			"and checks the epoch and grandpa metadata are consistent.\n" +
+			"With --repair, the chain is rewound to the last consistent finalised block " +
+			"if inconsistencies are found.\n" +
+			"\tUsage: gossamer db check --chain westend --repair\n",
+	}
+
+	keyCommand = cli.Command{
+		Name:     keyCommandName,
+		Usage:    "Generate, inspect and insert keys",
+		Category: "KEY",
+		Subcommands: []*cli.Command{
+			&keyGenerateNodeKeyCommand,
+			&keyInspectNodeKeyCommand,
+			&keyInspectCommand,
+			&keyInsertCommand,
+		},
+	}
+
+	keyGenerateNodeKeyCommand = cli.Command{
+		Action:    FixFlagOrder(keyGenerateNodeKeyAction),
+		Name:      keyGenerateNodeKeyName,
+		Usage:     "Generate a random node key and print its peer id",
+		ArgsUsage: "",
+		Flags:     KeyNodeKeyFlags,
+		Description: "The key generate-node-key command generates a random ed25519 node key.\n" +
+			"The secret seed of the key, as accepted by --node-key, is printed to stdout " +
+			"and the peer id of the key is printed to stderr.\n" +
+			"With --file, the key is written to the file in the format of the node.key file of the base path.\n" +
+			"\tUsage: gossamer key generate-node-key --file ~/.gossamer/westend/node.key\n",
+	}
+
+	keyInspectNodeKeyCommand = cli.Command{
+		Action:    FixFlagOrder(keyInspectNodeKeyAction),
+		Name:      keyInspectNodeKeyName,
+		Usage:     "Print the peer id of a node key",
+		ArgsUsage: "[node key]",
+		Flags:     KeyNodeKeyFlags,
+		Description: "The key inspect-node-key command prints the peer id of the hex encoded node key given " +
+			"as argument, or read from the file given with --file.\n" +
+			"The node key is either a secret seed as given to --node-key, or the content of a node.key file.\n" +
+			"\tUsage: gossamer key inspect-node-key --file ~/.gossamer/westend/node.key\n",
+	}
+
+	keyInspectCommand = cli.Command{
+		Action:    FixFlagOrder(keyInspectAction),
+		Name:      keyInspectName,
+		Usage:     "Print the public key and SS58 addresses of a key",
+		ArgsUsage: "<secret or public key>",
+		Flags:     KeyInspectFlags,
+		Description: "The key inspect command prints the public key, account id and SS58 addresses " +
+			"of the key of the 0x prefixed hex encoded secret seed or BIP39 mnemonic given.\n" +
+			"With --public, the key given is a hex encoded public key.\n" +
+			"\tUsage: gossamer key inspect --scheme ed25519 --network 0 0x...\n",
+	}
+
+	keyInsertCommand = cli.Command{
+		Action:    FixFlagOrder(keyInsertAction),
+		Name:      keyInsertName,
+		Usage:     "Insert a key into the keystore of the node",
+		ArgsUsage: "",
+		Flags:     KeyInsertFlags,
+		Description: "The key insert command inserts the key of the secret seed or BIP39 mnemonic given " +
+			"with --suri into the keystore directory of the base path, encrypted with the keystore password.\n" +
+			"The key scheme defaults to sr25519, and ed25519 must be used for grandpa keys.\n" +
+			"The key is not loaded automatically: it must be unlocked at startup with --unlock, " +
+			"using the index printed, and --password.\n" +
+			"\tUsage: gossamer key insert --chain westend --scheme ed25519 --suri 0x...\n",
+	}
+
 	pruningCommand = cli.Command{
 		Action:    FixFlagOrder(pruneState),
 		Name:      pruningStateCommandName,
@@ -143,6 +323,13 @@ func init() {
 		&importRuntimeCommand,
 		&importStateCommand,
 		&pruningCommand,
+		&tryRuntimeCommand,
+		&exportBlocksCommand,
+		&importBlocksCommand,
+		&exportSnapshotCommand,
+		&importSnapshotCommand,
+		&dbCommand,
+		&keyCommand,
 	}
 	app.Flags = RootFlags
 }
@@ -320,6 +507,11 @@ func loadBuiltInTestKeys(accountKey string, ks keystore.GlobalKeystore) (err err
 		return fmt.Errorf("loading grandpa keystore: %w", err)
 	}
 
+	err = keystore.LoadKeystore(accountKey, ks.Audi, sr25519keyRing)
+	if err != nil {
+		return fmt.Errorf("loading authority discovery keystore: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,95 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var errSnapshotNoFile = errors.New("please provide a snapshot file")
+
+// exportSnapshotAction writes a snapshot of a finalised block
+// from the local database to the given file.
+func exportSnapshotAction(ctx *cli.Context) (err error) {
+	arguments := ctx.Args()
+	if arguments.Len() != 1 {
+		return errSnapshotNoFile
+	}
+
+	var blockHash common.Hash
+	if hexHash := ctx.String(SnapshotBlockFlag.Name); hexHash != "" {
+		blockHash, err = common.HexToHash(hexHash)
+		if err != nil {
+			return fmt.Errorf("parsing block hash: %w", err)
+		}
+	}
+
+	cfg, err := createDBConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+
+	file, err := os.Create(filepath.Clean(arguments.Get(0)))
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing snapshot file: %w", closeErr)
+		}
+	}()
+
+	header, err := dot.ExportSnapshot(utils.ExpandDir(cfg.Global.BasePath), blockHash, file)
+	if err != nil {
+		return fmt.Errorf("exporting snapshot: %w", err)
+	}
+
+	logger.Infof("exported snapshot of block number %d with hash %s to %s",
+		header.Number, header.Hash(), file.Name())
+	return nil
+}
+
+// importSnapshotAction imports the snapshot of the given file into the local database.
+func importSnapshotAction(ctx *cli.Context) (err error) {
+	arguments := ctx.Args()
+	if arguments.Len() != 1 {
+		return errSnapshotNoFile
+	}
+
+	cfg, err := createDBConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+
+	file, err := os.Open(filepath.Clean(arguments.Get(0)))
+	if err != nil {
+		return fmt.Errorf("opening snapshot file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing snapshot file: %w", closeErr)
+		}
+	}()
+
+	header, err := dot.ImportSnapshot(utils.ExpandDir(cfg.Global.BasePath), file)
+	if err != nil {
+		return fmt.Errorf("importing snapshot: %w", err)
+	}
+
+	logger.Infof("imported snapshot of block number %d with hash %s, "+
+		"which is now the highest finalised block", header.Number, header.Hash())
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var errTryRuntimeNoWasm = errors.New("please provide a wasm runtime file")
+
+// tryRuntimeAction dry-runs a runtime upgrade using the given .wasm runtime
+// binary against the state of a block from the local database.
+func tryRuntimeAction(ctx *cli.Context) error {
+	arguments := ctx.Args()
+	if arguments.Len() != 1 {
+		return errTryRuntimeNoWasm
+	}
+
+	code, err := os.ReadFile(filepath.Clean(arguments.Get(0)))
+	if err != nil {
+		return fmt.Errorf("reading wasm runtime file: %w", err)
+	}
+
+	var blockHash common.Hash
+	if hexHash := ctx.String(TryRuntimeBlockFlag.Name); hexHash != "" {
+		blockHash, err = common.HexToHash(hexHash)
+		if err != nil {
+			return fmt.Errorf("parsing block hash: %w", err)
+		}
+	}
+
+	cfg, err := createTryRuntimeConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+	cfg.Global.BasePath = utils.ExpandDir(cfg.Global.BasePath)
+
+	report, err := dot.TryRuntime(dot.TryRuntimeConfig{
+		BasePath:  cfg.Global.BasePath,
+		Code:      code,
+		BlockHash: blockHash,
+		Checks:    ctx.Bool(TryRuntimeChecksFlag.Name),
+		Blocks:    ctx.Uint(TryRuntimeBlocksFlag.Name),
+		LogLvl:    cfg.Log.RuntimeLvl,
+	})
+	if err != nil {
+		return fmt.Errorf("trying runtime: %w", err)
+	}
+
+	writeTryRuntimeReport(os.Stdout, report)
+	return nil
+}
+
+func writeTryRuntimeReport(w io.Writer, report *dot.TryRuntimeReport) {
+	_, _ = fmt.Fprintf(w, "block: #%d (%s)\n", report.BlockNumber, report.BlockHash)
+	_, _ = fmt.Fprintf(w, "runtime: %s-%d (%s-%d), transaction version %d, state version %d\n",
+		report.Version.SpecName, report.Version.SpecVersion,
+		report.Version.ImplName, report.Version.ImplVersion,
+		report.Version.TransactionVersion, report.Version.StateVersion)
+	_, _ = fmt.Fprintf(w, "metadata: %d bytes\n", report.MetadataSize)
+
+	if report.UpgradeErr != nil {
+		_, _ = fmt.Fprintf(w, "runtime upgrade: failed: %s\n", report.UpgradeErr)
+	} else {
+		weights := report.UpgradeWeights
+		_, _ = fmt.Fprintf(w, "runtime upgrade: weight %d of %d (%.2f%%)",
+			weights.Weight, weights.MaxWeight, percentage(weights.Weight, weights.MaxWeight))
+		if weights.MaxProofSize > 0 {
+			_, _ = fmt.Fprintf(w, ", proof size %d of %d (%.2f%%)", weights.ProofSize,
+				weights.MaxProofSize, percentage(weights.ProofSize, weights.MaxProofSize))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	for _, block := range report.Blocks {
+		_, _ = fmt.Fprintf(w, "block #%d (%s): ", block.Number, block.Hash)
+		if len(block.InvalidExtrinsics) > 0 {
+			_, _ = fmt.Fprintf(w, "invalid extrinsics %v, ", block.InvalidExtrinsics)
+		}
+		if block.Err != nil {
+			_, _ = fmt.Fprintf(w, "failed: %s\n", block.Err)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "executed with state root %s\n", block.StateRoot)
+	}
+}
+
+func percentage(value, max uint64) float64 {
+	if max == 0 {
+		return 0
+	}
+	return 100 * float64(value) / float64(max)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writeTryRuntimeReport(t *testing.T) {
+	t.Parallel()
+
+	version := runtime.Version{
+		SpecName:           []byte("westend"),
+		ImplName:           []byte("parity-westend"),
+		SpecVersion:        9300,
+		ImplVersion:        1,
+		TransactionVersion: 12,
+		StateVersion:       0,
+	}
+
+	testCases := map[string]struct {
+		report *dot.TryRuntimeReport
+		output string
+	}{
+		"upgrade_failed": {
+			report: &dot.TryRuntimeReport{
+				BlockHash:    common.Hash{1},
+				BlockNumber:  10,
+				Version:      version,
+				MetadataSize: 100,
+				UpgradeErr:   errors.New("export function not found"),
+			},
+			output: "block: #10 (0x0100000000000000000000000000000000000000000000000000000000000000)\n" +
+				"runtime: westend-9300 (parity-westend-1), transaction version 12, state version 0\n" +
+				"metadata: 100 bytes\n" +
+				"runtime upgrade: failed: export function not found\n",
+		},
+		"upgrade_and_blocks": {
+			report: &dot.TryRuntimeReport{
+				BlockHash:    common.Hash{1},
+				BlockNumber:  10,
+				Version:      version,
+				MetadataSize: 100,
+				UpgradeWeights: &runtime.UpgradeWeights{
+					Weight:       500,
+					MaxWeight:    2000,
+					ProofSize:    1,
+					MaxProofSize: 4,
+				},
+				Blocks: []dot.TryRuntimeBlockResult{
+					{Hash: common.Hash{2}, Number: 11, StateRoot: common.Hash{3}},
+					{Hash: common.Hash{4}, Number: 12, InvalidExtrinsics: []int{1}, Err: errors.New("trap")},
+				},
+			},
+			output: "block: #10 (0x0100000000000000000000000000000000000000000000000000000000000000)\n" +
+				"runtime: westend-9300 (parity-westend-1), transaction version 12, state version 0\n" +
+				"metadata: 100 bytes\n" +
+				"runtime upgrade: weight 500 of 2000 (25.00%), proof size 1 of 4 (25.00%)\n" +
+				"block #11 (0x0200000000000000000000000000000000000000000000000000000000000000): " +
+				"executed with state root 0x0300000000000000000000000000000000000000000000000000000000000000\n" +
+				"block #12 (0x0400000000000000000000000000000000000000000000000000000000000000): " +
+				"invalid extrinsics [1], failed: trap\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+
+			writeTryRuntimeReport(buffer, testCase.report)
+
+			assert.Equal(t, testCase.output, buffer.String())
+		})
+	}
+}
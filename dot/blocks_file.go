@@ -0,0 +1,291 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var (
+	ErrBlocksFileFormatNotValid = errors.New("blocks file format is not valid")
+	ErrBlockDataIncomplete      = errors.New("block data is incomplete")
+	ErrBlockDataTooLarge        = errors.New("block data is too large")
+)
+
+// maxBlockDataSize is the maximum size of the SCALE encoded block data
+// of a block in a binary blocks file, which is the maximum size of a
+// block response since blocks larger than this cannot be synced either.
+const maxBlockDataSize = network.MaxBlockResponseSize
+
+// BlocksFileFormat is the format of a blocks file.
+type BlocksFileFormat string
+
+const (
+	// BlocksFileBinary is the binary blocks file format, where each block
+	// is encoded as its SCALE encoded block data prefixed with the length
+	// of the encoding as a 32 bit little endian unsigned integer.
+	BlocksFileBinary BlocksFileFormat = "binary"
+	// BlocksFileJSON is the JSON blocks file format, where each block
+	// is encoded as a JSON object on its own line.
+	BlocksFileJSON BlocksFileFormat = "json"
+)
+
+// IsValid returns true if the blocks file format is valid.
+func (f BlocksFileFormat) IsValid() bool {
+	switch f {
+	case BlocksFileBinary, BlocksFileJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonBlockData is the JSON encoding of a block in a blocks file.
+type jsonBlockData struct {
+	Hash   common.Hash `json:"hash"`
+	Number uint        `json:"number"`
+	// Header is the hex encoded SCALE encoding of the header.
+	Header string `json:"header"`
+	// Extrinsics are the hex encoded extrinsics of the body.
+	Extrinsics    []string `json:"extrinsics"`
+	Justification string   `json:"justification,omitempty"`
+}
+
+// blocksFileWriter writes blocks to a blocks file.
+type blocksFileWriter struct {
+	format BlocksFileFormat
+	writer *bufio.Writer
+}
+
+func newBlocksFileWriter(w io.Writer, format BlocksFileFormat) (*blocksFileWriter, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrBlocksFileFormatNotValid, format)
+	}
+
+	return &blocksFileWriter{
+		format: format,
+		writer: bufio.NewWriter(w),
+	}, nil
+}
+
+// write writes the block data given, which must have its header and body set.
+func (w *blocksFileWriter) write(blockData *types.BlockData) (err error) {
+	if blockData.Header == nil || blockData.Body == nil {
+		return fmt.Errorf("%w: for block %s", ErrBlockDataIncomplete, blockData.Hash)
+	}
+
+	switch w.format {
+	case BlocksFileBinary:
+		return w.writeBinary(blockData)
+	default:
+		return w.writeJSON(blockData)
+	}
+}
+
+func (w *blocksFileWriter) writeBinary(blockData *types.BlockData) (err error) {
+	encoded, err := scale.Marshal(*blockData)
+	if err != nil {
+		return fmt.Errorf("scale encoding block data: %w", err)
+	}
+
+	if uint64(len(encoded)) > maxBlockDataSize {
+		return fmt.Errorf("%w: %d bytes exceeds the maximum size of %d bytes",
+			ErrBlockDataTooLarge, len(encoded), maxBlockDataSize)
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(encoded)))
+	_, err = w.writer.Write(length[:])
+	if err != nil {
+		return fmt.Errorf("writing block data length: %w", err)
+	}
+
+	_, err = w.writer.Write(encoded)
+	if err != nil {
+		return fmt.Errorf("writing block data: %w", err)
+	}
+
+	return nil
+}
+
+func (w *blocksFileWriter) writeJSON(blockData *types.BlockData) (err error) {
+	encodedHeader, err := scale.Marshal(*blockData.Header)
+	if err != nil {
+		return fmt.Errorf("scale encoding header: %w", err)
+	}
+
+	jsonBlock := jsonBlockData{
+		Hash:       blockData.Hash,
+		Number:     blockData.Header.Number,
+		Header:     common.BytesToHex(encodedHeader),
+		Extrinsics: make([]string, len(*blockData.Body)),
+	}
+
+	for i, extrinsic := range *blockData.Body {
+		jsonBlock.Extrinsics[i] = common.BytesToHex(extrinsic)
+	}
+
+	if blockData.Justification != nil {
+		jsonBlock.Justification = common.BytesToHex(*blockData.Justification)
+	}
+
+	encoded, err := json.Marshal(jsonBlock)
+	if err != nil {
+		return fmt.Errorf("json encoding block data: %w", err)
+	}
+	encoded = append(encoded, '\n')
+
+	_, err = w.writer.Write(encoded)
+	if err != nil {
+		return fmt.Errorf("writing block data: %w", err)
+	}
+
+	return nil
+}
+
+// flush flushes the buffered blocks to the underlying writer.
+func (w *blocksFileWriter) flush() error {
+	return w.writer.Flush()
+}
+
+// blocksFileReader reads blocks from a blocks file.
+type blocksFileReader struct {
+	format      BlocksFileFormat
+	reader      *bufio.Reader
+	jsonDecoder *json.Decoder
+}
+
+func newBlocksFileReader(r io.Reader, format BlocksFileFormat) (*blocksFileReader, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrBlocksFileFormatNotValid, format)
+	}
+
+	reader := &blocksFileReader{
+		format: format,
+		reader: bufio.NewReader(r),
+	}
+
+	if format == BlocksFileJSON {
+		reader.jsonDecoder = json.NewDecoder(reader.reader)
+	}
+
+	return reader, nil
+}
+
+// read reads the next block data, and returns io.EOF
+// once all the blocks of the file have been read.
+func (r *blocksFileReader) read() (blockData *types.BlockData, err error) {
+	switch r.format {
+	case BlocksFileBinary:
+		blockData, err = r.readBinary()
+	default:
+		blockData, err = r.readJSON()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if blockData.Header == nil || blockData.Body == nil {
+		return nil, fmt.Errorf("%w: for block %s", ErrBlockDataIncomplete, blockData.Hash)
+	}
+
+	if blockData.Hash != blockData.Header.Hash() {
+		return nil, fmt.Errorf("block hash %s does not match header hash %s",
+			blockData.Hash, blockData.Header.Hash())
+	}
+
+	return blockData, nil
+}
+
+func (r *blocksFileReader) readBinary() (blockData *types.BlockData, err error) {
+	var length [4]byte
+	_, err = io.ReadFull(r.reader, length[:])
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("reading block data length: %w", err)
+	}
+
+	size := binary.LittleEndian.Uint32(length[:])
+	if uint64(size) > maxBlockDataSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the maximum size of %d bytes",
+			ErrBlockDataTooLarge, size, maxBlockDataSize)
+	}
+
+	encoded := make([]byte, size)
+	_, err = io.ReadFull(r.reader, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("reading block data: %w", err)
+	}
+
+	// The header is allocated before decoding so its digest
+	// has its varying data type values set for decoding.
+	blockData = &types.BlockData{
+		Header: types.NewEmptyHeader(),
+	}
+	err = scale.Unmarshal(encoded, blockData)
+	if err != nil {
+		return nil, fmt.Errorf("scale decoding block data: %w", err)
+	}
+
+	return blockData, nil
+}
+
+func (r *blocksFileReader) readJSON() (blockData *types.BlockData, err error) {
+	var jsonBlock jsonBlockData
+	err = r.jsonDecoder.Decode(&jsonBlock)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("json decoding block data: %w", err)
+	}
+
+	encodedHeader, err := common.HexToBytes(jsonBlock.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding header hex: %w", err)
+	}
+
+	header := types.NewEmptyHeader()
+	err = scale.Unmarshal(encodedHeader, header)
+	if err != nil {
+		return nil, fmt.Errorf("scale decoding header: %w", err)
+	}
+
+	body := make(types.Body, len(jsonBlock.Extrinsics))
+	for i, hexExtrinsic := range jsonBlock.Extrinsics {
+		body[i], err = common.HexToBytes(hexExtrinsic)
+		if err != nil {
+			return nil, fmt.Errorf("decoding extrinsic %d hex: %w", i, err)
+		}
+	}
+
+	blockData = &types.BlockData{
+		Hash:   jsonBlock.Hash,
+		Header: header,
+		Body:   &body,
+	}
+
+	if jsonBlock.Justification != "" {
+		justification, err := common.HexToBytes(jsonBlock.Justification)
+		if err != nil {
+			return nil, fmt.Errorf("decoding justification hex: %w", err)
+		}
+		blockData.Justification = &justification
+	}
+
+	return blockData, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlockData(t *testing.T, number uint, justification *[]byte) *types.BlockData {
+	t.Helper()
+
+	digest := types.NewDigest()
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, uint64(number)).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	err = digest.Add(*preDigest)
+	require.NoError(t, err)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, number, digest)
+	body := types.NewBody([]types.Extrinsic{{1, 2}, {3}})
+
+	return &types.BlockData{
+		Hash:          header.Hash(),
+		Header:        header,
+		Body:          body,
+		Justification: justification,
+	}
+}
+
+func Test_blocksFile_writeRead(t *testing.T) {
+	t.Parallel()
+
+	justification := []byte{4, 5, 6}
+	blocks := []*types.BlockData{
+		newTestBlockData(t, 1, nil),
+		newTestBlockData(t, 2, &justification),
+	}
+
+	for _, format := range []BlocksFileFormat{BlocksFileBinary, BlocksFileJSON} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+			writer, err := newBlocksFileWriter(buffer, format)
+			require.NoError(t, err)
+
+			for _, block := range blocks {
+				err = writer.write(block)
+				require.NoError(t, err)
+			}
+			err = writer.flush()
+			require.NoError(t, err)
+
+			reader, err := newBlocksFileReader(buffer, format)
+			require.NoError(t, err)
+
+			for _, expected := range blocks {
+				block, err := reader.read()
+				require.NoError(t, err)
+				assert.Equal(t, expected.Hash, block.Hash)
+				assert.Equal(t, expected.Header.Hash(), block.Header.Hash())
+				assert.Equal(t, expected.Body, block.Body)
+				assert.Equal(t, expected.Justification, block.Justification)
+			}
+
+			_, err = reader.read()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func Test_blocksFile_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := newBlocksFileWriter(nil, BlocksFileFormat("xml"))
+	assert.ErrorIs(t, err, ErrBlocksFileFormatNotValid)
+	assert.EqualError(t, err, `blocks file format is not valid: "xml"`)
+
+	_, err = newBlocksFileReader(nil, BlocksFileFormat("xml"))
+	assert.ErrorIs(t, err, ErrBlocksFileFormatNotValid)
+
+	writer, err := newBlocksFileWriter(io.Discard, BlocksFileBinary)
+	require.NoError(t, err)
+	err = writer.write(&types.BlockData{Header: types.NewEmptyHeader()})
+	assert.ErrorIs(t, err, ErrBlockDataIncomplete)
+
+	reader, err := newBlocksFileReader(bytes.NewReader([]byte{10, 0, 0, 0, 1}), BlocksFileBinary)
+	require.NoError(t, err)
+	_, err = reader.read()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	reader, err = newBlocksFileReader(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}), BlocksFileBinary)
+	require.NoError(t, err)
+	_, err = reader.read()
+	assert.ErrorIs(t, err, ErrBlockDataTooLarge)
+	assert.EqualError(t, err, "block data is too large: "+
+		"4294967295 bytes exceeds the maximum size of 16777216 bytes")
+}
+
+func TestExportBlocks_ImportBlocks(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []BlocksFileFormat{BlocksFileBinary, BlocksFileJSON} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			exportCfg := NewWestendDevConfig(t)
+			exportCfg.Init.Genesis = NewTestGenesisRawFile(t, exportCfg)
+			err := InitNode(exportCfg)
+			require.NoError(t, err)
+
+			buffer := bytes.NewBuffer(nil)
+			blocks, err := ExportBlocks(ExportBlocksConfig{
+				BasePath: exportCfg.Global.BasePath,
+				Format:   format,
+			}, buffer)
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), blocks)
+
+			importCfg := NewWestendDevConfig(t)
+			importCfg.Init.Genesis = exportCfg.Init.Genesis
+			err = InitNode(importCfg)
+			require.NoError(t, err)
+
+			// the exported genesis block is found in the
+			// database of the node initialised with the same genesis.
+			report, err := ImportBlocks(importCfg, buffer, format)
+			require.NoError(t, err)
+			expectedReport := &ImportBlocksReport{Skipped: 1}
+			assert.Equal(t, expectedReport, report)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+// CheckDatabase checks the integrity of the node database at the base path
+// given. If repair is true and inconsistencies are found, the chain is rewound
+// to the last consistent finalised block. The check report is returned, and
+// repaired is true if the chain was rewound.
+func CheckDatabase(basepath string, repair bool) (report *state.CheckReport, repaired bool, err error) {
+	stateSrvc := state.NewService(state.Config{
+		Path:      basepath,
+		LogLevel:  log.Info,
+		Telemetry: telemetry.NewNoopMailer(),
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot setup state database: %w", err)
+	}
+
+	report, err = stateSrvc.Check()
+	if err != nil {
+		closeErr := stateSrvc.DB().Close()
+		if closeErr != nil {
+			logger.Errorf("cannot close state database: %s", closeErr)
+		}
+		return nil, false, fmt.Errorf("checking database: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop state service: %w", stopErr)
+		}
+	}()
+
+	if !repair || len(report.Issues) == 0 {
+		return report, false, nil
+	}
+
+	err = stateSrvc.Repair(report)
+	if err != nil {
+		return report, false, fmt.Errorf("repairing database: %w", err)
+	}
+
+	return report, true, nil
+}
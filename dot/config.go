@@ -5,14 +5,17 @@
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ChainSafe/gossamer/chain/kusama"
 	"github.com/ChainSafe/gossamer/chain/polkadot"
 	"github.com/ChainSafe/gossamer/chain/westend"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/pprof"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -62,6 +65,9 @@ type LogConfig struct {
 	RuntimeLvl        log.Level
 	BlockProducerLvl  log.Level
 	FinalityGadgetLvl log.Level
+	// TargetLvls maps log targets, such as `sync` or `runtime::system`,
+	// to a log level overriding the package log levels above.
+	TargetLvls map[string]log.Level
 }
 
 func (l LogConfig) String() string {
@@ -76,12 +82,33 @@ func (l LogConfig) String() string {
 		fmt.Sprintf("block producer: %s", l.BlockProducerLvl),
 		fmt.Sprintf("finality gadget: %s", l.FinalityGadgetLvl),
 	}
+	if len(l.TargetLvls) > 0 {
+		entries = append(entries, fmt.Sprintf("targets: %s", l.Targets()))
+	}
 	return strings.Join(entries, ", ")
 }
 
+// Targets returns the log target levels as a
+// comma separated list of `target=level` directives.
+func (l LogConfig) Targets() string {
+	directives := make([]string, 0, len(l.TargetLvls))
+	for target, level := range l.TargetLvls {
+		if target == "" {
+			directives = append(directives, level.String())
+			continue
+		}
+		directives = append(directives, target+"="+level.String())
+	}
+	sort.Strings(directives)
+	return strings.Join(directives, ",")
+}
+
 // InitConfig is the configuration for the node initialization
 type InitConfig struct {
 	Genesis string
+	// DatabaseBackend is the database backend used to store the
+	// node state. It defaults to badger if left empty.
+	DatabaseBackend database.Backend
 }
 
 // AccountConfig is to marshal/unmarshal account config vars
@@ -99,12 +126,21 @@ type NetworkConfig struct {
 	NoMDNS            bool
 	MinPeers          int
 	MaxPeers          int
+	MaxLightPeers     int
+	MaxAuthorityPeers int
 	PersistentPeers   []string
 	DiscoveryInterval time.Duration
 	PublicIP          string
 	PublicDNS         string
 	NodeKey           string
 	ListenAddress     string
+	IPFSServer        bool
+	// AllowNonGlobalsInDHT allows publishing and resolving loopback
+	// and private authority addresses in the DHT.
+	AllowNonGlobalsInDHT bool
+
+	MaxInboundRequestsPerSecond  uint32
+	MaxConcurrentInboundRequests uint32
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
@@ -160,11 +196,17 @@ func (r *RPCConfig) String() string {
 
 // StateConfig is the config for the State service
 type StateConfig struct {
-	Rewind uint
+	Rewind                   uint
+	TransactionStoragePeriod uint
+	TrieCacheSize            uint
+	BlocksPruning            state.BlocksPruning
 }
 
 func (s *StateConfig) String() string {
-	return "rewind " + fmt.Sprint(s.Rewind)
+	return "rewind " + fmt.Sprint(s.Rewind) + " " +
+		"transaction storage period " + fmt.Sprint(s.TransactionStoragePeriod) + " " +
+		"trie cache size " + fmt.Sprint(s.TrieCacheSize) + " " +
+		"blocks pruning " + s.BlocksPruning.String()
 }
 
 // networkServiceEnabled returns true if the network service is enabled
@@ -38,11 +38,13 @@ type LogConfig struct {
 	RuntimeLvl        string `toml:"runtime,omitempty"`
 	BlockProducerLvl  string `toml:"babe,omitempty"`
 	FinalityGadgetLvl string `toml:"grandpa,omitempty"`
+	Targets           string `toml:"targets,omitempty"`
 }
 
 // InitConfig is the configuration for the node initialization
 type InitConfig struct {
-	Genesis string `toml:"genesis,omitempty"`
+	Genesis         string `toml:"genesis,omitempty"`
+	DatabaseBackend string `toml:"db-backend,omitempty"`
 }
 
 // AccountConfig is to marshal/unmarshal account config vars
@@ -60,12 +62,20 @@ type NetworkConfig struct {
 	NoMDNS            bool     `toml:"nomdns,omitempty"`
 	MinPeers          int      `toml:"min-peers,omitempty"`
 	MaxPeers          int      `toml:"max-peers,omitempty"`
+	MaxLightPeers     int      `toml:"max-light-peers,omitempty"`
+	MaxAuthorityPeers int      `toml:"max-authority-peers,omitempty"`
 	PersistentPeers   []string `toml:"persistent-peers,omitempty"`
 	DiscoveryInterval int      `toml:"discovery-interval,omitempty"`
 	PublicIP          string   `toml:"public-ip,omitempty"`
 	PublicDNS         string   `toml:"public-dns,omitempty"`
 	NodeKey           string   `toml:"node-key,omitempty"`
 	ListenAddress     string   `toml:"listen-addr,omitempty"`
+	IPFSServer        bool     `toml:"ipfs-server,omitempty"`
+
+	AllowNonGlobalsInDHT bool `toml:"allow-non-globals-in-dht,omitempty"`
+
+	MaxInboundRequestsPerSecond  uint32 `toml:"max-inbound-requests-per-second,omitempty"`
+	MaxConcurrentInboundRequests uint32 `toml:"max-concurrent-inbound-requests,omitempty"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
@@ -81,7 +91,10 @@ type CoreConfig struct {
 
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
-	Rewind uint `toml:"rewind,omitempty"`
+	Rewind                   uint   `toml:"rewind,omitempty"`
+	TransactionStoragePeriod uint   `toml:"transaction-storage-period,omitempty"`
+	TrieCacheSize            uint   `toml:"trie-cache-size,omitempty"`
+	BlocksPruning            string `toml:"blocks-pruning,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
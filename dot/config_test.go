@@ -342,6 +342,19 @@ func TestLogConfig_String(t *testing.T) {
 			want: "core: DEBUG, digest: INFO, sync: WARN, network: ERROR, rpc: CRITICAL, " +
 				"state: DEBUG, runtime: INFO, block producer: WARN, finality gadget: ERROR",
 		},
+		{
+			name: "targets_case",
+			logConfig: LogConfig{
+				TargetLvls: map[string]log.Level{
+					"runtime::system": log.Trace,
+					"sync":            log.Debug,
+					"":                log.Warn,
+				},
+			},
+			want: "core: CRITICAL, digest: CRITICAL, sync: CRITICAL, network: CRITICAL, rpc: CRITICAL, " +
+				"state: CRITICAL, runtime: CRITICAL, block producer: CRITICAL, finality gadget: CRITICAL, " +
+				"targets: WARN,runtime::system=TRACE,sync=DEBUG",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
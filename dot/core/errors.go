@@ -15,5 +15,8 @@
 	// ErrEmptyRuntimeCode is returned when the storage :code is empty
 	ErrEmptyRuntimeCode = errors.New("new :code is empty")
 
+	// ErrTraceGenesisBlock is returned when tracing the genesis block, which has no parent state
+	ErrTraceGenesisBlock = errors.New("cannot trace genesis block")
+
 	errInvalidTransactionQueueVersion = errors.New("invalid transaction queue version")
 )
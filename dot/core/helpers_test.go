@@ -292,5 +292,7 @@ func getWestendDevRuntimeCode(t *testing.T) (code []byte) {
 
 	trieState := rtstorage.NewTrieState(&genesisTrie)
 
-	return trieState.LoadCode()
+	code, err = trieState.LoadCode()
+	require.NoError(t, err)
+	return code
 }
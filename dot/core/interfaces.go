@@ -36,6 +36,7 @@ type RuntimeInstance interface {
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
 	GrandpaAuthorities() ([]types.Authority, error)
+	AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error)
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -65,6 +66,8 @@ type RuntimeInstance interface {
 type BlockState interface {
 	BestBlockHash() common.Hash
 	BestBlockHeader() (*types.Header, error)
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetBlockByHash(hash common.Hash) (*types.Block, error)
 	AddBlock(*types.Block) error
 	GetBlockStateRoot(bhash common.Hash) (common.Hash, error)
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
@@ -73,6 +76,7 @@ type BlockState interface {
 	GetRuntime(blockHash common.Hash) (instance state.Runtime, err error)
 	StoreRuntime(blockHash common.Hash, runtime state.Runtime)
 	LowestCommonAncestor(a, b common.Hash) (common.Hash, error)
+	StoreIndexedTransactions(block *types.Block, operations []rtstorage.IndexOperation) error
 }
 
 // StorageState interface for storage state methods
@@ -81,6 +85,7 @@ type StorageState interface {
 	StoreTrie(*rtstorage.TrieState, *types.Header) error
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
+	GenerateChildTrieProof(stateRoot common.Hash, keyToChild []byte, keys [][]byte) ([][]byte, error)
 	sync.Locker
 }
 
@@ -103,6 +103,21 @@ func (mr *MockBlockStateMockRecorder) GetBlockBody(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockBody", reflect.TypeOf((*MockBlockState)(nil).GetBlockBody), arg0)
 }
 
+// GetBlockByHash mocks base method.
+func (m *MockBlockState) GetBlockByHash(arg0 common.Hash) (*types.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockByHash", arg0)
+	ret0, _ := ret[0].(*types.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockByHash indicates an expected call of GetBlockByHash.
+func (mr *MockBlockStateMockRecorder) GetBlockByHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockState)(nil).GetBlockByHash), arg0)
+}
+
 // GetBlockStateRoot mocks base method.
 func (m *MockBlockState) GetBlockStateRoot(arg0 common.Hash) (common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +133,21 @@ func (mr *MockBlockStateMockRecorder) GetBlockStateRoot(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockStateRoot", reflect.TypeOf((*MockBlockState)(nil).GetBlockStateRoot), arg0)
 }
 
+// GetHeader mocks base method.
+func (m *MockBlockState) GetHeader(arg0 common.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", arg0)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockBlockStateMockRecorder) GetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
+}
+
 // GetRuntime mocks base method.
 func (m *MockBlockState) GetRuntime(arg0 common.Hash) (state.Runtime, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +207,20 @@ func (mr *MockBlockStateMockRecorder) RangeInMemory(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeInMemory", reflect.TypeOf((*MockBlockState)(nil).RangeInMemory), arg0, arg1)
 }
 
+// StoreIndexedTransactions mocks base method.
+func (m *MockBlockState) StoreIndexedTransactions(arg0 *types.Block, arg1 []storage.IndexOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreIndexedTransactions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreIndexedTransactions indicates an expected call of StoreIndexedTransactions.
+func (mr *MockBlockStateMockRecorder) StoreIndexedTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreIndexedTransactions", reflect.TypeOf((*MockBlockState)(nil).StoreIndexedTransactions), arg0, arg1)
+}
+
 // StoreRuntime mocks base method.
 func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 state.Runtime) {
 	m.ctrl.T.Helper()
@@ -212,6 +256,21 @@ func (m *MockStorageState) EXPECT() *MockStorageStateMockRecorder {
 	return m.recorder
 }
 
+// GenerateChildTrieProof mocks base method.
+func (m *MockStorageState) GenerateChildTrieProof(arg0 common.Hash, arg1 []byte, arg2 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateChildTrieProof", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateChildTrieProof indicates an expected call of GenerateChildTrieProof.
+func (mr *MockStorageStateMockRecorder) GenerateChildTrieProof(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateChildTrieProof", reflect.TypeOf((*MockStorageState)(nil).GenerateChildTrieProof), arg0, arg1, arg2)
+}
+
 // GenerateTrieProof mocks base method.
 func (m *MockStorageState) GenerateTrieProof(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
 	m.ctrl.T.Helper()
@@ -535,6 +594,21 @@ func (mr *MockRuntimeInstanceMockRecorder) ApplyExtrinsic(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).ApplyExtrinsic), arg0)
 }
 
+// AuthorityDiscoveryAuthorities mocks base method.
+func (m *MockRuntimeInstance) AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityDiscoveryAuthorities")
+	ret0, _ := ret[0].([]types.AuthorityID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityDiscoveryAuthorities indicates an expected call of AuthorityDiscoveryAuthorities.
+func (mr *MockRuntimeInstanceMockRecorder) AuthorityDiscoveryAuthorities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityDiscoveryAuthorities", reflect.TypeOf((*MockRuntimeInstance)(nil).AuthorityDiscoveryAuthorities))
+}
+
 // BabeConfiguration mocks base method.
 func (m *MockRuntimeInstance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	m.ctrl.T.Helper()
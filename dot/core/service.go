@@ -18,6 +18,7 @@
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 
@@ -209,6 +210,14 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		}
 	}
 
+	// store the transaction data indexed by the runtime during block execution
+	if indexOperations := state.IndexOperations(); len(indexOperations) > 0 {
+		err = s.blockState.StoreIndexedTransactions(block, indexOperations)
+		if err != nil {
+			return fmt.Errorf("storing indexed transactions: %w", err)
+		}
+	}
+
 	logger.Debugf("imported block %s and stored state trie with root %s",
 		block.Header.Hash(), state.MustRoot())
 
@@ -574,6 +583,85 @@ func (s *Service) GetReadProofAt(block common.Hash, keys [][]byte) (
 	return block, proofForKeys, nil
 }
 
+// GetChildReadProofAt returns the proofs for the keys of the child trie at the key to child
+// given, based on the block hash given. If the block hash is empty, the best block is used.
+func (s *Service) GetChildReadProofAt(block common.Hash, keyToChild []byte, keys [][]byte) (
+	hash common.Hash, proofForKeys [][]byte, err error) {
+	if block.IsEmpty() {
+		block = s.blockState.BestBlockHash()
+	}
+
+	stateRoot, err := s.blockState.GetBlockStateRoot(block)
+	if err != nil {
+		return hash, nil, fmt.Errorf("getting state root for block %s: %w", block, err)
+	}
+
+	proofForKeys, err = s.storageState.GenerateChildTrieProof(stateRoot, keyToChild, keys)
+	if err != nil {
+		return hash, nil, fmt.Errorf("generating child trie proof: %w", err)
+	}
+
+	return block, proofForKeys, nil
+}
+
+// TraceBlock re-executes the block with the given hash on top of the state of its
+// parent block, using a new runtime instance recording the execution in the given tracer.
+func (s *Service) TraceBlock(blockHash common.Hash, tracer *tracing.Tracer) error {
+	block, err := s.blockState.GetBlockByHash(blockHash)
+	if err != nil {
+		return fmt.Errorf("getting block: %w", err)
+	}
+
+	if block.Header.Number == 0 {
+		return ErrTraceGenesisBlock
+	}
+
+	parentHeader, err := s.blockState.GetHeader(block.Header.ParentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent header: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&parentHeader.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(parentHeader.Hash())
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	// this needs to create a new runtime instance, so the tracer does not
+	// record the calls made to the runtime instance shared with block import
+	cfg := wasmer.Config{
+		Storage:     trieState,
+		Keystore:    rt.Keystore(),
+		NodeStorage: rt.NodeStorage(),
+		Network:     rt.NetworkService(),
+		Tracer:      tracer,
+	}
+
+	code, err := trieState.LoadCode()
+	if err != nil {
+		return fmt.Errorf("loading code: %w", err)
+	}
+
+	instance, err := wasmer.NewInstance(code, cfg)
+	if err != nil {
+		return fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	trieState.SetTracer(tracer)
+
+	_, err = instance.ExecuteBlock(block)
+	if err != nil {
+		return fmt.Errorf("executing block: %w", err)
+	}
+
+	return nil
+}
+
 // buildExternalTransaction builds an external transaction based on the current transaction queue API version
 // See https://github.com/paritytech/substrate/blob/polkadot-v0.9.25/primitives/transaction-pool/src/runtime_api.rs#L25-L55
 func (s *Service) buildExternalTransaction(rt runtime.Instance, ext types.Extrinsic) (types.Extrinsic, error) {
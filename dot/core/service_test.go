@@ -408,6 +408,32 @@ func Test_Service_handleBlock(t *testing.T) {
 		execTest(t, service, &block, trieState, blocktree.ErrParentNotFound)
 	})
 
+	t.Run("store indexed transactions error", func(t *testing.T) {
+		t.Parallel()
+		trieState := rtstorage.NewTrieState(nil)
+		trieState.IndexTransaction(0, 1, common.Hash{1})
+
+		testHeader := types.NewEmptyHeader()
+		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
+		block.Header.Number = 21
+
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().AddBlock(&block).Return(nil)
+		mockBlockState.EXPECT().StoreIndexedTransactions(&block, trieState.IndexOperations()).
+			Return(errTestDummyError)
+
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		err := service.handleBlock(&block, trieState)
+		assert.ErrorIs(t, err, errTestDummyError)
+		assert.EqualError(t, err, "storing indexed transactions: "+errTestDummyError.Error())
+	})
+
 	t.Run("addBlock error continue", func(t *testing.T) {
 		t.Parallel()
 		trieState := rtstorage.NewTrieState(nil)
@@ -1447,3 +1473,143 @@ func TestService_GetReadProofAt(t *testing.T) {
 		execTest(t, service, common.Hash{}, [][]byte{{1}}, common.Hash{2}, [][]byte{{2}}, nil)
 	})
 }
+
+func TestService_GetChildReadProofAt(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		serviceBuilder  func(ctrl *gomock.Controller) *Service
+		block           common.Hash
+		expHash         common.Hash
+		expProofForKeys [][]byte
+		errWrapped      error
+		errMessage      string
+	}{
+		"get_block_state_root_error": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{2})
+				mockBlockState.EXPECT().GetBlockStateRoot(common.Hash{2}).Return(common.Hash{}, errDummyErr)
+				return &Service{blockState: mockBlockState}
+			},
+			errWrapped: errDummyErr,
+			errMessage: "getting state root for block " +
+				"0x0200000000000000000000000000000000000000000000000000000000000000: dummy error for testing",
+		},
+		"generate_child_trie_proof_error": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetBlockStateRoot(common.Hash{1}).Return(common.Hash{3}, nil)
+				mockStorageState := NewMockStorageState(ctrl)
+				mockStorageState.EXPECT().GenerateChildTrieProof(common.Hash{3}, []byte("child"), [][]byte{{1}}).
+					Return(nil, errDummyErr)
+				return &Service{blockState: mockBlockState, storageState: mockStorageState}
+			},
+			block:      common.Hash{1},
+			errWrapped: errDummyErr,
+			errMessage: "generating child trie proof: dummy error for testing",
+		},
+		"success": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{2})
+				mockBlockState.EXPECT().GetBlockStateRoot(common.Hash{2}).Return(common.Hash{3}, nil)
+				mockStorageState := NewMockStorageState(ctrl)
+				mockStorageState.EXPECT().GenerateChildTrieProof(common.Hash{3}, []byte("child"), [][]byte{{1}}).
+					Return([][]byte{{2}}, nil)
+				return &Service{blockState: mockBlockState, storageState: mockStorageState}
+			},
+			expHash:         common.Hash{2},
+			expProofForKeys: [][]byte{{2}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := testCase.serviceBuilder(ctrl)
+			hash, proofForKeys, err := service.GetChildReadProofAt(testCase.block, []byte("child"), [][]byte{{1}})
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expHash, hash)
+			assert.Equal(t, testCase.expProofForKeys, proofForKeys)
+		})
+	}
+}
+
+func TestService_TraceBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get block error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetBlockByHash(common.Hash{1}).Return(nil, errDummyErr)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		err := service.TraceBlock(common.Hash{1}, nil)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting block: dummy error for testing")
+	})
+
+	t.Run("genesis block", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetBlockByHash(common.Hash{1}).Return(&types.Block{}, nil)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		err := service.TraceBlock(common.Hash{1}, nil)
+		assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+	})
+
+	t.Run("get parent header error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		block := &types.Block{
+			Header: types.Header{Number: 1, ParentHash: common.Hash{2}},
+		}
+		mockBlockState.EXPECT().GetBlockByHash(common.Hash{1}).Return(block, nil)
+		mockBlockState.EXPECT().GetHeader(common.Hash{2}).Return(nil, errDummyErr)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		err := service.TraceBlock(common.Hash{1}, nil)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting parent header: dummy error for testing")
+	})
+
+	t.Run("trie state error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		block := &types.Block{
+			Header: types.Header{Number: 1, ParentHash: common.Hash{2}},
+		}
+		parentHeader := &types.Header{StateRoot: common.Hash{3}}
+		mockBlockState.EXPECT().GetBlockByHash(common.Hash{1}).Return(block, nil)
+		mockBlockState.EXPECT().GetHeader(common.Hash{2}).Return(parentHeader, nil)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{3}).Return(nil, errDummyErr)
+		service := &Service{
+			blockState:   mockBlockState,
+			storageState: mockStorageState,
+		}
+
+		err := service.TraceBlock(common.Hash{1}, nil)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting trie state: dummy error for testing")
+	})
+}
@@ -7,6 +7,7 @@
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -21,6 +22,8 @@
 type Handler struct {
 	ctx    context.Context
 	cancel context.CancelFunc
+	// done is used to wait for the handling goroutines to exit when stopping.
+	done sync.WaitGroup
 
 	// interfaces
 	blockState   BlockState
@@ -58,14 +61,22 @@ func NewHandler(lvl log.Level, blockState BlockState, epochState EpochState,
 
 // Start starts the Handler
 func (h *Handler) Start() error {
-	go h.handleBlockImport(h.ctx)
-	go h.handleBlockFinalisation(h.ctx)
+	h.done.Add(2)
+	go func() {
+		defer h.done.Done()
+		h.handleBlockImport(h.ctx)
+	}()
+	go func() {
+		defer h.done.Done()
+		h.handleBlockFinalisation(h.ctx)
+	}()
 	return nil
 }
 
 // Stop stops the Handler
 func (h *Handler) Stop() error {
 	h.cancel()
+	h.done.Wait()
 	h.blockState.FreeImportedBlockNotifierChannel(h.imported)
 	h.blockState.FreeFinalisedNotifierChannel(h.finalised)
 	return nil
@@ -0,0 +1,126 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+var ErrBlocksRangeNotValid = errors.New("blocks range is not valid")
+
+// ExportBlocksConfig is the configuration to export
+// finalised blocks from the local database.
+type ExportBlocksConfig struct {
+	BasePath string
+	// From is the number of the first block to export.
+	From uint
+	// To is the number of the last block to export.
+	// It defaults to the highest finalised block number if zero.
+	To     uint
+	Format BlocksFileFormat
+}
+
+// ExportBlocks writes the finalised blocks in the configured range, with
+// their justifications, from the local database to the writer given using
+// the configured blocks file format. It returns the number of blocks written.
+func ExportBlocks(cfg ExportBlocksConfig, w io.Writer) (blocks uint, err error) {
+	writer, err := newBlocksFileWriter(w, cfg.Format)
+	if err != nil {
+		return 0, err
+	}
+
+	stateSrvc := state.NewService(state.Config{
+		Path:      cfg.BasePath,
+		LogLevel:  log.Warn,
+		Telemetry: telemetry.NewNoopMailer(),
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return 0, fmt.Errorf("cannot setup state database: %w", err)
+	}
+
+	err = stateSrvc.Start()
+	if err != nil {
+		return 0, fmt.Errorf("cannot start state service: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop state service: %w", stopErr)
+		}
+	}()
+
+	finalisedHeader, err := stateSrvc.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return 0, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	to := cfg.To
+	if to == 0 {
+		to = finalisedHeader.Number
+	}
+
+	switch {
+	case cfg.From > to:
+		return 0, fmt.Errorf("%w: from block number %d is after to block number %d",
+			ErrBlocksRangeNotValid, cfg.From, to)
+	case to > finalisedHeader.Number:
+		return 0, fmt.Errorf("%w: to block number %d is after the highest finalised block number %d",
+			ErrBlocksRangeNotValid, to, finalisedHeader.Number)
+	}
+
+	for number := cfg.From; number <= to; number++ {
+		blockData, err := getFinalisedBlockData(stateSrvc.Block, number)
+		if err != nil {
+			return blocks, err
+		}
+
+		err = writer.write(blockData)
+		if err != nil {
+			return blocks, fmt.Errorf("writing block number %d: %w", number, err)
+		}
+		blocks++
+	}
+
+	err = writer.flush()
+	if err != nil {
+		return blocks, fmt.Errorf("flushing blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+func getFinalisedBlockData(blockState *state.BlockState, number uint) (
+	blockData *types.BlockData, err error) {
+	block, err := blockState.GetBlockByNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("getting block number %d: %w", number, err)
+	}
+
+	hash := block.Header.Hash()
+	blockData = &types.BlockData{
+		Hash:   hash,
+		Header: &block.Header,
+		Body:   &block.Body,
+	}
+
+	justification, err := blockState.GetJustification(hash)
+	switch {
+	case err == nil:
+		blockData.Justification = &justification
+	case !errors.Is(err, database.ErrKeyNotFound):
+		return nil, fmt.Errorf("getting justification of block number %d: %w", number, err)
+	}
+
+	return blockData, nil
+}
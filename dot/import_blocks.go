@@ -0,0 +1,195 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/sync"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// importBlocksLogInterval is the number of imported blocks between progress logs.
+const importBlocksLogInterval = 1000
+
+// noopGrandpaNetwork is the grandpa network used to verify justifications
+// when importing blocks, where no messages are exchanged with peers.
+type noopGrandpaNetwork struct{}
+
+func (noopGrandpaNetwork) GossipMessage(network.NotificationsMessage) {}
+
+func (noopGrandpaNetwork) SendMessage(peer.ID, grandpa.NotificationsMessage) error { return nil }
+
+func (noopGrandpaNetwork) RegisterNotificationsProtocol(protocol.ID, []protocol.ID, byte, network.HandshakeGetter,
+	network.HandshakeDecoder, network.HandshakeValidator, network.MessageDecoder,
+	network.NotificationsMessageHandler, network.NotificationsMessageBatchHandler, uint64) error {
+	return nil
+}
+
+func (noopGrandpaNetwork) RegisterGossipValidator(byte, network.MessageValidator, network.RebroadcastPolicy) error {
+	return nil
+}
+
+// ImportBlocksReport is the result of importing blocks from a blocks file.
+type ImportBlocksReport struct {
+	// Imported is the number of blocks imported.
+	Imported uint
+	// Skipped is the number of blocks skipped since
+	// they were already in the local database.
+	Skipped uint
+	// FinalisedNumber is the number of the highest
+	// finalised block once the blocks are imported.
+	FinalisedNumber uint
+	// BestNumber is the number of the best block
+	// once the blocks are imported.
+	BestNumber uint
+}
+
+// ImportBlocks reads blocks from the reader given using the blocks file format
+// given, and imports them in the node database with the configuration given.
+// Each block is verified, executed and imported through the same path as blocks
+// synced from the network, and its justification is verified and finalises the
+// block if present. Blocks already in the database are skipped.
+// Imported blocks which are not finalised are not persisted in the database.
+func ImportBlocks(cfg *Config, r io.Reader, format BlocksFileFormat) (
+	report *ImportBlocksReport, err error) {
+	reader, err := newBlocksFileReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := nodeBuilder{}
+
+	stateSrvc, err := builder.createStateService(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state service: %w", err)
+	}
+	stateSrvc.Telemetry = telemetry.NewNoopMailer()
+
+	err = startStateService(cfg, stateSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start state service: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop state service: %w", stopErr)
+		}
+	}()
+
+	ks := keystore.NewGlobalKeystore()
+	nodeStorage, err := builder.createRuntimeStorage(stateSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime storage: %w", err)
+	}
+
+	err = builder.loadRuntime(cfg, nodeStorage, stateSrvc, ks, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load runtime: %w", err)
+	}
+
+	digestHandler, err := builder.createDigestHandler(cfg.Log.DigestLvl, stateSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create digest handler: %w", err)
+	}
+
+	err = digestHandler.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start digest handler: %w", err)
+	}
+	defer func() {
+		stopErr := digestHandler.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop digest handler: %w", stopErr)
+		}
+	}()
+
+	coreSrvc, err := builder.createCoreService(cfg, ks, stateSrvc, nil, digestHandler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create core service: %w", err)
+	}
+
+	err = coreSrvc.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start core service: %w", err)
+	}
+	defer func() {
+		stopErr := coreSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop core service: %w", stopErr)
+		}
+	}()
+
+	// The finality gadget is only used to verify justifications,
+	// so it is not started and does not vote as an authority.
+	finalityCfg := *cfg
+	finalityCfg.Core.GrandpaAuthority = false
+	finalityGadget, err := builder.createGRANDPAService(&finalityCfg, stateSrvc, ks.Gran,
+		noopGrandpaNetwork{}, telemetry.NewNoopMailer())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grandpa service: %w", err)
+	}
+
+	importer := sync.NewBlockImporter(sync.BlockImporterConfig{
+		BlockState:         stateSrvc.Block,
+		StorageState:       stateSrvc.Storage,
+		TransactionState:   stateSrvc.Transaction,
+		BabeVerifier:       builder.createBlockVerifier(stateSrvc),
+		FinalityGadget:     finalityGadget,
+		BlockImportHandler: coreSrvc,
+		Telemetry:          telemetry.NewNoopMailer(),
+	})
+
+	report = &ImportBlocksReport{}
+	for {
+		blockData, err := reader.read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return report, fmt.Errorf("reading block: %w", err)
+		}
+
+		hasHeader, err := stateSrvc.Block.HasHeader(blockData.Hash)
+		if err != nil {
+			return report, fmt.Errorf("checking if block state has header: %w", err)
+		}
+
+		if hasHeader {
+			report.Skipped++
+			continue
+		}
+
+		err = importer.ImportBlock(*blockData)
+		if err != nil {
+			return report, fmt.Errorf("importing block number %d with hash %s: %w",
+				blockData.Header.Number, blockData.Hash, err)
+		}
+
+		report.Imported++
+		if report.Imported%importBlocksLogInterval == 0 {
+			logger.Infof("imported %d blocks, up to block number %d",
+				report.Imported, blockData.Header.Number)
+		}
+	}
+
+	finalisedHeader, err := stateSrvc.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return report, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+	report.FinalisedNumber = finalisedHeader.Number
+
+	report.BestNumber, err = stateSrvc.Block.BestBlockNumber()
+	if err != nil {
+		return report, fmt.Errorf("getting best block number: %w", err)
+	}
+
+	return report, nil
+}
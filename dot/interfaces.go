@@ -40,8 +40,8 @@ type Telemetry interface {
 	SendMessage(msg json.Marshaler)
 }
 
-// Note: only used internally in `loadRuntime` and
-// `createRuntime`.
+// Note: only used internally in `loadRuntime`,
+// `createRuntime` and `tryRuntime`.
 type runtimeInterface interface {
 	Stop()
 	NodeStorage() runtime.NodeStorage
@@ -55,6 +55,7 @@ type runtimeInterface interface {
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
 	GrandpaAuthorities() ([]types.Authority, error)
+	AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error)
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -106,7 +106,7 @@ func (mr *MocknodeBuilderIfaceMockRecorder) createDigestHandler(lvl, st interfac
 }
 
 // createGRANDPAService mocks base method.
-func (m *MocknodeBuilderIface) createGRANDPAService(cfg *Config, st *state.Service, ks KeyStore, net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error) {
+func (m *MocknodeBuilderIface) createGRANDPAService(cfg *Config, st *state.Service, ks KeyStore, net grandpa.Network, telemetryMailer Telemetry) (*grandpa.Service, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "createGRANDPAService", cfg, st, ks, net, telemetryMailer)
 	ret0, _ := ret[0].(*grandpa.Service)
@@ -121,18 +121,18 @@ func (mr *MocknodeBuilderIfaceMockRecorder) createGRANDPAService(cfg, st, ks, ne
 }
 
 // createNetworkService mocks base method.
-func (m *MocknodeBuilderIface) createNetworkService(cfg *Config, stateSrvc *state.Service, telemetryMailer Telemetry) (*network.Service, error) {
+func (m *MocknodeBuilderIface) createNetworkService(cfg *Config, stateSrvc *state.Service, ks keystore.Keystore, telemetryMailer Telemetry) (*network.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "createNetworkService", cfg, stateSrvc, telemetryMailer)
+	ret := m.ctrl.Call(m, "createNetworkService", cfg, stateSrvc, ks, telemetryMailer)
 	ret0, _ := ret[0].(*network.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // createNetworkService indicates an expected call of createNetworkService.
-func (mr *MocknodeBuilderIfaceMockRecorder) createNetworkService(cfg, stateSrvc, telemetryMailer interface{}) *gomock.Call {
+func (mr *MocknodeBuilderIfaceMockRecorder) createNetworkService(cfg, stateSrvc, ks, telemetryMailer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createNetworkService", reflect.TypeOf((*MocknodeBuilderIface)(nil).createNetworkService), cfg, stateSrvc, telemetryMailer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createNetworkService", reflect.TypeOf((*MocknodeBuilderIface)(nil).createNetworkService), cfg, stateSrvc, ks, telemetryMailer)
 }
 
 // createRPCService mocks base method.
@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: try_runtime.go
+
+// Package dot is a generated GoMock package.
+package dot
+
+import (
+	reflect "reflect"
+
+	types "github.com/ChainSafe/gossamer/dot/types"
+	common "github.com/ChainSafe/gossamer/lib/common"
+	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocktryRuntimeBlockState is a mock of tryRuntimeBlockState interface.
+type MocktryRuntimeBlockState struct {
+	ctrl     *gomock.Controller
+	recorder *MocktryRuntimeBlockStateMockRecorder
+}
+
+// MocktryRuntimeBlockStateMockRecorder is the mock recorder for MocktryRuntimeBlockState.
+type MocktryRuntimeBlockStateMockRecorder struct {
+	mock *MocktryRuntimeBlockState
+}
+
+// NewMocktryRuntimeBlockState creates a new mock instance.
+func NewMocktryRuntimeBlockState(ctrl *gomock.Controller) *MocktryRuntimeBlockState {
+	mock := &MocktryRuntimeBlockState{ctrl: ctrl}
+	mock.recorder = &MocktryRuntimeBlockStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktryRuntimeBlockState) EXPECT() *MocktryRuntimeBlockStateMockRecorder {
+	return m.recorder
+}
+
+// BestBlockHash mocks base method.
+func (m *MocktryRuntimeBlockState) BestBlockHash() common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlockHash")
+	ret0, _ := ret[0].(common.Hash)
+	return ret0
+}
+
+// BestBlockHash indicates an expected call of BestBlockHash.
+func (mr *MocktryRuntimeBlockStateMockRecorder) BestBlockHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlockHash", reflect.TypeOf((*MocktryRuntimeBlockState)(nil).BestBlockHash))
+}
+
+// GetBlockByHash mocks base method.
+func (m *MocktryRuntimeBlockState) GetBlockByHash(hash common.Hash) (*types.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockByHash", hash)
+	ret0, _ := ret[0].(*types.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockByHash indicates an expected call of GetBlockByHash.
+func (mr *MocktryRuntimeBlockStateMockRecorder) GetBlockByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MocktryRuntimeBlockState)(nil).GetBlockByHash), hash)
+}
+
+// GetHashByNumber mocks base method.
+func (m *MocktryRuntimeBlockState) GetHashByNumber(number uint) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashByNumber", number)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHashByNumber indicates an expected call of GetHashByNumber.
+func (mr *MocktryRuntimeBlockStateMockRecorder) GetHashByNumber(number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashByNumber", reflect.TypeOf((*MocktryRuntimeBlockState)(nil).GetHashByNumber), number)
+}
+
+// GetHeader mocks base method.
+func (m *MocktryRuntimeBlockState) GetHeader(hash common.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", hash)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MocktryRuntimeBlockStateMockRecorder) GetHeader(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MocktryRuntimeBlockState)(nil).GetHeader), hash)
+}
+
+// MocktryRuntimeStorageState is a mock of tryRuntimeStorageState interface.
+type MocktryRuntimeStorageState struct {
+	ctrl     *gomock.Controller
+	recorder *MocktryRuntimeStorageStateMockRecorder
+}
+
+// MocktryRuntimeStorageStateMockRecorder is the mock recorder for MocktryRuntimeStorageState.
+type MocktryRuntimeStorageStateMockRecorder struct {
+	mock *MocktryRuntimeStorageState
+}
+
+// NewMocktryRuntimeStorageState creates a new mock instance.
+func NewMocktryRuntimeStorageState(ctrl *gomock.Controller) *MocktryRuntimeStorageState {
+	mock := &MocktryRuntimeStorageState{ctrl: ctrl}
+	mock.recorder = &MocktryRuntimeStorageStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktryRuntimeStorageState) EXPECT() *MocktryRuntimeStorageStateMockRecorder {
+	return m.recorder
+}
+
+// TrieState mocks base method.
+func (m *MocktryRuntimeStorageState) TrieState(root *common.Hash) (*storage.TrieState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrieState", root)
+	ret0, _ := ret[0].(*storage.TrieState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrieState indicates an expected call of TrieState.
+func (mr *MocktryRuntimeStorageStateMockRecorder) TrieState(root interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieState", reflect.TypeOf((*MocktryRuntimeStorageState)(nil).TrieState), root)
+}
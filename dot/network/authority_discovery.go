@@ -0,0 +1,517 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/ChainSafe/gossamer/dot/network/proto"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+var (
+	initialAuthorityDiscoveryInterval = time.Second * 2
+	authorityDiscoveryPublishInterval = time.Hour
+	authorityDiscoveryResolveInterval = time.Minute * 10
+	authorityRecordLookupTimeout      = time.Second * 10
+)
+
+// maxInFlightAuthorityLookups is the maximum number of authority
+// records looked up concurrently in the DHT.
+const maxInFlightAuthorityLookups = 8
+
+var (
+	errAuthorityRecordNoAddress    = errors.New("authority record has no address")
+	errAuthorityRecordPeerMismatch = errors.New("authority record addresses have different peer ids")
+	errPeerSignatureNotValid       = errors.New("peer signature is not valid")
+	errNoPublicAddress             = errors.New("no public address to publish")
+	errNoValidAuthorityRecord      = errors.New("no valid authority record")
+)
+
+// authorityRecordStore stores the signed authority records.
+type authorityRecordStore interface {
+	putValue(key, value []byte) error
+	getValue(ctx context.Context, key []byte) (value []byte, err error)
+}
+
+// authorityDiscovery publishes our addresses signed with our authority discovery
+// keys in the DHT, and resolves the addresses of the current and next authority
+// sets from the DHT, adding them as reserved peers to the peer set.
+type authorityDiscovery struct {
+	ctx        context.Context
+	peerID     peer.ID
+	privateKey crypto.PrivKey
+	addrs      func() []ma.Multiaddr
+	peerstore  peerstore.Peerstore
+	records    authorityRecordStore
+	// validator is the validator of the authority records of the DHT, which
+	// verifies the signatures of the records of the authorities resolved.
+	validator *authorityRecordValidator
+	handler   PeerSetHandler
+	api       AuthorityDiscoveryAPI
+	// keystore holds the authority discovery keys used to sign our addresses,
+	// and is nil if we only resolve the addresses of the authorities.
+	keystore keystore.Keystore
+	// allowNonGlobals allows publishing and resolving loopback and private
+	// addresses, which is only useful for local test networks.
+	allowNonGlobals bool
+
+	// resolved maps the authorities to the reserved peer resolved for them,
+	// and is only accessed by the resolving goroutine.
+	resolved map[types.AuthorityID]peer.ID
+}
+
+func newAuthorityDiscovery(ctx context.Context, h *host, records authorityRecordStore,
+	validator *authorityRecordValidator, api AuthorityDiscoveryAPI, ks keystore.Keystore,
+	allowNonGlobals bool) *authorityDiscovery {
+	return &authorityDiscovery{
+		ctx:             ctx,
+		peerID:          h.id(),
+		privateKey:      h.p2pHost.Peerstore().PrivKey(h.id()),
+		addrs:           h.multiaddrs,
+		peerstore:       h.p2pHost.Peerstore(),
+		records:         records,
+		validator:       validator,
+		handler:         h.cm.peerSetHandler,
+		api:             api,
+		keystore:        ks,
+		allowNonGlobals: allowNonGlobals,
+		resolved:        make(map[types.AuthorityID]peer.ID),
+	}
+}
+
+// start starts publishing and resolving the authority records periodically.
+func (a *authorityDiscovery) start() {
+	if a.keystore != nil {
+		go a.runPeriodically(a.publish, authorityDiscoveryPublishInterval)
+	}
+	go a.runPeriodically(a.resolve, authorityDiscoveryResolveInterval)
+}
+
+// runPeriodically runs the function given with an interval starting at
+// initialAuthorityDiscoveryInterval and doubling until it reaches maxInterval,
+// so the records are published and resolved soon after joining the DHT.
+func (a *authorityDiscovery) runPeriodically(run func() error, maxInterval time.Duration) {
+	interval := initialAuthorityDiscoveryInterval
+	for {
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-a.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			err := run()
+			if err != nil {
+				logger.Warnf("authority discovery: %s", err)
+			}
+
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
+
+// publish signs our addresses with each of our authority discovery keys belonging
+// to the current or next authority sets, and publishes them in the DHT.
+func (a *authorityDiscovery) publish() error {
+	authorities, err := a.api.AuthorityDiscoveryAuthorities()
+	if err != nil {
+		return fmt.Errorf("getting authorities: %w", err)
+	}
+
+	keypairs := ourAuthorityKeypairs(a.keystore, authorities)
+	if len(keypairs) == 0 {
+		return nil
+	}
+
+	addrs := a.addrs()
+	if !a.allowNonGlobals {
+		addrs = publicAddrs(addrs)
+	}
+	if len(addrs) == 0 {
+		return errNoPublicAddress
+	}
+
+	record := &pb.AuthorityRecord{
+		Addresses: make([][]byte, len(addrs)),
+	}
+	for i, addr := range addrs {
+		record.Addresses[i] = addr.Bytes()
+	}
+
+	encodedRecord, err := proto.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding authority record: %w", err)
+	}
+
+	peerSignature, err := a.privateKey.Sign(encodedRecord)
+	if err != nil {
+		return fmt.Errorf("signing authority record with peer key: %w", err)
+	}
+
+	encodedPublicKey, err := crypto.MarshalPublicKey(a.privateKey.GetPublic())
+	if err != nil {
+		return fmt.Errorf("encoding peer public key: %w", err)
+	}
+
+	for _, keypair := range keypairs {
+		authSignature, err := keypair.Sign(encodedRecord)
+		if err != nil {
+			return fmt.Errorf("signing authority record with authority key %s: %w",
+				keypair.Public().Hex(), err)
+		}
+
+		signedRecord := &pb.SignedAuthorityRecord{
+			Record:        encodedRecord,
+			AuthSignature: authSignature,
+			PeerSignature: &pb.PeerSignature{
+				Signature: peerSignature,
+				PublicKey: encodedPublicKey,
+			},
+		}
+
+		encodedSignedRecord, err := proto.Marshal(signedRecord)
+		if err != nil {
+			return fmt.Errorf("encoding signed authority record: %w", err)
+		}
+
+		key := authorityRecordKey(keypair.Public().Encode())
+		err = a.records.putValue(key, encodedSignedRecord)
+		if err != nil {
+			return fmt.Errorf("publishing authority record of authority %s: %w",
+				keypair.Public().Hex(), err)
+		}
+
+		logger.Debugf("published authority record of authority %s with %d addresses",
+			keypair.Public().Hex(), len(addrs))
+	}
+
+	return nil
+}
+
+// resolve gets the authority records of the current and next authority sets from the
+// DHT concurrently, and adds their peers as reserved peers to the peer set. The peers
+// of the authorities which are no longer in the authority sets are removed from the
+// reserved peers.
+func (a *authorityDiscovery) resolve() error {
+	authorities, err := a.api.AuthorityDiscoveryAuthorities()
+	if err != nil {
+		return fmt.Errorf("getting authorities: %w", err)
+	}
+
+	// records of these authorities received from the DHT
+	// are only accepted if they are signed by the authority.
+	a.validator.setAuthorities(authorities)
+
+	// TODO: currently we only have one set so setID is 0, change this once we have more set in peerSet
+	const setID = 0
+
+	results := a.lookupAuthorities(authorities)
+
+	authoritiesSet := make(map[types.AuthorityID]struct{}, len(authorities))
+	for i, authority := range authorities {
+		authoritiesSet[authority] = struct{}{}
+
+		addrInfo, err := results[i].addrInfo, results[i].err
+		if err != nil {
+			logger.Debugf("cannot resolve authority 0x%x: %s", authority, err)
+			continue
+		}
+
+		if addrInfo.ID == a.peerID {
+			continue
+		}
+
+		a.peerstore.AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
+
+		previousPeerID, has := a.resolved[authority]
+		if has && previousPeerID == addrInfo.ID {
+			continue
+		} else if has {
+			a.handler.RemoveReservedPeer(setID, previousPeerID)
+		}
+
+		logger.Debugf("resolved authority 0x%x to peer %s", authority, addrInfo.ID)
+		a.resolved[authority] = addrInfo.ID
+		a.handler.AddReservedPeer(setID, addrInfo.ID)
+	}
+
+	for authority, peerID := range a.resolved {
+		_, has := authoritiesSet[authority]
+		if has {
+			continue
+		}
+
+		delete(a.resolved, authority)
+		a.handler.RemoveReservedPeer(setID, peerID)
+	}
+
+	return nil
+}
+
+// authorityLookupResult is the result of the lookup of an authority record.
+type authorityLookupResult struct {
+	addrInfo peer.AddrInfo
+	err      error
+}
+
+// lookupAuthorities looks up the authority records of the authorities given in the
+// DHT, with at most maxInFlightAuthorityLookups lookups running concurrently, and
+// returns the lookup results in the order of the authorities given.
+func (a *authorityDiscovery) lookupAuthorities(authorities []types.AuthorityID) (
+	results []authorityLookupResult) {
+	results = make([]authorityLookupResult, len(authorities))
+
+	workers := maxInFlightAuthorityLookups
+	if len(authorities) < workers {
+		workers = len(authorities)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				addrInfo, err := a.lookupAuthority(authorities[index])
+				results[index] = authorityLookupResult{addrInfo: addrInfo, err: err}
+			}
+		}()
+	}
+
+	for index := range authorities {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// lookupAuthority gets the authority record of the authority given from the DHT,
+// within authorityRecordLookupTimeout, and returns the peer address information
+// of the record. Non global addresses are removed unless they are allowed.
+func (a *authorityDiscovery) lookupAuthority(authority types.AuthorityID) (
+	addrInfo peer.AddrInfo, err error) {
+	ctx, cancel := context.WithTimeout(a.ctx, authorityRecordLookupTimeout)
+	defer cancel()
+
+	value, err := a.records.getValue(ctx, authorityRecordKey(authority[:]))
+	if err != nil {
+		return addrInfo, fmt.Errorf("getting authority record: %w", err)
+	}
+
+	addrInfo, err = decodeSignedAuthorityRecord(authority, value)
+	if err != nil {
+		return addrInfo, fmt.Errorf("decoding authority record: %w", err)
+	}
+
+	if !a.allowNonGlobals {
+		addrInfo.Addrs = publicAddrs(addrInfo.Addrs)
+		if len(addrInfo.Addrs) == 0 {
+			return addrInfo, fmt.Errorf("%w: for peer %s", errAuthorityRecordNoAddress, addrInfo.ID)
+		}
+	}
+
+	return addrInfo, nil
+}
+
+// publicAddrs returns the addresses given which are neither loopback nor
+// private addresses, such that only addresses reachable by the other
+// authorities are published.
+func publicAddrs(addrs []ma.Multiaddr) (public []ma.Multiaddr) {
+	for _, addr := range addrs {
+		if manet.IsIPLoopback(addr) || manet.IsPrivateAddr(addr) {
+			continue
+		}
+		public = append(public, addr)
+	}
+	return public
+}
+
+// ourAuthorityKeypairs returns the keypairs of the keystore
+// belonging to the authorities given.
+func ourAuthorityKeypairs(ks keystore.Keystore, authorities []types.AuthorityID) (
+	keypairs []keystore.KeyPair) {
+	authoritiesSet := make(map[types.AuthorityID]struct{}, len(authorities))
+	for _, authority := range authorities {
+		authoritiesSet[authority] = struct{}{}
+	}
+
+	for _, keypair := range ks.Keypairs() {
+		var authority types.AuthorityID
+		copy(authority[:], keypair.Public().Encode())
+		_, has := authoritiesSet[authority]
+		if has {
+			keypairs = append(keypairs, keypair)
+		}
+	}
+
+	return keypairs
+}
+
+// authorityRecordKey returns the DHT key of the authority record
+// of the authority discovery public key given.
+func authorityRecordKey(publicKey []byte) (key []byte) {
+	hash := sha256.Sum256(publicKey)
+	return hash[:]
+}
+
+// decodeSignedAuthorityRecord decodes the signed authority record given,
+// verifies it is signed by the authority and by the peer it advertises,
+// and returns the peer address information of the record.
+func decodeSignedAuthorityRecord(authority types.AuthorityID, encoded []byte) (
+	addrInfo peer.AddrInfo, err error) {
+	signedRecord := new(pb.SignedAuthorityRecord)
+	err = proto.Unmarshal(encoded, signedRecord)
+	if err != nil {
+		return addrInfo, fmt.Errorf("decoding signed authority record: %w", err)
+	}
+
+	err = sr25519.VerifySignature(authority[:], signedRecord.AuthSignature, signedRecord.Record)
+	if err != nil {
+		return addrInfo, fmt.Errorf("verifying authority signature: %w", err)
+	}
+
+	record := new(pb.AuthorityRecord)
+	err = proto.Unmarshal(signedRecord.Record, record)
+	if err != nil {
+		return addrInfo, fmt.Errorf("decoding authority record: %w", err)
+	}
+
+	if len(record.Addresses) == 0 {
+		return addrInfo, errAuthorityRecordNoAddress
+	}
+
+	for _, encodedAddr := range record.Addresses {
+		addr, err := ma.NewMultiaddrBytes(encodedAddr)
+		if err != nil {
+			return addrInfo, fmt.Errorf("decoding address: %w", err)
+		}
+
+		info, err := peer.AddrInfoFromP2pAddr(addr)
+		if err != nil {
+			return addrInfo, fmt.Errorf("parsing address %s: %w", addr, err)
+		}
+
+		if addrInfo.ID == "" {
+			addrInfo.ID = info.ID
+		} else if addrInfo.ID != info.ID {
+			return addrInfo, fmt.Errorf("%w: %s and %s", errAuthorityRecordPeerMismatch, addrInfo.ID, info.ID)
+		}
+		addrInfo.Addrs = append(addrInfo.Addrs, info.Addrs...)
+	}
+
+	peerSignature := signedRecord.PeerSignature
+	if peerSignature == nil {
+		// older authority records are not signed by the peer
+		return addrInfo, nil
+	}
+
+	err = verifyPeerSignature(addrInfo.ID, peerSignature, signedRecord.Record)
+	if err != nil {
+		return addrInfo, fmt.Errorf("verifying peer signature: %w", err)
+	}
+
+	return addrInfo, nil
+}
+
+// verifyPeerSignature verifies the peer signature of the authority record is
+// valid and made with the private key of the peer id given.
+func verifyPeerSignature(peerID peer.ID, peerSignature *pb.PeerSignature, record []byte) error {
+	publicKey, err := crypto.UnmarshalPublicKey(peerSignature.PublicKey)
+	if err != nil {
+		return fmt.Errorf("decoding public key: %w", err)
+	}
+
+	if !peerID.MatchesPublicKey(publicKey) {
+		return fmt.Errorf("%w: public key does not match peer id %s", errPeerSignatureNotValid, peerID)
+	}
+
+	ok, err := publicKey.Verify(record, peerSignature.Signature)
+	if err != nil {
+		return fmt.Errorf("verifying signature: %w", err)
+	} else if !ok {
+		return fmt.Errorf("%w: for peer id %s", errPeerSignatureNotValid, peerID)
+	}
+
+	return nil
+}
+
+// authorityRecordValidator validates the signed authority records stored
+// in the DHT. The authority public key is only known from its hash used as
+// DHT key, so the authority signature is verified for the records of the
+// authorities set with setAuthorities, and other records are only decoded.
+type authorityRecordValidator struct {
+	// authorities maps the DHT keys to the authorities whose records are verified.
+	authorities map[string]types.AuthorityID
+	mutex       sync.RWMutex
+}
+
+func newAuthorityRecordValidator() *authorityRecordValidator {
+	return &authorityRecordValidator{
+		authorities: make(map[string]types.AuthorityID),
+	}
+}
+
+// setAuthorities sets the authorities whose records are verified.
+func (v *authorityRecordValidator) setAuthorities(authorities []types.AuthorityID) {
+	if v == nil {
+		return
+	}
+
+	keyToAuthority := make(map[string]types.AuthorityID, len(authorities))
+	for _, authority := range authorities {
+		keyToAuthority[string(authorityRecordKey(authority[:]))] = authority
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.authorities = keyToAuthority
+}
+
+// Validate checks the value is a signed authority record, signed by the
+// authority if it is one of the authorities set.
+func (v *authorityRecordValidator) Validate(key string, value []byte) error {
+	v.mutex.RLock()
+	authority, ok := v.authorities[key]
+	v.mutex.RUnlock()
+
+	if !ok {
+		return proto.Unmarshal(value, new(pb.SignedAuthorityRecord))
+	}
+
+	_, err := decodeSignedAuthorityRecord(authority, value)
+	return err
+}
+
+// Select selects the first value valid, so a forged record cannot be
+// selected over the record of the authority, and a newly published record
+// replaces the previous record of the authority.
+func (v *authorityRecordValidator) Select(key string, values [][]byte) (index int, err error) {
+	for i, value := range values {
+		if v.Validate(key, value) == nil {
+			return i, nil
+		}
+	}
+	return 0, errNoValidAuthorityRecord
+}
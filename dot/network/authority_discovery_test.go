@@ -0,0 +1,488 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/ChainSafe/gossamer/dot/network/proto"
+	"github.com/ChainSafe/gossamer/dot/types"
+	gossamercrypto "github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+type mapRecordStore map[string][]byte
+
+func (m mapRecordStore) putValue(key, value []byte) error {
+	m[string(key)] = value
+	return nil
+}
+
+func (m mapRecordStore) getValue(_ context.Context, key []byte) (value []byte, err error) {
+	value, ok := m[string(key)]
+	if !ok {
+		return nil, routing.ErrNotFound
+	}
+	return value, nil
+}
+
+func newTestAuthorityDiscovery(t *testing.T, records authorityRecordStore,
+	api AuthorityDiscoveryAPI, handler PeerSetHandler, ks keystore.Keystore) *authorityDiscovery {
+	t.Helper()
+
+	privateKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	require.NoError(t, err)
+	privateAddr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/30333/p2p/" + peerID.String())
+	require.NoError(t, err)
+	publicAddr, err := ma.NewMultiaddr("/ip4/1.2.3.4/tcp/30333/p2p/" + peerID.String())
+	require.NoError(t, err)
+
+	peerstore, err := pstoremem.NewPeerstore()
+	require.NoError(t, err)
+
+	return &authorityDiscovery{
+		ctx:        context.Background(),
+		peerID:     peerID,
+		privateKey: privateKey,
+		addrs:      func() []ma.Multiaddr { return []ma.Multiaddr{privateAddr, publicAddr} },
+		peerstore:  peerstore,
+		records:    records,
+		validator:  newAuthorityRecordValidator(),
+		handler:    handler,
+		api:        api,
+		keystore:   ks,
+		resolved:   make(map[types.AuthorityID]peer.ID),
+	}
+}
+
+func Test_authorityDiscovery_publish_resolve(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	records := mapRecordStore{}
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := keystore.NewGlobalKeystore().Audi
+	err = ks.Insert(keypair)
+	require.NoError(t, err)
+	var authority types.AuthorityID
+	copy(authority[:], keypair.Public().Encode())
+	otherAuthority := types.AuthorityID{1}
+
+	publisherAPI := NewMockAuthorityDiscoveryAPI(ctrl)
+	publisherAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{otherAuthority, authority}, nil)
+	publisher := newTestAuthorityDiscovery(t, records, publisherAPI, nil, ks)
+
+	err = publisher.publish()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	resolverAPI := NewMockAuthorityDiscoveryAPI(ctrl)
+	handler := NewMockPeerSetHandler(ctrl)
+	resolver := newTestAuthorityDiscovery(t, records, resolverAPI, handler, nil)
+
+	resolverAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{otherAuthority, authority}, nil)
+	handler.EXPECT().AddReservedPeer(0, publisher.peerID)
+
+	err = resolver.resolve()
+	require.NoError(t, err)
+	// the private address is not published
+	expectedAddrs := []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/30333")}
+	assert.Equal(t, expectedAddrs, resolver.peerstore.Addrs(publisher.peerID))
+
+	// resolving the same peer again does not add it again to the reserved peers
+	resolverAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{authority}, nil)
+
+	err = resolver.resolve()
+	require.NoError(t, err)
+
+	// the peer is removed from the reserved peers once it is no longer an authority
+	resolverAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{otherAuthority}, nil)
+	handler.EXPECT().RemoveReservedPeer(0, publisher.peerID)
+
+	err = resolver.resolve()
+	require.NoError(t, err)
+	assert.Empty(t, resolver.resolved)
+}
+
+func Test_authorityDiscovery_publish_resolve_allowNonGlobals(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	records := mapRecordStore{}
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := keystore.NewGlobalKeystore().Audi
+	err = ks.Insert(keypair)
+	require.NoError(t, err)
+	var authority types.AuthorityID
+	copy(authority[:], keypair.Public().Encode())
+
+	publisherAPI := NewMockAuthorityDiscoveryAPI(ctrl)
+	publisherAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{authority}, nil)
+	publisher := newTestAuthorityDiscovery(t, records, publisherAPI, nil, ks)
+	publisher.allowNonGlobals = true
+
+	err = publisher.publish()
+	require.NoError(t, err)
+
+	resolverAPI := NewMockAuthorityDiscoveryAPI(ctrl)
+	handler := NewMockPeerSetHandler(ctrl)
+	resolver := newTestAuthorityDiscovery(t, records, resolverAPI, handler, nil)
+	resolver.allowNonGlobals = true
+
+	resolverAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{authority}, nil)
+	handler.EXPECT().AddReservedPeer(0, publisher.peerID)
+
+	err = resolver.resolve()
+	require.NoError(t, err)
+	expectedAddrs := []ma.Multiaddr{
+		ma.StringCast("/ip4/10.0.0.1/tcp/30333"),
+		ma.StringCast("/ip4/1.2.3.4/tcp/30333"),
+	}
+	assert.ElementsMatch(t, expectedAddrs, resolver.peerstore.Addrs(publisher.peerID))
+
+	// the private address is ignored by a resolver not allowing non global addresses
+	otherHandler := NewMockPeerSetHandler(ctrl)
+	otherResolver := newTestAuthorityDiscovery(t, records, resolverAPI, otherHandler, nil)
+
+	resolverAPI.EXPECT().AuthorityDiscoveryAuthorities().
+		Return([]types.AuthorityID{authority}, nil)
+	otherHandler.EXPECT().AddReservedPeer(0, publisher.peerID)
+
+	err = otherResolver.resolve()
+	require.NoError(t, err)
+	expectedAddrs = []ma.Multiaddr{ma.StringCast("/ip4/1.2.3.4/tcp/30333")}
+	assert.Equal(t, expectedAddrs, otherResolver.peerstore.Addrs(publisher.peerID))
+}
+
+// concurrentRecordStore blocks the lookups until maxInFlight lookups run
+// concurrently, and records the maximum number of concurrent lookups seen.
+type concurrentRecordStore struct {
+	maxInFlight int
+	release     chan struct{}
+
+	mutex            sync.Mutex
+	inFlight         int
+	maxInFlightSeen  int
+	lookups          int
+	lookupsNoTimeout int
+}
+
+func (c *concurrentRecordStore) putValue([]byte, []byte) error { return nil }
+
+func (c *concurrentRecordStore) getValue(ctx context.Context, _ []byte) (value []byte, err error) {
+	c.mutex.Lock()
+	c.lookups++
+	if _, ok := ctx.Deadline(); !ok {
+		c.lookupsNoTimeout++
+	}
+	c.inFlight++
+	if c.inFlight > c.maxInFlightSeen {
+		c.maxInFlightSeen = c.inFlight
+		if c.maxInFlightSeen == c.maxInFlight {
+			close(c.release)
+		}
+	}
+	c.mutex.Unlock()
+
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+	}
+
+	c.mutex.Lock()
+	c.inFlight--
+	c.mutex.Unlock()
+
+	return nil, routing.ErrNotFound
+}
+
+func Test_authorityDiscovery_resolve_concurrentLookups(t *testing.T) {
+	t.Parallel()
+
+	authorities := make([]types.AuthorityID, 3*maxInFlightAuthorityLookups)
+	for i := range authorities {
+		authorities[i] = types.AuthorityID{byte(i)}
+	}
+
+	api := NewMockAuthorityDiscoveryAPI(gomock.NewController(t))
+	api.EXPECT().AuthorityDiscoveryAuthorities().Return(authorities, nil)
+	records := &concurrentRecordStore{
+		maxInFlight: maxInFlightAuthorityLookups,
+		release:     make(chan struct{}),
+	}
+	a := newTestAuthorityDiscovery(t, records, api, nil, nil)
+
+	err := a.resolve()
+	require.NoError(t, err)
+
+	assert.Equal(t, len(authorities), records.lookups)
+	assert.Zero(t, records.lookupsNoTimeout)
+	assert.Equal(t, maxInFlightAuthorityLookups, records.maxInFlightSeen)
+	assert.Empty(t, a.resolved)
+}
+
+func Test_decodeSignedAuthorityRecord(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	var authority types.AuthorityID
+	copy(authority[:], keypair.Public().Encode())
+	otherKeypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	var otherAuthority types.AuthorityID
+	copy(otherAuthority[:], otherKeypair.Public().Encode())
+
+	privateKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	require.NoError(t, err)
+	otherPrivateKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	otherPeerID, err := peer.IDFromPrivateKey(otherPrivateKey)
+	require.NoError(t, err)
+
+	addr := ma.StringCast("/ip4/10.0.0.1/tcp/30333")
+	p2pAddr := func(id peer.ID) []byte {
+		return addr.Encapsulate(ma.StringCast("/p2p/" + id.String())).Bytes()
+	}
+
+	encodeRecord := func(t *testing.T, addresses ...[]byte) []byte {
+		t.Helper()
+		encoded, err := proto.Marshal(&pb.AuthorityRecord{Addresses: addresses})
+		require.NoError(t, err)
+		return encoded
+	}
+
+	signWithPeerKey := func(t *testing.T, key crypto.PrivKey, record []byte) *pb.PeerSignature {
+		t.Helper()
+		signature, err := key.Sign(record)
+		require.NoError(t, err)
+		publicKey, err := crypto.MarshalPublicKey(key.GetPublic())
+		require.NoError(t, err)
+		return &pb.PeerSignature{Signature: signature, PublicKey: publicKey}
+	}
+
+	encodeSignedRecord := func(t *testing.T, record []byte, peerSignature *pb.PeerSignature) []byte {
+		t.Helper()
+		authSignature, err := keypair.Sign(record)
+		require.NoError(t, err)
+		encoded, err := proto.Marshal(&pb.SignedAuthorityRecord{
+			Record:        record,
+			AuthSignature: authSignature,
+			PeerSignature: peerSignature,
+		})
+		require.NoError(t, err)
+		return encoded
+	}
+
+	testCases := map[string]struct {
+		encodedBuilder func(t *testing.T) []byte
+		authority      types.AuthorityID
+		addrInfo       peer.AddrInfo
+		errWrapped     error
+		errMessage     string
+	}{
+		"signed_by_authority_and_peer": {
+			encodedBuilder: func(t *testing.T) []byte {
+				record := encodeRecord(t, p2pAddr(peerID))
+				return encodeSignedRecord(t, record, signWithPeerKey(t, privateKey, record))
+			},
+			authority: authority,
+			addrInfo:  peer.AddrInfo{ID: peerID, Addrs: []ma.Multiaddr{addr}},
+		},
+		"without_peer_signature": {
+			encodedBuilder: func(t *testing.T) []byte {
+				return encodeSignedRecord(t, encodeRecord(t, p2pAddr(peerID)), nil)
+			},
+			authority: authority,
+			addrInfo:  peer.AddrInfo{ID: peerID, Addrs: []ma.Multiaddr{addr}},
+		},
+		"signed_by_other_authority": {
+			encodedBuilder: func(t *testing.T) []byte {
+				return encodeSignedRecord(t, encodeRecord(t, p2pAddr(peerID)), nil)
+			},
+			authority:  otherAuthority,
+			errWrapped: gossamercrypto.ErrSignatureVerificationFailed,
+		},
+		"no_address": {
+			encodedBuilder: func(t *testing.T) []byte {
+				return encodeSignedRecord(t, encodeRecord(t), nil)
+			},
+			authority:  authority,
+			errWrapped: errAuthorityRecordNoAddress,
+			errMessage: "authority record has no address",
+		},
+		"addresses_of_different_peers": {
+			encodedBuilder: func(t *testing.T) []byte {
+				return encodeSignedRecord(t, encodeRecord(t, p2pAddr(peerID), p2pAddr(otherPeerID)), nil)
+			},
+			authority:  authority,
+			errWrapped: errAuthorityRecordPeerMismatch,
+			errMessage: "authority record addresses have different peer ids: " +
+				peerID.String() + " and " + otherPeerID.String(),
+		},
+		"signed_by_other_peer": {
+			encodedBuilder: func(t *testing.T) []byte {
+				record := encodeRecord(t, p2pAddr(peerID))
+				return encodeSignedRecord(t, record, signWithPeerKey(t, otherPrivateKey, record))
+			},
+			authority:  authority,
+			errWrapped: errPeerSignatureNotValid,
+			errMessage: "verifying peer signature: peer signature is not valid: " +
+				"public key does not match peer id " + peerID.String(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded := testCase.encodedBuilder(t)
+
+			addrInfo, err := decodeSignedAuthorityRecord(testCase.authority, encoded)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			if testCase.errWrapped == nil {
+				assert.Equal(t, testCase.addrInfo, addrInfo)
+			}
+		})
+	}
+}
+
+func Test_authorityDiscovery_publish_noPublicAddress(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := keystore.NewGlobalKeystore().Audi
+	err = ks.Insert(keypair)
+	require.NoError(t, err)
+	var authority types.AuthorityID
+	copy(authority[:], keypair.Public().Encode())
+
+	api := NewMockAuthorityDiscoveryAPI(gomock.NewController(t))
+	api.EXPECT().AuthorityDiscoveryAuthorities().Return([]types.AuthorityID{authority}, nil)
+	records := mapRecordStore{}
+	a := newTestAuthorityDiscovery(t, records, api, nil, ks)
+	a.addrs = func() []ma.Multiaddr {
+		return []ma.Multiaddr{
+			ma.StringCast("/ip4/127.0.0.1/tcp/30333"),
+			ma.StringCast("/ip4/192.168.1.2/tcp/30333"),
+		}
+	}
+
+	err = a.publish()
+	assert.ErrorIs(t, err, errNoPublicAddress)
+	assert.Empty(t, records)
+}
+
+func Test_publicAddrs(t *testing.T) {
+	t.Parallel()
+
+	addrs := []ma.Multiaddr{
+		ma.StringCast("/ip4/127.0.0.1/tcp/30333"),
+		ma.StringCast("/ip4/10.0.0.1/tcp/30333"),
+		ma.StringCast("/ip4/1.2.3.4/tcp/30333"),
+		ma.StringCast("/ip6/::1/tcp/30333"),
+		ma.StringCast("/ip6/2001:db8::1/tcp/30333"),
+		ma.StringCast("/dns4/example.com/tcp/30333"),
+	}
+
+	public := publicAddrs(addrs)
+
+	expected := []ma.Multiaddr{
+		ma.StringCast("/ip4/1.2.3.4/tcp/30333"),
+		ma.StringCast("/ip6/2001:db8::1/tcp/30333"),
+		ma.StringCast("/dns4/example.com/tcp/30333"),
+	}
+	assert.Equal(t, expected, public)
+}
+
+func Test_authorityRecordValidator(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	var authority types.AuthorityID
+	copy(authority[:], keypair.Public().Encode())
+	forgerKeypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	privateKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	encodeSignedRecord := func(t *testing.T, signer *sr25519.Keypair) []byte {
+		t.Helper()
+		addr := ma.StringCast("/ip4/1.2.3.4/tcp/30333/p2p/" + peerID.String())
+		record, err := proto.Marshal(&pb.AuthorityRecord{Addresses: [][]byte{addr.Bytes()}})
+		require.NoError(t, err)
+		authSignature, err := signer.Sign(record)
+		require.NoError(t, err)
+		encoded, err := proto.Marshal(&pb.SignedAuthorityRecord{
+			Record:        record,
+			AuthSignature: authSignature,
+		})
+		require.NoError(t, err)
+		return encoded
+	}
+
+	valid := encodeSignedRecord(t, keypair)
+	forged := encodeSignedRecord(t, forgerKeypair)
+	key := string(authorityRecordKey(authority[:]))
+
+	validator := newAuthorityRecordValidator()
+
+	// records of unknown authorities are only decoded
+	err = validator.Validate(key, forged)
+	require.NoError(t, err)
+	err = validator.Validate(key, []byte{0xff})
+	require.Error(t, err)
+
+	validator.setAuthorities([]types.AuthorityID{authority})
+
+	err = validator.Validate(key, valid)
+	require.NoError(t, err)
+	err = validator.Validate(key, forged)
+	assert.ErrorIs(t, err, gossamercrypto.ErrSignatureVerificationFailed)
+
+	index, err := validator.Select(key, [][]byte{forged, valid})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = validator.Select(key, [][]byte{forged})
+	assert.ErrorIs(t, err, errNoValidAuthorityRecord)
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/common"
+	bsnet "github.com/ipfs/boxo/bitswap/network"
+	bsserver "github.com/ipfs/boxo/bitswap/server"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	"github.com/multiformats/go-multihash"
+)
+
+const (
+	// blake2b256Multihash is the multihash code of the blake2b-256 hash function,
+	// which is the hash function used to index transaction data.
+	blake2b256Multihash = multihash.BLAKE2B_MIN + 31
+	blake2b256Length    = 32
+)
+
+var (
+	errBitswapReadOnly       = errors.New("bitswap blockstore is read only")
+	errBitswapListingKeys    = errors.New("listing bitswap blockstore keys is not supported")
+	errUnsupportedBitswapCID = errors.New("unsupported content identifier")
+)
+
+// bitswapServer answers the Bitswap want-lists of our peers with the
+// transaction data indexed by the runtime, such as the data stored by
+// the transaction storage pallet.
+type bitswapServer struct {
+	network bsnet.BitSwapNetwork
+	server  *bsserver.Server
+}
+
+func newBitswapServer(ctx context.Context, p2pHost libp2phost.Host, blockState BlockState) *bitswapServer {
+	// the server neither looks for providers nor announces
+	// itself as a provider, so no content routing is needed.
+	network := bsnet.NewFromIpfsHost(p2pHost, nil)
+	blockstore := &bitswapBlockstore{blockState: blockState}
+	server := bsserver.New(ctx, network, blockstore, bsserver.ProvideEnabled(false))
+
+	return &bitswapServer{
+		network: network,
+		server:  server,
+	}
+}
+
+// start registers the Bitswap protocol handlers on the host.
+func (b *bitswapServer) start() {
+	b.network.Start(b.server)
+}
+
+// stop unregisters from the host network notifications and closes the server.
+func (b *bitswapServer) stop() error {
+	b.network.Stop()
+	return b.server.Close()
+}
+
+// bitswapBlockstore is a read only blockstore backed by the transaction data
+// indexed in the block state. The content identifier of the data is a CIDv1
+// of any codec, whose multihash is the blake2b-256 hash of the data.
+type bitswapBlockstore struct {
+	blockState BlockState
+}
+
+// indexedTransactionCID returns the content identifier under which
+// the transaction data indexed with the given hash is served.
+func indexedTransactionCID(hash common.Hash) (cid.Cid, error) {
+	encoded, err := multihash.Encode(hash[:], blake2b256Multihash)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("encoding multihash: %w", err)
+	}
+
+	return cid.NewCidV1(cid.Raw, encoded), nil
+}
+
+// indexedTransactionHash returns the hash of the indexed transaction data
+// identified by the given content identifier.
+func indexedTransactionHash(c cid.Cid) (hash common.Hash, err error) {
+	prefix := c.Prefix()
+	if prefix.Version != 1 || prefix.MhType != blake2b256Multihash || prefix.MhLength != blake2b256Length {
+		return hash, fmt.Errorf("%w: %s", errUnsupportedBitswapCID, c)
+	}
+
+	decoded, err := multihash.Decode(c.Hash())
+	if err != nil {
+		return hash, fmt.Errorf("decoding multihash: %w", err)
+	}
+
+	return common.BytesToHash(decoded.Digest), nil
+}
+
+// Has returns true if transaction data is indexed for the given content identifier.
+func (b *bitswapBlockstore) Has(_ context.Context, c cid.Cid) (bool, error) {
+	hash, err := indexedTransactionHash(c)
+	if errors.Is(err, errUnsupportedBitswapCID) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return b.blockState.HasIndexedTransaction(hash)
+}
+
+// Get returns the transaction data indexed for the given content identifier.
+func (b *bitswapBlockstore) Get(_ context.Context, c cid.Cid) (blocks.Block, error) {
+	data, err := b.getData(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks.NewBlockWithCid(data, c)
+}
+
+// GetSize returns the size of the transaction data indexed for the given content identifier.
+func (b *bitswapBlockstore) GetSize(_ context.Context, c cid.Cid) (int, error) {
+	data, err := b.getData(c)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func (b *bitswapBlockstore) getData(c cid.Cid) (data []byte, err error) {
+	hash, err := indexedTransactionHash(c)
+	if errors.Is(err, errUnsupportedBitswapCID) {
+		return nil, ipld.ErrNotFound{Cid: c}
+	} else if err != nil {
+		return nil, err
+	}
+
+	data, err = b.blockState.GetIndexedTransaction(hash)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return nil, ipld.ErrNotFound{Cid: c}
+	} else if err != nil {
+		return nil, fmt.Errorf("getting indexed transaction %s: %w", hash, err)
+	}
+
+	return data, nil
+}
+
+// DeleteBlock always returns an error since the blockstore is read only.
+func (*bitswapBlockstore) DeleteBlock(context.Context, cid.Cid) error {
+	return errBitswapReadOnly
+}
+
+// Put always returns an error since the blockstore is read only.
+func (*bitswapBlockstore) Put(context.Context, blocks.Block) error {
+	return errBitswapReadOnly
+}
+
+// PutMany always returns an error since the blockstore is read only.
+func (*bitswapBlockstore) PutMany(context.Context, []blocks.Block) error {
+	return errBitswapReadOnly
+}
+
+// AllKeysChan always returns an error since indexed transactions cannot be listed.
+func (*bitswapBlockstore) AllKeysChan(context.Context) (<-chan cid.Cid, error) {
+	return nil, errBitswapListingKeys
+}
+
+// HashOnRead does nothing since the indexed transaction data is looked up by its hash.
+func (*bitswapBlockstore) HashOnRead(bool) {}
@@ -0,0 +1,90 @@
+//go:build integration
+
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/golang/mock/gomock"
+	bsclient "github.com/ipfs/boxo/bitswap/client"
+	bsnet "github.com/ipfs/boxo/bitswap/network"
+	"github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitswapServer(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	data := []byte("transaction storage data")
+	hash := common.MustBlake2bHash(data)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().BestBlockHeader().Return(types.NewEmptyHeader(), nil).AnyTimes()
+	blockState.EXPECT().GetHighestFinalisedHeader().Return(types.NewEmptyHeader(), nil).AnyTimes()
+	blockState.EXPECT().GenesisHash().Return(common.NewHash([]byte{})).AnyTimes()
+	blockState.EXPECT().HasIndexedTransaction(gomock.Any()).
+		DoAndReturn(func(h common.Hash) (bool, error) { return h == hash, nil }).AnyTimes()
+	blockState.EXPECT().GetIndexedTransaction(gomock.Any()).
+		DoAndReturn(func(h common.Hash) ([]byte, error) {
+			if h != hash {
+				return nil, chaindb.ErrKeyNotFound
+			}
+			return data, nil
+		}).AnyTimes()
+
+	config := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+		BlockState:  blockState,
+		IPFSServer:  true,
+	}
+	node := createTestService(t, config)
+
+	// in-process IPFS client fetching the indexed data over Bitswap
+	clientHost, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := clientHost.Close()
+		assert.NoError(t, err)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientNetwork := bsnet.NewFromIpfsHost(clientHost, nil)
+	clientBlockstore := blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	// the client only asks the peers it is connected to, and never looks for providers
+	client := bsclient.New(ctx, clientNetwork, clientBlockstore, bsclient.ProviderSearchDelay(time.Hour))
+	clientNetwork.Start(client)
+	t.Cleanup(func() {
+		clientNetwork.Stop()
+		err := client.Close()
+		assert.NoError(t, err)
+	})
+
+	err = clientHost.Connect(ctx, addrInfo(node.host))
+	require.NoError(t, err)
+
+	indexedCID, err := indexedTransactionCID(hash)
+	require.NoError(t, err)
+
+	block, err := client.GetBlock(ctx, indexedCID)
+	require.NoError(t, err)
+	assert.Equal(t, indexedCID, block.Cid())
+	assert.Equal(t, data, block.RawData())
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/golang/mock/gomock"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_indexedTransactionHash(t *testing.T) {
+	t.Parallel()
+
+	hash := common.MustBlake2bHash([]byte("data"))
+
+	blake2bCID, err := indexedTransactionCID(hash)
+	require.NoError(t, err)
+
+	// the codec of the content identifier is not checked
+	blake2bDagPb := cid.NewCidV1(cid.DagProtobuf, blake2bCID.Hash())
+
+	sha256Hash, err := multihash.Sum([]byte("data"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+
+	blake2b512Hash, err := multihash.Sum([]byte("data"), multihash.BLAKE2B_MAX, -1)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		cid        cid.Cid
+		hash       common.Hash
+		errWrapped error
+	}{
+		"blake2b_raw": {
+			cid:  blake2bCID,
+			hash: hash,
+		},
+		"blake2b_dag_pb": {
+			cid:  blake2bDagPb,
+			hash: hash,
+		},
+		"cid_v0": {
+			cid:        cid.NewCidV0(sha256Hash),
+			errWrapped: errUnsupportedBitswapCID,
+		},
+		"sha256": {
+			cid:        cid.NewCidV1(cid.Raw, sha256Hash),
+			errWrapped: errUnsupportedBitswapCID,
+		},
+		"blake2b_512": {
+			cid:        cid.NewCidV1(cid.Raw, blake2b512Hash),
+			errWrapped: errUnsupportedBitswapCID,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hash, err := indexedTransactionHash(testCase.cid)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.hash, hash)
+		})
+	}
+}
+
+func Test_bitswapBlockstore_Get(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("indexed transaction")
+	hash := common.MustBlake2bHash(data)
+	indexedCID, err := indexedTransactionCID(hash)
+	require.NoError(t, err)
+
+	sha256Hash, err := multihash.Sum(data, multihash.SHA2_256, -1)
+	require.NoError(t, err)
+	sha256CID := cid.NewCidV1(cid.Raw, sha256Hash)
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		cid               cid.Cid
+		data              []byte
+		notFound          bool
+		errWrapped        error
+		errMessage        string
+	}{
+		"unsupported_cid": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			cid:        sha256CID,
+			notFound:   true,
+			errMessage: "ipld: could not find " + sha256CID.String(),
+		},
+		"not_indexed": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetIndexedTransaction(hash).Return(nil, chaindb.ErrKeyNotFound)
+				return blockState
+			},
+			cid:        indexedCID,
+			notFound:   true,
+			errMessage: "ipld: could not find " + indexedCID.String(),
+		},
+		"get_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetIndexedTransaction(hash).Return(nil, errTest)
+				return blockState
+			},
+			cid:        indexedCID,
+			errWrapped: errTest,
+			errMessage: "getting indexed transaction " + hash.String() + ": test error",
+		},
+		"indexed": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetIndexedTransaction(hash).Return(data, nil)
+				return blockState
+			},
+			cid:  indexedCID,
+			data: data,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockstore := &bitswapBlockstore{
+				blockState: testCase.blockStateBuilder(ctrl),
+			}
+
+			block, err := blockstore.Get(context.Background(), testCase.cid)
+
+			assert.Equal(t, testCase.notFound, ipld.IsNotFound(err))
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.cid, block.Cid())
+			assert.Equal(t, testCase.data, block.RawData())
+		})
+	}
+}
+
+func Test_bitswapBlockstore_Has(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	hash := common.MustBlake2bHash([]byte("indexed transaction"))
+	indexedCID, err := indexedTransactionCID(hash)
+	require.NoError(t, err)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().HasIndexedTransaction(hash).Return(true, nil)
+	blockstore := &bitswapBlockstore{blockState: blockState}
+
+	has, err := blockstore.Has(context.Background(), indexedCID)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	sha256Hash, err := multihash.Sum([]byte("indexed transaction"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+
+	has, err = blockstore.Has(context.Background(), cid.NewCidV1(cid.Raw, sha256Hash))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	err = blockstore.Put(context.Background(), nil)
+	assert.ErrorIs(t, err, errBitswapReadOnly)
+}
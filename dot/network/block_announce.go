@@ -172,6 +172,13 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 		return errors.New("genesis hash mismatch")
 	}
 
+	// light clients and authorities occupy their own slots, and the roles of a peer
+	// are only known once we receive its handshake. Full nodes are also classified,
+	// since incoming peers may be accepted before a full node slot is available.
+	// TODO: currently we only have one set so setID is 0, change this once we have more set in peerSet
+	const setID = 0
+	s.host.cm.peerSetHandler.SetPeerClass(setID, peerClassFromRoles(bhs.Roles), from)
+
 	np, ok := s.notificationsProtocols[blockAnnounceMsgType]
 	if !ok {
 		// this should never happen.
@@ -186,6 +193,12 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 		np.peersData.setInboundHandshakeData(from, data)
 	}
 
+	// light clients are never synced from, and the syncer is told about them
+	// whatever their best block so it does not pick them as sync targets.
+	if bhs.Roles == common.LightClientRole {
+		return s.syncer.HandleBlockAnnounceHandshake(from, bhs)
+	}
+
 	// if peer has higher best block than us, begin syncing
 	latestHeader, err := s.blockState.BestBlockHeader()
 	if err != nil {
@@ -200,6 +213,44 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 	return s.syncer.HandleBlockAnnounceHandshake(from, bhs)
 }
 
+// peerClassFromRoles returns the peerset class of a peer with the roles given.
+func peerClassFromRoles(roles common.Roles) peerset.PeerClass {
+	switch roles {
+	case common.LightClientRole:
+		return peerset.LightClientPeer
+	case common.AuthorityRole:
+		return peerset.AuthorityPeer
+	default:
+		return peerset.FullNodePeer
+	}
+}
+
+// validateBlockAnnounceMessage validates a BlockAnnounce message before it is handled.
+// Announces of the genesis block are invalid, and announces of blocks not
+// above our highest finalised block are outdated.
+func (s *Service) validateBlockAnnounceMessage(from peer.ID, msg NotificationsMessage) ValidationResult {
+	bam, ok := msg.(*BlockAnnounceMessage)
+	if !ok {
+		return ValidationPenalise
+	}
+
+	if bam.Number == 0 {
+		return ValidationPenalise
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		logger.Warnf("failed to get highest finalised header to validate block announce from peer %s: %s", from, err)
+		return ValidationKeep
+	}
+
+	if bam.Number <= finalised.Number {
+		return ValidationDiscard
+	}
+
+	return ValidationKeep
+}
+
 // handleBlockAnnounceMessage handles BlockAnnounce messages
 // if some more blocks are required to sync the announced block, the node will open a sync stream
 // with its peer and send a BlockRequest message
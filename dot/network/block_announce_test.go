@@ -4,10 +4,14 @@
 package network
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,3 +70,106 @@ func Test_BlockAnnounceMessage_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_peerClassFromRoles(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		roles common.Roles
+		class peerset.PeerClass
+	}{
+		"full_node": {
+			roles: common.FullNodeRole,
+			class: peerset.FullNodePeer,
+		},
+		"light_client": {
+			roles: common.LightClientRole,
+			class: peerset.LightClientPeer,
+		},
+		"authority": {
+			roles: common.AuthorityRole,
+			class: peerset.AuthorityPeer,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			class := peerClassFromRoles(testCase.roles)
+
+			require.Equal(t, testCase.class, class)
+		})
+	}
+}
+
+func Test_Service_validateBlockAnnounceMessage(t *testing.T) {
+	t.Parallel()
+
+	finalisedHeader := &types.Header{Number: 5}
+
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		msg               NotificationsMessage
+		result            ValidationResult
+	}{
+		"not_block_announce": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			msg:    &TransactionMessage{},
+			result: ValidationPenalise,
+		},
+		"genesis_block": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			msg:    &BlockAnnounceMessage{Number: 0},
+			result: ValidationPenalise,
+		},
+		"finalised_header_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(nil, errors.New("test error"))
+				return blockState
+			},
+			msg:    &BlockAnnounceMessage{Number: 1},
+			result: ValidationKeep,
+		},
+		"finalised_block": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalisedHeader, nil)
+				return blockState
+			},
+			msg:    &BlockAnnounceMessage{Number: 5},
+			result: ValidationDiscard,
+		},
+		"block_above_finalised_block": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalisedHeader, nil)
+				return blockState
+			},
+			msg:    &BlockAnnounceMessage{Number: 6},
+			result: ValidationKeep,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				blockState: testCase.blockStateBuilder(ctrl),
+			}
+
+			result := service.validateBlockAnnounceMessage("", testCase.msg)
+
+			assert.Equal(t, testCase.result, result)
+		})
+	}
+}
@@ -12,9 +12,11 @@
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 )
 
 const (
@@ -42,9 +44,23 @@
 	// DefaultMaxPeerCount is the default maximum peer count
 	DefaultMaxPeerCount = 50
 
+	// DefaultMaxLightPeerCount is the default maximum light client peer count
+	DefaultMaxLightPeerCount = 100
+
+	// DefaultMaxAuthorityPeerCount is the default maximum authority peer count
+	DefaultMaxAuthorityPeerCount = 25
+
 	// DefaultDiscoveryInterval is the default interval for searching for DHT peers
 	DefaultDiscoveryInterval = time.Minute * 5
 
+	// DefaultMaxInboundRequestsPerSecond is the default maximum number of
+	// inbound sync and light requests accepted from a peer every second
+	DefaultMaxInboundRequestsPerSecond = 32
+
+	// DefaultMaxConcurrentInboundRequests is the default maximum number of
+	// inbound sync and light requests of a peer handled concurrently
+	DefaultMaxConcurrentInboundRequests = 4
+
 	defaultTxnBatchSize = 100
 )
 
@@ -89,9 +105,22 @@ type Config struct {
 
 	MinPeers int
 	MaxPeers int
+	// MaxLightPeers is the maximum number of light client peers,
+	// which do not count towards the full node peers.
+	MaxLightPeers int
+	// MaxAuthorityPeers is the maximum number of authority peers,
+	// which do not count towards the full node peers.
+	MaxAuthorityPeers int
 
 	DiscoveryInterval time.Duration
 
+	// MaxInboundRequestsPerSecond is the maximum number of inbound sync and light requests
+	// accepted from a peer every second, and the peer is penalised for any request above it.
+	MaxInboundRequestsPerSecond uint32
+	// MaxConcurrentInboundRequests is the maximum number of inbound sync and light requests
+	// of a peer handled concurrently, and the peer is penalised for any request above it.
+	MaxConcurrentInboundRequests uint32
+
 	// PersistentPeers is a list of multiaddrs which the node should remain connected to
 	PersistentPeers []string
 
@@ -111,6 +140,24 @@ type Config struct {
 
 	Telemetry Telemetry
 	Metrics   metrics.IntervalConfig
+
+	// AuthorityDiscoveryAPI gives the authority discovery identifiers of the authorities
+	// whose addresses are resolved from the DHT, and authority discovery is disabled if it is nil.
+	AuthorityDiscoveryAPI AuthorityDiscoveryAPI
+	// AuthorityDiscoveryKeystore holds the authority discovery keys used to sign the
+	// addresses we publish in the DHT, and addresses are not published if it is nil.
+	AuthorityDiscoveryKeystore keystore.Keystore
+	// AllowNonGlobalsInDHT allows publishing and resolving loopback and private
+	// authority addresses in the DHT, which is only useful for local test networks.
+	AllowNonGlobalsInDHT bool
+
+	// IPFSServer enables the Bitswap server answering the want-lists
+	// of our peers with the transaction data indexed by the runtime.
+	IPFSServer bool
+
+	// PeerSetDatabase persists the peer reputations and bans across
+	// restarts, and they are only kept in memory if it is nil.
+	PeerSetDatabase peerset.Database
 }
 
 // build checks the configuration, sets up the private key for the network service,
@@ -43,20 +43,22 @@ type discovery struct {
 	pid                protocol.ID
 	minPeers, maxPeers int
 	handler            PeerSetHandler
+	recordValidator    *authorityRecordValidator
 }
 
 func newDiscovery(ctx context.Context, h libp2phost.Host,
 	bootnodes []peer.AddrInfo, ds *badger.Datastore,
 	pid protocol.ID, min, max int, handler PeerSetHandler) *discovery {
 	return &discovery{
-		ctx:       ctx,
-		h:         h,
-		bootnodes: bootnodes,
-		ds:        ds,
-		pid:       pid,
-		minPeers:  min,
-		maxPeers:  max,
-		handler:   handler,
+		ctx:             ctx,
+		h:               h,
+		bootnodes:       bootnodes,
+		ds:              ds,
+		pid:             pid,
+		minPeers:        min,
+		maxPeers:        max,
+		handler:         handler,
+		recordValidator: newAuthorityRecordValidator(),
 	}
 }
 
@@ -107,6 +109,11 @@ func (d *discovery) start() error {
 		dual.DHTOption(kaddht.BootstrapPeers(d.bootnodes...)),
 		dual.DHTOption(kaddht.V1ProtocolOverride(d.pid + "/kad")),
 		dual.DHTOption(kaddht.Mode(kaddht.ModeAutoServer)),
+		// the DHT records are the signed authority records keyed by the hashed
+		// authority identifiers, so the protocol prefix is changed from the
+		// default one which requires the /pk and /ipns namespaced records.
+		dual.DHTOption(kaddht.ProtocolPrefix(d.pid)),
+		dual.DHTOption(kaddht.Validator(d.recordValidator)),
 	}
 
 	// create DHT service
@@ -222,3 +229,14 @@ func (d *discovery) findPeers() {
 func (d *discovery) findPeer(peerID peer.ID) (peer.AddrInfo, error) {
 	return d.dht.FindPeer(d.ctx, peerID)
 }
+
+// putValue stores the value with the given key in the DHT.
+func (d *discovery) putValue(key, value []byte) error {
+	return d.dht.PutValue(d.ctx, string(key), value)
+}
+
+// getValue gets the value with the given key from the DHT,
+// until the context given is canceled.
+func (d *discovery) getValue(ctx context.Context, key []byte) (value []byte, err error) {
+	return d.dht.GetValue(ctx, string(key))
+}
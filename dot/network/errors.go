@@ -16,4 +16,5 @@
 	errInvalidStartingBlockType      = errors.New("invalid StartingBlock in messsage")
 	errInboundHanshakeExists         = errors.New("an inbound handshake already exists for given peer")
 	errInvalidRole                   = errors.New("invalid role")
+	errNotificationsProtocolNotFound = errors.New("notifications protocol not found")
 )
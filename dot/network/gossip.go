@@ -6,43 +6,161 @@
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-// gossip submodule
+const (
+	// maxSeenGossipMessages is the number of message hashes kept
+	// to detect the gossip messages we have already seen.
+	maxSeenGossipMessages = 8192
+
+	// defaultRebroadcastInterval is the interval at which the messages of the
+	// notifications protocols rebroadcasting periodically are gossiped again.
+	defaultRebroadcastInterval = 30 * time.Second
+)
+
+// ValidationResult is the result of the validation of a gossip message.
+type ValidationResult byte
+
+const (
+	// ValidationKeep is returned for a valid message, which is handled and
+	// propagated following the rebroadcast policy of its notifications protocol.
+	ValidationKeep ValidationResult = iota
+	// ValidationDiscard is returned for a message which is dropped
+	// without being handled, such as an outdated message.
+	ValidationDiscard
+	// ValidationPenalise is returned for an invalid message, which is dropped
+	// and lowers the reputation of the peer which sent it.
+	ValidationPenalise
+	// ValidationProcess is returned for a valid message which is handled but
+	// neither propagated nor kept to be rebroadcast, such as a message for a single peer.
+	ValidationProcess
+)
+
+// String returns the string representation of the validation result.
+func (v ValidationResult) String() string {
+	switch v {
+	case ValidationKeep:
+		return "keep"
+	case ValidationDiscard:
+		return "discard"
+	case ValidationPenalise:
+		return "penalise"
+	case ValidationProcess:
+		return "process"
+	default:
+		return fmt.Sprintf("unknown validation result %d", byte(v))
+	}
+}
+
+// MessageValidator validates a gossip message received from a peer before it is handled.
+type MessageValidator = func(from peer.ID, msg NotificationsMessage) ValidationResult
+
+// RebroadcastPolicy defines how the gossip messages of a notifications protocol are propagated.
+type RebroadcastPolicy byte
+
+const (
+	// RebroadcastOnce gossips a message once, to the peers
+	// connected when the message is received or gossiped.
+	RebroadcastOnce RebroadcastPolicy = iota
+	// RebroadcastPeriodically also gossips a message again periodically to the
+	// connected peers not knowing it yet, as long as its validator keeps it and
+	// for at most the duration peers are remembered to know a message.
+	RebroadcastPeriodically
+)
+
+// gossipTopic holds the gossip configuration of a notifications protocol.
+type gossipTopic struct {
+	validator   MessageValidator
+	rebroadcast RebroadcastPolicy
+}
+
+// keptMessage is a gossip message rebroadcast periodically.
+type keptMessage struct {
+	from   peer.ID
+	msg    NotificationsMessage
+	expiry time.Time
+}
+
+// gossip submodule, which tracks the messages seen and the
+// messages to rebroadcast periodically to our peers.
 type gossip struct {
-	logger    Logger
-	seenMap   map[common.Hash]struct{}
-	seenMutex sync.RWMutex
+	logger Logger
+	seen   *lru.Cache[common.Hash, struct{}]
+
+	rebroadcastInterval time.Duration
+	kept                map[common.Hash]*keptMessage
+	keptMutex           sync.Mutex
 }
 
 // newGossip creates a new gossip message tracker
 func newGossip() *gossip {
+	seen, err := lru.New[common.Hash, struct{}](maxSeenGossipMessages)
+	if err != nil {
+		panic(err) // only happens for a non-positive size
+	}
+
 	return &gossip{
-		logger:  log.NewFromGlobal(log.AddContext("module", "gossip")),
-		seenMap: make(map[common.Hash]struct{}),
+		logger:              log.NewFromGlobal(log.AddContext("module", "gossip")),
+		seen:                seen,
+		rebroadcastInterval: defaultRebroadcastInterval,
+		kept:                make(map[common.Hash]*keptMessage),
 	}
 }
 
-// hasSeen checks if we have seen the given message before.
+// hasSeen checks if we have seen the given message before,
+// and marks the message as seen if not.
 func (g *gossip) hasSeen(msg NotificationsMessage) (bool, error) {
 	msgHash, err := msg.Hash()
 	if err != nil {
 		return false, fmt.Errorf("could not hash notification message: %w", err)
 	}
 
-	g.seenMutex.Lock()
-	defer g.seenMutex.Unlock()
+	seen, _ := g.seen.ContainsOrAdd(msgHash, struct{}{})
+	return seen, nil
+}
+
+// keep keeps the message to rebroadcast it periodically until the given expiry time.
+func (g *gossip) keep(from peer.ID, msg NotificationsMessage, expiry time.Time) error {
+	msgHash, err := msg.Hash()
+	if err != nil {
+		return fmt.Errorf("could not hash notification message: %w", err)
+	}
+
+	g.keptMutex.Lock()
+	defer g.keptMutex.Unlock()
+
+	if _, has := g.kept[msgHash]; has {
+		return nil
+	}
+
+	g.kept[msgHash] = &keptMessage{
+		from:   from,
+		msg:    msg,
+		expiry: expiry,
+	}
+	return nil
+}
+
+// keptMessages removes the kept messages expired at the given time
+// or no longer kept by the given function, and returns the others.
+func (g *gossip) keptMessages(now time.Time, stillKept func(*keptMessage) bool) []*keptMessage {
+	g.keptMutex.Lock()
+	defer g.keptMutex.Unlock()
 
-	// check if message has not been seen
-	_, ok := g.seenMap[msgHash]
-	if !ok {
-		// set message to has been seen
-		g.seenMap[msgHash] = struct{}{}
-		return false, nil
+	messages := make([]*keptMessage, 0, len(g.kept))
+	for msgHash, kept := range g.kept {
+		if !now.Before(kept.expiry) || !stillKept(kept) {
+			delete(g.kept, msgHash)
+			continue
+		}
+		messages = append(messages, kept)
 	}
 
-	return true, nil
+	return messages
 }
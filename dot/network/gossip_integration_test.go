@@ -92,12 +92,12 @@ func TestGossip(t *testing.T) {
 	hash, err := announceMessage.Hash()
 	require.NoError(t, err)
 
-	_, ok := nodeB.gossip.seenMap[hash]
+	ok := nodeB.gossip.seen.Contains(hash)
 	require.True(t, ok, "node B did not receive block request message from node A")
 
-	_, ok = nodeC.gossip.seenMap[hash]
+	ok = nodeC.gossip.seen.Contains(hash)
 	require.True(t, ok, "node C did not receive block request message from node B")
 
-	_, ok = nodeA.gossip.seenMap[hash]
+	ok = nodeA.gossip.seen.Contains(hash)
 	require.True(t, ok, "node A did not receive block request message from node C")
 }
@@ -0,0 +1,114 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gossip_hasSeen(t *testing.T) {
+	t.Parallel()
+
+	g := newGossip()
+	msg := &TransactionMessage{Extrinsics: []types.Extrinsic{{1}}}
+
+	seen, err := g.hasSeen(msg)
+	require.NoError(t, err)
+	assert.False(t, seen)
+
+	seen, err = g.hasSeen(msg)
+	require.NoError(t, err)
+	assert.True(t, seen)
+
+	otherMsg := &TransactionMessage{Extrinsics: []types.Extrinsic{{2}}}
+	seen, err = g.hasSeen(otherMsg)
+	require.NoError(t, err)
+	assert.False(t, seen)
+}
+
+func Test_gossip_keptMessages(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	msgA := &TransactionMessage{Extrinsics: []types.Extrinsic{{1}}}
+	msgB := &TransactionMessage{Extrinsics: []types.Extrinsic{{2}}}
+	msgC := &TransactionMessage{Extrinsics: []types.Extrinsic{{3}}}
+
+	g := newGossip()
+	err := g.keep(peer.ID("a"), msgA, now.Add(time.Minute))
+	require.NoError(t, err)
+	// keeping a message already kept does not change its expiry
+	err = g.keep(peer.ID("a"), msgA, now.Add(time.Hour))
+	require.NoError(t, err)
+	err = g.keep(peer.ID("b"), msgB, now.Add(time.Hour))
+	require.NoError(t, err)
+	err = g.keep(peer.ID(""), msgC, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	stillKept := func(kept *keptMessage) bool {
+		return kept.msg != msgB
+	}
+
+	kept := g.keptMessages(now, stillKept)
+	require.Len(t, kept, 2)
+	keptMessages := []NotificationsMessage{kept[0].msg, kept[1].msg}
+	assert.ElementsMatch(t, []NotificationsMessage{msgA, msgC}, keptMessages)
+
+	// message B is no longer kept and message A is expired
+	kept = g.keptMessages(now.Add(time.Minute), func(*keptMessage) bool { return true })
+	expected := []*keptMessage{{from: peer.ID(""), msg: msgC, expiry: now.Add(time.Hour)}}
+	assert.Equal(t, expected, kept)
+}
+
+func Test_notificationsProtocol_validate(t *testing.T) {
+	t.Parallel()
+
+	msg := &TransactionMessage{}
+	np := &notificationsProtocol{}
+
+	// messages are kept when no validator is set
+	result := np.validate(peer.ID("a"), msg)
+	assert.Equal(t, ValidationKeep, result)
+
+	np.setGossipTopic(gossipTopic{
+		validator: func(from peer.ID, m NotificationsMessage) ValidationResult {
+			assert.Equal(t, peer.ID("a"), from)
+			assert.Equal(t, msg, m)
+			return ValidationPenalise
+		},
+		rebroadcast: RebroadcastPeriodically,
+	})
+
+	result = np.validate(peer.ID("a"), msg)
+	assert.Equal(t, ValidationPenalise, result)
+	assert.Equal(t, RebroadcastPeriodically, np.getGossipTopic().rebroadcast)
+}
+
+func Test_Service_RegisterGossipValidator(t *testing.T) {
+	t.Parallel()
+
+	np := &notificationsProtocol{}
+	s := &Service{
+		notificationsProtocols: map[byte]*notificationsProtocol{
+			transactionMsgType: np,
+		},
+	}
+
+	validator := func(peer.ID, NotificationsMessage) ValidationResult { return ValidationDiscard }
+
+	err := s.RegisterGossipValidator(blockAnnounceMsgType, validator, RebroadcastOnce)
+	assert.ErrorIs(t, err, errNotificationsProtocolNotFound)
+	assert.EqualError(t, err, "notifications protocol not found: message type 3")
+
+	err = s.RegisterGossipValidator(transactionMsgType, validator, RebroadcastPeriodically)
+	require.NoError(t, err)
+	assert.Equal(t, RebroadcastPeriodically, np.getGossipTopic().rebroadcast)
+	assert.Equal(t, ValidationDiscard, np.validate(peer.ID("a"), &TransactionMessage{}))
+}
@@ -27,6 +27,7 @@
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
+	"golang.org/x/exp/slices"
 )
 
 func newPrivateIPFilters() (privateIPs *ma.Filters, err error) {
@@ -73,12 +74,17 @@ type host struct {
 	discovery       *discovery
 	bootnodes       []peer.AddrInfo
 	persistentPeers []peer.AddrInfo
-	protocolID      protocol.ID
-	cm              *ConnManager
-	ds              *badger.Datastore
-	messageCache    *messageCache
-	bwc             *metrics.BandwidthCounter
-	closeSync       sync.Once
+	// protocolID is the legacy protocol ID prefix from the chain specification,
+	// and genesisProtocolID is the /<genesis hash> protocol ID prefix preferred to it.
+	protocolID        protocol.ID
+	genesisProtocolID protocol.ID
+	cm                *ConnManager
+	ds                *badger.Datastore
+	messageCache      *messageCache
+	bwc               *metrics.BandwidthCounter
+	// bitswap is nil if the IPFS server is disabled
+	bitswap   *bitswapServer
+	closeSync sync.Once
 }
 
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
@@ -145,15 +151,19 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		return nil, err
 	}
 
-	// We have tried to set maxInPeers and maxOutPeers such that number of peer
-	// connections remain between min peers and max peers
+	// We have tried to set maxInPeers and maxOutPeers such that number of full node
+	// peer connections remain between min peers and max peers, while light clients
+	// and authorities have their own slots.
 	const reservedOnly = false
 	peerCfgSet := peerset.NewConfigSet(
 		uint32(cfg.MaxPeers-cfg.MinPeers),
 		uint32(cfg.MaxPeers/2),
+		uint32(cfg.MaxLightPeers),
+		uint32(cfg.MaxAuthorityPeers),
 		reservedOnly,
 		peerSetSlotAllocTime,
 	)
+	peerCfgSet.Database = cfg.PeerSetDatabase
 
 	// create connection manager
 	cm, err := newConnManager(cfg.MinPeers, cfg.MaxPeers, peerCfgSet)
@@ -165,8 +175,10 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		cm.persistentPeers.Store(pp.ID, struct{}{})
 	}
 
-	// format protocol id
+	// format protocol ids
 	pid := protocol.ID(cfg.ProtocolID)
+	genesisHash := strings.TrimPrefix(cfg.BlockState.GenesisHash().String(), "0x")
+	genesisPID := protocol.ID("/" + genesisHash)
 
 	ds, err := badger.NewDatastore(path.Join(cfg.BasePath, "libp2p-datastore"), &badger.DefaultOptions)
 	if err != nil {
@@ -178,9 +190,14 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		return nil, err
 	}
 
+	// the bandwidth counter is reported every byte sent and received
+	// by the host, and keeps track of them per protocol and per peer
+	bwc := metrics.NewBandwidthCounter()
+
 	// set libp2p host options
 	opts := []libp2p.Option{
 		libp2p.ListenAddrs(addr),
+		libp2p.BandwidthReporter(bwc),
 		libp2p.DisableRelay(),
 		libp2p.Identity(cfg.privateKey),
 		libp2p.NATPortMap(),
@@ -220,20 +237,26 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		return nil, err
 	}
 
-	bwc := metrics.NewBandwidthCounter()
 	discovery := newDiscovery(ctx, h, bns, ds, pid, cfg.MinPeers, cfg.MaxPeers, cm.peerSetHandler)
 
+	var bitswap *bitswapServer
+	if cfg.IPFSServer {
+		bitswap = newBitswapServer(ctx, h, cfg.BlockState)
+	}
+
 	host := &host{
-		ctx:             ctx,
-		p2pHost:         h,
-		discovery:       discovery,
-		bootnodes:       bns,
-		protocolID:      pid,
-		cm:              cm,
-		ds:              ds,
-		persistentPeers: pps,
-		messageCache:    msgCache,
-		bwc:             bwc,
+		ctx:               ctx,
+		p2pHost:           h,
+		discovery:         discovery,
+		bootnodes:         bns,
+		protocolID:        pid,
+		genesisProtocolID: genesisPID,
+		cm:                cm,
+		ds:                ds,
+		persistentPeers:   pps,
+		messageCache:      msgCache,
+		bwc:               bwc,
+		bitswap:           bitswap,
 	}
 
 	cm.host = host
@@ -249,6 +272,15 @@ func (h *host) close() error {
 		return err
 	}
 
+	// close Bitswap server
+	if h.bitswap != nil {
+		err = h.bitswap.stop()
+		if err != nil {
+			logger.Errorf("Failed to close Bitswap server: %s", err)
+			return err
+		}
+	}
+
 	// close libp2p host
 	err = h.p2pHost.Close()
 	if err != nil {
@@ -274,7 +306,40 @@ func (h *host) close() error {
 
 // registerStreamHandler registers the stream handler for the given protocol id.
 func (h *host) registerStreamHandler(pid protocol.ID, handler func(network.Stream)) {
-	h.p2pHost.SetStreamHandler(pid, handler)
+	h.p2pHost.SetStreamHandler(pid, func(stream network.Stream) {
+		h.recordNegotiatedProtocol(stream)
+		handler(stream)
+	})
+}
+
+// newStream opens an outbound stream with the given peer using the first
+// protocol id supported by the peer, in the order of the protocol ids given.
+func (h *host) newStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	stream, err := h.p2pHost.NewStream(ctx, p, pids...)
+	if err != nil {
+		return nil, err
+	}
+
+	h.recordNegotiatedProtocol(stream)
+	return stream, nil
+}
+
+// recordNegotiatedProtocol records the protocol negotiated for the stream, and
+// whether its protocol name is based on the genesis hash or is a legacy one.
+func (h *host) recordNegotiatedProtocol(stream network.Stream) {
+	pid := stream.Protocol()
+
+	name := "legacy"
+	if strings.HasPrefix(string(pid), string(h.genesisProtocolID)+"/") {
+		name = "genesis_hash"
+	}
+
+	direction := "outbound"
+	if isInbound(stream) {
+		direction = "inbound"
+	}
+
+	negotiatedStreamsCounter.WithLabelValues(string(pid), name, direction).Inc()
 }
 
 // connect connects the host to a specific peer address
@@ -301,10 +366,12 @@ func (h *host) bootstrap() {
 }
 
 // send creates a new outbound stream with the given peer and writes the message. It also returns
-// the newly created stream.
-func (h *host) send(p peer.ID, pid protocol.ID, msg Message) (network.Stream, error) {
+// the newly created stream. The fallback protocol ids are used if the peer does not support the
+// protocol id given.
+func (h *host) send(p peer.ID, pid protocol.ID, msg Message,
+	fallbackPIDs ...protocol.ID) (network.Stream, error) {
 	// open outbound stream with host protocol id
-	stream, err := h.p2pHost.NewStream(h.ctx, p, pid)
+	stream, err := h.newStream(h.ctx, p, append([]protocol.ID{pid}, fallbackPIDs...)...)
 	if err != nil {
 		logger.Tracef("failed to open new stream with peer %s using protocol %s: %s", p, pid, err)
 		return nil, err
@@ -312,7 +379,7 @@ func (h *host) send(p peer.ID, pid protocol.ID, msg Message) (network.Stream, er
 
 	logger.Tracef(
 		"Opened stream with host %s, peer %s and protocol %s",
-		h.id(), p, pid)
+		h.id(), p, stream.Protocol())
 
 	err = h.writeToStream(stream, msg)
 	if err != nil {
@@ -321,7 +388,7 @@ func (h *host) send(p peer.ID, pid protocol.ID, msg Message) (network.Stream, er
 
 	logger.Tracef(
 		"Sent message %s to peer %s using protocol %s and host %s",
-		msg, p, pid, h.id())
+		msg, p, stream.Protocol(), h.id())
 
 	return stream, nil
 }
@@ -336,14 +403,8 @@ func (h *host) writeToStream(s network.Stream, msg Message) error {
 	lenBytes := uint64ToLEB128(msgLen)
 	encMsg = append(lenBytes, encMsg...)
 
-	sent, err := s.Write(encMsg)
-	if err != nil {
-		return err
-	}
-
-	h.bwc.LogSentMessage(int64(sent))
-
-	return nil
+	_, err = s.Write(encMsg)
+	return err
 }
 
 // id returns the host id
@@ -389,10 +450,32 @@ func (h *host) removeReservedPeers(ids ...string) error {
 	return nil
 }
 
-// supportsProtocol checks if the protocol is supported by peerID
+// banPeer bans the given peer for the duration given, disconnecting from it
+func (h *host) banPeer(id string, duration time.Duration) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return err
+	}
+
+	h.cm.peerSetHandler.BanPeer(duration, peerID)
+	return nil
+}
+
+// unbanPeer lifts the ban of the given peer
+func (h *host) unbanPeer(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return err
+	}
+
+	h.cm.peerSetHandler.UnbanPeer(peerID)
+	return nil
+}
+
+// supportsProtocol checks if one of the protocols is supported by peerID
 // returns an error if could not get peer protocols
-func (h *host) supportsProtocol(peerID peer.ID, protocol protocol.ID) (bool, error) {
-	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocol)
+func (h *host) supportsProtocol(peerID peer.ID, protocols ...protocol.ID) (bool, error) {
+	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocols...)
 	if err != nil {
 		return false, err
 	}
@@ -434,16 +517,16 @@ func (h *host) closePeer(peer peer.ID) error {
 	return h.p2pHost.Network().ClosePeer(peer)
 }
 
-func (h *host) closeProtocolStream(pID protocol.ID, p peer.ID) {
+func (h *host) closeProtocolStream(pIDs []protocol.ID, p peer.ID) {
 	connToPeer := h.p2pHost.Network().ConnsToPeer(p)
 	for _, c := range connToPeer {
 		for _, st := range c.GetStreams() {
-			if st.Protocol() != pID {
+			if !slices.Contains(pIDs, st.Protocol()) {
 				continue
 			}
 			err := st.Close()
 			if err != nil {
-				logger.Tracef("Failed to close stream for protocol %s: %s", pID, err)
+				logger.Tracef("Failed to close stream for protocol %s: %s", st.Protocol(), err)
 			}
 		}
 	}
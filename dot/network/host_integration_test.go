@@ -416,6 +416,14 @@ func Test_PeerSupportsProtocol(t *testing.T) {
 			protocol: protocol.ID("/gossamer/test/0/transactions/1"),
 			expect:   true,
 		},
+		{
+			protocol: nodeB.host.genesisProtocolID + syncID,
+			expect:   true,
+		},
+		{
+			protocol: nodeB.host.genesisProtocolID + blockAnnounceID,
+			expect:   true,
+		},
 		{
 			protocol: protocol.ID("/gossamer/not_supported/protocol"),
 			expect:   false,
@@ -429,6 +437,64 @@ func Test_PeerSupportsProtocol(t *testing.T) {
 	}
 }
 
+func Test_host_send_fallbackProtocol(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeA := createTestService(t, configA)
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	const (
+		legacyOnlyProtocolID = protocol.ID("/gossamer/test/legacy-only/1")
+		newProtocolID        = protocol.ID("/gossamer/test/new/1")
+		legacyProtocolID     = protocol.ID("/gossamer/test/legacy/1")
+	)
+	handler := newTestStreamHandler(testBlockAnnounceHandshakeDecoder)
+	nodeB.host.registerStreamHandler(legacyOnlyProtocolID, handler.handleStream)
+	nodeB.host.registerStreamHandler(newProtocolID, handler.handleStream)
+	nodeB.host.registerStreamHandler(legacyProtocolID, handler.handleStream)
+
+	addrInfoB := addrInfo(nodeB.host)
+	err := nodeA.host.connect(addrInfoB)
+	// retry connect if "failed to dial" error
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(addrInfoB)
+	}
+	require.NoError(t, err)
+
+	testHandshake := &BlockAnnounceHandshake{
+		Roles:       common.AuthorityRole,
+		GenesisHash: nodeB.blockState.GenesisHash(),
+	}
+
+	// node B only supports the legacy protocol id
+	stream, err := nodeA.host.send(nodeB.host.id(), "/gossamer/test/not-supported/1",
+		testHandshake, legacyOnlyProtocolID)
+	require.NoError(t, err)
+	require.Equal(t, legacyOnlyProtocolID, stream.Protocol())
+
+	// the new protocol id is preferred when node B supports both
+	stream, err = nodeA.host.send(nodeB.host.id(), newProtocolID, testHandshake, legacyProtocolID)
+	require.NoError(t, err)
+	require.Equal(t, newProtocolID, stream.Protocol())
+}
+
 func Test_AddReservedPeers(t *testing.T) {
 	t.Parallel()
 
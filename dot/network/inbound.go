@@ -5,6 +5,7 @@
 
 import (
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"golang.org/x/exp/slices"
 )
 
 func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder, handler messageHandler,
@@ -48,8 +49,6 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 			logger.Tracef("failed to handle message %s from stream id %s: %s", msg, stream.ID(), err)
 			return
 		}
-
-		s.host.bwc.LogRecvMessage(int64(n))
 	}
 }
 
@@ -61,7 +60,7 @@ func (s *Service) resetInboundStream(stream libp2pnetwork.Stream) {
 	defer s.notificationsMu.Unlock()
 
 	for _, prtl := range s.notificationsProtocols {
-		if prtl.protocolID != protocolID {
+		if !slices.Contains(prtl.protocolIDs(), protocolID) {
 			continue
 		}
 
@@ -16,7 +16,7 @@
 
 // handleLightStream handles streams with the <protocol-id>/light/2 protocol ID
 func (s *Service) handleLightStream(stream libp2pnetwork.Stream) {
-	s.readStream(stream, s.decodeLightMessage, s.handleLightMsg, maxBlockResponseSize)
+	s.readStream(stream, s.decodeLightMessage, s.handleLightMsg, MaxBlockResponseSize)
 }
 
 func (s *Service) decodeLightMessage(in []byte, peer peer.ID, _ bool) (Message, error) {
@@ -46,6 +46,12 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 		return nil
 	}
 
+	release, err := s.acquireInboundRequest(stream.Conn().RemotePeer())
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	resp := NewLightResponse()
 	switch {
 	case lr.RemoteCallRequest != nil:
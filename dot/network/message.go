@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/ChainSafe/gossamer/dot/network/proto"
@@ -43,6 +44,7 @@ type NotificationsMessage interface {
 	RequestedDataReceipt       = byte(4)
 	RequestedDataMessageQueue  = byte(8)
 	RequestedDataJustification = byte(16)
+	RequestedDataIndexedBody   = byte(32)
 )
 
 var _ Message = &BlockRequestMessage{}
@@ -75,6 +77,9 @@ type BlockRequestMessage struct {
 	StartingBlock variadic.Uint32OrHash // first byte 0 = block hash (32 byte), first byte 1 = block number (uint32)
 	Direction     SyncDirection         // 0 = ascending, 1 = descending
 	Max           *uint32
+	// SupportMultipleJustifications is true if the requester supports receiving
+	// the justifications of all the consensus engines instead of the GRANDPA justification only.
+	SupportMultipleJustifications bool
 }
 
 // String formats a BlockRequestMessage as a string
@@ -83,11 +88,13 @@ func (bm *BlockRequestMessage) String() string {
 	if bm.Max != nil {
 		max = *bm.Max
 	}
-	return fmt.Sprintf("BlockRequestMessage RequestedData=%d StartingBlock=%v Direction=%d Max=%d",
+	return fmt.Sprintf("BlockRequestMessage RequestedData=%d StartingBlock=%v Direction=%d Max=%d "+
+		"SupportMultipleJustifications=%t",
 		bm.RequestedData,
 		bm.StartingBlock,
 		bm.Direction,
-		max)
+		max,
+		bm.SupportMultipleJustifications)
 }
 
 // Encode returns the protobuf encoded BlockRequestMessage
@@ -98,9 +105,10 @@ func (bm *BlockRequestMessage) Encode() ([]byte, error) {
 	}
 
 	msg := &pb.BlockRequest{
-		Fields:    uint32(bm.RequestedData) << 24, // put byte in most significant byte of uint32
-		Direction: pb.Direction(bm.Direction),
-		MaxBlocks: max,
+		Fields:                        uint32(bm.RequestedData) << 24, // put byte in most significant byte of uint32
+		Direction:                     pb.Direction(bm.Direction),
+		MaxBlocks:                     max,
+		SupportMultipleJustifications: bm.SupportMultipleJustifications,
 	}
 
 	if bm.StartingBlock.IsHash() {
@@ -162,12 +170,16 @@ func (bm *BlockRequestMessage) Decode(in []byte) error {
 	bm.StartingBlock = *startingBlock
 	bm.Direction = SyncDirection(byte(msg.Direction))
 	bm.Max = max
+	bm.SupportMultipleJustifications = msg.SupportMultipleJustifications
 
 	return nil
 }
 
 var _ Message = &BlockResponseMessage{}
 
+// blockResponseBlocksField is the protobuf field number of the blocks of a block response.
+const blockResponseBlocksField = 1
+
 // BlockResponseMessage is sent in response to a BlockRequestMessage
 type BlockResponseMessage struct {
 	BlockData []*types.BlockData
@@ -262,6 +274,18 @@ func blockDataToProtobuf(bd *types.BlockData) (*pb.BlockData, error) {
 		}
 	}
 
+	if bd.Justifications != nil {
+		justifications, err := scale.Marshal(*bd.Justifications)
+		if err != nil {
+			return nil, fmt.Errorf("encoding justifications: %w", err)
+		}
+		p.Justifications = justifications
+	}
+
+	if bd.IndexedBody != nil {
+		p.IndexedBody = *bd.IndexedBody
+	}
+
 	return p, nil
 }
 
@@ -313,9 +337,41 @@ func protobufToBlockData(pbd *pb.BlockData) (*types.BlockData, error) {
 		bd.Justification = &[]byte{}
 	}
 
+	if pbd.Justifications != nil {
+		var justifications types.Justifications
+		err := scale.Unmarshal(pbd.Justifications, &justifications)
+		if err != nil {
+			return nil, fmt.Errorf("decoding justifications: %w", err)
+		}
+		bd.Justifications = &justifications
+
+		// the block data consumers only handle the GRANDPA justification,
+		// which is only sent in the justifications when they are supported.
+		grandpaJustification, ok := justifications.Get(types.GrandpaEngineID)
+		if bd.Justification == nil && ok {
+			bd.Justification = &grandpaJustification
+		}
+	}
+
+	if pbd.IndexedBody != nil {
+		bd.IndexedBody = &pbd.IndexedBody
+	}
+
 	return bd, nil
 }
 
+// BlockDataSize returns the size of the block data once protobuf encoded in a block response message.
+func BlockDataSize(bd *types.BlockData) (size int, err error) {
+	p, err := blockDataToProtobuf(bd)
+	if err != nil {
+		return 0, err
+	}
+
+	// each block data is encoded in the block response with its field tag and length prefix.
+	size = proto.Size(p)
+	return protowire.SizeTag(blockResponseBlocksField) + protowire.SizeBytes(size), nil
+}
+
 var _ NotificationsMessage = &ConsensusMessage{}
 
 // ConsensusMessage is mostly opaque to us
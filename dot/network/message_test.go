@@ -96,7 +96,7 @@ func TestBlockRequestString(t *testing.T) {
 	}
 
 	var blockRequestStringRegex = regexp.MustCompile(
-		`^\ABlockRequestMessage RequestedData=[0-9]* StartingBlock={[\[0-9(\s?)]+\]} Direction=[0-9]* Max=[0-9]*\z$`) //nolint:lll
+		`^\ABlockRequestMessage RequestedData=[0-9]* StartingBlock={[\[0-9(\s?)]+\]} Direction=[0-9]* Max=[0-9]* SupportMultipleJustifications=(true|false)\z$`) //nolint:lll
 
 	match := blockRequestStringRegex.MatchString(bm.String())
 	require.True(t, match)
@@ -256,6 +256,115 @@ func TestEncodeBlockResponseMessage_WithAll(t *testing.T) {
 	require.Equal(t, bm, act)
 }
 
+func TestEncodeBlockResponseMessage_WithIndexedBody(t *testing.T) {
+	t.Parallel()
+
+	exp := common.MustHexToBytes("0x0a2a0a2000000000000000000000000000000000000000000000000000000000000000004a0301020a4a0103") //nolint:lll
+	bd := &types.BlockData{
+		Hash:        common.NewHash([]byte{0}),
+		IndexedBody: &[][]byte{{1, 2, 10}, {3}},
+	}
+
+	bm := &BlockResponseMessage{
+		BlockData: []*types.BlockData{bd},
+	}
+
+	enc, err := bm.Encode()
+	require.NoError(t, err)
+	require.Equal(t, exp, enc)
+
+	act := &BlockResponseMessage{}
+	err = act.Decode(enc)
+	require.NoError(t, err)
+	require.Equal(t, bm, act)
+}
+
+func TestEncodeBlockRequestMessage_SupportMultipleJustifications(t *testing.T) {
+	t.Parallel()
+
+	expected := common.MustHexToBytes("0x0880808080012801300138011a0400010000")
+
+	var one uint32 = 1
+	bm := &BlockRequestMessage{
+		RequestedData:                 RequestedDataJustification,
+		StartingBlock:                 *variadic.MustNewUint32OrHash(uint32(0x100)),
+		Direction:                     Descending,
+		Max:                           &one,
+		SupportMultipleJustifications: true,
+	}
+
+	encMsg, err := bm.Encode()
+	require.NoError(t, err)
+	require.Equal(t, expected, encMsg)
+
+	res := new(BlockRequestMessage)
+	err = res.Decode(encMsg)
+	require.NoError(t, err)
+	require.Equal(t, bm, res)
+}
+
+func TestEncodeBlockResponseMessage_WithJustifications(t *testing.T) {
+	t.Parallel()
+
+	exp := common.MustHexToBytes("0x0a320a2000000000000000000000000000000000000000000000000000000000000000" +
+		"00420e084241424508010246524e4b0403")
+	bd := &types.BlockData{
+		Hash: common.NewHash([]byte{0}),
+		Justifications: &types.Justifications{
+			{EngineID: types.BabeEngineID, EncodedJustification: []byte{1, 2}},
+			{EngineID: types.GrandpaEngineID, EncodedJustification: []byte{3}},
+		},
+	}
+
+	bm := &BlockResponseMessage{
+		BlockData: []*types.BlockData{bd},
+	}
+
+	enc, err := bm.Encode()
+	require.NoError(t, err)
+	require.Equal(t, exp, enc)
+
+	act := &BlockResponseMessage{}
+	err = act.Decode(enc)
+	require.NoError(t, err)
+
+	// the GRANDPA justification is also set as the single justification of the block
+	bd.Justification = &[]byte{3}
+	require.Equal(t, bm, act)
+}
+
+func TestBlockDataSize(t *testing.T) {
+	t.Parallel()
+
+	exts := [][]byte{{1, 3, 5, 7}, {9, 1, 2}, {3, 4, 5}}
+	blockData := []*types.BlockData{
+		{
+			Hash:   common.NewHash([]byte{0}),
+			Header: types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 1, types.NewDigest()),
+			Body:   types.NewBody(types.BytesArrayToExtrinsics(exts)),
+		},
+		{
+			Hash:          common.NewHash([]byte{1}),
+			Justification: &[]byte{4},
+			IndexedBody:   &[][]byte{make([]byte, 300)},
+		},
+	}
+
+	var size int
+	for _, bd := range blockData {
+		bdSize, err := BlockDataSize(bd)
+		require.NoError(t, err)
+		size += bdSize
+	}
+
+	bm := &BlockResponseMessage{
+		BlockData: blockData,
+	}
+	enc, err := bm.Encode()
+	require.NoError(t, err)
+	require.Equal(t, len(enc), size)
+}
+
 func TestEncodeBlockAnnounceMessage(t *testing.T) {
 	/* this value is a concatenation of:
 	 *  ParentHash: Hash: 0x4545454545454545454545454545454545454545454545454545454545454545
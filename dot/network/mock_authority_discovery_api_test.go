@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ChainSafe/gossamer/dot/network (interfaces: AuthorityDiscoveryAPI)
+
+// Package network is a generated GoMock package.
+package network
+
+import (
+	reflect "reflect"
+
+	types "github.com/ChainSafe/gossamer/dot/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorityDiscoveryAPI is a mock of AuthorityDiscoveryAPI interface.
+type MockAuthorityDiscoveryAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorityDiscoveryAPIMockRecorder
+}
+
+// MockAuthorityDiscoveryAPIMockRecorder is the mock recorder for MockAuthorityDiscoveryAPI.
+type MockAuthorityDiscoveryAPIMockRecorder struct {
+	mock *MockAuthorityDiscoveryAPI
+}
+
+// NewMockAuthorityDiscoveryAPI creates a new mock instance.
+func NewMockAuthorityDiscoveryAPI(ctrl *gomock.Controller) *MockAuthorityDiscoveryAPI {
+	mock := &MockAuthorityDiscoveryAPI{ctrl: ctrl}
+	mock.recorder = &MockAuthorityDiscoveryAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorityDiscoveryAPI) EXPECT() *MockAuthorityDiscoveryAPIMockRecorder {
+	return m.recorder
+}
+
+// AuthorityDiscoveryAuthorities mocks base method.
+func (m *MockAuthorityDiscoveryAPI) AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityDiscoveryAuthorities")
+	ret0, _ := ret[0].([]types.AuthorityID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityDiscoveryAuthorities indicates an expected call of AuthorityDiscoveryAuthorities.
+func (mr *MockAuthorityDiscoveryAPIMockRecorder) AuthorityDiscoveryAuthorities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityDiscoveryAuthorities", reflect.TypeOf((*MockAuthorityDiscoveryAPI)(nil).AuthorityDiscoveryAuthorities))
+}
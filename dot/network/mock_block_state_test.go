@@ -78,3 +78,33 @@ func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
 }
+
+// GetIndexedTransaction mocks base method.
+func (m *MockBlockState) GetIndexedTransaction(arg0 common.Hash) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexedTransaction", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexedTransaction indicates an expected call of GetIndexedTransaction.
+func (mr *MockBlockStateMockRecorder) GetIndexedTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexedTransaction", reflect.TypeOf((*MockBlockState)(nil).GetIndexedTransaction), arg0)
+}
+
+// HasIndexedTransaction mocks base method.
+func (m *MockBlockState) HasIndexedTransaction(arg0 common.Hash) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasIndexedTransaction", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasIndexedTransaction indicates an expected call of HasIndexedTransaction.
+func (mr *MockBlockStateMockRecorder) HasIndexedTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasIndexedTransaction", reflect.TypeOf((*MockBlockState)(nil).HasIndexedTransaction), arg0)
+}
@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ChainSafe/gossamer/dot/network (interfaces: PeerSetHandler)
+
+// Package network is a generated GoMock package.
+package network
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	peerset "github.com/ChainSafe/gossamer/dot/peerset"
+	gomock "github.com/golang/mock/gomock"
+	peer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// MockPeerSetHandler is a mock of PeerSetHandler interface.
+type MockPeerSetHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeerSetHandlerMockRecorder
+}
+
+// MockPeerSetHandlerMockRecorder is the mock recorder for MockPeerSetHandler.
+type MockPeerSetHandlerMockRecorder struct {
+	mock *MockPeerSetHandler
+}
+
+// NewMockPeerSetHandler creates a new mock instance.
+func NewMockPeerSetHandler(ctrl *gomock.Controller) *MockPeerSetHandler {
+	mock := &MockPeerSetHandler{ctrl: ctrl}
+	mock.recorder = &MockPeerSetHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeerSetHandler) EXPECT() *MockPeerSetHandlerMockRecorder {
+	return m.recorder
+}
+
+// AddPeer mocks base method.
+func (m *MockPeerSetHandler) AddPeer(arg0 int, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "AddPeer", varargs...)
+}
+
+// AddPeer indicates an expected call of AddPeer.
+func (mr *MockPeerSetHandlerMockRecorder) AddPeer(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).AddPeer), varargs...)
+}
+
+// AddReservedPeer mocks base method.
+func (m *MockPeerSetHandler) AddReservedPeer(arg0 int, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "AddReservedPeer", varargs...)
+}
+
+// AddReservedPeer indicates an expected call of AddReservedPeer.
+func (mr *MockPeerSetHandlerMockRecorder) AddReservedPeer(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReservedPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).AddReservedPeer), varargs...)
+}
+
+// BanPeer mocks base method.
+func (m *MockPeerSetHandler) BanPeer(arg0 time.Duration, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "BanPeer", varargs...)
+}
+
+// BanPeer indicates an expected call of BanPeer.
+func (mr *MockPeerSetHandlerMockRecorder) BanPeer(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).BanPeer), varargs...)
+}
+
+// Incoming mocks base method.
+func (m *MockPeerSetHandler) Incoming(arg0 int, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Incoming", varargs...)
+}
+
+// Incoming indicates an expected call of Incoming.
+func (mr *MockPeerSetHandlerMockRecorder) Incoming(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incoming", reflect.TypeOf((*MockPeerSetHandler)(nil).Incoming), varargs...)
+}
+
+// Messages mocks base method.
+func (m *MockPeerSetHandler) Messages() chan peerset.Message {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Messages")
+	ret0, _ := ret[0].(chan peerset.Message)
+	return ret0
+}
+
+// Messages indicates an expected call of Messages.
+func (mr *MockPeerSetHandlerMockRecorder) Messages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Messages", reflect.TypeOf((*MockPeerSetHandler)(nil).Messages))
+}
+
+// PeerReputation mocks base method.
+func (m *MockPeerSetHandler) PeerReputation(arg0 peer.ID) (peerset.Reputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerReputation", arg0)
+	ret0, _ := ret[0].(peerset.Reputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerReputation indicates an expected call of PeerReputation.
+func (mr *MockPeerSetHandlerMockRecorder) PeerReputation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerReputation", reflect.TypeOf((*MockPeerSetHandler)(nil).PeerReputation), arg0)
+}
+
+// RemoveReservedPeer mocks base method.
+func (m *MockPeerSetHandler) RemoveReservedPeer(arg0 int, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "RemoveReservedPeer", varargs...)
+}
+
+// RemoveReservedPeer indicates an expected call of RemoveReservedPeer.
+func (mr *MockPeerSetHandlerMockRecorder) RemoveReservedPeer(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReservedPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).RemoveReservedPeer), varargs...)
+}
+
+// ReportPeer mocks base method.
+func (m *MockPeerSetHandler) ReportPeer(arg0 peerset.ReputationChange, arg1 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "ReportPeer", varargs...)
+}
+
+// ReportPeer indicates an expected call of ReportPeer.
+func (mr *MockPeerSetHandlerMockRecorder) ReportPeer(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).ReportPeer), varargs...)
+}
+
+// SetPeerClass mocks base method.
+func (m *MockPeerSetHandler) SetPeerClass(arg0 int, arg1 peerset.PeerClass, arg2 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetPeerClass", varargs...)
+}
+
+// SetPeerClass indicates an expected call of SetPeerClass.
+func (mr *MockPeerSetHandlerMockRecorder) SetPeerClass(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerClass", reflect.TypeOf((*MockPeerSetHandler)(nil).SetPeerClass), varargs...)
+}
+
+// SortedPeers mocks base method.
+func (m *MockPeerSetHandler) SortedPeers(arg0 int) chan peer.IDSlice {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SortedPeers", arg0)
+	ret0, _ := ret[0].(chan peer.IDSlice)
+	return ret0
+}
+
+// SortedPeers indicates an expected call of SortedPeers.
+func (mr *MockPeerSetHandlerMockRecorder) SortedPeers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SortedPeers", reflect.TypeOf((*MockPeerSetHandler)(nil).SortedPeers), arg0)
+}
+
+// Start mocks base method.
+func (m *MockPeerSetHandler) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockPeerSetHandlerMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockPeerSetHandler)(nil).Start), arg0)
+}
+
+// UnbanPeer mocks base method.
+func (m *MockPeerSetHandler) UnbanPeer(arg0 ...peer.ID) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "UnbanPeer", varargs...)
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer.
+func (mr *MockPeerSetHandlerMockRecorder) UnbanPeer(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockPeerSetHandler)(nil).UnbanPeer), arg0...)
+}
@@ -77,6 +77,18 @@ func (mr *MockSyncerMockRecorder) HandleBlockAnnounceHandshake(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockAnnounceHandshake", reflect.TypeOf((*MockSyncer)(nil).HandleBlockAnnounceHandshake), arg0, arg1)
 }
 
+// HandlePeerDisconnected mocks base method.
+func (m *MockSyncer) HandlePeerDisconnected(arg0 peer.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandlePeerDisconnected", arg0)
+}
+
+// HandlePeerDisconnected indicates an expected call of HandlePeerDisconnected.
+func (mr *MockSyncerMockRecorder) HandlePeerDisconnected(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePeerDisconnected", reflect.TypeOf((*MockSyncer)(nil).HandlePeerDisconnected), arg0)
+}
+
 // IsSynced mocks base method.
 func (m *MockSyncer) IsSynced() bool {
 	m.ctrl.T.Helper()
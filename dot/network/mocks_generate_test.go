@@ -7,3 +7,5 @@
 //go:generate mockgen -destination=mock_syncer_test.go -package $GOPACKAGE . Syncer
 //go:generate mockgen -destination=mock_block_state_test.go -package $GOPACKAGE . BlockState
 //go:generate mockgen -destination=mock_transaction_handler_test.go -package $GOPACKAGE . TransactionHandler
+//go:generate mockgen -destination=mock_authority_discovery_api_test.go -package $GOPACKAGE . AuthorityDiscoveryAPI
+//go:generate mockgen -destination=mock_peer_set_handler_test.go -package $GOPACKAGE . PeerSetHandler
@@ -7,6 +7,7 @@
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
@@ -57,26 +58,63 @@ type handshakeReader struct {
 }
 
 type notificationsProtocol struct {
-	protocolID         protocol.ID
-	getHandshake       HandshakeGetter
-	handshakeDecoder   HandshakeDecoder
-	handshakeValidator HandshakeValidator
-	peersData          *peersData
-	maxSize            uint64
+	protocolID protocol.ID
+	// fallbackProtocolIDs are the protocol IDs also supported by the
+	// notifications protocol, in order of preference after protocolID.
+	fallbackProtocolIDs []protocol.ID
+	getHandshake        HandshakeGetter
+	handshakeDecoder    HandshakeDecoder
+	handshakeValidator  HandshakeValidator
+	peersData           *peersData
+	maxSize             uint64
+
+	gossipTopic      gossipTopic
+	gossipTopicMutex sync.RWMutex
 }
 
-func newNotificationsProtocol(protocolID protocol.ID, handshakeGetter HandshakeGetter,
-	handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
+func newNotificationsProtocol(protocolID protocol.ID, fallbackProtocolIDs []protocol.ID,
+	handshakeGetter HandshakeGetter, handshakeDecoder HandshakeDecoder,
+	handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
 	return &notificationsProtocol{
-		protocolID:         protocolID,
-		getHandshake:       handshakeGetter,
-		handshakeValidator: handshakeValidator,
-		handshakeDecoder:   handshakeDecoder,
-		peersData:          newPeersData(),
-		maxSize:            maxSize,
+		protocolID:          protocolID,
+		fallbackProtocolIDs: fallbackProtocolIDs,
+		getHandshake:        handshakeGetter,
+		handshakeValidator:  handshakeValidator,
+		handshakeDecoder:    handshakeDecoder,
+		peersData:           newPeersData(),
+		maxSize:             maxSize,
 	}
 }
 
+// setGossipTopic sets the message validator and rebroadcast policy of the notifications protocol.
+func (n *notificationsProtocol) setGossipTopic(topic gossipTopic) {
+	n.gossipTopicMutex.Lock()
+	defer n.gossipTopicMutex.Unlock()
+	n.gossipTopic = topic
+}
+
+// getGossipTopic returns the message validator and rebroadcast policy of the notifications protocol.
+func (n *notificationsProtocol) getGossipTopic() gossipTopic {
+	n.gossipTopicMutex.RLock()
+	defer n.gossipTopicMutex.RUnlock()
+	return n.gossipTopic
+}
+
+// validate validates a gossip message received from the given peer,
+// keeping it if no validator is set for the notifications protocol.
+func (n *notificationsProtocol) validate(from peer.ID, msg NotificationsMessage) ValidationResult {
+	validator := n.getGossipTopic().validator
+	if validator == nil {
+		return ValidationKeep
+	}
+	return validator(from, msg)
+}
+
+// protocolIDs returns the protocol ID followed by the fallback protocol IDs.
+func (n *notificationsProtocol) protocolIDs() []protocol.ID {
+	return append([]protocol.ID{n.protocolID}, n.fallbackProtocolIDs...)
+}
+
 type handshakeData struct {
 	received  bool
 	validated bool
@@ -155,6 +193,9 @@ func (s *Service) createNotificationsMessageHandler(
 			return fmt.Errorf("could not check if message was seen before: %w", err)
 		}
 
+		// the peer knows the message, so we never send it back to the peer.
+		s.markKnown(peer, msg)
+
 		if hasSeen {
 			// report peer if we get duplicate gossip message.
 			s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
@@ -167,6 +208,23 @@ func (s *Service) createNotificationsMessageHandler(
 		logger.Tracef("received message on notifications sub-protocol %s from peer %s, message is: %s",
 			info.protocolID, stream.Conn().RemotePeer(), msg)
 
+		result := info.validate(peer, msg)
+		switch result {
+		case ValidationKeep, ValidationProcess:
+		case ValidationPenalise:
+			logger.Debugf("penalising peer %s for invalid message on notifications sub-protocol %s: %s",
+				peer, info.protocolID, msg)
+			s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.InvalidGossipValue,
+				Reason: peerset.InvalidGossipReason,
+			}, peer)
+			return nil
+		default:
+			logger.Tracef("discarding message %s from peer %s on notifications sub-protocol %s: validation result %s",
+				msg, peer, info.protocolID, result)
+			return nil
+		}
+
 		if batchHandler != nil {
 			batchHandler(peer, msg)
 			return nil
@@ -177,11 +235,11 @@ func (s *Service) createNotificationsMessageHandler(
 			return err
 		}
 
-		if !propagate || s.noGossip {
+		if !propagate || result != ValidationKeep || s.noGossip {
 			return nil
 		}
 
-		s.broadcastExcluding(info, peer, msg)
+		s.propagate(info, peer, msg)
 		return nil
 	}
 }
@@ -254,7 +312,7 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		return
 	}
 
-	support, err := s.host.supportsProtocol(peer, info.protocolID)
+	support, err := s.host.supportsProtocol(peer, info.protocolIDs()...)
 	if err != nil {
 		logger.Errorf("could not check if protocol %s is supported by peer %s: %s", info.protocolID, peer, err)
 		return
@@ -275,13 +333,6 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		return
 	}
 
-	_, isConsensusMsg := msg.(*ConsensusMessage)
-
-	if s.host.messageCache != nil && s.host.messageCache.exists(peer, msg) && !isConsensusMsg {
-		logger.Tracef("message has already been sent, ignoring: peer=%s msg=%s", peer, msg)
-		return
-	}
-
 	// we've completed the handshake with the peer, send message directly
 	logger.Tracef("sending message to peer %s using protocol %s: %s", peer, info.protocolID, msg)
 	if err := s.host.writeToStream(stream, msg); err != nil {
@@ -292,12 +343,8 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 			closeOutboundStream(info, peer, stream)
 		}
 		return
-	} else if s.host.messageCache != nil {
-		if _, err := s.host.messageCache.put(peer, msg); err != nil {
-			logger.Errorf("failed to add message to cache for peer %s: %w", peer, err)
-			return
-		}
 	}
+	s.markKnown(peer, msg)
 
 	logger.Tracef("successfully sent message on protocol %s to peer %s: message=", info.protocolID, peer, msg)
 	s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
@@ -339,7 +386,7 @@ func (s *Service) sendHandshake(peer peer.ID, hs Handshake, info *notificationsP
 
 	logger.Tracef("sending outbound handshake to peer %s on protocol %s, message: %s",
 		peer, info.protocolID, hs)
-	stream, err := s.host.send(peer, info.protocolID, hs)
+	stream, err := s.host.send(peer, info.protocolID, hs, info.fallbackProtocolIDs...)
 	if err != nil {
 		logger.Tracef("failed to send handshake to peer %s: %s", peer, err)
 		// don't need to close the stream here, as it's nil!
@@ -396,8 +443,37 @@ func (s *Service) sendHandshake(peer peer.ID, hs Handshake, info *notificationsP
 	return hsData.stream, nil
 }
 
+// markKnown remembers the given peer knows the message, so it is not gossiped to the peer.
+func (s *Service) markKnown(peer peer.ID, msg NotificationsMessage) {
+	if s.host.messageCache == nil {
+		return
+	}
+
+	if _, err := s.host.messageCache.put(peer, msg); err != nil {
+		logger.Errorf("failed to add message to cache for peer %s: %s", peer, err)
+	}
+}
+
+// propagate gossips a message received from the given peer, or sent by us if the peer is empty,
+// and keeps it to rebroadcast it periodically if required by the notifications protocol.
+func (s *Service) propagate(info *notificationsProtocol, from peer.ID, msg NotificationsMessage) {
+	s.broadcastExcluding(info, from, msg)
+
+	if info.getGossipTopic().rebroadcast != RebroadcastPeriodically ||
+		info.validate(from, msg) != ValidationKeep {
+		return
+	}
+
+	err := s.gossip.keep(from, msg, time.Now().Add(msgCacheTTL))
+	if err != nil {
+		logger.Errorf("failed to keep message to rebroadcast: %s", err)
+	}
+}
+
 // broadcastExcluding sends a message to each connected peer except the given peer,
 // and peers that have previously sent us the message or who we have already sent the message to.
+// Consensus messages are sent to the peers knowing them too, since the same message,
+// such as a GRANDPA neighbour packet, is sent again on purpose.
 // used for notifications sub-protocols to gossip a message
 func (s *Service) broadcastExcluding(info *notificationsProtocol, excluding peer.ID, msg NotificationsMessage) {
 	logger.Tracef("broadcasting message from notifications sub-protocol %s", info.protocolID)
@@ -408,12 +484,19 @@ func (s *Service) broadcastExcluding(info *notificationsProtocol, excluding peer
 		return
 	}
 
+	_, isConsensusMsg := msg.(*ConsensusMessage)
+
 	peers := s.host.peers()
 	for _, peer := range peers {
 		if peer == excluding {
 			continue
 		}
 
+		if s.host.messageCache != nil && s.host.messageCache.exists(peer, msg) && !isConsensusMsg {
+			logger.Tracef("peer already knows the message, ignoring: peer=%s msg=%s", peer, msg)
+			continue
+		}
+
 		info.peersData.setMutex(peer)
 
 		go s.sendData(peer, hs, info, msg)
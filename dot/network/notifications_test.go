@@ -253,7 +253,8 @@ func Test_HandshakeTimeout(t *testing.T) {
 	testHandshakeDecoder := func([]byte) (Handshake, error) {
 		return nil, errors.New("unimplemented")
 	}
-	info := newNotificationsProtocol(nodeA.host.protocolID+blockAnnounceID, nodeA.getBlockAnnounceHandshake,
+	info := newNotificationsProtocol(nodeA.host.protocolID+blockAnnounceID, nil,
+		nodeA.getBlockAnnounceHandshake,
 		testHandshakeDecoder, nodeA.validateBlockAnnounceHandshake, maxBlockAnnounceNotificationSize)
 
 	nodeB.host.p2pHost.SetStreamHandler(info.protocolID, func(stream libp2pnetwork.Stream) {
@@ -0,0 +1,318 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Schema definition for the authority discovery records published in the DHT.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.10
+// source: authority_discovery.v2.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// First we need to serialize the addresses in order to be able to sign them.
+type AuthorityRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Possibly multiple `MultiAddress`es through which the node can be
+	// reached.
+	Addresses [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *AuthorityRecord) Reset() {
+	*x = AuthorityRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_authority_discovery_v2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthorityRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorityRecord) ProtoMessage() {}
+
+func (x *AuthorityRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_authority_discovery_v2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorityRecord.ProtoReflect.Descriptor instead.
+func (*AuthorityRecord) Descriptor() ([]byte, []int) {
+	return file_authority_discovery_v2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthorityRecord) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type PeerSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (x *PeerSignature) Reset() {
+	*x = PeerSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_authority_discovery_v2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerSignature) ProtoMessage() {}
+
+func (x *PeerSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_authority_discovery_v2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerSignature.ProtoReflect.Descriptor instead.
+func (*PeerSignature) Descriptor() ([]byte, []int) {
+	return file_authority_discovery_v2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PeerSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *PeerSignature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// Then we need to serialize the authority record and signature to send them over the wire.
+type SignedAuthorityRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record        []byte `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	AuthSignature []byte `protobuf:"bytes,2,opt,name=auth_signature,json=authSignature,proto3" json:"auth_signature,omitempty"`
+	// Even if there are multiple `record.addresses`, all of them have the same peer id.
+	// Old versions are missing this field. It is optional in order to provide compatibility both ways.
+	PeerSignature *PeerSignature `protobuf:"bytes,3,opt,name=peer_signature,json=peerSignature,proto3" json:"peer_signature,omitempty"`
+}
+
+func (x *SignedAuthorityRecord) Reset() {
+	*x = SignedAuthorityRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_authority_discovery_v2_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedAuthorityRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedAuthorityRecord) ProtoMessage() {}
+
+func (x *SignedAuthorityRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_authority_discovery_v2_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedAuthorityRecord.ProtoReflect.Descriptor instead.
+func (*SignedAuthorityRecord) Descriptor() ([]byte, []int) {
+	return file_authority_discovery_v2_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignedAuthorityRecord) GetRecord() []byte {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *SignedAuthorityRecord) GetAuthSignature() []byte {
+	if x != nil {
+		return x.AuthSignature
+	}
+	return nil
+}
+
+func (x *SignedAuthorityRecord) GetPeerSignature() *PeerSignature {
+	if x != nil {
+		return x.PeerSignature
+	}
+	return nil
+}
+
+var File_authority_discovery_v2_proto protoreflect.FileDescriptor
+
+var file_authority_discovery_v2_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x76, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x5f, 0x76, 0x32, 0x22, 0x2f, 0x0a, 0x0f, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x0d, 0x50, 0x65, 0x65, 0x72, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0xa4, 0x01, 0x0a, 0x15, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x61, 0x75, 0x74, 0x68, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x4c,
+	0x0a, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x76, 0x32, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0d, 0x70,
+	0x65, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x31, 0x5a, 0x2f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x53, 0x61, 0x66, 0x65, 0x2f, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2f, 0x64, 0x6f,
+	0x74, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_authority_discovery_v2_proto_rawDescOnce sync.Once
+	file_authority_discovery_v2_proto_rawDescData = file_authority_discovery_v2_proto_rawDesc
+)
+
+func file_authority_discovery_v2_proto_rawDescGZIP() []byte {
+	file_authority_discovery_v2_proto_rawDescOnce.Do(func() {
+		file_authority_discovery_v2_proto_rawDescData = protoimpl.X.CompressGZIP(file_authority_discovery_v2_proto_rawDescData)
+	})
+	return file_authority_discovery_v2_proto_rawDescData
+}
+
+var file_authority_discovery_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_authority_discovery_v2_proto_goTypes = []interface{}{
+	(*AuthorityRecord)(nil),       // 0: authority_discovery_v2.AuthorityRecord
+	(*PeerSignature)(nil),         // 1: authority_discovery_v2.PeerSignature
+	(*SignedAuthorityRecord)(nil), // 2: authority_discovery_v2.SignedAuthorityRecord
+}
+var file_authority_discovery_v2_proto_depIdxs = []int32{
+	1, // 0: authority_discovery_v2.SignedAuthorityRecord.peer_signature:type_name -> authority_discovery_v2.PeerSignature
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_authority_discovery_v2_proto_init() }
+func file_authority_discovery_v2_proto_init() {
+	if File_authority_discovery_v2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_authority_discovery_v2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthorityRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_authority_discovery_v2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_authority_discovery_v2_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedAuthorityRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_authority_discovery_v2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_authority_discovery_v2_proto_goTypes,
+		DependencyIndexes: file_authority_discovery_v2_proto_depIdxs,
+		MessageInfos:      file_authority_discovery_v2_proto_msgTypes,
+	}.Build()
+	File_authority_discovery_v2_proto = out.File
+	file_authority_discovery_v2_proto_rawDesc = nil
+	file_authority_discovery_v2_proto_goTypes = nil
+	file_authority_discovery_v2_proto_depIdxs = nil
+}
@@ -5,3 +5,4 @@
 package proto
 
 //go:generate protoc --go_out=. --go_opt=paths=source_relative api.v1.proto
+//go:generate protoc --go_out=. --go_opt=paths=source_relative authority_discovery.v2.proto
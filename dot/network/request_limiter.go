@@ -0,0 +1,115 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var (
+	errTooManyInboundRequests           = errors.New("too many inbound requests per second")
+	errTooManyConcurrentInboundRequests = errors.New("too many concurrent inbound requests")
+)
+
+// inboundRequestLimiter limits the number of inbound requests accepted
+// from each peer every second, and the number of them handled concurrently.
+type inboundRequestLimiter struct {
+	maxPerSecond  uint32
+	maxConcurrent uint32
+
+	mutex sync.Mutex
+	peers map[peer.ID]*peerInboundRequests
+	now   func() time.Time
+}
+
+// peerInboundRequests is the number of requests of a peer accepted in
+// the current one second window, and the number of them being handled.
+type peerInboundRequests struct {
+	windowStart time.Time
+	inWindow    uint32
+	inFlight    uint32
+}
+
+func newInboundRequestLimiter(maxPerSecond, maxConcurrent uint32) *inboundRequestLimiter {
+	return &inboundRequestLimiter{
+		maxPerSecond:  maxPerSecond,
+		maxConcurrent: maxConcurrent,
+		peers:         make(map[peer.ID]*peerInboundRequests),
+		now:           time.Now,
+	}
+}
+
+// acquire accepts a request of the peer, and release must be called once it is handled.
+// It returns an error and the request must be refused if the peer exceeds one of the limits.
+func (l *inboundRequestLimiter) acquire(peerID peer.ID) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	requests, ok := l.peers[peerID]
+	if !ok {
+		requests = new(peerInboundRequests)
+		l.peers[peerID] = requests
+	}
+
+	now := l.now()
+	if now.Sub(requests.windowStart) >= time.Second {
+		requests.windowStart = now
+		requests.inWindow = 0
+	}
+
+	if requests.inWindow >= l.maxPerSecond {
+		return errTooManyInboundRequests
+	}
+
+	if requests.inFlight >= l.maxConcurrent {
+		return errTooManyConcurrentInboundRequests
+	}
+
+	requests.inWindow++
+	requests.inFlight++
+	return nil
+}
+
+// release marks a request of the peer previously accepted by acquire as handled.
+func (l *inboundRequestLimiter) release(peerID peer.ID) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	requests, ok := l.peers[peerID]
+	if !ok || requests.inFlight == 0 {
+		// the peer disconnected while its request was handled
+		return
+	}
+
+	requests.inFlight--
+}
+
+// removePeer forgets about the requests of a disconnected peer.
+func (l *inboundRequestLimiter) removePeer(peerID peer.ID) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.peers, peerID)
+}
+
+// acquireInboundRequest accepts an inbound request of the peer, and the returned release function
+// must be called once it is handled. The peer is penalised if it exceeds the inbound request limits.
+func (s *Service) acquireInboundRequest(peerID peer.ID) (release func(), err error) {
+	err = s.inboundRequestLimiter.acquire(peerID)
+	if err != nil {
+		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.TooManyRequestsValue,
+			Reason: peerset.TooManyRequestsReason,
+		}, peerID)
+		return nil, fmt.Errorf("refusing request of peer %s: %w", peerID, err)
+	}
+
+	return func() { s.inboundRequestLimiter.release(peerID) }, nil
+}
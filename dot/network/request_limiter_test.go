@@ -0,0 +1,143 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_inboundRequestLimiter_acquire(t *testing.T) {
+	t.Parallel()
+
+	const peerID = peer.ID("noot")
+	start := time.Unix(1000, 0)
+
+	testCases := map[string]struct {
+		maxPerSecond  uint32
+		maxConcurrent uint32
+		acquired      uint32
+		released      uint32
+		elapsed       time.Duration
+		errWrapped    error
+	}{
+		"first_request": {
+			maxPerSecond:  2,
+			maxConcurrent: 2,
+		},
+		"requests_per_second_exceeded": {
+			maxPerSecond:  2,
+			maxConcurrent: 3,
+			acquired:      2,
+			released:      2,
+			errWrapped:    errTooManyInboundRequests,
+		},
+		"requests_per_second_reset_after_a_second": {
+			maxPerSecond:  2,
+			maxConcurrent: 3,
+			acquired:      2,
+			released:      2,
+			elapsed:       time.Second,
+		},
+		"concurrent_requests_exceeded": {
+			maxPerSecond:  3,
+			maxConcurrent: 2,
+			acquired:      2,
+			errWrapped:    errTooManyConcurrentInboundRequests,
+		},
+		"concurrent_requests_exceeded_after_a_second": {
+			maxPerSecond:  3,
+			maxConcurrent: 2,
+			acquired:      2,
+			elapsed:       time.Second,
+			errWrapped:    errTooManyConcurrentInboundRequests,
+		},
+		"concurrent_request_released": {
+			maxPerSecond:  3,
+			maxConcurrent: 2,
+			acquired:      2,
+			released:      1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := newInboundRequestLimiter(testCase.maxPerSecond, testCase.maxConcurrent)
+			limiter.now = func() time.Time { return start }
+
+			for i := uint32(0); i < testCase.acquired; i++ {
+				err := limiter.acquire(peerID)
+				require.NoError(t, err)
+			}
+			for i := uint32(0); i < testCase.released; i++ {
+				limiter.release(peerID)
+			}
+
+			limiter.now = func() time.Time { return start.Add(testCase.elapsed) }
+			err := limiter.acquire(peerID)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			// other peers are not limited
+			err = limiter.acquire(peer.ID("other"))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_inboundRequestLimiter_removePeer(t *testing.T) {
+	t.Parallel()
+
+	const peerID = peer.ID("noot")
+	limiter := newInboundRequestLimiter(1, 1)
+
+	err := limiter.acquire(peerID)
+	require.NoError(t, err)
+	err = limiter.acquire(peerID)
+	require.ErrorIs(t, err, errTooManyInboundRequests)
+
+	limiter.removePeer(peerID)
+	// releasing the request of a disconnected peer is a no-op
+	limiter.release(peerID)
+	assert.Empty(t, limiter.peers)
+
+	err = limiter.acquire(peerID)
+	assert.NoError(t, err)
+}
+
+func TestService_acquireInboundRequest(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	const peerID = peer.ID("noot")
+
+	peerSetHandler := NewMockPeerSetHandler(ctrl)
+	s := &Service{
+		host:                  &host{cm: &ConnManager{peerSetHandler: peerSetHandler}},
+		inboundRequestLimiter: newInboundRequestLimiter(2, 1),
+	}
+
+	release, err := s.acquireInboundRequest(peerID)
+	require.NoError(t, err)
+
+	peerSetHandler.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.TooManyRequestsValue,
+		Reason: peerset.TooManyRequestsReason,
+	}, peerID)
+	_, err = s.acquireInboundRequest(peerID)
+	assert.ErrorIs(t, err, errTooManyConcurrentInboundRequests)
+	assert.EqualError(t, err, "refusing request of peer "+peerID.String()+": too many concurrent inbound requests")
+
+	release()
+	_, err = s.acquireInboundRequest(peerID)
+	assert.NoError(t, err)
+}
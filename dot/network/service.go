@@ -18,6 +18,7 @@
 	"github.com/ChainSafe/gossamer/internal/mdns"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/common"
+	libp2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -87,6 +88,22 @@
 		Name:      "outbound_total",
 		Help:      "total number of outbound streams",
 	})
+	protocolBandwidthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_bandwidth",
+		Name:      "protocol_bytes_total",
+		Help:      "total number of bytes sent and received by protocol and direction",
+	}, []string{"protocol", "direction"})
+	peerBandwidthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_bandwidth",
+		Name:      "peer_bytes_total",
+		Help:      "total number of bytes sent to and received from connected peers by peer and direction",
+	}, []string{"peer", "direction"})
+	negotiatedStreamsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_streams",
+		Name:      "negotiated_total",
+		Help: "total number of streams opened by negotiated protocol, " +
+			"protocol name (genesis_hash or legacy) and direction",
+	}, []string{"protocol", "name", "direction"})
 	processStartTimeGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "substrate", // Note: this is using substrate namespace because that is what zombienet uses
 		//  to confirm nodes have started TODO: consider other ways to handle this, see issue #3205
@@ -117,6 +134,9 @@ type Service struct {
 	bufPool       *sync.Pool
 	streamManager *streamManager
 
+	// authorityDiscovery is nil if authority discovery is disabled
+	authorityDiscovery *authorityDiscovery
+
 	notificationsProtocols map[byte]*notificationsProtocol // map of sub-protocol msg ID to protocol info
 	notificationsMu        sync.RWMutex
 
@@ -143,6 +163,8 @@ type Service struct {
 	blockResponseBuf   []byte
 	blockResponseBufMu sync.Mutex
 	telemetry          Telemetry
+
+	inboundRequestLimiter *inboundRequestLimiter
 }
 
 // NewService creates a new network service from the configuration and message channels
@@ -167,6 +189,14 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg.MaxPeers = DefaultMaxPeerCount
 	}
 
+	if cfg.MaxLightPeers == 0 {
+		cfg.MaxLightPeers = DefaultMaxLightPeerCount
+	}
+
+	if cfg.MaxAuthorityPeers == 0 {
+		cfg.MaxAuthorityPeers = DefaultMaxAuthorityPeerCount
+	}
+
 	if cfg.MinPeers > cfg.MaxPeers {
 		logger.Warn("min peers higher than max peers; setting to default")
 		cfg.MinPeers = DefaultMinPeerCount
@@ -181,6 +211,14 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg.batchSize = defaultTxnBatchSize
 	}
 
+	if cfg.MaxInboundRequestsPerSecond == 0 {
+		cfg.MaxInboundRequestsPerSecond = DefaultMaxInboundRequestsPerSecond
+	}
+
+	if cfg.MaxConcurrentInboundRequests == 0 {
+		cfg.MaxConcurrentInboundRequests = DefaultMaxConcurrentInboundRequests
+	}
+
 	// create a new host instance
 	host, err := newHost(ctx, cfg)
 	if err != nil {
@@ -203,6 +241,14 @@ func NewService(cfg *Config) (*Service, error) {
 		host.id(), host.protocolID)
 	mdnsService := mdns.NewService(host.p2pHost, serviceTag, mdnsLogger, notifee)
 
+	var authorityDiscovery *authorityDiscovery
+	if cfg.AuthorityDiscoveryAPI != nil {
+		authorityDiscovery = newAuthorityDiscovery(ctx, host, host.discovery, host.discovery.recordValidator,
+			cfg.AuthorityDiscoveryAPI, cfg.AuthorityDiscoveryKeystore, cfg.AllowNonGlobalsInDHT)
+	}
+
+	requestLimiter := newInboundRequestLimiter(cfg.MaxInboundRequestsPerSecond, cfg.MaxConcurrentInboundRequests)
+
 	network := &Service{
 		ctx:                    ctx,
 		cancel:                 cancel,
@@ -210,6 +256,7 @@ func NewService(cfg *Config) (*Service, error) {
 		host:                   host,
 		mdns:                   mdnsService,
 		gossip:                 newGossip(),
+		authorityDiscovery:     authorityDiscovery,
 		blockState:             cfg.BlockState,
 		transactionHandler:     cfg.TransactionHandler,
 		noBootstrap:            cfg.NoBootstrap,
@@ -221,9 +268,10 @@ func NewService(cfg *Config) (*Service, error) {
 		closeCh:                make(chan struct{}),
 		bufPool:                bufPool,
 		streamManager:          newStreamManager(ctx),
-		blockResponseBuf:       make([]byte, maxBlockResponseSize),
+		blockResponseBuf:       make([]byte, MaxBlockResponseSize),
 		telemetry:              cfg.Telemetry,
 		Metrics:                cfg.Metrics,
+		inboundRequestLimiter:  requestLimiter,
 	}
 
 	return network, err
@@ -253,12 +301,17 @@ func (s *Service) Start() error {
 		s.ctx, s.cancel = context.WithCancel(context.Background())
 	}
 
-	s.host.registerStreamHandler(s.host.protocolID+syncID, s.handleSyncStream)
-	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
+	// register the request-response protocols under both the genesis hash
+	// based protocol name and the legacy protocol name
+	for _, pid := range []protocol.ID{s.host.genesisProtocolID, s.host.protocolID} {
+		s.host.registerStreamHandler(pid+syncID, s.handleSyncStream)
+		s.host.registerStreamHandler(pid+lightID, s.handleLightStream)
+	}
 
 	// register block announce protocol
 	err := s.RegisterNotificationsProtocol(
-		s.host.protocolID+blockAnnounceID,
+		s.host.genesisProtocolID+blockAnnounceID,
+		[]protocol.ID{s.host.protocolID + blockAnnounceID},
 		blockAnnounceMsgType,
 		s.getBlockAnnounceHandshake,
 		decodeBlockAnnounceHandshake,
@@ -278,7 +331,8 @@ func (s *Service) Start() error {
 
 	// register transactions protocol
 	err = s.RegisterNotificationsProtocol(
-		s.host.protocolID+transactionsID,
+		s.host.genesisProtocolID+transactionsID,
+		[]protocol.ID{s.host.protocolID + transactionsID},
 		transactionMsgType,
 		s.getTransactionHandshake,
 		decodeTransactionHandshake,
@@ -292,6 +346,16 @@ func (s *Service) Start() error {
 		logger.Warnf("failed to register notifications protocol with transaction id %s: %s", transactionsID, err)
 	}
 
+	err = s.RegisterGossipValidator(blockAnnounceMsgType, s.validateBlockAnnounceMessage, RebroadcastOnce)
+	if err != nil {
+		logger.Warnf("failed to register block announce message validator: %s", err)
+	}
+
+	err = s.RegisterGossipValidator(transactionMsgType, s.validateTransactionMessage, RebroadcastOnce)
+	if err != nil {
+		logger.Warnf("failed to register transaction message validator: %s", err)
+	}
+
 	// this handles all new connections (incoming and outgoing)
 	// it creates a per-protocol mutex for sending outbound handshakes to the peer
 	s.host.cm.connectHandler = func(peerID peer.ID) {
@@ -310,6 +374,8 @@ func (s *Service) Start() error {
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+		s.inboundRequestLimiter.removePeer(peerID)
+		s.syncer.HandlePeerDisconnected(peerID)
 	}
 
 	// log listening addresses to console
@@ -319,6 +385,11 @@ func (s *Service) Start() error {
 
 	s.startPeerSetHandler()
 
+	if s.host.bitswap != nil {
+		s.host.bitswap.start()
+		logger.Info("started Bitswap server for indexed transactions")
+	}
+
 	if !s.noMDNS {
 		err = s.mdns.Start()
 		if err != nil {
@@ -328,9 +399,14 @@ func (s *Service) Start() error {
 
 	if !s.noDiscover {
 		go func() {
-			err = s.host.discovery.start()
+			err := s.host.discovery.start()
 			if err != nil {
 				logger.Errorf("failed to begin DHT discovery: %s", err)
+				return
+			}
+
+			if s.authorityDiscovery != nil {
+				s.authorityDiscovery.start()
 			}
 		}()
 	}
@@ -345,6 +421,7 @@ func (s *Service) Start() error {
 	}
 
 	go s.logPeerCount()
+	go s.rebroadcastKeptMessages()
 	go s.publishNetworkTelemetry(s.closeCh)
 	go s.sentBlockIntervalTelemetry()
 	s.streamManager.start()
@@ -372,10 +449,26 @@ func (s *Service) updateMetrics() {
 			outboundGrandpaStreamsGauge.Set(float64(s.getNumStreams(ConsensusMsgType, false)))
 			inboundStreamsGauge.Set(float64(s.getTotalStreams(true)))
 			outboundStreamsGauge.Set(float64(s.getTotalStreams(false)))
+			s.updateBandwidthMetrics()
 		}
 	}
 }
 
+func (s *Service) updateBandwidthMetrics() {
+	for protocolID, stats := range s.host.bwc.GetBandwidthByProtocol() {
+		protocolBandwidthGauge.WithLabelValues(string(protocolID), "inbound").Set(float64(stats.TotalIn))
+		protocolBandwidthGauge.WithLabelValues(string(protocolID), "outbound").Set(float64(stats.TotalOut))
+	}
+
+	// only the connected peers are exported to bound the number of label values
+	peerBandwidthGauge.Reset()
+	for _, peerID := range s.host.peers() {
+		stats := s.host.bwc.GetBandwidthForPeer(peerID)
+		peerBandwidthGauge.WithLabelValues(peerID.String(), "inbound").Set(float64(stats.TotalIn))
+		peerBandwidthGauge.WithLabelValues(peerID.String(), "outbound").Set(float64(stats.TotalOut))
+	}
+}
+
 func (s *Service) getTotalStreams(inbound bool) (count int64) {
 	for _, conn := range s.host.p2pHost.Network().Conns() {
 		for _, stream := range conn.GetStreams() {
@@ -493,8 +586,11 @@ func (s *Service) Stop() error {
 
 // RegisterNotificationsProtocol registers a protocol with the network service with the given handler
 // messageID is a user-defined message ID for the message passed over this protocol.
+// The protocol is also registered under the fallback protocol IDs, which are used in this order
+// for outbound streams to peers not supporting the protocol ID.
 func (s *Service) RegisterNotificationsProtocol(
 	protocolID protocol.ID,
+	fallbackProtocolIDs []protocol.ID,
 	messageID byte,
 	handshakeGetter HandshakeGetter,
 	handshakeDecoder HandshakeDecoder,
@@ -511,20 +607,71 @@ func (s *Service) RegisterNotificationsProtocol(
 		return errors.New("notifications protocol with message type already exists")
 	}
 
-	np := newNotificationsProtocol(protocolID, handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
+	np := newNotificationsProtocol(protocolID, fallbackProtocolIDs,
+		handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
 
-	s.host.registerStreamHandler(protocolID, func(stream libp2pnetwork.Stream) {
-		logger.Tracef("received stream using sub-protocol %s", protocolID)
-		s.readStream(stream, decoder, handlerWithValidate, maxSize)
-	})
+	for _, pid := range np.protocolIDs() {
+		s.host.registerStreamHandler(pid, func(stream libp2pnetwork.Stream) {
+			logger.Tracef("received stream using sub-protocol %s", stream.Protocol())
+			s.readStream(stream, decoder, handlerWithValidate, maxSize)
+		})
+
+		logger.Infof("registered notifications sub-protocol %s", pid)
+	}
+	return nil
+}
+
+// RegisterGossipValidator sets the validator of the messages received on the notifications protocol
+// with the given message ID, and the policy used to rebroadcast the messages it keeps.
+func (s *Service) RegisterGossipValidator(messageID byte, validator MessageValidator, policy RebroadcastPolicy) error {
+	s.notificationsMu.RLock()
+	defer s.notificationsMu.RUnlock()
+
+	np, has := s.notificationsProtocols[messageID]
+	if !has {
+		return fmt.Errorf("%w: message type %d", errNotificationsProtocolNotFound, messageID)
+	}
 
-	logger.Infof("registered notifications sub-protocol %s", protocolID)
+	np.setGossipTopic(gossipTopic{
+		validator:   validator,
+		rebroadcast: policy,
+	})
 	return nil
 }
 
+// rebroadcastKeptMessages periodically gossips the kept messages still valid
+// to the connected peers not knowing them yet.
+func (s *Service) rebroadcastKeptMessages() {
+	ticker := time.NewTicker(s.gossip.rebroadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.rebroadcast(time.Now())
+		}
+	}
+}
+
+func (s *Service) rebroadcast(now time.Time) {
+	s.notificationsMu.RLock()
+	defer s.notificationsMu.RUnlock()
+
+	stillKept := func(kept *keptMessage) bool {
+		np, has := s.notificationsProtocols[kept.msg.Type()]
+		return has && np.validate(kept.from, kept.msg) == ValidationKeep
+	}
+
+	for _, kept := range s.gossip.keptMessages(now, stillKept) {
+		s.broadcastExcluding(s.notificationsProtocols[kept.msg.Type()], kept.from, kept.msg)
+	}
+}
+
 // IsStopped returns true if the service is stopped
 func (s *Service) IsStopped() bool {
 	return s.ctx.Err() != nil
@@ -548,7 +695,7 @@ func (s *Service) GossipMessage(msg NotificationsMessage) {
 			continue
 		}
 
-		s.broadcastExcluding(prtl, peer.ID(""), msg)
+		s.propagate(prtl, peer.ID(""), msg)
 		return
 	}
 
@@ -594,6 +741,35 @@ func (s *Service) NetworkState() common.NetworkState {
 	}
 }
 
+// Bandwidth returns the bandwidth used by the host in total, by protocol
+// and by connected peer, needed for the rpc server
+func (s *Service) Bandwidth() common.Bandwidth {
+	bandwidth := common.Bandwidth{
+		Total:      toBandwidthStats(s.host.bwc.GetBandwidthTotals()),
+		ByProtocol: make(map[string]common.BandwidthStats),
+		ByPeer:     make(map[string]common.BandwidthStats),
+	}
+
+	for protocolID, stats := range s.host.bwc.GetBandwidthByProtocol() {
+		bandwidth.ByProtocol[string(protocolID)] = toBandwidthStats(stats)
+	}
+
+	for _, peerID := range s.host.peers() {
+		bandwidth.ByPeer[peerID.String()] = toBandwidthStats(s.host.bwc.GetBandwidthForPeer(peerID))
+	}
+
+	return bandwidth
+}
+
+func toBandwidthStats(stats libp2pmetrics.Stats) common.BandwidthStats {
+	return common.BandwidthStats{
+		TotalIn:  stats.TotalIn,
+		TotalOut: stats.TotalOut,
+		RateIn:   stats.RateIn,
+		RateOut:  stats.RateOut,
+	}
+}
+
 // Peers returns information about connected peers needed for the rpc server
 func (s *Service) Peers() []common.PeerInfo {
 	var peers []common.PeerInfo
@@ -603,10 +779,14 @@ func (s *Service) Peers() []common.PeerInfo {
 	s.notificationsMu.RUnlock()
 
 	for _, p := range s.host.peers() {
+		// the peer may not be known by the peer set yet, in which case its reputation is 0.
+		reputation, _ := s.host.cm.peerSetHandler.PeerReputation(p)
+
 		data := np.peersData.getInboundHandshakeData(p)
 		if data == nil || data.handshake == nil {
 			peers = append(peers, common.PeerInfo{
-				PeerID: p.String(),
+				PeerID:     p.String(),
+				Reputation: int32(reputation),
 			})
 
 			continue
@@ -618,6 +798,7 @@ func (s *Service) Peers() []common.PeerInfo {
 			Roles:      peerHandshakeMessage.(*BlockAnnounceHandshake).Roles,
 			BestHash:   peerHandshakeMessage.(*BlockAnnounceHandshake).BestBlockHash,
 			BestNumber: uint64(peerHandshakeMessage.(*BlockAnnounceHandshake).BestBlockNumber),
+			Reputation: int32(reputation),
 		})
 	}
 
@@ -634,6 +815,17 @@ func (s *Service) RemoveReservedPeers(addrs ...string) error {
 	return s.host.removeReservedPeers(addrs...)
 }
 
+// BanPeer bans the peer for the duration given, disconnecting from it and
+// refusing any connection with it until the ban expires.
+func (s *Service) BanPeer(peerID string, duration time.Duration) error {
+	return s.host.banPeer(peerID, duration)
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (s *Service) UnbanPeer(peerID string) error {
+	return s.host.unbanPeer(peerID)
+}
+
 // NodeRoles Returns the roles the node is running as.
 func (s *Service) NodeRoles() common.Roles {
 	return s.cfg.Roles
@@ -669,6 +861,14 @@ func (s *Service) startPeerSetHandler() {
 	}
 
 	go s.startProcessingMsg()
+
+	// peers which connected before the peer set handler started are not known by it,
+	// so they are checked as incoming connections, dropping for example banned peers.
+	// TODO: currently we only have one set so setID is 0, change this once we have more set in peerSet
+	const setID = 0
+	for _, peerID := range s.host.peers() {
+		s.host.cm.peerSetHandler.Incoming(setID, peerID)
+	}
 }
 
 func (s *Service) processMessage(msg peerset.Message) {
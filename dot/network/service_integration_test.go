@@ -70,7 +70,7 @@ func TestBroadcastMessages(t *testing.T) {
 	nodeB := createTestService(t, configB)
 	nodeB.noGossip = true
 	handler := newTestStreamHandler(testBlockAnnounceHandshakeDecoder)
-	nodeB.host.registerStreamHandler(nodeB.host.protocolID+blockAnnounceID, handler.handleStream)
+	nodeB.host.registerStreamHandler(nodeB.host.genesisProtocolID+blockAnnounceID, handler.handleStream)
 
 	addrInfoB := addrInfo(nodeB.host)
 	err := nodeA.host.connect(addrInfoB)
@@ -5,6 +5,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -18,6 +19,8 @@ type BlockState interface {
 	BestBlockHeader() (*types.Header, error)
 	GenesisHash() common.Hash
 	GetHighestFinalisedHeader() (*types.Header, error)
+	HasIndexedTransaction(hash common.Hash) (bool, error)
+	GetIndexedTransaction(hash common.Hash) ([]byte, error)
 }
 
 // Syncer is implemented by the syncing service
@@ -28,6 +31,9 @@ type Syncer interface {
 	// If a request needs to be sent to the peer to retrieve the full block, this function will return it.
 	HandleBlockAnnounce(from peer.ID, msg *BlockAnnounceMessage) error
 
+	// HandlePeerDisconnected is called when the connection to the peer is closed, to forget about the peer.
+	HandlePeerDisconnected(from peer.ID)
+
 	// IsSynced exposes the internal synced state
 	IsSynced() bool
 
@@ -41,12 +47,20 @@ type TransactionHandler interface {
 	TransactionsCount() int
 }
 
+// AuthorityDiscoveryAPI is implemented by the runtime to get the authority
+// discovery identifiers of the current and next authority sets.
+type AuthorityDiscoveryAPI interface {
+	AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error)
+}
+
 // PeerSetHandler is the interface used by the connection manager to handle peerset.
 type PeerSetHandler interface {
 	Start(context.Context)
 	ReportPeer(peerset.ReputationChange, ...peer.ID)
 	PeerAdd
 	PeerRemove
+	PeerBan
+	PeerClassify
 	Peer
 }
 
@@ -62,8 +76,20 @@ type PeerRemove interface {
 	RemoveReservedPeer(int, ...peer.ID)
 }
 
+// PeerBan is the interface used by the PeerSetHandler to ban and unban peers.
+type PeerBan interface {
+	BanPeer(time.Duration, ...peer.ID)
+	UnbanPeer(...peer.ID)
+}
+
+// PeerClassify is the interface used by the PeerSetHandler to set the class of connected peers.
+type PeerClassify interface {
+	SetPeerClass(int, peerset.PeerClass, ...peer.ID)
+}
+
 // Peer is the interface used by the PeerSetHandler to get the peer data from peerSet.
 type Peer interface {
 	SortedPeers(idx int) chan peer.IDSlice
 	Messages() chan peerset.Message
+	PeerReputation(peer.ID) (peerset.Reputation, error)
 }
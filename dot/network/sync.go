@@ -21,15 +21,14 @@
 // If a response is received within a certain time period, it is returned,
 // otherwise an error is returned.
 func (s *Service) DoBlockRequest(to peer.ID, req *BlockRequestMessage) (*BlockResponseMessage, error) {
-	fullSyncID := s.host.protocolID + syncID
-
 	s.host.p2pHost.ConnManager().Protect(to, "")
 	defer s.host.p2pHost.ConnManager().Unprotect(to, "")
 
 	ctx, cancel := context.WithTimeout(s.ctx, blockRequestTimeout)
 	defer cancel()
 
-	stream, err := s.host.p2pHost.NewStream(ctx, to, fullSyncID)
+	stream, err := s.host.newStream(ctx, to,
+		s.host.genesisProtocolID+syncID, s.host.protocolID+syncID)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +59,7 @@ func (s *Service) receiveBlockResponse(stream libp2pnetwork.Stream) (*BlockRespo
 
 	buf := s.blockResponseBuf
 
-	n, err := readStream(stream, &buf, maxBlockResponseSize)
+	n, err := readStream(stream, &buf, MaxBlockResponseSize)
 	if err != nil {
 		return nil, fmt.Errorf("read stream error: %w", err)
 	}
@@ -88,7 +87,7 @@ func (s *Service) handleSyncStream(stream libp2pnetwork.Stream) {
 		return
 	}
 
-	s.readStream(stream, decodeSyncMessage, s.handleSyncMessage, maxBlockResponseSize)
+	s.readStream(stream, decodeSyncMessage, s.handleSyncMessage, MaxBlockResponseSize)
 }
 
 func decodeSyncMessage(in []byte, _ peer.ID, _ bool) (Message, error) {
@@ -112,6 +111,13 @@ func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg Message) er
 	}()
 
 	if req, ok := msg.(*BlockRequestMessage); ok {
+		release, err := s.acquireInboundRequest(stream.Conn().RemotePeer())
+		if err != nil {
+			logger.Debugf("cannot handle block request: %s", err)
+			return err
+		}
+		defer release()
+
 		resp, err := s.syncer.CreateBlockResponse(req)
 		if err != nil {
 			logger.Debugf("cannot create response for request: %s", err)
@@ -9,6 +9,7 @@
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -88,7 +89,10 @@ func decodeTransactionHandshake(_ []byte) (Handshake, error) {
 }
 
 func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDuration time.Duration) {
-	protocolID := s.host.protocolID + transactionsID
+	protocolIDs := []protocol.ID{
+		s.host.genesisProtocolID + transactionsID,
+		s.host.protocolID + transactionsID,
+	}
 	ticker := time.NewTicker(slotDuration)
 	defer ticker.Stop()
 
@@ -107,7 +111,7 @@ func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDur
 					propagate, err := s.handleTransactionMessage(txnMsg.peer, txnMsg.msg)
 					if err != nil {
 						logger.Warnf("could not handle transaction message: %s", err)
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
+						s.host.closeProtocolStream(protocolIDs, txnMsg.peer)
 						continue
 					}
 
@@ -115,18 +119,7 @@ func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDur
 						continue
 					}
 
-					// TODO: Check if s.gossip.hasSeen should be moved before handleTransactionMessage. #2445
-					// That we could avoid handling the transactions again, which we would have already seen.
-
-					hasSeen, err := s.gossip.hasSeen(txnMsg.msg)
-					if err != nil {
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
-						logger.Debugf("could not check if message was seen before: %s", err)
-						continue
-					}
-					if !hasSeen {
-						s.broadcastExcluding(s.notificationsProtocols[transactionMsgType], txnMsg.peer, txnMsg.msg)
-					}
+					s.propagate(s.notificationsProtocols[transactionMsgType], txnMsg.peer, txnMsg.msg)
 				}
 			}
 		}
@@ -163,6 +156,21 @@ func decodeTransactionMessage(in []byte) (NotificationsMessage, error) {
 	return msg, err
 }
 
+// validateTransactionMessage validates a transaction message before it is handled.
+// Transactions are discarded until we are synced, since we cannot check them.
+func (s *Service) validateTransactionMessage(_ peer.ID, msg NotificationsMessage) ValidationResult {
+	txMsg, ok := msg.(*TransactionMessage)
+	if !ok {
+		return ValidationPenalise
+	}
+
+	if !s.syncer.IsSynced() || len(txMsg.Extrinsics) == 0 {
+		return ValidationDiscard
+	}
+
+	return ValidationKeep
+}
+
 func (s *Service) handleTransactionMessage(peerID peer.ID, msg NotificationsMessage) (bool, error) {
 	txMsg, ok := msg.(*TransactionMessage)
 	if !ok {
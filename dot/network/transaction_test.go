@@ -0,0 +1,73 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_validateTransactionMessage(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		syncerBuilder func(ctrl *gomock.Controller) Syncer
+		msg           NotificationsMessage
+		result        ValidationResult
+	}{
+		"not_transaction_message": {
+			syncerBuilder: func(ctrl *gomock.Controller) Syncer {
+				return NewMockSyncer(ctrl)
+			},
+			msg:    &BlockAnnounceMessage{},
+			result: ValidationPenalise,
+		},
+		"not_synced": {
+			syncerBuilder: func(ctrl *gomock.Controller) Syncer {
+				syncer := NewMockSyncer(ctrl)
+				syncer.EXPECT().IsSynced().Return(false)
+				return syncer
+			},
+			msg:    &TransactionMessage{Extrinsics: []types.Extrinsic{{1}}},
+			result: ValidationDiscard,
+		},
+		"no_extrinsic": {
+			syncerBuilder: func(ctrl *gomock.Controller) Syncer {
+				syncer := NewMockSyncer(ctrl)
+				syncer.EXPECT().IsSynced().Return(true)
+				return syncer
+			},
+			msg:    &TransactionMessage{},
+			result: ValidationDiscard,
+		},
+		"synced": {
+			syncerBuilder: func(ctrl *gomock.Controller) Syncer {
+				syncer := NewMockSyncer(ctrl)
+				syncer.EXPECT().IsSynced().Return(true)
+				return syncer
+			},
+			msg:    &TransactionMessage{Extrinsics: []types.Extrinsic{{1}}},
+			result: ValidationKeep,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				syncer: testCase.syncerBuilder(ctrl),
+			}
+
+			result := service.validateTransactionMessage("", testCase.msg)
+
+			assert.Equal(t, testCase.result, result)
+		})
+	}
+}
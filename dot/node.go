@@ -24,6 +24,7 @@
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/babe"
@@ -52,8 +53,8 @@ type nodeBuilderIface interface {
 	isNodeInitialised(basepath string) error
 	initNode(config *Config) error
 	createStateService(config *Config) (*state.Service, error)
-	createNetworkService(cfg *Config, stateSrvc *state.Service, telemetryMailer Telemetry) (*network.Service,
-		error)
+	createNetworkService(cfg *Config, stateSrvc *state.Service, ks keystore.Keystore,
+		telemetryMailer Telemetry) (*network.Service, error)
 	createRuntimeStorage(st *state.Service) (*runtime.NodeStorage, error)
 	loadRuntime(cfg *Config, ns *runtime.NodeStorage, stateSrvc *state.Service, ks *keystore.GlobalKeystore,
 		net *network.Service) error
@@ -62,7 +63,7 @@ type nodeBuilderIface interface {
 	createCoreService(cfg *Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service,
 		dh *digest.Handler) (*core.Service, error)
 	createGRANDPAService(cfg *Config, st *state.Service, ks KeyStore,
-		net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error)
+		net grandpa.Network, telemetryMailer Telemetry) (*grandpa.Service, error)
 	newSyncService(cfg *Config, st *state.Service, finalityGadget BlockJustificationVerifier,
 		verifier *babe.VerificationManager, cs *core.Service, net *network.Service,
 		telemetryMailer Telemetry) (*dotsync.Service, error)
@@ -87,12 +88,10 @@ func IsNodeInitialised(basepath string) bool {
 // isNodeInitialised returns nil if the node is successfully initialised
 // and an error otherwise.
 func (*nodeBuilder) isNodeInitialised(basepath string) error {
-	// check if key registry exists
-	registry := filepath.Join(basepath, utils.DefaultDatabaseDir, "KEYREGISTRY")
-
-	_, err := os.Stat(registry)
-	if os.IsNotExist(err) {
-		return fmt.Errorf("cannot find key registry in database directory: %w", err)
+	// check if a database exists
+	_, err := database.DetectBackend(filepath.Join(basepath, utils.DefaultDatabaseDir))
+	if err != nil {
+		return fmt.Errorf("cannot find database in database directory: %w", err)
 	}
 
 	db, err := utils.SetupDatabase(basepath, false)
@@ -161,8 +160,9 @@ func (*nodeBuilder) initNode(cfg *Config) error {
 	}
 
 	config := state.Config{
-		Path:     cfg.Global.BasePath,
-		LogLevel: cfg.Global.LogLvl,
+		Path:            cfg.Global.BasePath,
+		LogLevel:        cfg.Global.LogLvl,
+		DatabaseBackend: cfg.Init.DatabaseBackend,
 		PrunerCfg: pruner.Config{
 			Mode:           cfg.Global.Pruning,
 			RetainedBlocks: cfg.Global.RetainBlocks,
@@ -241,6 +241,7 @@ func newNode(cfg *Config,
 	}
 
 	logger.Patch(log.SetLevel(cfg.Global.LogLvl))
+	log.SetFilter(cfg.Log.TargetLvls)
 
 	logger.Infof(
 		"🕸️ initialising node services with global configuration name %s, id %s and base path %s...",
@@ -287,7 +288,7 @@ func newNode(cfg *Config,
 	// check if network service is enabled
 	if enabled := networkServiceEnabled(cfg); enabled {
 		// create network service and append network service to node services
-		networkSrvc, err = builder.createNetworkService(cfg, stateSrvc, telemetryMailer)
+		networkSrvc, err = builder.createNetworkService(cfg, stateSrvc, ks.Audi, telemetryMailer)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create network service: %s", err)
 		}
@@ -145,7 +145,7 @@ func TestNewNode(t *testing.T) {
 			systemService := system.NewService(cfg, gd)
 			return systemService, err
 		})
-	m.EXPECT().createNetworkService(dotConfig, gomock.AssignableToTypeOf(&state.Service{}),
+	m.EXPECT().createNetworkService(dotConfig, gomock.AssignableToTypeOf(&state.Service{}), ks.Audi,
 		gomock.AssignableToTypeOf(&telemetry.Mailer{})).Return(testNetworkService, nil)
 
 	got, err := newNode(dotConfig, ks, m, mockServiceRegistry)
@@ -67,4 +67,31 @@
 	GenesisMismatch Reputation = math.MinInt32
 	// GenesisMismatchReason used when a peer has a different genesis
 	GenesisMismatchReason = "Genesis mismatch"
+
+	// GoodBlockResponseValue is used when a peer answers a block request with a valid response.
+	GoodBlockResponseValue Reputation = 1 << 4
+	// GoodBlockResponseReason is used when a peer answers a block request with a valid response.
+	GoodBlockResponseReason = "Good block response"
+
+	// EmptyBlockResponseValue is used when a peer answers a block request without any block.
+	EmptyBlockResponseValue Reputation = -(1 << 8)
+	// EmptyBlockResponseReason is used when a peer answers a block request without any block.
+	EmptyBlockResponseReason = "Empty block response"
+
+	// BadBlockResponseValue is used when a peer answers a block request with blocks not forming
+	// a chain, missing bodies or known bad blocks.
+	BadBlockResponseValue Reputation = -(1 << 12)
+	// BadBlockResponseReason is used when a peer answers a block request with blocks not forming
+	// a chain, missing bodies or known bad blocks.
+	BadBlockResponseReason = "Bad block response"
+
+	// TooManyRequestsValue is used when a peer exceeds the inbound request limits.
+	TooManyRequestsValue Reputation = -(1 << 10)
+	// TooManyRequestsReason is used when a peer exceeds the inbound request limits.
+	TooManyRequestsReason = "Too many requests"
+
+	// InvalidGossipValue is used when a peer sends us a gossip message rejected by its validator.
+	InvalidGossipValue Reputation = -(1 << 10)
+	// InvalidGossipReason is used when a peer sends us a gossip message rejected by its validator.
+	InvalidGossipReason = "Invalid gossip message"
 )
@@ -17,4 +17,6 @@
 	ErrOutgoingSlotsUnavailable = errors.New("not enough outgoing slots")
 
 	ErrIncomingSlotsUnavailable = errors.New("not enough incoming slots")
+
+	ErrClassSlotsUnavailable = errors.New("not enough slots")
 )
@@ -5,6 +5,7 @@
 
 import (
 	"context"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -88,6 +89,35 @@ func (h *Handler) ReportPeer(rep ReputationChange, peers ...peer.ID) {
 	}
 }
 
+// BanPeer bans the peers for the duration given, disconnecting them and
+// rejecting any connection with them until the ban expires.
+func (h *Handler) BanPeer(duration time.Duration, peers ...peer.ID) {
+	h.actionQueue <- action{
+		actionCall:  banPeer,
+		banDuration: duration,
+		peers:       peers,
+	}
+}
+
+// UnbanPeer lifts the ban of the peers.
+func (h *Handler) UnbanPeer(peers ...peer.ID) {
+	h.actionQueue <- action{
+		actionCall: unbanPeer,
+		peers:      peers,
+	}
+}
+
+// SetPeerClass sets the class of connected peers once their roles are known,
+// which determines the slots they occupy.
+func (h *Handler) SetPeerClass(setID int, class PeerClass, peers ...peer.ID) {
+	h.actionQueue <- action{
+		actionCall: setPeerClass,
+		setID:      setID,
+		peerClass:  class,
+		peers:      peers,
+	}
+}
+
 // Incoming calls when we have an incoming connection from peer.
 func (h *Handler) Incoming(setID int, peers ...peer.ID) {
 	h.actionQueue <- action{
@@ -55,6 +55,12 @@
 	sortedPeers
 	// disconnect peer
 	disconnect
+	// banPeer is for banning peers for a duration
+	banPeer
+	// unbanPeer is for lifting the ban of peers
+	unbanPeer
+	// setPeerClass is for setting the class of connected peers
+	setPeerClass
 )
 
 func (a ActionReceiver) String() string {
@@ -79,6 +85,12 @@ func (a ActionReceiver) String() string {
 		return "sortedPeers"
 	case disconnect:
 		return "disconnect"
+	case banPeer:
+		return "banPeer"
+	case unbanPeer:
+		return "unbanPeer"
+	case setPeerClass:
+		return "setPeerClass"
 	default:
 		return "invalid action"
 	}
@@ -89,6 +101,8 @@ type action struct {
 	actionCall    ActionReceiver
 	setID         int
 	reputation    ReputationChange
+	banDuration   time.Duration
+	peerClass     PeerClass
 	peers         peer.IDSlice
 	resultPeersCh chan peer.IDSlice
 }
@@ -188,6 +202,13 @@ type PeerSet struct {
 	nextPeriodicAllocSlots time.Duration
 	// chan for receiving action request.
 	actionQueue <-chan action
+
+	// db persists the reputations and bans, and is nil if they are only kept in memory.
+	db Database
+	// peers whose reputation changed since it was last stored in the database.
+	changedReputations map[peer.ID]struct{}
+	// banned peers mapped to the time their ban expires.
+	bannedPeers map[peer.ID]time.Time
 }
 
 // config is configuration of a single set.
@@ -196,6 +217,10 @@ type config struct {
 	maxInPeers uint32
 	// maximum number of slot occupying nodes for outgoing connections.
 	maxOutPeers uint32
+	// maximum number of slot occupying light clients, which do not occupy the slots above.
+	maxLightPeers uint32
+	// maximum number of slot occupying authorities, which do not occupy the slots above.
+	maxAuthorityPeers uint32
 
 	// TODO Use in future for reserved only peers
 	// if true, we only accept reservedNodes (#1888).
@@ -208,13 +233,19 @@ type config struct {
 // ConfigSet set of peerSet config.
 type ConfigSet struct {
 	Set []*config
+	// Database persists the peer reputations and bans across restarts if it is not nil.
+	Database Database
 }
 
-// NewConfigSet creates a new config set for the peerSet
-func NewConfigSet(maxInPeers, maxOutPeers uint32, reservedOnly bool, allocTime time.Duration) *ConfigSet {
+// NewConfigSet creates a new config set for the peerSet. The incoming and outgoing slots are
+// occupied by full nodes, while light clients and authorities have their own slots.
+func NewConfigSet(maxInPeers, maxOutPeers, maxLightPeers, maxAuthorityPeers uint32,
+	reservedOnly bool, allocTime time.Duration) *ConfigSet {
 	set := &config{
 		maxInPeers:        maxInPeers,
 		maxOutPeers:       maxOutPeers,
+		maxLightPeers:     maxLightPeers,
+		maxAuthorityPeers: maxAuthorityPeers,
 		reservedOnly:      reservedOnly,
 		periodicAllocTime: allocTime,
 	}
@@ -247,6 +278,21 @@ func newPeerSet(cfg *ConfigSet) (*PeerSet, error) {
 		created:                now,
 		latestTimeUpdate:       now,
 		nextPeriodicAllocSlots: cfgSet.periodicAllocTime,
+		db:                     cfg.Database,
+		changedReputations:     make(map[peer.ID]struct{}),
+		bannedPeers:            make(map[peer.ID]time.Time),
+	}
+
+	if ps.db != nil {
+		err = ps.loadReputations(now)
+		if err != nil {
+			return nil, fmt.Errorf("loading reputations: %w", err)
+		}
+
+		err = ps.loadBans(now)
+		if err != nil {
+			return nil, fmt.Errorf("loading bans: %w", err)
+		}
 	}
 
 	return ps, nil
@@ -297,7 +343,16 @@ func (ps *PeerSet) updateTime() error {
 			// if the peer reaches reputation 0, and there is no connection to it, forget it.
 			length := ps.peerState.getSetLength()
 			for set := 0; set < length; set++ {
-				if ps.peerState.peerStatus(set, peerID) != notConnectedPeer {
+				switch ps.peerState.peerStatus(set, peerID) {
+				case connectedPeer:
+					continue
+				case unknownPeer:
+					// the peer reputation was loaded from the database but
+					// the peer was not discovered since, so forget it now.
+					err = ps.peerState.forgetPeer(set, peerID)
+					if err != nil {
+						return fmt.Errorf("cannot forget peer: %w", err)
+					}
 					continue
 				}
 
@@ -337,32 +392,95 @@ func (ps *PeerSet) reportPeer(change ReputationChange, peers ...peer.ID) error {
 		if err != nil {
 			return fmt.Errorf("cannot add reputation: %w", err)
 		}
+		ps.changedReputations[pid] = struct{}{}
 
 		if rep >= BannedThresholdValue {
-			return nil
+			continue
 		}
 
-		setLen := ps.peerState.getSetLength()
-		for i := 0; i < setLen; i++ {
-			if ps.peerState.peerStatus(i, pid) != connectedPeer {
-				continue
-			}
+		if err = ps.dropPeer(pid); err != nil {
+			return fmt.Errorf("cannot drop peer: %w", err)
+		}
+	}
+	return nil
+}
 
-			// disconnect peer
-			err = ps.peerState.disconnect(i, pid)
-			if err != nil {
-				return fmt.Errorf("cannot disconnect: %w", err)
-			}
+// dropPeer disconnects the peer from all the sets it is connected to,
+// sending a drop message for each of them.
+func (ps *PeerSet) dropPeer(pid peer.ID) error {
+	setLen := ps.peerState.getSetLength()
+	for i := 0; i < setLen; i++ {
+		if ps.peerState.peerStatus(i, pid) != connectedPeer {
+			continue
+		}
 
-			ps.resultMsgCh <- Message{
-				Status: Drop,
-				setID:  uint64(i),
-				PeerID: pid,
-			}
+		// disconnect peer
+		err := ps.peerState.disconnect(i, pid)
+		if err != nil {
+			return fmt.Errorf("cannot disconnect: %w", err)
+		}
 
-			if err = ps.allocSlots(i); err != nil {
-				return fmt.Errorf("could not allocate slots: %w", err)
-			}
+		ps.resultMsgCh <- Message{
+			Status: Drop,
+			setID:  uint64(i),
+			PeerID: pid,
+		}
+
+		if err = ps.allocSlots(i); err != nil {
+			return fmt.Errorf("could not allocate slots: %w", err)
+		}
+	}
+	return nil
+}
+
+// banPeer bans the peers for the duration given, disconnecting them and
+// rejecting any connection with them until the ban expires.
+func (ps *PeerSet) banPeer(duration time.Duration, peers ...peer.ID) error {
+	expiry := time.Now().Add(duration)
+	for _, pid := range peers {
+		ps.bannedPeers[pid] = expiry
+		err := ps.storeBan(pid, expiry)
+		if err != nil {
+			return err
+		}
+
+		if err = ps.dropPeer(pid); err != nil {
+			return fmt.Errorf("cannot drop peer: %w", err)
+		}
+	}
+	return nil
+}
+
+// unbanPeer lifts the ban of the peers.
+func (ps *PeerSet) unbanPeer(peers ...peer.ID) error {
+	for _, pid := range peers {
+		delete(ps.bannedPeers, pid)
+		err := ps.deleteBan(pid)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBanned returns true if the peer is banned and its ban has not expired yet.
+func (ps *PeerSet) isBanned(pid peer.ID) bool {
+	expiry, ok := ps.bannedPeers[pid]
+	return ok && time.Now().Before(expiry)
+}
+
+// removeExpiredBans removes the bans which expired.
+func (ps *PeerSet) removeExpiredBans() error {
+	now := time.Now()
+	for pid, expiry := range ps.bannedPeers {
+		if now.Before(expiry) {
+			continue
+		}
+
+		delete(ps.bannedPeers, pid)
+		err := ps.deleteBan(pid)
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -377,6 +495,10 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 
 	peerState := ps.peerState
 	for reservePeer := range ps.reservedNode {
+		if ps.isBanned(reservePeer) {
+			continue
+		}
+
 		status := peerState.peerStatus(setIdx, reservePeer)
 		switch status {
 		case connectedPeer:
@@ -412,8 +534,15 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 		return nil
 	}
 
+	bannedPeers := make([]peer.ID, 0, len(ps.bannedPeers))
+	for pid := range ps.bannedPeers {
+		if ps.isBanned(pid) {
+			bannedPeers = append(bannedPeers, pid)
+		}
+	}
+
 	for peerState.hasFreeOutgoingSlot(setIdx) {
-		peerID := peerState.highestNotConnectedPeer(setIdx)
+		peerID := peerState.highestNotConnectedPeer(setIdx, bannedPeers...)
 		if peerID == "" {
 			break
 		}
@@ -631,7 +760,7 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 			PeerID: pid,
 		}
 
-		if nodeReputation < BannedThresholdValue {
+		if nodeReputation < BannedThresholdValue || ps.isBanned(pid) {
 			message.Status = Reject
 		} else {
 			err := state.tryAcceptIncoming(setID, pid)
@@ -654,6 +783,42 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 	return nil
 }
 
+// setPeerClass sets the class of the connected peers once their roles are known, moving them from
+// the full node slots or the unclassified headroom to the slots of their class. Peers are dropped
+// if all the slots of their class are occupied, and are no longer members of the set so we do not
+// connect to them again.
+func (ps *PeerSet) setPeerClass(setID int, class PeerClass, peers ...peer.ID) error {
+	for _, pid := range peers {
+		err := ps.peerState.setClass(setID, pid, class)
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, ErrPeerDisconnected), errors.Is(err, ErrPeerDoesNotExist):
+			logger.Debugf("cannot set class of peer %s: %s", pid, err)
+			continue
+		case !errors.Is(err, ErrClassSlotsUnavailable):
+			return fmt.Errorf("cannot set peer class: %w", err)
+		}
+
+		logger.Debugf("dropping peer %s: %s", pid, err)
+		if err = ps.peerState.disconnect(setID, pid); err != nil {
+			return fmt.Errorf("cannot disconnect: %w", err)
+		}
+
+		ps.resultMsgCh <- Message{
+			Status: Drop,
+			setID:  uint64(setID),
+			PeerID: pid,
+		}
+
+		if err = ps.peerState.forgetPeer(setID, pid); err != nil {
+			return fmt.Errorf("cannot forget peer: %w", err)
+		}
+	}
+
+	return ps.allocSlots(setID)
+}
+
 // DropReason represents reason for disconnection of the peer
 type DropReason int
 
@@ -684,6 +849,7 @@ func (ps *PeerSet) disconnect(setIdx int, reason DropReason, peers ...peer.ID) e
 		n := state.nodes[pid]
 		n.addReputation(disconnectReputationChange)
 		state.nodes[pid] = n
+		ps.changedReputations[pid] = struct{}{}
 
 		if err = state.disconnect(setIdx, pid); err != nil {
 			return fmt.Errorf("cannot disconnect: %w", err)
@@ -726,13 +892,24 @@ func (ps *PeerSet) listenActionAllocSlots(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			logger.Debugf("peerset slot allocation exiting: %s", ctx.Err())
+			if err := ps.storeReputations(); err != nil {
+				logger.Warnf("failed to store reputations: %s", err)
+			}
 			return
 		case <-ticker.C:
+			if err := ps.removeExpiredBans(); err != nil {
+				logger.Warnf("failed to remove expired bans: %s", err)
+			}
+
 			for setID := 0; setID < ps.peerState.getSetLength(); setID++ {
 				if err := ps.allocSlots(setID); err != nil {
 					logger.Warnf("failed to allocate slots: %s", err)
 				}
 			}
+
+			if err := ps.storeReputations(); err != nil {
+				logger.Warnf("failed to store reputations: %s", err)
+			}
 		case act, ok := <-ps.actionQueue:
 			if !ok {
 				return
@@ -762,6 +939,12 @@ func (ps *PeerSet) listenActionAllocSlots(ctx context.Context) {
 				act.resultPeersCh <- ps.peerState.sortedPeers(act.setID)
 			case disconnect:
 				err = ps.disconnect(act.setID, UnknownDrop, act.peers...)
+			case banPeer:
+				err = ps.banPeer(act.banDuration, act.peers...)
+			case unbanPeer:
+				err = ps.unbanPeer(act.peers...)
+			case setPeerClass:
+				err = ps.setPeerClass(act.setID, act.peerClass, act.peers...)
 			}
 
 			if err != nil {
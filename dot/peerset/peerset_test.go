@@ -406,3 +406,82 @@ func checkPeerSetReservedNodeCount(t *testing.T, ps *PeerSet, expectedCount int)
 
 	require.Equal(t, expectedCount, len(ps.reservedNode))
 }
+
+func TestPeerSet_setPeerClass(t *testing.T) {
+	t.Parallel()
+
+	ps, err := newPeerSet(&ConfigSet{
+		Set: []*config{{
+			maxInPeers:        1,
+			maxOutPeers:       1,
+			maxLightPeers:     1,
+			periodicAllocTime: allocTimeDuration,
+		}},
+	})
+	require.NoError(t, err)
+	ps.resultMsgCh = make(chan Message, msgChanSize)
+
+	err = ps.incoming(0, peer1)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	err = ps.setPeerClass(0, LightClientPeer, peer1)
+	require.NoError(t, err)
+
+	// the incoming full node slot is free again
+	err = ps.incoming(0, peer2)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	// peer2 is dropped since all the light client slots are occupied
+	err = ps.setPeerClass(0, LightClientPeer, peer2)
+	require.NoError(t, err)
+	msg := <-ps.resultMsgCh
+	checkMessageStatus(t, msg, Drop)
+	require.Equal(t, peer2, msg.PeerID)
+	// peer2 is not connected to again
+	require.Equal(t, unknownPeer, ps.peerState.peerStatus(0, peer2))
+	require.Empty(t, ps.resultMsgCh)
+	require.Equal(t, connectedPeer, ps.peerState.peerStatus(0, peer1))
+}
+
+func TestPeerSet_setPeerClass_fullIncomingSlots(t *testing.T) {
+	t.Parallel()
+
+	// there is no outgoing slot, so the rejected peer is not connected to
+	ps, err := newPeerSet(&ConfigSet{
+		Set: []*config{{
+			maxInPeers:        1,
+			maxLightPeers:     1,
+			periodicAllocTime: allocTimeDuration,
+		}},
+	})
+	require.NoError(t, err)
+	ps.resultMsgCh = make(chan Message, msgChanSize)
+
+	// peer1 is a full node occupying the only incoming full node slot
+	err = ps.incoming(0, peer1)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+	err = ps.setPeerClass(0, FullNodePeer, peer1)
+	require.NoError(t, err)
+
+	// peer2 is accepted before its roles are known since a light client slot is free
+	err = ps.incoming(0, peer2)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	// there is no headroom left for a third unclassified peer
+	err = ps.incoming(0, incoming3)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Reject)
+
+	// the light client connects
+	err = ps.setPeerClass(0, LightClientPeer, peer2)
+	require.NoError(t, err)
+	require.Empty(t, ps.resultMsgCh)
+	require.Equal(t, connectedPeer, ps.peerState.peerStatus(0, peer2))
+	require.Equal(t, uint32(1), ps.peerState.sets[0].numIn)
+	require.Equal(t, uint32(1), ps.peerState.sets[0].numLight)
+	require.Equal(t, uint32(0), ps.peerState.sets[0].numUnclassified)
+}
@@ -12,6 +12,7 @@
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -37,6 +38,37 @@
 	notConnected
 )
 
+// PeerClass is the class of a connected peer, and each class has its own slots.
+type PeerClass uint8
+
+const (
+	// FullNodePeer is a full node, and is the class of peers until their roles are known.
+	FullNodePeer PeerClass = iota
+	// LightClientPeer is a light client, which does not occupy full node slots.
+	LightClientPeer
+	// AuthorityPeer is an authority, which does not occupy full node slots.
+	AuthorityPeer
+	// unclassifiedPeer is an incoming peer accepted while the incoming full node slots are
+	// occupied, which occupies the headroom left by the free light client and authority slots
+	// until its roles are known.
+	unclassifiedPeer
+)
+
+func (c PeerClass) String() string {
+	switch c {
+	case FullNodePeer:
+		return "full node"
+	case LightClientPeer:
+		return "light client"
+	case AuthorityPeer:
+		return "authority"
+	case unclassifiedPeer:
+		return "unclassified"
+	default:
+		return "unknown"
+	}
+}
+
 // Info is state of a single set.
 type Info struct {
 	// number of slot occupying nodes for which the MembershipState is ingoing.
@@ -51,6 +83,22 @@ type Info struct {
 	// maximum allowed number of slot occupying nodes for which the MembershipState is outgoing.
 	maxOut uint32
 
+	// number of slot occupying light clients, whatever the direction of their connection.
+	numLight uint32
+
+	// maximum allowed number of slot occupying light clients.
+	maxLight uint32
+
+	// number of slot occupying authorities, whatever the direction of their connection.
+	numAuthority uint32
+
+	// maximum allowed number of slot occupying authorities.
+	maxAuthority uint32
+
+	// number of incoming nodes accepted whose roles are not known yet, occupying
+	// the headroom left by the free light client and authority slots.
+	numUnclassified uint32
+
 	// list of node identities (discovered or not) that don't occupy slots.
 	// Note for future readers: this module is purely dedicated to managing slots.
 	// If you are considering adding more features, please consider doing so outside this module rather
@@ -69,6 +117,9 @@ type node struct {
 	// discovered it.
 	lastConnected []time.Time
 
+	// class of the node in each set, which determines the slots it occupies while connected.
+	class []PeerClass
+
 	// Reputation of the node, between int32 MIN and int32 MAX.
 	reputation Reputation
 }
@@ -86,6 +137,7 @@ func newNode(n int) *node {
 	return &node{
 		state:         sets,
 		lastConnected: lastConnected,
+		class:         make([]PeerClass, n),
 	}
 }
 
@@ -125,11 +177,13 @@ func NewPeerState(cfgs []*config) (*PeersState, error) {
 	infoSet := make([]Info, 0, len(cfgs))
 	for _, cfg := range cfgs {
 		info := Info{
-			numIn:       0,
-			numOut:      0,
-			maxIn:       cfg.maxInPeers,
-			maxOut:      cfg.maxOutPeers,
-			noSlotNodes: make(map[peer.ID]struct{}),
+			numIn:        0,
+			numOut:       0,
+			maxIn:        cfg.maxInPeers,
+			maxOut:       cfg.maxOutPeers,
+			maxLight:     cfg.maxLightPeers,
+			maxAuthority: cfg.maxAuthorityPeers,
+			noSlotNodes:  make(map[peer.ID]struct{}),
 		}
 
 		infoSet = append(infoSet, info)
@@ -249,14 +303,15 @@ func (ps *PeersState) addReputation(peerID peer.ID, change ReputationChange) (
 	return newReputation, nil
 }
 
-// highestNotConnectedPeer returns the peer with the highest Reputation and that we are not connected to.
-func (ps *PeersState) highestNotConnectedPeer(set int) (highestPeerID peer.ID) {
+// highestNotConnectedPeer returns the peer with the highest Reputation and that we are not connected to,
+// ignoring the excluded peers given.
+func (ps *PeersState) highestNotConnectedPeer(set int, excluded ...peer.ID) (highestPeerID peer.ID) {
 	ps.RLock()
 	defer ps.RUnlock()
 
 	maxRep := math.MinInt32
 	for peerID, node := range ps.nodes {
-		if node.state[set] != notConnected {
+		if node.state[set] != notConnected || slices.Contains(excluded, peerID) {
 			continue
 		}
 
@@ -299,12 +354,7 @@ func (ps *PeersState) addNoSlotNode(idx int, peerID peer.ID) error {
 		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
 	}
 
-	switch node.state[idx] {
-	case ingoing:
-		ps.sets[idx].numIn--
-	case outgoing:
-		ps.sets[idx].numOut--
-	}
+	ps.sets[idx].releaseSlot(node, idx)
 
 	return nil
 }
@@ -325,13 +375,120 @@ func (ps *PeersState) removeNoSlotNode(idx int, peerID peer.ID) error {
 		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
 	}
 
-	switch node.state[idx] {
-	case ingoing:
-		ps.sets[idx].numIn++
-	case outgoing:
-		ps.sets[idx].numOut++
+	ps.sets[idx].occupySlot(node, idx)
+
+	return nil
+}
+
+// releaseSlot frees the slot occupied by the node in the set, depending on its class
+// and on the direction of its connection. It has no effect if the node is not connected.
+func (info *Info) releaseSlot(n *node, set int) {
+	if !isPeerConnected(n.state[set]) {
+		return
+	}
+
+	switch n.class[set] {
+	case LightClientPeer:
+		info.numLight--
+	case AuthorityPeer:
+		info.numAuthority--
+	case unclassifiedPeer:
+		info.numUnclassified--
+	default:
+		if n.state[set] == ingoing {
+			info.numIn--
+		} else {
+			info.numOut--
+		}
+	}
+}
+
+// occupySlot occupies a slot for the node in the set, depending on its class
+// and on the direction of its connection. It has no effect if the node is not connected.
+func (info *Info) occupySlot(n *node, set int) {
+	if !isPeerConnected(n.state[set]) {
+		return
+	}
+
+	switch n.class[set] {
+	case LightClientPeer:
+		info.numLight++
+	case AuthorityPeer:
+		info.numAuthority++
+	case unclassifiedPeer:
+		info.numUnclassified++
+	default:
+		if n.state[set] == ingoing {
+			info.numIn++
+		} else {
+			info.numOut++
+		}
+	}
+}
+
+// hasFreeClassSlot returns true if a node of the current class given can occupy a slot of
+// the class given. Full node slots are checked when the connection is established, so
+// moving a node back to them is always allowed, even if it temporarily exceeds their
+// maximum, except for unclassified nodes which did not get a full node slot.
+func (info *Info) hasFreeClassSlot(current, class PeerClass) bool {
+	switch class {
+	case LightClientPeer:
+		return info.numLight < info.maxLight
+	case AuthorityPeer:
+		return info.numAuthority < info.maxAuthority
+	default:
+		return current != unclassifiedPeer || info.numIn < info.maxIn
 	}
+}
 
+// hasFreeUnclassifiedSlot returns true if an incoming node can be accepted while its
+// roles are not known, that is if there are more free light client and authority
+// slots than unclassified nodes.
+func (info *Info) hasFreeUnclassifiedSlot() bool {
+	var free uint32
+	if info.numLight < info.maxLight {
+		free += info.maxLight - info.numLight
+	}
+	if info.numAuthority < info.maxAuthority {
+		free += info.maxAuthority - info.numAuthority
+	}
+	return info.numUnclassified < free
+}
+
+// setClass sets the class of the connected node, moving it from the slot it occupies to
+// a slot of its new class. If all the slots of the class are occupied, the node keeps its
+// current class and slot and ErrClassSlotsUnavailable is returned. Non slot occupying
+// nodes only have their class set.
+func (ps *PeersState) setClass(set int, peerID peer.ID, class PeerClass) error {
+	ps.Lock()
+	defer ps.Unlock()
+
+	n, has := ps.nodes[peerID]
+	if !has {
+		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
+	}
+
+	if !isPeerConnected(n.state[set]) {
+		return ErrPeerDisconnected
+	}
+
+	if n.class[set] == class {
+		return nil
+	}
+
+	info := &ps.sets[set]
+	if _, isNoSlotNode := info.noSlotNodes[peerID]; isNoSlotNode {
+		n.class[set] = class
+		return nil
+	}
+
+	if !info.hasFreeClassSlot(n.class[set], class) {
+		return fmt.Errorf("%w: for %s peers", ErrClassSlotsUnavailable, class)
+	}
+
+	info.releaseSlot(n, set)
+	n.class[set] = class
+	info.occupySlot(n, set)
 	return nil
 }
 
@@ -341,7 +498,7 @@ func (ps *PeersState) disconnect(idx int, peerID peer.ID) error {
 	ps.Lock()
 	defer ps.Unlock()
 
-	info := ps.sets[idx]
+	info := &ps.sets[idx]
 	node, has := ps.nodes[peerID]
 	if !has {
 		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
@@ -349,38 +506,53 @@ func (ps *PeersState) disconnect(idx int, peerID peer.ID) error {
 
 	_, has = info.noSlotNodes[peerID]
 	if !has {
-		switch node.state[idx] {
-		case ingoing:
-			info.numIn--
-		case outgoing:
-			info.numOut--
-		default:
+		if !isPeerConnected(node.state[idx]) {
 			return ErrPeerDisconnected
 		}
+		info.releaseSlot(node, idx)
 	}
 
-	// set node state to notConnected.
+	// set node state to notConnected, the class of the node is known again once it reconnects.
 	node.state[idx] = notConnected
 	node.lastConnected[idx] = time.Now()
-	ps.sets[idx] = info
+	node.class[idx] = FullNodePeer
 
 	return nil
 }
 
 // discover takes input for set id and create a node and insert in the list.
 // the initial Reputation of the peer will be 0 and ingoing notMember state.
+// If the node is already known but is not a member of the set, it becomes
+// a not connected member of the set and keeps its reputation.
 func (ps *PeersState) discover(set int, peerID peer.ID) {
 	ps.Lock()
 	defer ps.Unlock()
 
 	numSet := len(ps.sets)
 
-	_, has := ps.nodes[peerID]
+	n, has := ps.nodes[peerID]
 	if !has {
-		n := newNode(numSet)
+		n = newNode(numSet)
+		ps.nodes[peerID] = n
+	}
+
+	if n.state[set] == notMember {
 		n.state[set] = notConnected
+	}
+}
+
+// setReputation sets the reputation of the peer, creating
+// a node not member of any set if the peer is unknown.
+func (ps *PeersState) setReputation(peerID peer.ID, reputation Reputation) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	n, has := ps.nodes[peerID]
+	if !has {
+		n = newNode(len(ps.sets))
 		ps.nodes[peerID] = n
 	}
+	n.reputation = reputation
 }
 
 func (ps *PeersState) lastConnectedAndDiscovered(set int, peerID peer.ID) (time.Time, error) {
@@ -463,17 +635,24 @@ func (ps *PeersState) tryOutgoing(setID int, peerID peer.ID) error {
 
 // tryAcceptIncoming tries to accept the peer as an incoming connection.
 // if there are enough slots available, switches the node to Connected and returns nil.
+// If the incoming full node slots are full, the node is still accepted as unclassified
+// if there are free light client or authority slots, since its roles are not known yet.
 // If the slots are full, the node stays "not connected" and we return Err.
 // non slot occupying nodes don't count towards the number of slots.
 func (ps *PeersState) tryAcceptIncoming(setID int, peerID peer.ID) error {
 	ps.Lock()
 	defer ps.Unlock()
 
-	_, isNoSlotOccupied := ps.sets[setID].noSlotNodes[peerID]
+	info := &ps.sets[setID]
+	_, isNoSlotOccupied := info.noSlotNodes[peerID]
 
+	class := FullNodePeer
 	// if slot is not available and the node is not a reserved node then error
 	if ps.hasFreeIncomingSlot(setID) && !isNoSlotOccupied {
-		return ErrIncomingSlotsUnavailable
+		if !info.hasFreeUnclassifiedSlot() {
+			return ErrIncomingSlotsUnavailable
+		}
+		class = unclassifiedPeer
 	}
 
 	node, has := ps.nodes[peerID]
@@ -483,9 +662,10 @@ func (ps *PeersState) tryAcceptIncoming(setID int, peerID peer.ID) error {
 	}
 
 	node.state[setID] = ingoing
+	node.class[setID] = class
 	if !isNoSlotOccupied {
 		// this need to be added as incoming connection allocate slot.
-		ps.sets[setID].numIn++
+		info.occupySlot(node, setID)
 	}
 
 	return nil
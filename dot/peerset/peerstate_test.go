@@ -197,3 +197,88 @@ func TestHighestNotConnectedPeer(t *testing.T) {
 
 	require.Equal(t, peer1, state.highestNotConnectedPeer(0))
 }
+
+func TestPeersState_setClass(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewPeerState([]*config{{
+		maxInPeers:    1,
+		maxOutPeers:   1,
+		maxLightPeers: 1,
+	}})
+	require.NoError(t, err)
+
+	state.discover(0, peer1)
+	err = state.tryAcceptIncoming(0, peer1)
+	require.NoError(t, err)
+
+	// peers are full nodes until their class is set
+	require.Equal(t, uint32(1), state.sets[0].numIn)
+
+	err = state.setClass(0, peer1, LightClientPeer)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), state.sets[0].numIn)
+	require.Equal(t, uint32(1), state.sets[0].numLight)
+
+	// the light client does not occupy the incoming full node slot
+	state.discover(0, peer2)
+	err = state.tryAcceptIncoming(0, peer2)
+	require.NoError(t, err)
+
+	// there is no slot left for a second light client or for authorities
+	err = state.setClass(0, peer2, LightClientPeer)
+	require.ErrorIs(t, err, ErrClassSlotsUnavailable)
+	err = state.setClass(0, peer2, AuthorityPeer)
+	require.ErrorIs(t, err, ErrClassSlotsUnavailable)
+	require.Equal(t, uint32(1), state.sets[0].numIn)
+
+	// the light client slot is freed on disconnect
+	err = state.disconnect(0, peer1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), state.sets[0].numLight)
+	require.Equal(t, FullNodePeer, state.nodes[peer1].class[0])
+
+	err = state.setClass(0, peer1, LightClientPeer)
+	require.ErrorIs(t, err, ErrPeerDisconnected)
+}
+
+func TestPeersState_tryAcceptIncoming_unclassified(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewPeerState([]*config{{
+		maxInPeers:    1,
+		maxOutPeers:   1,
+		maxLightPeers: 1,
+	}})
+	require.NoError(t, err)
+
+	state.discover(0, peer1)
+	err = state.tryAcceptIncoming(0, peer1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), state.sets[0].numIn)
+
+	// the incoming full node slots are full, but a light client slot is free
+	state.discover(0, peer2)
+	err = state.tryAcceptIncoming(0, peer2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), state.sets[0].numUnclassified)
+
+	// a full node cannot occupy the headroom reserved for the other classes
+	err = state.setClass(0, peer2, FullNodePeer)
+	require.ErrorIs(t, err, ErrClassSlotsUnavailable)
+
+	// the headroom is freed on disconnect
+	err = state.disconnect(0, peer2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), state.sets[0].numUnclassified)
+
+	// an unclassified full node occupies a full node slot once one is free
+	err = state.tryAcceptIncoming(0, peer2)
+	require.NoError(t, err)
+	err = state.disconnect(0, peer1)
+	require.NoError(t, err)
+	err = state.setClass(0, peer2, FullNodePeer)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), state.sets[0].numIn)
+	require.Equal(t, uint32(0), state.sets[0].numUnclassified)
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package peerset
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Database is the database used to persist the peer
+// reputations and bans across restarts.
+type Database interface {
+	Put(key, value []byte) error
+	Del(key []byte) error
+	NewPrefixIterator(prefix []byte) database.Iterator
+}
+
+var (
+	reputationPrefix = []byte("reputation")
+	banPrefix        = []byte("ban")
+)
+
+// storedReputation is the reputation of a peer as stored in the database,
+// together with the unix time in seconds at which it was stored.
+type storedReputation struct {
+	Reputation int32
+	Timestamp  int64
+}
+
+func reputationKey(peerID peer.ID) []byte {
+	return prefixedKey(reputationPrefix, peerID)
+}
+
+func banKey(peerID peer.ID) []byte {
+	return prefixedKey(banPrefix, peerID)
+}
+
+func prefixedKey(prefix []byte, peerID peer.ID) (key []byte) {
+	key = make([]byte, 0, len(prefix)+len(peerID))
+	key = append(key, prefix...)
+	return append(key, peerID...)
+}
+
+// decayReputation returns the reputation decayed by the elapsed time,
+// as if it had been decayed by updateTime every second.
+func decayReputation(reputation Reputation, elapsed time.Duration) Reputation {
+	for seconds := int64(elapsed.Seconds()); seconds > 0 && reputation != 0; seconds-- {
+		reputation = reputationTick(reputation)
+	}
+	return reputation
+}
+
+// loadReputations loads the stored peer reputations, decaying each of them by the time
+// elapsed since it was stored. Reputations decayed to zero are deleted from the database.
+func (ps *PeerSet) loadReputations(now time.Time) error {
+	reputations := make(map[peer.ID]storedReputation)
+	iterator := ps.db.NewPrefixIterator(reputationPrefix)
+	for iterator.Next() {
+		peerID := peer.ID(bytes.TrimPrefix(iterator.Key(), reputationPrefix))
+		var stored storedReputation
+		err := scale.Unmarshal(iterator.Value(), &stored)
+		if err != nil {
+			iterator.Release()
+			return fmt.Errorf("decoding reputation of peer %s: %w", peerID, err)
+		}
+		reputations[peerID] = stored
+	}
+	err := iterator.Error()
+	iterator.Release()
+	if err != nil {
+		return fmt.Errorf("iterating over reputations: %w", err)
+	}
+
+	for peerID, stored := range reputations {
+		elapsed := now.Sub(time.Unix(stored.Timestamp, 0))
+		reputation := decayReputation(Reputation(stored.Reputation), elapsed)
+		if reputation == 0 {
+			err := ps.db.Del(reputationKey(peerID))
+			if err != nil {
+				return fmt.Errorf("deleting reputation of peer %s: %w", peerID, err)
+			}
+			continue
+		}
+
+		ps.peerState.setReputation(peerID, reputation)
+	}
+
+	return nil
+}
+
+// storeReputations stores the reputations changed since they were last stored.
+// The reputations of peers no longer known are deleted from the database.
+func (ps *PeerSet) storeReputations() error {
+	if ps.db == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for peerID := range ps.changedReputations {
+		n, err := ps.peerState.getNode(peerID)
+		if err != nil {
+			err = ps.db.Del(reputationKey(peerID))
+			if err != nil {
+				return fmt.Errorf("deleting reputation of peer %s: %w", peerID, err)
+			}
+			delete(ps.changedReputations, peerID)
+			continue
+		}
+
+		ps.peerState.RLock()
+		stored := storedReputation{
+			Reputation: int32(n.reputation),
+			Timestamp:  now.Unix(),
+		}
+		ps.peerState.RUnlock()
+
+		err = ps.db.Put(reputationKey(peerID), scale.MustMarshal(stored))
+		if err != nil {
+			return fmt.Errorf("storing reputation of peer %s: %w", peerID, err)
+		}
+		delete(ps.changedReputations, peerID)
+	}
+
+	return nil
+}
+
+// loadBans loads the stored peer bans, deleting the expired ones from the database.
+func (ps *PeerSet) loadBans(now time.Time) error {
+	bans := make(map[peer.ID]time.Time)
+	iterator := ps.db.NewPrefixIterator(banPrefix)
+	for iterator.Next() {
+		peerID := peer.ID(bytes.TrimPrefix(iterator.Key(), banPrefix))
+		var expiry int64
+		err := scale.Unmarshal(iterator.Value(), &expiry)
+		if err != nil {
+			iterator.Release()
+			return fmt.Errorf("decoding ban of peer %s: %w", peerID, err)
+		}
+		bans[peerID] = time.Unix(expiry, 0)
+	}
+	err := iterator.Error()
+	iterator.Release()
+	if err != nil {
+		return fmt.Errorf("iterating over bans: %w", err)
+	}
+
+	for peerID, expiry := range bans {
+		if !now.Before(expiry) {
+			err := ps.db.Del(banKey(peerID))
+			if err != nil {
+				return fmt.Errorf("deleting ban of peer %s: %w", peerID, err)
+			}
+			continue
+		}
+
+		ps.bannedPeers[peerID] = expiry
+	}
+
+	return nil
+}
+
+// storeBan stores the ban of the peer expiring at the time given.
+func (ps *PeerSet) storeBan(peerID peer.ID, expiry time.Time) error {
+	if ps.db == nil {
+		return nil
+	}
+
+	err := ps.db.Put(banKey(peerID), scale.MustMarshal(expiry.Unix()))
+	if err != nil {
+		return fmt.Errorf("storing ban of peer %s: %w", peerID, err)
+	}
+	return nil
+}
+
+// deleteBan deletes the ban of the peer from the database.
+func (ps *PeerSet) deleteBan(peerID peer.ID) error {
+	if ps.db == nil {
+		return nil
+	}
+
+	err := ps.db.Del(banKey(peerID))
+	if err != nil {
+		return fmt.Errorf("deleting ban of peer %s: %w", peerID, err)
+	}
+	return nil
+}
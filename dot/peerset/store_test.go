@@ -0,0 +1,221 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package peerset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDatabase(t *testing.T) *database.Table {
+	t.Helper()
+
+	db, err := database.New(database.Badger, "", true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	return database.NewTable(db, "peerset")
+}
+
+func newTestPersistedPeerSet(t *testing.T, db Database) *PeerSet {
+	t.Helper()
+
+	ps, err := newPeerSet(&ConfigSet{
+		Set: []*config{{
+			maxInPeers:        25,
+			maxOutPeers:       25,
+			periodicAllocTime: allocTimeDuration,
+		}},
+		Database: db,
+	})
+	require.NoError(t, err)
+	ps.resultMsgCh = make(chan Message, msgChanSize)
+
+	return ps
+}
+
+func Test_decayReputation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		reputation Reputation
+		elapsed    time.Duration
+		decayed    Reputation
+	}{
+		"no_time_elapsed": {
+			reputation: -1000,
+			decayed:    -1000,
+		},
+		"less_than_a_second_elapsed": {
+			reputation: -1000,
+			elapsed:    999 * time.Millisecond,
+			decayed:    -1000,
+		},
+		"negative_reputation": {
+			reputation: -1000,
+			elapsed:    2 * time.Second,
+			decayed:    -961,
+		},
+		"positive_reputation": {
+			reputation: 1000,
+			elapsed:    2 * time.Second,
+			decayed:    961,
+		},
+		"decayed_to_zero": {
+			reputation: BadProtocolValue,
+			elapsed:    time.Hour,
+			decayed:    0,
+		},
+		"clock_went_backwards": {
+			reputation: 1000,
+			elapsed:    -time.Hour,
+			decayed:    1000,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decayed := decayReputation(testCase.reputation, testCase.elapsed)
+
+			assert.Equal(t, testCase.decayed, decayed)
+		})
+	}
+}
+
+func TestPeerSet_storeReputations(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDatabase(t)
+	ps := newTestPersistedPeerSet(t, db)
+
+	ps.peerState.discover(0, peer1)
+	ps.peerState.discover(0, peer2)
+	err := ps.reportPeer(newReputationChange(BadMessageValue, BadMessageReason), peer1, peer2)
+	require.NoError(t, err)
+
+	// peer2 is forgotten before its reputation is stored
+	delete(ps.peerState.nodes, peer2)
+	err = db.Put(reputationKey(peer2), scale.MustMarshal(storedReputation{Reputation: 1}))
+	require.NoError(t, err)
+
+	before := time.Now().Unix()
+	err = ps.storeReputations()
+	require.NoError(t, err)
+	assert.Empty(t, ps.changedReputations)
+
+	encoded, err := db.Get(reputationKey(peer1))
+	require.NoError(t, err)
+	var stored storedReputation
+	err = scale.Unmarshal(encoded, &stored)
+	require.NoError(t, err)
+	assert.Equal(t, int32(BadMessageValue), stored.Reputation)
+	assert.GreaterOrEqual(t, stored.Timestamp, before)
+
+	_, err = db.Get(reputationKey(peer2))
+	assert.ErrorIs(t, err, database.ErrKeyNotFound)
+}
+
+func TestPeerSet_loadReputations(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDatabase(t)
+	const storedAt = 1000
+	err := db.Put(reputationKey(peer1), scale.MustMarshal(storedReputation{
+		Reputation: int32(BadMessageValue),
+		Timestamp:  storedAt,
+	}))
+	require.NoError(t, err)
+	err = db.Put(reputationKey(peer2), scale.MustMarshal(storedReputation{
+		Reputation: 5,
+		Timestamp:  storedAt,
+	}))
+	require.NoError(t, err)
+
+	ps := newTestPersistedPeerSet(t, nil)
+	ps.db = db
+
+	err = ps.loadReputations(time.Unix(storedAt+10, 0))
+	require.NoError(t, err)
+
+	reputation, err := (&Handler{peerSet: ps}).PeerReputation(peer1)
+	require.NoError(t, err)
+	assert.Equal(t, decayReputation(BadMessageValue, 10*time.Second), reputation)
+	// the peer is known but not a member of any set until it is discovered again
+	assert.Equal(t, unknownPeer, ps.peerState.peerStatus(0, peer1))
+	ps.peerState.discover(0, peer1)
+	assert.Equal(t, notConnectedPeer, ps.peerState.peerStatus(0, peer1))
+
+	// the reputation of peer2 decayed to zero
+	_, err = ps.peerState.getNode(peer2)
+	assert.ErrorIs(t, err, ErrPeerDoesNotExist)
+	_, err = db.Get(reputationKey(peer2))
+	assert.ErrorIs(t, err, database.ErrKeyNotFound)
+}
+
+func TestPeerSet_banPeer(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDatabase(t)
+	ps := newTestPersistedPeerSet(t, db)
+
+	ps.peerState.discover(0, peer1)
+	err := ps.peerState.tryAcceptIncoming(0, peer1)
+	require.NoError(t, err)
+
+	err = ps.banPeer(time.Hour, peer1)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Drop)
+	assert.Equal(t, notConnectedPeer, ps.peerState.peerStatus(0, peer1))
+
+	err = ps.incoming(0, peer1)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-ps.resultMsgCh, Reject)
+
+	// banned peers are not connected to
+	err = ps.allocSlots(0)
+	require.NoError(t, err)
+	assert.Empty(t, ps.resultMsgCh)
+
+	// the ban is loaded on restart
+	reloaded := newTestPersistedPeerSet(t, db)
+	assert.True(t, reloaded.isBanned(peer1))
+
+	err = reloaded.unbanPeer(peer1)
+	require.NoError(t, err)
+	assert.False(t, reloaded.isBanned(peer1))
+	_, err = db.Get(banKey(peer1))
+	assert.ErrorIs(t, err, database.ErrKeyNotFound)
+
+	err = reloaded.incoming(0, peer1)
+	require.NoError(t, err)
+	checkMessageStatus(t, <-reloaded.resultMsgCh, Accept)
+}
+
+func TestPeerSet_loadBans_expired(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDatabase(t)
+	err := db.Put(banKey(peer1), scale.MustMarshal(time.Now().Add(-time.Second).Unix()))
+	require.NoError(t, err)
+	err = db.Put(banKey(peer2), scale.MustMarshal(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	ps := newTestPersistedPeerSet(t, db)
+
+	assert.False(t, ps.isBanned(peer1))
+	_, err = db.Get(banKey(peer1))
+	assert.ErrorIs(t, err, database.ErrKeyNotFound)
+	assert.True(t, ps.isBanned(peer2))
+}
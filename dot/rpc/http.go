@@ -112,7 +112,8 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "rpc":
 			srvc = modules.NewRPCModule(h.serverConfig.RPCAPI)
 		case "dev":
-			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI)
+			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI,
+				h.serverConfig.SyncAPI)
 		case "offchain":
 			srvc = modules.NewOffchainModule(h.serverConfig.NodeStorage)
 		case "childstate":
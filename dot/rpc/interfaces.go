@@ -5,6 +5,7 @@
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
@@ -14,6 +15,7 @@
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 )
@@ -49,6 +51,8 @@ type BlockAPI interface {
 	RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error)
 	UnregisterRuntimeUpdatedChannel(id uint32) bool
 	GetRuntime(blockHash common.Hash) (runtime state.Runtime, err error)
+	HasStorageChanges(blockHash common.Hash) (bool, error)
+	GetStorageChanges(blockHash common.Hash) (keys [][]byte, err error)
 }
 
 // NetworkAPI interface for network state methods
@@ -56,12 +60,15 @@ type NetworkAPI interface {
 	Health() common.Health
 	NetworkState() common.NetworkState
 	Peers() []common.PeerInfo
+	Bandwidth() common.Bandwidth
 	NodeRoles() common.Roles
 	Stop() error
 	Start() error
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	BanPeer(peerID string, duration time.Duration) error
+	UnbanPeer(peerID string) error
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -89,6 +96,8 @@ type CoreAPI interface {
 	GetMetadata(bhash *common.Hash) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	GetChildReadProofAt(block common.Hash, keyToChild []byte, keys [][]byte) (common.Hash, [][]byte, error)
+	TraceBlock(blockHash common.Hash, tracer *tracing.Tracer) error
 }
 
 // API is the interface for methods related to RPC service
@@ -123,6 +132,7 @@ type SyncStateAPI interface {
 // SyncAPI is the interface to interact with the sync service
 type SyncAPI interface {
 	HighestBlock() uint
+	PeerSyncStats() []common.PeerSyncStats
 }
 
 // Telemetry is the telemetry client to send telemetry messages.
@@ -4,6 +4,8 @@
 package modules
 
 import (
+	"time"
+
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -12,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 )
@@ -47,6 +50,8 @@ type BlockAPI interface {
 	RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error)
 	UnregisterRuntimeUpdatedChannel(id uint32) bool
 	GetRuntime(blockHash common.Hash) (instance state.Runtime, err error)
+	HasStorageChanges(blockHash common.Hash) (bool, error)
+	GetStorageChanges(blockHash common.Hash) (keys [][]byte, err error)
 }
 
 // NetworkAPI interface for network state methods
@@ -54,12 +59,15 @@ type NetworkAPI interface {
 	Health() common.Health
 	NetworkState() common.NetworkState
 	Peers() []common.PeerInfo
+	Bandwidth() common.Bandwidth
 	NodeRoles() common.Roles
 	Stop() error
 	Start() error
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	BanPeer(peerID string, duration time.Duration) error
+	UnbanPeer(peerID string) error
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -84,6 +92,8 @@ type CoreAPI interface {
 	GetMetadata(bhash *common.Hash) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	GetChildReadProofAt(block common.Hash, keyToChild []byte, keys [][]byte) (common.Hash, [][]byte, error)
+	TraceBlock(blockHash common.Hash, tracer *tracing.Tracer) error
 }
 
 // RPCAPI is the interface for methods related to RPC service
@@ -125,4 +135,5 @@ type SyncStateAPI interface {
 // SyncAPI is the interface to interact with the sync service
 type SyncAPI interface {
 	HighestBlock() uint
+	PeerSyncStats() []common.PeerSyncStats
 }
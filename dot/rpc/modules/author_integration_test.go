@@ -632,6 +632,7 @@ type coreStorageState interface {
 	StoreTrie(*storage.TrieState, *types.Header) error
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
+	GenerateChildTrieProof(stateRoot common.Hash, keyToChild []byte, keys [][]byte) ([][]byte, error)
 	sync.Locker
 }
 
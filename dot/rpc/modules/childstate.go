@@ -4,9 +4,13 @@
 package modules
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
 // GetKeysRequest represents the request to retrieve the keys of a child storage
@@ -37,6 +41,28 @@ type GetChildStorageRequest struct {
 	Hash     *common.Hash
 }
 
+// ChildStateKeysPagedRequest is the request to retrieve
+// the keys of a child storage with pagination support
+type ChildStateKeysPagedRequest struct {
+	ChildStorageKey string       `json:"childStorageKey"`
+	Prefix          string       `json:"prefix"`
+	Qty             uint32       `json:"qty"`
+	AfterKey        string       `json:"afterKey"`
+	Hash            *common.Hash `json:"block"`
+}
+
+// ChildStateStorageEntriesRequest is the request to
+// retrieve multiple entries of a child storage
+type ChildStateStorageEntriesRequest struct {
+	ChildStorageKey string       `json:"childStorageKey"`
+	Keys            []string     `json:"keys"`
+	Hash            *common.Hash `json:"block"`
+}
+
+// ChildStateStorageEntriesResponse holds the hex encoded values of child
+// storage entries, where the value of an entry not found is nil
+type ChildStateStorageEntriesResponse []*string
+
 // ChildStateModule is the module responsible to implement all the childstate RPC calls
 type ChildStateModule struct {
 	storageAPI StorageAPI
@@ -71,7 +97,11 @@ func (cs *ChildStateModule) GetKeys(_ *http.Request, req *GetKeysRequest, res *[
 		return err
 	}
 
-	keys := trie.GetKeysWithPrefix(req.Prefix)
+	keys, err := trie.GetKeysWithPrefix(req.Prefix)
+	if err != nil {
+		return fmt.Errorf("getting keys with prefix: %w", err)
+	}
+
 	hexKeys := make([]string, len(keys))
 	for idx, k := range keys {
 		hexKeys[idx] = common.BytesToHex(k)
@@ -166,3 +196,126 @@ func (cs *ChildStateModule) GetStorage(
 
 	return nil
 }
+
+// GetKeysPaged returns at most the requested quantity of keys with the prefix
+// given from the specified child storage, in lexicographic order, starting
+// after the given key if any.
+func (cs *ChildStateModule) GetKeysPaged(
+	_ *http.Request, req *ChildStateKeysPagedRequest, res *StateStorageKeysResponse) error {
+	prefix, err := common.HexToBytes(emptyHexToZero(req.Prefix))
+	if err != nil {
+		return fmt.Errorf("decoding prefix: %w", err)
+	}
+
+	afterKey, err := common.HexToBytes(emptyHexToZero(req.AfterKey))
+	if err != nil {
+		return fmt.Errorf("decoding after key: %w", err)
+	}
+
+	*res = StateStorageKeysResponse{}
+
+	childTrie, err := cs.getChildTrie(req.Hash, req.ChildStorageKey)
+	if err != nil {
+		return err
+	} else if childTrie == nil || req.Qty == 0 {
+		return nil
+	}
+
+	key := afterKey
+	if bytes.Compare(afterKey, prefix) < 0 {
+		// the prefix itself is the first key with the prefix
+		value, err := childTrie.Get(prefix)
+		if err != nil {
+			return fmt.Errorf("getting value at prefix: %w", err)
+		} else if value != nil {
+			*res = append(*res, common.BytesToHex(prefix))
+		}
+		key = prefix
+	}
+
+	for uint32(len(*res)) < req.Qty {
+		key, err = childTrie.NextKey(key)
+		if err != nil {
+			return fmt.Errorf("getting next key: %w", err)
+		} else if key == nil || !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		*res = append(*res, common.BytesToHex(key))
+	}
+
+	return nil
+}
+
+// GetStorageEntries returns the values of the given keys from the specified
+// child storage, where the value of a key not found is nil.
+func (cs *ChildStateModule) GetStorageEntries(
+	_ *http.Request, req *ChildStateStorageEntriesRequest, res *ChildStateStorageEntriesResponse) error {
+	keys, err := hexKeysToBytes(req.Keys)
+	if err != nil {
+		return fmt.Errorf("decoding keys: %w", err)
+	}
+
+	childTrie, err := cs.getChildTrie(req.Hash, req.ChildStorageKey)
+	if err != nil {
+		return err
+	}
+
+	*res = make(ChildStateStorageEntriesResponse, len(keys))
+	if childTrie == nil {
+		return nil
+	}
+
+	for i, key := range keys {
+		value, err := childTrie.Get(key)
+		if err != nil {
+			return fmt.Errorf("getting value at key 0x%x: %w", key, err)
+		} else if value == nil {
+			continue
+		}
+		hexValue := common.BytesToHex(value)
+		(*res)[i] = &hexValue
+	}
+
+	return nil
+}
+
+// getChildTrie returns the child trie at the hex encoded child storage key given,
+// which can be prefixed with the default child storage key prefix, in the state of
+// the block with the given hash, or of the best block if the hash is nil.
+// It returns a nil trie if the child trie does not exist.
+func (cs *ChildStateModule) getChildTrie(blockHash *common.Hash, childStorageKey string) (
+	childTrie *trie.Trie, err error) {
+	keyToChild, err := common.HexToBytes(childStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("decoding child storage key: %w", err)
+	}
+	keyToChild = bytes.TrimPrefix(keyToChild, trie.ChildStorageKeyPrefix)
+
+	var hash common.Hash
+	if blockHash == nil {
+		hash = cs.blockAPI.BestBlockHash()
+	} else {
+		hash = *blockHash
+	}
+
+	stateRoot, err := cs.storageAPI.GetStateRootFromBlock(&hash)
+	if err != nil {
+		return nil, err
+	}
+
+	childTrie, err = cs.storageAPI.GetStorageChild(stateRoot, keyToChild)
+	if errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return childTrie, nil
+}
+
+func emptyHexToZero(hexString string) string {
+	if hexString == "" {
+		return "0x"
+	}
+	return hexString
+}
@@ -46,7 +46,8 @@ func TestChildStateModule_GetKeys(t *testing.T) {
 
 	tr, sr := createTestTrieState(t)
 
-	expKeys := tr.GetKeysWithPrefix([]byte{})
+	expKeys, err := tr.GetKeysWithPrefix([]byte{})
+	require.NoError(t, err)
 	expHexKeys := make([]string, len(expKeys))
 	for idx, k := range expKeys {
 		expHexKeys[idx] = common.BytesToHex(k)
@@ -496,3 +497,250 @@ type args struct {
 		})
 	}
 }
+
+func TestChildStateModule_GetKeysPaged(t *testing.T) {
+	t.Parallel()
+
+	tr, stateRoot := createTestTrieState(t)
+	childTrie, err := tr.GetChild([]byte(":child_storage_key"))
+	require.NoError(t, err)
+
+	blockHash := common.Hash{1}
+	childStorageKey := common.BytesToHex([]byte(":child_storage_key"))
+	prefixedChildStorageKey := common.BytesToHex([]byte(":child_storage:default::child_storage_key"))
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		blockAPIBuilder   func(ctrl *gomock.Controller) BlockAPI
+		request           *ChildStateKeysPagedRequest
+		response          StateStorageKeysResponse
+		errWrapped        error
+		errMessage        string
+	}{
+		"invalid_prefix": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI { return nil },
+			blockAPIBuilder:   func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request:           &ChildStateKeysPagedRequest{Prefix: "0xzz"},
+			errMessage: "decoding prefix: encoding/hex: " +
+				"invalid byte: U+007A 'z': 0xzz",
+		},
+		"state_root_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(nil, errTest)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(blockHash)
+				return blockAPI
+			},
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: childStorageKey,
+				Qty:             10,
+			},
+			response:   StateStorageKeysResponse{},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+		"child_trie_not_found": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":unknown")).
+					Return(nil, trie.ErrChildTrieDoesNotExist)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: common.BytesToHex([]byte(":unknown")),
+				Qty:             10,
+				Hash:            &blockHash,
+			},
+			response: StateStorageKeysResponse{},
+		},
+		"all_keys": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(childTrie, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: prefixedChildStorageKey,
+				Qty:             10,
+				Hash:            &blockHash,
+			},
+			response: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":another_child")),
+				common.BytesToHex([]byte(":child_first")),
+				common.BytesToHex([]byte(":child_second")),
+			},
+		},
+		"prefix_and_quantity": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(childTrie, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: childStorageKey,
+				Prefix:          common.BytesToHex([]byte(":child")),
+				Qty:             1,
+				Hash:            &blockHash,
+			},
+			response: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":child_first")),
+			},
+		},
+		"prefix_key_included": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(childTrie, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: childStorageKey,
+				Prefix:          common.BytesToHex([]byte(":child_first")),
+				Qty:             10,
+				Hash:            &blockHash,
+			},
+			response: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":child_first")),
+			},
+		},
+		"after_key": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(childTrie, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI { return nil },
+			request: &ChildStateKeysPagedRequest{
+				ChildStorageKey: childStorageKey,
+				Prefix:          common.BytesToHex([]byte(":child")),
+				Qty:             10,
+				AfterKey:        common.BytesToHex([]byte(":child_first")),
+				Hash:            &blockHash,
+			},
+			response: StateStorageKeysResponse{
+				common.BytesToHex([]byte(":child_second")),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			childStateModule := NewChildStateModule(
+				testCase.storageAPIBuilder(ctrl), testCase.blockAPIBuilder(ctrl))
+
+			var response StateStorageKeysResponse
+			err := childStateModule.GetKeysPaged(nil, testCase.request, &response)
+
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
+
+func TestChildStateModule_GetStorageEntries(t *testing.T) {
+	t.Parallel()
+
+	tr, stateRoot := createTestTrieState(t)
+	childTrie, err := tr.GetChild([]byte(":child_storage_key"))
+	require.NoError(t, err)
+
+	blockHash := common.Hash{1}
+	childStorageKey := common.BytesToHex([]byte(":child_storage_key"))
+	keys := []string{
+		common.BytesToHex([]byte(":child_first")),
+		common.BytesToHex([]byte(":unknown")),
+	}
+	firstValue := common.BytesToHex([]byte(":child_first_value"))
+
+	testCases := map[string]struct {
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		request           *ChildStateStorageEntriesRequest
+		response          ChildStateStorageEntriesResponse
+		errMessage        string
+	}{
+		"invalid_key": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI { return nil },
+			request:           &ChildStateStorageEntriesRequest{Keys: []string{"0xzz"}},
+			errMessage: "decoding keys: encoding/hex: " +
+				"invalid byte: U+007A 'z': 0xzz",
+		},
+		"child_trie_not_found": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(nil, trie.ErrChildTrieDoesNotExist)
+				return storageAPI
+			},
+			request: &ChildStateStorageEntriesRequest{
+				ChildStorageKey: childStorageKey,
+				Keys:            keys,
+				Hash:            &blockHash,
+			},
+			response: ChildStateStorageEntriesResponse{nil, nil},
+		},
+		"entries": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().GetStorageChild(&stateRoot, []byte(":child_storage_key")).
+					Return(childTrie, nil)
+				return storageAPI
+			},
+			request: &ChildStateStorageEntriesRequest{
+				ChildStorageKey: childStorageKey,
+				Keys:            keys,
+				Hash:            &blockHash,
+			},
+			response: ChildStateStorageEntriesResponse{&firstValue, nil},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			childStateModule := NewChildStateModule(testCase.storageAPIBuilder(ctrl), nil)
+
+			var response ChildStateStorageEntriesResponse
+			err := childStateModule.GetStorageEntries(nil, testCase.request, &response)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
@@ -20,13 +20,18 @@
 type DevModule struct {
 	networkAPI       NetworkAPI
 	blockProducerAPI BlockProducerAPI
+	syncAPI          SyncAPI
 }
 
+// DevSyncPeerStatsResponse is the response of the dev_syncPeerStats rpc call
+type DevSyncPeerStatsResponse []common.PeerSyncStats
+
 // NewDevModule creates a new Dev module.
-func NewDevModule(bp BlockProducerAPI, net NetworkAPI) *DevModule {
+func NewDevModule(bp BlockProducerAPI, net NetworkAPI, syncAPI SyncAPI) *DevModule {
 	return &DevModule{
 		networkAPI:       net,
 		blockProducerAPI: bp,
+		syncAPI:          syncAPI,
 	}
 }
 
@@ -76,6 +81,17 @@ func (m *DevModule) EpochLength(r *http.Request, req *EmptyRequest, res *string)
 	return err
 }
 
+// SyncPeerStats Dev RPC to return the statistics of the block requests sent to each peer,
+// sorted by decreasing score of the peers when picking a peer to sync from
+func (m *DevModule) SyncPeerStats(r *http.Request, req *EmptyRequest, res *DevSyncPeerStatsResponse) error {
+	if m.syncAPI == nil {
+		return errors.New("sync service is not available")
+	}
+
+	*res = m.syncAPI.PeerSyncStats()
+	return nil
+}
+
 // uint64ToHex converts a uint64 to a hexed string
 func uint64ToHex(input uint64) string {
 	buffer := make([]byte, 8)
@@ -91,7 +91,7 @@ func newBABEService(t *testing.T) *babe.Service {
 func TestDevControl_Babe(t *testing.T) {
 	t.Skip() // skip for now, blocks on `babe.Service.Resume()`
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"babe", "stop"}, &res)
@@ -107,7 +107,7 @@ func TestDevControl_Babe(t *testing.T) {
 
 func TestDevControl_Network(t *testing.T) {
 	net := newNetworkService(t)
-	m := NewDevModule(nil, net)
+	m := NewDevModule(nil, net, nil)
 
 	var res string
 	err := m.Control(nil, &[]string{"network", "stop"}, &res)
@@ -123,7 +123,7 @@ func TestDevControl_Network(t *testing.T) {
 
 func TestDevControl_SlotDuration(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	slotDurationSource := m.blockProducerAPI.SlotDuration()
 
@@ -137,7 +137,7 @@ func TestDevControl_SlotDuration(t *testing.T) {
 
 func TestDevControl_EpochLength(t *testing.T) {
 	bs := newBABEService(t)
-	m := NewDevModule(bs, nil)
+	m := NewDevModule(bs, nil, nil)
 
 	epochLengthSource := m.blockProducerAPI.EpochLength()
 
@@ -7,8 +7,10 @@
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
@@ -58,7 +60,7 @@ func TestDevModule_EpochLength(t *testing.T) {
 
 	mockBlockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
 	mockBlockProducerAPI.EXPECT().EpochLength().Return(uint64(23))
-	devModule := NewDevModule(mockBlockProducerAPI, nil)
+	devModule := NewDevModule(mockBlockProducerAPI, nil, nil)
 
 	type fields struct {
 		networkAPI       NetworkAPI
@@ -312,3 +314,50 @@ type args struct {
 		})
 	}
 }
+
+func TestDevModule_SyncPeerStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	stats := []common.PeerSyncStats{{
+		PeerID:              "peer",
+		Requests:            2,
+		Blocks:              128,
+		AverageResponseTime: time.Second,
+		BlocksPerSecond:     64,
+		Score:               0.5,
+	}}
+	mockSyncAPI := NewMockSyncAPI(ctrl)
+	mockSyncAPI.EXPECT().PeerSyncStats().Return(stats)
+
+	tests := []struct {
+		name    string
+		syncAPI SyncAPI
+		expErr  error
+		exp     DevSyncPeerStatsResponse
+	}{
+		{
+			name:   "SyncPeerStats_no_sync_service",
+			expErr: errors.New("sync service is not available"),
+		},
+		{
+			name:    "SyncPeerStats_OK",
+			syncAPI: mockSyncAPI,
+			exp:     stats,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &DevModule{
+				syncAPI: tt.syncAPI,
+			}
+			var res DevSyncPeerStatsResponse
+			err := m.SyncPeerStats(nil, &EmptyRequest{}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
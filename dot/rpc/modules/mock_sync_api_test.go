@@ -7,6 +7,7 @@
 import (
 	reflect "reflect"
 
+	common "github.com/ChainSafe/gossamer/lib/common"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -46,3 +47,17 @@ func (mr *MockSyncAPIMockRecorder) HighestBlock() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HighestBlock", reflect.TypeOf((*MockSyncAPI)(nil).HighestBlock))
 }
+
+// PeerSyncStats mocks base method.
+func (m *MockSyncAPI) PeerSyncStats() []common.PeerSyncStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerSyncStats")
+	ret0, _ := ret[0].([]common.PeerSyncStats)
+	return ret0
+}
+
+// PeerSyncStats indicates an expected call of PeerSyncStats.
+func (mr *MockSyncAPIMockRecorder) PeerSyncStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerSyncStats", reflect.TypeOf((*MockSyncAPI)(nil).PeerSyncStats))
+}
@@ -6,6 +6,7 @@
 
 import (
 	reflect "reflect"
+	time "time"
 
 	core "github.com/ChainSafe/gossamer/dot/core"
 	state "github.com/ChainSafe/gossamer/dot/state"
@@ -14,6 +15,7 @@
 	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	genesis "github.com/ChainSafe/gossamer/lib/genesis"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
+	tracing "github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
 	trie "github.com/ChainSafe/gossamer/lib/trie"
 	gomock "github.com/golang/mock/gomock"
@@ -365,6 +367,21 @@ func (mr *MockBlockAPIMockRecorder) GetRuntime(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntime), arg0)
 }
 
+// GetStorageChanges mocks base method.
+func (m *MockBlockAPI) GetStorageChanges(arg0 common.Hash) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageChanges", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageChanges indicates an expected call of GetStorageChanges.
+func (mr *MockBlockAPIMockRecorder) GetStorageChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageChanges", reflect.TypeOf((*MockBlockAPI)(nil).GetStorageChanges), arg0)
+}
+
 // HasJustification mocks base method.
 func (m *MockBlockAPI) HasJustification(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -380,6 +397,21 @@ func (mr *MockBlockAPIMockRecorder) HasJustification(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasJustification", reflect.TypeOf((*MockBlockAPI)(nil).HasJustification), arg0)
 }
 
+// HasStorageChanges mocks base method.
+func (m *MockBlockAPI) HasStorageChanges(arg0 common.Hash) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasStorageChanges", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasStorageChanges indicates an expected call of HasStorageChanges.
+func (mr *MockBlockAPIMockRecorder) HasStorageChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasStorageChanges", reflect.TypeOf((*MockBlockAPI)(nil).HasStorageChanges), arg0)
+}
+
 // RangeInMemory mocks base method.
 func (m *MockBlockAPI) RangeInMemory(arg0, arg1 common.Hash) ([]common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -465,6 +497,34 @@ func (mr *MockNetworkAPIMockRecorder) AddReservedPeers(arg0 ...interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReservedPeers", reflect.TypeOf((*MockNetworkAPI)(nil).AddReservedPeers), arg0...)
 }
 
+// BanPeer mocks base method.
+func (m *MockNetworkAPI) BanPeer(arg0 string, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanPeer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanPeer indicates an expected call of BanPeer.
+func (mr *MockNetworkAPIMockRecorder) BanPeer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockNetworkAPI)(nil).BanPeer), arg0, arg1)
+}
+
+// Bandwidth mocks base method.
+func (m *MockNetworkAPI) Bandwidth() common.Bandwidth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bandwidth")
+	ret0, _ := ret[0].(common.Bandwidth)
+	return ret0
+}
+
+// Bandwidth indicates an expected call of Bandwidth.
+func (mr *MockNetworkAPIMockRecorder) Bandwidth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bandwidth", reflect.TypeOf((*MockNetworkAPI)(nil).Bandwidth))
+}
+
 // Health mocks base method.
 func (m *MockNetworkAPI) Health() common.Health {
 	m.ctrl.T.Helper()
@@ -581,6 +641,20 @@ func (mr *MockNetworkAPIMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockNetworkAPI)(nil).Stop))
 }
 
+// UnbanPeer mocks base method.
+func (m *MockNetworkAPI) UnbanPeer(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanPeer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer.
+func (mr *MockNetworkAPIMockRecorder) UnbanPeer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockNetworkAPI)(nil).UnbanPeer), arg0)
+}
+
 // MockBlockProducerAPI is a mock of BlockProducerAPI interface.
 type MockBlockProducerAPI struct {
 	ctrl     *gomock.Controller
@@ -735,6 +809,22 @@ func (mr *MockCoreAPIMockRecorder) DecodeSessionKeys(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodeSessionKeys", reflect.TypeOf((*MockCoreAPI)(nil).DecodeSessionKeys), arg0)
 }
 
+// GetChildReadProofAt mocks base method.
+func (m *MockCoreAPI) GetChildReadProofAt(arg0 common.Hash, arg1 []byte, arg2 [][]byte) (common.Hash, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildReadProofAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChildReadProofAt indicates an expected call of GetChildReadProofAt.
+func (mr *MockCoreAPIMockRecorder) GetChildReadProofAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildReadProofAt", reflect.TypeOf((*MockCoreAPI)(nil).GetChildReadProofAt), arg0, arg1, arg2)
+}
+
 // GetMetadata mocks base method.
 func (m *MockCoreAPI) GetMetadata(arg0 *common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -824,6 +914,20 @@ func (mr *MockCoreAPIMockRecorder) InsertKey(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertKey", reflect.TypeOf((*MockCoreAPI)(nil).InsertKey), arg0, arg1)
 }
 
+// TraceBlock mocks base method.
+func (m *MockCoreAPI) TraceBlock(arg0 common.Hash, arg1 *tracing.Tracer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceBlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TraceBlock indicates an expected call of TraceBlock.
+func (mr *MockCoreAPIMockRecorder) TraceBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceBlock", reflect.TypeOf((*MockCoreAPI)(nil).TraceBlock), arg0, arg1)
+}
+
 // MockSystemAPI is a mock of SystemAPI interface.
 type MockSystemAPI struct {
 	ctrl     *gomock.Controller
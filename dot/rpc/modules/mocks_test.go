@@ -362,6 +362,21 @@ func (mr *MockBlockAPIMockRecorder) GetRuntime(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntime), arg0)
 }
 
+// GetStorageChanges mocks base method.
+func (m *MockBlockAPI) GetStorageChanges(arg0 common.Hash) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageChanges", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageChanges indicates an expected call of GetStorageChanges.
+func (mr *MockBlockAPIMockRecorder) GetStorageChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageChanges", reflect.TypeOf((*MockBlockAPI)(nil).GetStorageChanges), arg0)
+}
+
 // HasJustification mocks base method.
 func (m *MockBlockAPI) HasJustification(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -377,6 +392,21 @@ func (mr *MockBlockAPIMockRecorder) HasJustification(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasJustification", reflect.TypeOf((*MockBlockAPI)(nil).HasJustification), arg0)
 }
 
+// HasStorageChanges mocks base method.
+func (m *MockBlockAPI) HasStorageChanges(arg0 common.Hash) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasStorageChanges", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasStorageChanges indicates an expected call of HasStorageChanges.
+func (mr *MockBlockAPIMockRecorder) HasStorageChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasStorageChanges", reflect.TypeOf((*MockBlockAPI)(nil).HasStorageChanges), arg0)
+}
+
 // RangeInMemory mocks base method.
 func (m *MockBlockAPI) RangeInMemory(arg0, arg1 common.Hash) ([]common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"net/http"
@@ -11,13 +12,25 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/trie/proof"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 // StateGetReadProofRequest json fields
 type StateGetReadProofRequest struct {
-	Keys []string
-	Hash common.Hash
+	Keys    []string
+	Hash    common.Hash
+	Compact bool
+}
+
+// StateGetChildReadProofRequest json fields
+type StateGetChildReadProofRequest struct {
+	ChildStorageKey string
+	Keys            []string
+	Hash            common.Hash
+	Compact         bool
 }
 
 // StateCallRequest holds json fields
@@ -82,6 +95,14 @@ type StateStorageQueryAtRequest struct {
 	At   common.Hash `json:"at"`
 }
 
+// StateTraceBlockRequest holds json fields
+type StateTraceBlockRequest struct {
+	Block       common.Hash `json:"block" validate:"required"`
+	Targets     *string     `json:"targets"`
+	StorageKeys *string     `json:"storageKeys"`
+	Methods     *string     `json:"methods"`
+}
+
 // StateStorageKeysQuery field to store storage keys
 type StateStorageKeysQuery [][]byte
 
@@ -133,6 +154,50 @@ type StorageChangeSetResponse struct {
 	Changes [][2]*string `json:"changes"`
 }
 
+// StateTraceBlockResponse holds either the trace of the block or the error
+// preventing to trace it, matching the Substrate tracing output.
+type StateTraceBlockResponse struct {
+	BlockTrace *BlockTrace `json:"blockTrace,omitempty"`
+	TraceError *TraceError `json:"traceError,omitempty"`
+}
+
+// BlockTrace is the trace of a block execution
+type BlockTrace struct {
+	BlockHash      string       `json:"blockHash"`
+	ParentHash     string       `json:"parentHash"`
+	TracingTargets string       `json:"tracingTargets"`
+	StorageKeys    string       `json:"storageKeys"`
+	Methods        string       `json:"methods"`
+	Spans          []TraceSpan  `json:"spans"`
+	Events         []TraceEvent `json:"events"`
+}
+
+// TraceSpan is a span recorded during a block execution
+type TraceSpan struct {
+	ID       uint64  `json:"id"`
+	ParentID *uint64 `json:"parentId"`
+	Name     string  `json:"name"`
+	Target   string  `json:"target"`
+	Wasm     bool    `json:"wasm"`
+}
+
+// TraceEvent is an event recorded during a block execution
+type TraceEvent struct {
+	Target   string         `json:"target"`
+	Data     TraceEventData `json:"data"`
+	ParentID *uint64        `json:"parentId"`
+}
+
+// TraceEventData holds the values of a trace event
+type TraceEventData struct {
+	StringValues map[string]string `json:"stringValues"`
+}
+
+// TraceError holds the error preventing to trace a block
+type TraceError struct {
+	Error string `json:"error"`
+}
+
 // KeyValueOption struct holds json fields
 type KeyValueOption []byte
 
@@ -294,17 +359,13 @@ func (sm *StateModule) GetMetadata(_ *http.Request, req *StateRuntimeMetadataQue
 	return err
 }
 
-// GetReadProof returns the proof to the received storage keys
+// GetReadProof returns the proof to the received storage keys.
+// If compact is set, the proof is returned in its compact form.
 func (sm *StateModule) GetReadProof(
 	_ *http.Request, req *StateGetReadProofRequest, res *StateGetReadProofResponse) error {
-	keys := make([][]byte, len(req.Keys))
-	for i, hexKey := range req.Keys {
-		bKey, err := common.HexToBytes(hexKey)
-		if err != nil {
-			return err
-		}
-
-		keys[i] = bKey
+	keys, err := hexKeysToBytes(req.Keys)
+	if err != nil {
+		return err
 	}
 
 	block, proofs, err := sm.coreAPI.GetReadProofAt(req.Hash, keys)
@@ -312,6 +373,59 @@ func (sm *StateModule) GetReadProof(
 		return err
 	}
 
+	return setReadProofResponse(block, proofs, req.Compact, res)
+}
+
+// GetChildReadProof returns the proof to the received storage keys of the
+// child trie at the child storage key given.
+// If compact is set, the proof is returned in its compact form.
+func (sm *StateModule) GetChildReadProof(
+	_ *http.Request, req *StateGetChildReadProofRequest, res *StateGetReadProofResponse) error {
+	keyToChild, err := common.HexToBytes(req.ChildStorageKey)
+	if err != nil {
+		return fmt.Errorf("decoding child storage key: %w", err)
+	}
+	keyToChild = bytes.TrimPrefix(keyToChild, trie.ChildStorageKeyPrefix)
+
+	keys, err := hexKeysToBytes(req.Keys)
+	if err != nil {
+		return err
+	}
+
+	block, proofs, err := sm.coreAPI.GetChildReadProofAt(req.Hash, keyToChild, keys)
+	if err != nil {
+		return err
+	}
+
+	return setReadProofResponse(block, proofs, req.Compact, res)
+}
+
+func hexKeysToBytes(hexKeys []string) (keys [][]byte, err error) {
+	keys = make([][]byte, len(hexKeys))
+	for i, hexKey := range hexKeys {
+		keys[i], err = common.HexToBytes(hexKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func setReadProofResponse(block common.Hash, proofs [][]byte, compact bool,
+	res *StateGetReadProofResponse) (err error) {
+	if compact && len(proofs) > 0 {
+		// The root node encoding is the first proof node.
+		rootHash, err := common.Blake2bHash(proofs[0])
+		if err != nil {
+			return fmt.Errorf("hashing root node: %w", err)
+		}
+
+		proofs, err = proof.EncodeCompact(proofs, rootHash[:])
+		if err != nil {
+			return fmt.Errorf("encoding compact proof: %w", err)
+		}
+	}
+
 	var decProof []string
 	for _, p := range proofs {
 		decProof = append(decProof, common.BytesToHex(p))
@@ -455,6 +569,11 @@ func (sm *StateModule) QueryStorage(
 	}
 	endBlockNumber := endBlock.Header.Number
 
+	keys := make([][]byte, len(req.Keys))
+	for i, key := range req.Keys {
+		keys[i] = common.MustHexToBytes(key)
+	}
+
 	response := make([]StorageChangeSetResponse, 0, endBlockNumber-startBlockNumber)
 	lastValue := make([]*string, len(req.Keys))
 
@@ -465,8 +584,23 @@ func (sm *StateModule) QueryStorage(
 		}
 		changes := make([][2]*string, 0, len(req.Keys))
 
+		var changedKeys map[string]struct{}
+		if i != startBlockNumber {
+			changedKeys, err = sm.getStorageChanges(blockHash)
+			if err != nil {
+				return fmt.Errorf("getting storage changes: %w", err)
+			}
+		}
+
 		for j, key := range req.Keys {
-			value, err := sm.storageAPI.GetStorageByBlockHash(&blockHash, common.MustHexToBytes(key))
+			if changedKeys != nil {
+				if _, changed := changedKeys[string(keys[j])]; !changed {
+					// the value is the same as in the parent block
+					continue
+				}
+			}
+
+			value, err := sm.storageAPI.GetStorageByBlockHash(&blockHash, keys[j])
 			if err != nil {
 				return fmt.Errorf("getting value by block hash: %w", err)
 			}
@@ -498,6 +632,28 @@ func (sm *StateModule) QueryStorage(
 	return nil
 }
 
+// getStorageChanges returns the set of keys of the state changed by the block
+// with the given hash, or a nil set if the changes are not recorded.
+func (sm *StateModule) getStorageChanges(blockHash common.Hash) (changedKeys map[string]struct{}, err error) {
+	has, err := sm.blockAPI.HasStorageChanges(blockHash)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+
+	keys, err := sm.blockAPI.GetStorageChanges(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	changedKeys = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		changedKeys[string(key)] = struct{}{}
+	}
+	return changedKeys, nil
+}
+
 // QueryStorageAt queries historical storage entries (by key) at the block hash given or
 // the best block if the given block hash is nil
 func (sm *StateModule) QueryStorageAt(
@@ -534,6 +690,85 @@ func (sm *StateModule) QueryStorageAt(
 
 func stringPtr(s string) *string { return &s }
 
+// TraceBlock re-executes the given block and returns the spans and events recorded
+// for the requested targets, storage key prefixes and storage methods, given as
+// comma separated lists. Targets default to `pallet,frame,state,runtime`.
+func (sm *StateModule) TraceBlock(
+	_ *http.Request, req *StateTraceBlockRequest, res *StateTraceBlockResponse) error {
+	targets := splitCommaSeparated(req.Targets)
+	storageKeys := splitCommaSeparated(req.StorageKeys)
+	methods := splitCommaSeparated(req.Methods)
+
+	tracer := tracing.NewTracer(targets, storageKeys, methods)
+
+	header, err := sm.blockAPI.GetHeader(req.Block)
+	if err != nil {
+		*res = StateTraceBlockResponse{
+			TraceError: &TraceError{Error: fmt.Sprintf("getting header: %s", err)},
+		}
+		return nil
+	}
+
+	err = sm.coreAPI.TraceBlock(req.Block, tracer)
+	if err != nil {
+		*res = StateTraceBlockResponse{
+			TraceError: &TraceError{Error: err.Error()},
+		}
+		return nil
+	}
+
+	spans := tracer.Spans()
+	traceSpans := make([]TraceSpan, len(spans))
+	for i, span := range spans {
+		traceSpans[i] = TraceSpan{
+			ID:       span.ID,
+			ParentID: span.ParentID,
+			Name:     span.Name,
+			Target:   span.Target,
+			Wasm:     span.Wasm,
+		}
+	}
+
+	events := tracer.Events()
+	traceEvents := make([]TraceEvent, len(events))
+	for i, event := range events {
+		traceEvents[i] = TraceEvent{
+			Target:   event.Target,
+			Data:     TraceEventData{StringValues: event.Values},
+			ParentID: event.ParentID,
+		}
+	}
+
+	*res = StateTraceBlockResponse{
+		BlockTrace: &BlockTrace{
+			BlockHash:      req.Block.String(),
+			ParentHash:     header.ParentHash.String(),
+			TracingTargets: strings.Join(tracer.Targets(), ","),
+			StorageKeys:    strings.Join(storageKeys, ","),
+			Methods:        strings.Join(methods, ","),
+			Spans:          traceSpans,
+			Events:         traceEvents,
+		},
+	}
+	return nil
+}
+
+// splitCommaSeparated returns the non empty trimmed values of
+// the given comma separated list, or nil if the list is nil.
+func splitCommaSeparated(list *string) (values []string) {
+	if list == nil {
+		return nil
+	}
+
+	for _, value := range strings.Split(*list, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // SubscribeRuntimeVersion initialised a runtime version subscription and returns the current version
 // See dot/rpc/subscription
 func (sm *StateModule) SubscribeRuntimeVersion(
@@ -368,6 +368,7 @@ func TestStateModule_QueryStorage(t *testing.T) {
 		}, nil)
 		mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{1, 2}, nil)
 		mockBlockAPI.EXPECT().GetHashByNumber(uint(4)).Return(common.Hash{3, 4}, nil)
+		mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3, 4}).Return(false, nil)
 
 		mockStorageAPI := NewMockStorageAPI(ctrl)
 		mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{1, 2}, []byte{144}).Return([]byte(`value`), nil)
@@ -26,9 +26,13 @@
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/trie/proof"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStateModuleGetPairs(t *testing.T) {
@@ -454,6 +458,112 @@ type args struct {
 	}
 }
 
+func TestStateModuleGetChildReadProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	childTrie := trie.NewEmptyTrie()
+	childTrie.Put([]byte("key1"), []byte("a value long enough to not be inlined in its parent"))
+	childTrie.Put([]byte("key2"), []byte("another value long enough to not be inlined"))
+	rootHash := childTrie.MustHash()
+
+	db := runtime.NewInMemoryDB(t)
+	err := childTrie.WriteDirty(db)
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("key1"), []byte("key2")}
+	proofNodes, err := proof.Generate(rootHash[:], keys, db)
+	require.NoError(t, err)
+
+	compactProofNodes, err := proof.EncodeCompact(proofNodes, rootHash[:])
+	require.NoError(t, err)
+
+	hash := common.Hash{1}
+	keyToChild := []byte("child")
+	childStorageKey := common.BytesToHex(append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...))
+
+	toHex := func(proofNodes [][]byte) (hexProofNodes []string) {
+		for _, proofNode := range proofNodes {
+			hexProofNodes = append(hexProofNodes, common.BytesToHex(proofNode))
+		}
+		return hexProofNodes
+	}
+
+	tests := map[string]struct {
+		coreAPIBuilder func(ctrl *gomock.Controller) CoreAPI
+		req            *StateGetChildReadProofRequest
+		exp            StateGetReadProofResponse
+		errMessage     string
+	}{
+		"invalid_child_storage_key": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI { return nil },
+			req:            &StateGetChildReadProofRequest{ChildStorageKey: "0xzz"},
+			errMessage: "decoding child storage key: " +
+				"encoding/hex: invalid byte: U+007A 'z': 0xzz",
+		},
+		"core_api_error": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetChildReadProofAt(hash, keyToChild, keys).
+					Return(common.Hash{}, nil, errors.New("test error"))
+				return coreAPI
+			},
+			req: &StateGetChildReadProofRequest{
+				ChildStorageKey: childStorageKey,
+				Keys:            []string{"0x6b657931", "0x6b657932"},
+				Hash:            hash,
+			},
+			errMessage: "test error",
+		},
+		"unprefixed_child_storage_key": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetChildReadProofAt(hash, keyToChild, keys).
+					Return(hash, proofNodes, nil)
+				return coreAPI
+			},
+			req: &StateGetChildReadProofRequest{
+				ChildStorageKey: common.BytesToHex(keyToChild),
+				Keys:            []string{"0x6b657931", "0x6b657932"},
+				Hash:            hash,
+			},
+			exp: StateGetReadProofResponse{At: hash, Proof: toHex(proofNodes)},
+		},
+		"compact_proof": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				coreAPI := mocks.NewMockCoreAPI(ctrl)
+				coreAPI.EXPECT().GetChildReadProofAt(hash, keyToChild, keys).
+					Return(hash, proofNodes, nil)
+				return coreAPI
+			},
+			req: &StateGetChildReadProofRequest{
+				ChildStorageKey: childStorageKey,
+				Keys:            []string{"0x6b657931", "0x6b657932"},
+				Hash:            hash,
+				Compact:         true,
+			},
+			exp: StateGetReadProofResponse{At: hash, Proof: toHex(compactProofNodes)},
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			sm := &StateModule{
+				coreAPI: testCase.coreAPIBuilder(ctrl),
+			}
+
+			var res StateGetReadProofResponse
+			err := sm.GetChildReadProof(nil, testCase.req, &res)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.exp, res)
+		})
+	}
+}
+
 func TestStateModuleGetRuntimeVersion(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -900,7 +1010,9 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3}).Return(false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{4}).Return(false, nil)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -954,8 +1066,11 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(0)).Return(common.Hash{1}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{2}).Return(false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3}).Return(false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{4}).Return(false, nil)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1009,6 +1124,7 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 2}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3}).Return(false, nil)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1031,6 +1147,96 @@ type args struct {
 				},
 			},
 		},
+		"start_block/no_end_block/storage_changes": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
+						Return([]byte{1, 1, 1}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{9, 9, 9}).
+						Return([]byte{9, 9, 9, 9}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{3}, []byte{9, 9, 9}).
+						Return([]byte{8, 8, 8, 8}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{4}, []byte{1, 2, 4}).
+						Return([]byte{3, 3, 3}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{4}, []byte{9, 9, 9}).
+						Return([]byte{8, 8, 8, 8}, nil)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().BestBlockHash().Return(common.Hash{4})
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{4}).
+						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3}).Return(true, nil)
+					mockBlockAPI.EXPECT().GetStorageChanges(common.Hash{3}).
+						Return([][]byte{{5}, {9, 9, 9}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{4}).Return(false, nil)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204", "0x090909"},
+					StartBlock: common.Hash{2},
+				},
+			},
+			exp: []StorageChangeSetResponse{
+				{
+					Block: &common.Hash{2},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x010101"),
+						makeChange("0x090909", "0x09090909"),
+					},
+				},
+				{
+					Block: &common.Hash{3},
+					Changes: [][2]*string{
+						makeChange("0x090909", "0x08080808"),
+					},
+				},
+				{
+					Block: &common.Hash{4},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x030303"),
+					},
+				},
+			},
+		},
+		"start_block/no_end_block/storage_changes_error": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
+						Return([]byte{1, 1, 1}, nil)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().BestBlockHash().Return(common.Hash{3})
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{3}).
+						Return(&types.Block{Header: types.Header{Number: 2}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().HasStorageChanges(common.Hash{3}).Return(true, nil)
+					mockBlockAPI.EXPECT().GetStorageChanges(common.Hash{3}).Return(nil, errTest)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204"},
+					StartBlock: common.Hash{2},
+				},
+			},
+			exp:       []StorageChangeSetResponse{},
+			errRegexp: "getting storage changes: test error",
+		},
 		"start_block/end_block/error_end_hash": {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
@@ -1265,3 +1471,98 @@ type fields struct {
 		})
 	}
 }
+
+func TestStateModuleTraceBlock(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.Hash{1}
+	header := &types.Header{ParentHash: common.Hash{2}}
+	targets := "runtime, state"
+	storageKeys := "0x26aa"
+
+	t.Run("get header error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockAPI := mocks.NewMockBlockAPI(ctrl)
+		blockAPI.EXPECT().GetHeader(blockHash).Return(nil, errors.New("not found"))
+		module := NewStateModule(nil, nil, nil, blockAPI)
+
+		var res StateTraceBlockResponse
+		err := module.TraceBlock(nil, &StateTraceBlockRequest{Block: blockHash}, &res)
+		assert.NoError(t, err)
+
+		expected := StateTraceBlockResponse{
+			TraceError: &TraceError{Error: "getting header: not found"},
+		}
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("trace block error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockAPI := mocks.NewMockBlockAPI(ctrl)
+		blockAPI.EXPECT().GetHeader(blockHash).Return(header, nil)
+		coreAPI := mocks.NewMockCoreAPI(ctrl)
+		coreAPI.EXPECT().TraceBlock(blockHash, gomock.Any()).Return(errors.New("execution failed"))
+		module := NewStateModule(nil, nil, coreAPI, blockAPI)
+
+		var res StateTraceBlockResponse
+		err := module.TraceBlock(nil, &StateTraceBlockRequest{Block: blockHash}, &res)
+		assert.NoError(t, err)
+
+		expected := StateTraceBlockResponse{
+			TraceError: &TraceError{Error: "execution failed"},
+		}
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockAPI := mocks.NewMockBlockAPI(ctrl)
+		blockAPI.EXPECT().GetHeader(blockHash).Return(header, nil)
+		coreAPI := mocks.NewMockCoreAPI(ctrl)
+		coreAPI.EXPECT().TraceBlock(blockHash, gomock.Any()).
+			DoAndReturn(func(_ common.Hash, tracer *tracing.Tracer) error {
+				tracer.EnterSpan("Core_execute_block", tracing.RuntimeTarget, false)
+				tracer.Event(tracing.StateTarget, map[string]string{"method": "Get", "key": "26aa01"})
+				tracer.Event(tracing.StateTarget, map[string]string{"method": "Get", "key": "3a63"})
+				tracer.ExitSpan()
+				return nil
+			})
+		module := NewStateModule(nil, nil, coreAPI, blockAPI)
+
+		request := &StateTraceBlockRequest{
+			Block:       blockHash,
+			Targets:     &targets,
+			StorageKeys: &storageKeys,
+		}
+		var res StateTraceBlockResponse
+		err := module.TraceBlock(nil, request, &res)
+		assert.NoError(t, err)
+
+		parentID := uint64(1)
+		expected := StateTraceBlockResponse{
+			BlockTrace: &BlockTrace{
+				BlockHash:      blockHash.String(),
+				ParentHash:     header.ParentHash.String(),
+				TracingTargets: "runtime,state",
+				StorageKeys:    "0x26aa",
+				Spans: []TraceSpan{
+					{ID: 1, Name: "Core_execute_block", Target: "runtime"},
+				},
+				Events: []TraceEvent{{
+					Target: "state",
+					Data: TraceEventData{
+						StringValues: map[string]string{"method": "Get", "key": "26aa01"},
+					},
+					ParentID: &parentID,
+				}},
+			},
+		}
+		assert.Equal(t, expected, res)
+	})
+}
@@ -6,10 +6,14 @@
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -18,6 +22,13 @@
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
+// defaultBanDuration is the duration of a peer ban when no duration is given.
+const defaultBanDuration = time.Hour
+
+// maxBanDurationSeconds is the maximum duration of a peer ban in seconds,
+// above which the ban duration overflows.
+const maxBanDurationSeconds = uint64(math.MaxInt64 / time.Second)
+
 // SystemModule is an RPC module providing access to core API points
 type SystemModule struct {
 	networkAPI NetworkAPI
@@ -42,6 +53,7 @@ type EmptyRequest struct{
 type NetworkStateString struct {
 	PeerID     string
 	Multiaddrs []string
+	Bandwidth  common.Bandwidth
 }
 
 // SystemNetworkStateResponse struct to marshal json
@@ -60,6 +72,13 @@ type StringRequest struct {
 	String string
 }
 
+// SystemBanPeerRequest holds the peer id to ban and the optional
+// duration of the ban in seconds, which defaults to one hour.
+type SystemBanPeerRequest struct {
+	PeerID   string
+	Duration *uint64
+}
+
 // SyncStateResponse is the struct to return on the system_syncState rpc call
 type SyncStateResponse struct {
 	CurrentBlock  uint32 `json:"currentBlock"`
@@ -119,13 +138,15 @@ func (sm *SystemModule) Health(r *http.Request, req *EmptyRequest, res *SystemHe
 	return nil
 }
 
-// NetworkState returns the network state (basic information about the host)
+// NetworkState returns the network state (basic information about the host
+// and the bandwidth it used in total, by protocol and by connected peer)
 func (sm *SystemModule) NetworkState(r *http.Request, req *EmptyRequest, res *SystemNetworkStateResponse) error {
 	networkState := sm.networkAPI.NetworkState()
 	res.NetworkState.PeerID = networkState.PeerID
 	for _, v := range networkState.Multiaddrs {
 		res.NetworkState.Multiaddrs = append(res.NetworkState.Multiaddrs, v.String())
 	}
+	res.NetworkState.Bandwidth = sm.networkAPI.Bandwidth()
 	return nil
 }
 
@@ -289,3 +310,49 @@ func (sm *SystemModule) RemoveReservedPeer(r *http.Request, req *StringRequest,
 
 	return sm.networkAPI.RemoveReservedPeers(req.String)
 }
+
+// BanPeer bans a peer, disconnecting from it and refusing connections with it
+// until the ban expires. The ban is kept across node restarts.
+func (sm *SystemModule) BanPeer(r *http.Request, req *SystemBanPeerRequest, res *[]byte) error {
+	if strings.TrimSpace(req.PeerID) == "" {
+		return errors.New("cannot ban an empty peer id")
+	}
+
+	duration := defaultBanDuration
+	if req.Duration != nil {
+		if *req.Duration == 0 {
+			return errors.New("ban duration must be greater than zero")
+		} else if *req.Duration > maxBanDurationSeconds {
+			return fmt.Errorf("ban duration %ds is larger than the maximum duration %ds",
+				*req.Duration, maxBanDurationSeconds)
+		}
+		duration = time.Duration(*req.Duration) * time.Second
+	}
+
+	return sm.networkAPI.BanPeer(req.PeerID, duration)
+}
+
+// UnbanPeer lifts the ban of a peer. The string should encode only the PeerId
+func (sm *SystemModule) UnbanPeer(r *http.Request, req *StringRequest, res *[]byte) error {
+	if strings.TrimSpace(req.String) == "" {
+		return errors.New("cannot unban an empty peer id")
+	}
+
+	return sm.networkAPI.UnbanPeer(req.String)
+}
+
+// AddLogFilter adds comma separated `target=level` log directives, such as
+// `runtime::system=trace,sync=debug`, to the node log filter.
+func (sm *SystemModule) AddLogFilter(r *http.Request, req *StringRequest, res *[]byte) error {
+	if strings.TrimSpace(req.String) == "" {
+		return errors.New("cannot add an empty log filter")
+	}
+
+	return log.AddFilter(req.String)
+}
+
+// ResetLogFilter resets the node log filter to the log target levels set at startup.
+func (sm *SystemModule) ResetLogFilter(r *http.Request, req *EmptyRequest, res *[]byte) error {
+	log.ResetFilter()
+	return nil
+}
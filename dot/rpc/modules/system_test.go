@@ -7,10 +7,12 @@
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/golang/mock/gomock"
@@ -124,8 +126,19 @@ func TestSystemModule_HealthTest(t *testing.T) {
 func TestSystemModule_NetworkStateTest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
+	bandwidth := common.Bandwidth{
+		Total: common.BandwidthStats{TotalIn: 3, TotalOut: 2},
+		ByProtocol: map[string]common.BandwidthStats{
+			"/sync/2": {TotalIn: 3, TotalOut: 2},
+		},
+		ByPeer: map[string]common.BandwidthStats{
+			"jimbo": {TotalIn: 3, TotalOut: 2, RateIn: 1.5, RateOut: 1},
+		},
+	}
+
 	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
 	mockNetworkAPI.EXPECT().NetworkState().Return(common.NetworkState{})
+	mockNetworkAPI.EXPECT().Bandwidth().Return(bandwidth)
 	sm := &SystemModule{
 		networkAPI: mockNetworkAPI,
 	}
@@ -134,7 +147,10 @@ func TestSystemModule_NetworkStateTest(t *testing.T) {
 	var networkStateRes SystemNetworkStateResponse
 	err := sm.NetworkState(nil, req, &networkStateRes)
 	require.NoError(t, err)
-	require.Equal(t, SystemNetworkStateResponse{}, networkStateRes)
+	expected := SystemNetworkStateResponse{
+		NetworkState: NetworkStateString{Bandwidth: bandwidth},
+	}
+	require.Equal(t, expected, networkStateRes)
 }
 
 func TestSystemModule_PeersTest(t *testing.T) {
@@ -644,3 +660,165 @@ type args struct {
 		})
 	}
 }
+
+func TestSystemModule_BanPeer(t *testing.T) {
+	t.Parallel()
+
+	duration := uint64(60)
+	zeroDuration := uint64(0)
+	maxDuration := maxBanDurationSeconds
+	tooLongDuration := maxBanDurationSeconds + 1
+
+	testCases := map[string]struct {
+		req               *SystemBanPeerRequest
+		networkAPIBuilder func(ctrl *gomock.Controller) NetworkAPI
+		errMessage        string
+	}{
+		"empty_peer_id": {
+			req:               &SystemBanPeerRequest{PeerID: " "},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI { return nil },
+			errMessage:        "cannot ban an empty peer id",
+		},
+		"zero_duration": {
+			req:               &SystemBanPeerRequest{PeerID: "jimbo", Duration: &zeroDuration},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI { return nil },
+			errMessage:        "ban duration must be greater than zero",
+		},
+		"too_long_duration": {
+			req:               &SystemBanPeerRequest{PeerID: "jimbo", Duration: &tooLongDuration},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI { return nil },
+			errMessage: "ban duration 9223372037s is larger than the maximum " +
+				"duration 9223372036s",
+		},
+		"max_duration": {
+			req: &SystemBanPeerRequest{PeerID: "jimbo", Duration: &maxDuration},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().BanPeer("jimbo", time.Duration(maxBanDurationSeconds)*time.Second).Return(nil)
+				return networkAPI
+			},
+		},
+		"default_duration": {
+			req: &SystemBanPeerRequest{PeerID: "jimbo"},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().BanPeer("jimbo", time.Hour).Return(nil)
+				return networkAPI
+			},
+		},
+		"duration_in_seconds": {
+			req: &SystemBanPeerRequest{PeerID: "jimbo", Duration: &duration},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().BanPeer("jimbo", time.Minute).Return(nil)
+				return networkAPI
+			},
+		},
+		"network_error": {
+			req: &SystemBanPeerRequest{PeerID: "jimbo"},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().BanPeer("jimbo", time.Hour).Return(errors.New("test error"))
+				return networkAPI
+			},
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			sm := NewSystemModule(testCase.networkAPIBuilder(ctrl), nil, nil, nil, nil, nil, nil)
+
+			var res []byte
+			err := sm.BanPeer(nil, testCase.req, &res)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSystemModule_UnbanPeer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		req               *StringRequest
+		networkAPIBuilder func(ctrl *gomock.Controller) NetworkAPI
+		errMessage        string
+	}{
+		"empty_peer_id": {
+			req:               &StringRequest{String: ""},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI { return nil },
+			errMessage:        "cannot unban an empty peer id",
+		},
+		"success": {
+			req: &StringRequest{String: "jimbo"},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().UnbanPeer("jimbo").Return(nil)
+				return networkAPI
+			},
+		},
+		"network_error": {
+			req: &StringRequest{String: "jimbo"},
+			networkAPIBuilder: func(ctrl *gomock.Controller) NetworkAPI {
+				networkAPI := mocks.NewMockNetworkAPI(ctrl)
+				networkAPI.EXPECT().UnbanPeer("jimbo").Return(errors.New("test error"))
+				return networkAPI
+			},
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			sm := NewSystemModule(testCase.networkAPIBuilder(ctrl), nil, nil, nil, nil, nil, nil)
+
+			var res []byte
+			err := sm.UnbanPeer(nil, testCase.req, &res)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSystemModule_AddLogFilter_ResetLogFilter(t *testing.T) {
+	sm := NewSystemModule(nil, nil, nil, nil, nil, nil, nil)
+	res := []byte(nil)
+
+	err := sm.AddLogFilter(nil, &StringRequest{""}, &res)
+	assert.EqualError(t, err, "cannot add an empty log filter")
+
+	err = sm.AddLogFilter(nil, &StringRequest{"sync=loud"}, &res)
+	assert.ErrorIs(t, err, log.ErrDirectiveMalformed)
+
+	err = sm.AddLogFilter(nil, &StringRequest{"runtime::system=trace,sync=warn"}, &res)
+	require.NoError(t, err)
+	t.Cleanup(log.ResetFilter)
+
+	level, ok := log.MaxFilterLevel()
+	assert.True(t, ok)
+	assert.Equal(t, log.Trace, level)
+
+	err = sm.ResetLogFilter(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+
+	_, ok = log.MaxFilterLevel()
+	assert.False(t, ok)
+	assert.Equal(t, []byte(nil), res)
+}
@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
 const (
@@ -49,17 +50,25 @@ type WSConnAPI interface {
 // Change type defining key value pair representing change
 type Change [2]string
 
+// ChildChange struct to hold the changes of a child storage
+type ChildChange struct {
+	ChildStorageKey string   `json:"childStorageKey"`
+	Changes         []Change `json:"changes"`
+}
+
 // ChangeResult struct to hold change result data
 type ChangeResult struct {
-	Changes []Change `json:"changes"`
-	Block   string   `json:"block"`
+	Changes      []Change      `json:"changes"`
+	ChildChanges []ChildChange `json:"childChanges,omitempty"`
+	Block        string        `json:"block"`
 }
 
 // StorageObserver struct to hold data for observer (Observer Design Pattern)
 type StorageObserver struct {
-	id     uint32
-	filter map[string][]byte
-	wsconn *WSConn
+	id          uint32
+	filter      map[string][]byte
+	childFilter map[string]map[string][]byte
+	wsconn      *WSConn
 }
 
 // Update is called to notify observer of new value
@@ -76,6 +85,20 @@ func (s *StorageObserver) Update(change *state.SubscriptionResult) {
 		changeResult.Changes[i] = Change{common.BytesToHex(v.Key), common.BytesToHex(v.Value)}
 	}
 
+	for _, childChanges := range change.ChildChanges {
+		childStorageKey := make([]byte, 0, len(trie.ChildStorageKeyPrefix)+len(childChanges.KeyToChild))
+		childStorageKey = append(childStorageKey, trie.ChildStorageKeyPrefix...)
+		childStorageKey = append(childStorageKey, childChanges.KeyToChild...)
+		childChange := ChildChange{
+			ChildStorageKey: common.BytesToHex(childStorageKey),
+			Changes:         make([]Change, len(childChanges.Changes)),
+		}
+		for i, v := range childChanges.Changes {
+			childChange.Changes[i] = Change{common.BytesToHex(v.Key), common.BytesToHex(v.Value)}
+		}
+		changeResult.ChildChanges = append(changeResult.ChildChanges, childChange)
+	}
+
 	res := newSubcriptionBaseResponseJSON()
 	res.Method = stateStorageMethod
 	res.Params.Result = changeResult
@@ -93,6 +116,11 @@ func (s *StorageObserver) GetFilter() map[string][]byte {
 	return s.filter
 }
 
+// GetChildFilter returns the child storage filters the Observer is using
+func (s *StorageObserver) GetChildFilter() map[string]map[string][]byte {
+	return s.childFilter
+}
+
 // Listen to satisfy Listener interface (but is no longer used by StorageObserver)
 func (*StorageObserver) Listen() {}
 
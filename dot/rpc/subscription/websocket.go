@@ -18,6 +18,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/gorilla/websocket"
 )
 
@@ -153,6 +154,77 @@ func (c *WSConn) executeRPCCall(data []byte) {
 	c.safeSend(wsresponse)
 }
 
+// isChildFilters returns true if the parameter given is a non empty list
+// of child storage filters, each being a list itself.
+func isChildFilters(param interface{}) bool {
+	list, ok := param.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	for _, element := range list {
+		if _, ok := element.([]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseChildFilters parses child storage filters of the form
+// ["0x<child storage key>", ["0x...", "0x..."] or null] into the given
+// filters, which are keyed by the hex encoded key to the child trie.
+func parseChildFilters(params []interface{}, childFilters map[string]map[string][]byte) error {
+	for _, param := range params {
+		childParams := param.([]interface{})
+		if len(childParams) != 1 && len(childParams) != 2 {
+			return fmt.Errorf("%w: %d, expected 1 or 2", errUnexpectedParamLen, len(childParams))
+		}
+
+		childStorageKey, ok := childParams[0].(string)
+		if !ok {
+			return fmt.Errorf("%w: %T, expected type string", errUnexpectedType, childParams[0])
+		}
+
+		keyToChild, err := common.HexToBytes(childStorageKey)
+		if err != nil {
+			return fmt.Errorf("decoding child storage key: %w", err)
+		}
+		keyToChild = bytes.TrimPrefix(keyToChild, trie.ChildStorageKeyPrefix)
+
+		hexKeyToChild := common.BytesToHex(keyToChild)
+		filter, ok := childFilters[hexKeyToChild]
+		if !ok {
+			filter = make(map[string][]byte)
+			childFilters[hexKeyToChild] = filter
+		}
+
+		if len(childParams) == 1 || childParams[1] == nil {
+			continue
+		}
+
+		keys, ok := childParams[1].([]interface{})
+		if !ok {
+			return fmt.Errorf("%w: %T, expected type []interface{}", errUnexpectedType, childParams[1])
+		}
+
+		for _, key := range keys {
+			key, ok := key.(string)
+			if !ok {
+				return fmt.Errorf("%w: %T, expected type string", errUnexpectedType, key)
+			}
+
+			_, err := common.HexToBytes(key)
+			if err != nil {
+				return fmt.Errorf("decoding child storage entry key: %w", err)
+			}
+
+			filter[key] = []byte{}
+		}
+	}
+
+	return nil
+}
+
 func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (Listener, error) {
 	if c.StorageAPI == nil {
 		c.safeSendError(reqID, nil, "error StorageAPI not set")
@@ -160,18 +232,30 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 	}
 
 	stgobs := &StorageObserver{
-		filter: make(map[string][]byte),
-		wsconn: c,
+		filter:      make(map[string][]byte),
+		childFilter: make(map[string]map[string][]byte),
+		wsconn:      c,
 	}
 
 	// the following type checking/casting is needed in order to satisfy some
 	// websocket request field params eg.:
 	// "params": ["0x..."] or
-	// "params": [["0x...", "0x..."]]
+	// "params": [["0x...", "0x..."]] or, to filter child storages,
+	// "params": [["0x..."], [["0x<child storage key>", ["0x...", "0x..."]]]]
+	// where a null list of child storage keys means all the child storage keys.
 	switch filters := params.(type) {
 	case []interface{}:
 		for _, interfaceKey := range filters {
+			if isChildFilters(interfaceKey) {
+				err := parseChildFilters(interfaceKey.([]interface{}), stgobs.childFilter)
+				if err != nil {
+					return nil, fmt.Errorf("parsing child storage filters: %w", err)
+				}
+				continue
+			}
+
 			switch key := interfaceKey.(type) {
+			case nil:
 			case string:
 				stgobs.filter[key] = []byte{}
 			case []string:
@@ -0,0 +1,125 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package subscription
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isChildFilters(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		param        interface{}
+		childFilters bool
+	}{
+		"nil": {},
+		"key": {
+			param: "0x01",
+		},
+		"empty_list": {
+			param: []interface{}{},
+		},
+		"keys": {
+			param: []interface{}{"0x01", "0x02"},
+		},
+		"mixed_list": {
+			param: []interface{}{"0x01", []interface{}{"0x02"}},
+		},
+		"child_filters": {
+			param:        []interface{}{[]interface{}{"0x01", nil}},
+			childFilters: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			childFilters := isChildFilters(testCase.param)
+
+			assert.Equal(t, testCase.childFilters, childFilters)
+		})
+	}
+}
+
+func Test_parseChildFilters(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		params       []interface{}
+		childFilters map[string]map[string][]byte
+		errWrapped   error
+		errMessage   string
+	}{
+		"empty_child_filter": {
+			params:       []interface{}{[]interface{}{}},
+			childFilters: map[string]map[string][]byte{},
+			errWrapped:   errUnexpectedParamLen,
+			errMessage:   "unexpected params length: 0, expected 1 or 2",
+		},
+		"child_storage_key_not_string": {
+			params:       []interface{}{[]interface{}{1}},
+			childFilters: map[string]map[string][]byte{},
+			errWrapped:   errUnexpectedType,
+			errMessage:   "unexpected type: int, expected type string",
+		},
+		"invalid_child_storage_key": {
+			params:       []interface{}{[]interface{}{"0xzz"}},
+			childFilters: map[string]map[string][]byte{},
+			errMessage: "decoding child storage key: encoding/hex: " +
+				"invalid byte: U+007A 'z': 0xzz",
+		},
+		"keys_not_list": {
+			params:       []interface{}{[]interface{}{"0x01", "0x02"}},
+			childFilters: map[string]map[string][]byte{"0x01": {}},
+			errWrapped:   errUnexpectedType,
+			errMessage:   "unexpected type: string, expected type []interface{}",
+		},
+		"all_keys": {
+			params: []interface{}{
+				[]interface{}{"0x01"},
+				[]interface{}{"0x02", nil},
+			},
+			childFilters: map[string]map[string][]byte{
+				"0x01": {},
+				"0x02": {},
+			},
+		},
+		"prefixed_child_storage_key_and_keys": {
+			params: []interface{}{
+				[]interface{}{
+					"0x3a6368696c645f73746f726167653a64656661756c743a01",
+					[]interface{}{"0xaa", "0xbb"},
+				},
+			},
+			childFilters: map[string]map[string][]byte{
+				"0x01": {"0xaa": {}, "0xbb": {}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			childFilters := make(map[string]map[string][]byte)
+			err := parseChildFilters(testCase.params, childFilters)
+
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.childFilters, childFilters)
+		})
+	}
+}
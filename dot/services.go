@@ -8,16 +8,17 @@
 	"fmt"
 	"strings"
 
-	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/digest"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/internal/pprof"
@@ -53,7 +54,7 @@ type rpcServiceSettings struct {
 	syncer        *sync.Service
 }
 
-func newInMemoryDB() (*chaindb.BadgerDB, error) {
+func newInMemoryDB() (database.Database, error) {
 	return utils.SetupDatabase("", true)
 }
 
@@ -64,7 +65,15 @@ func (nodeBuilder) createStateService(cfg *Config) (*state.Service, error) {
 	config := state.Config{
 		Path:     cfg.Global.BasePath,
 		LogLevel: cfg.Log.StateLvl,
-		Metrics:  metrics.NewIntervalConfig(cfg.Global.PublishMetrics),
+		PrunerCfg: pruner.Config{
+			Mode:           cfg.Global.Pruning,
+			RetainedBlocks: cfg.Global.RetainBlocks,
+		},
+		Metrics: metrics.NewIntervalConfig(cfg.Global.PublishMetrics),
+
+		TransactionStoragePeriod: cfg.State.TransactionStoragePeriod,
+		TrieCacheSize:            cfg.State.TrieCacheSize,
+		BlocksPruning:            cfg.State.BlocksPruning,
 	}
 
 	stateSrvc := state.NewService(config)
@@ -104,7 +113,7 @@ func (nodeBuilder) createRuntimeStorage(st *state.Service) (*runtime.NodeStorage
 
 	return &runtime.NodeStorage{
 		LocalStorage:      localStorage,
-		PersistentStorage: chaindb.NewTable(st.DB(), "offlinestorage"),
+		PersistentStorage: database.NewTable(st.DB(), "offlinestorage"),
 		BaseDB:            st.Base,
 	}, nil
 }
@@ -275,7 +284,7 @@ func (nodeBuilder) createCoreService(cfg *Config, ks *keystore.GlobalKeystore,
 // Network Service
 
 // createNetworkService creates a network service from the command configuration and genesis data
-func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
+func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service, ks keystore.Keystore,
 	telemetryMailer Telemetry) (*network.Service, error) {
 	logger.Debugf(
 		"creating network service with roles %d, port %d, bootnodes %s, protocol ID %s, nobootstrap=%t and noMDNS=%t...",
@@ -300,6 +309,8 @@ func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
 		NoMDNS:            cfg.Network.NoMDNS,
 		MinPeers:          cfg.Network.MinPeers,
 		MaxPeers:          cfg.Network.MaxPeers,
+		MaxLightPeers:     cfg.Network.MaxLightPeers,
+		MaxAuthorityPeers: cfg.Network.MaxAuthorityPeers,
 		PersistentPeers:   cfg.Network.PersistentPeers,
 		DiscoveryInterval: cfg.Network.DiscoveryInterval,
 		SlotDuration:      slotDuration,
@@ -309,6 +320,19 @@ func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
 		Metrics:           metrics.NewIntervalConfig(cfg.Global.PublishMetrics),
 		NodeKey:           cfg.Network.NodeKey,
 		ListenAddress:     cfg.Network.ListenAddress,
+		PeerSetDatabase:   database.NewTable(stateSrvc.DB(), "peerset"),
+		IPFSServer:        cfg.Network.IPFSServer,
+
+		AllowNonGlobalsInDHT:         cfg.Network.AllowNonGlobalsInDHT,
+		MaxInboundRequestsPerSecond:  cfg.Network.MaxInboundRequestsPerSecond,
+		MaxConcurrentInboundRequests: cfg.Network.MaxConcurrentInboundRequests,
+	}
+
+	// authorities publish their addresses and resolve the addresses of the
+	// other authorities in the DHT
+	if cfg.Core.Roles == common.AuthorityRole {
+		networkConfig.AuthorityDiscoveryAPI = &authorityDiscoveryAPI{blockState: stateSrvc.Block}
+		networkConfig.AuthorityDiscoveryKeystore = ks
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -320,6 +344,24 @@ func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
 	return networkSrvc, nil
 }
 
+// authorityDiscoveryAPI calls the authority discovery runtime API
+// using the runtime of the best block.
+type authorityDiscoveryAPI struct {
+	blockState *state.BlockState
+}
+
+// AuthorityDiscoveryAuthorities returns the authority discovery identifiers
+// of the current and next authority sets at the best block.
+func (a *authorityDiscoveryAPI) AuthorityDiscoveryAuthorities() (authorities []types.AuthorityID, err error) {
+	bestBlockHash := a.blockState.BestBlockHash()
+	rt, err := a.blockState.GetRuntime(bestBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime of best block %s: %w", bestBlockHash, err)
+	}
+
+	return rt.AuthorityDiscoveryAuthorities()
+}
+
 // RPC Service
 
 // createRPCService creates the RPC service from the provided core configuration
@@ -389,7 +431,7 @@ func (nodeBuilder) createSystemService(cfg *types.SystemInfo, stateSrvc *state.S
 
 // createGRANDPAService creates a new GRANDPA service
 func (nodeBuilder) createGRANDPAService(cfg *Config, st *state.Service, ks KeyStore,
-	net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error) {
+	net grandpa.Network, telemetryMailer Telemetry) (*grandpa.Service, error) {
 	bestBlockHash := st.Block.BestBlockHash()
 	rt, err := st.Block.GetRuntime(bestBlockHash)
 	if err != nil {
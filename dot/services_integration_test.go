@@ -198,7 +198,7 @@ func Test_nodeBuilder_createNetworkService(t *testing.T) {
 			cfg := NewWestendDevConfig(t)
 			stateSrvc := newStateService(t, ctrl)
 			no := nodeBuilder{}
-			got, err := no.createNetworkService(cfg, stateSrvc, nil)
+			got, err := no.createNetworkService(cfg, stateSrvc, nil, nil)
 			assert.ErrorIs(t, err, tt.err)
 			// TODO: create interface for network.NewService to handle assert.Equal test
 			if tt.expectNil {
@@ -559,7 +559,7 @@ func TestCreateNetworkService(t *testing.T) {
 	builder := nodeBuilder{}
 	stateSrvc := newStateServiceWithoutMock(t)
 
-	networkSrvc, err := builder.createNetworkService(cfg, stateSrvc, nil)
+	networkSrvc, err := builder.createNetworkService(cfg, stateSrvc, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, networkSrvc)
 }
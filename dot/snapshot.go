@@ -0,0 +1,99 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+)
+
+// ExportSnapshot writes a snapshot of the finalised block with the hash given
+// from the node database at the base path given to the writer given. The
+// snapshot is taken at the highest finalised block if the hash is empty.
+// The header of the snapshot block is returned.
+func ExportSnapshot(basepath string, blockHash common.Hash, w io.Writer) (
+	header *types.Header, err error) {
+	stateSrvc := state.NewService(state.Config{
+		Path:      basepath,
+		LogLevel:  log.Info,
+		Telemetry: telemetry.NewNoopMailer(),
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, fmt.Errorf("cannot setup state database: %w", err)
+	}
+
+	err = stateSrvc.Start()
+	if err != nil {
+		return nil, fmt.Errorf("cannot start state service: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop state service: %w", stopErr)
+		}
+	}()
+
+	if blockHash.IsEmpty() {
+		blockHash, err = stateSrvc.Block.GetHighestFinalisedHash()
+		if err != nil {
+			return nil, fmt.Errorf("getting highest finalised hash: %w", err)
+		}
+	}
+
+	header, err = stateSrvc.Block.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header of block %s: %w", blockHash, err)
+	}
+
+	err = stateSrvc.ExportSnapshot(blockHash, w)
+	if err != nil {
+		return nil, fmt.Errorf("exporting snapshot: %w", err)
+	}
+
+	return header, nil
+}
+
+// ImportSnapshot imports the snapshot read from the reader given in the
+// initialised node database at the base path given. The snapshot block
+// becomes the highest finalised block of the node, which produces and
+// finalises blocks from it once started. The GRANDPA justification of the
+// snapshot block is verified before the snapshot is imported. The header
+// of the snapshot block is returned.
+func ImportSnapshot(basepath string, r io.Reader) (header *types.Header, err error) {
+	stateSrvc := state.NewService(state.Config{
+		Path:      basepath,
+		LogLevel:  log.Info,
+		Telemetry: telemetry.NewNoopMailer(),
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, fmt.Errorf("cannot setup state database: %w", err)
+	}
+
+	header, err = stateSrvc.ImportSnapshot(r, grandpa.VerifyJustification)
+	if err != nil {
+		closeErr := stateSrvc.DB().Close()
+		if closeErr != nil {
+			logger.Errorf("cannot close state database: %s", closeErr)
+		}
+		return nil, fmt.Errorf("importing snapshot: %w", err)
+	}
+
+	err = stateSrvc.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("cannot stop state service: %w", err)
+	}
+
+	return header, nil
+}
@@ -6,8 +6,11 @@
 import (
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 )
@@ -125,6 +128,25 @@ func (s *BaseState) loadFirstSlot() (uint64, error) {
 	return binary.LittleEndian.Uint64(data), nil
 }
 
+func (s *BaseState) storeSnapshotBlockNumber(number uint) error {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(number))
+	return s.db.Put(snapshotBlockNumberKey, buf)
+}
+
+// loadSnapshotBlockNumber returns the number of the block of the last
+// snapshot imported, or zero if no snapshot was imported.
+func (s *BaseState) loadSnapshotBlockNumber() (uint, error) {
+	data, err := s.db.Get(snapshotBlockNumberKey)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return uint(binary.LittleEndian.Uint64(data)), nil
+}
+
 func (s *BaseState) storeEpochLength(l uint64) error {
 	buf := make([]byte, 8)
 	binary.LittleEndian.PutUint64(buf, l)
@@ -154,3 +176,26 @@ func (s *BaseState) loadSlotDuration() (uint64, error) {
 
 	return binary.LittleEndian.Uint64(data), nil
 }
+
+func (s *BaseState) storePruningConfig(config pruner.Config) error {
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encoding pruning configuration: %w", err)
+	}
+
+	return s.db.Put(common.PruningKey, encoded)
+}
+
+func (s *BaseState) loadPruningConfig() (config pruner.Config, err error) {
+	data, err := s.db.Get(common.PruningKey)
+	if err != nil {
+		return config, err
+	}
+
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return config, fmt.Errorf("decoding pruning configuration: %w", err)
+	}
+
+	return config, nil
+}
@@ -6,6 +6,8 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/trie"
@@ -89,3 +91,22 @@ func TestLoadAndStoreSlotDuration(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, d, ret)
 }
+
+func TestLoadAndStorePruningConfig(t *testing.T) {
+	db := NewInMemoryDB(t)
+	base := NewBaseState(db)
+
+	_, err := base.loadPruningConfig()
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	config := pruner.Config{
+		Mode:           pruner.Full,
+		RetainedBlocks: 256,
+	}
+	err = base.storePruningConfig(config)
+	require.NoError(t, err)
+
+	ret, err := base.loadPruningConfig()
+	require.NoError(t, err)
+	require.Equal(t, config, ret)
+}
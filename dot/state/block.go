@@ -12,7 +12,9 @@
 	"time"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -37,6 +39,12 @@
 	receiptPrefix       = []byte("rcp") // receiptPrefix + hash -> receipt
 	messageQueuePrefix  = []byte("mqp") // messageQueuePrefix + hash -> message queue
 	justificationPrefix = []byte("jcp") // justificationPrefix + hash -> justification
+	// indexOperationsPrefix + hash -> transaction index operations
+	indexOperationsPrefix = []byte("ixo")
+	// indexedTransactionPrefix + hash of indexed data -> indexed transaction
+	indexedTransactionPrefix = []byte("itx")
+	// storageChangesPrefix + hash -> keys of the state changed by the block
+	storageChangesPrefix = []byte("stc")
 
 	errNilBlockTree = errors.New("blocktree is nil")
 	errNilBlockBody = errors.New("block body is nil")
@@ -69,21 +77,41 @@ type BlockState struct {
 	runtimeUpdateSubscriptionsLock sync.RWMutex
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
 
+	// transaction storage indexing
+	indexedTransactionsLock  sync.Mutex
+	transactionStoragePeriod uint
+
+	// blocksPruning is the pruning of the block bodies, receipts,
+	// message queues and justifications, where grandpaState is used
+	// to retain the justifications of the authority set changes.
+	blocksPruning BlocksPruning
+	grandpaState  *GrandpaState
+
+	// pruner discards the states of pruned blocks
+	// and of finalised blocks no longer retained.
+	pruner pruner.Pruner
+
+	// snapshotNumber is the number of the block of the last snapshot
+	// imported, below which finalised blocks are not in the database,
+	// or zero if no snapshot was imported.
+	snapshotNumber uint
+
 	telemetry Telemetry
 }
 
 // NewBlockState will create a new BlockState backed by the database located at basePath
-func NewBlockState(db *chaindb.BadgerDB, trs *Tries, telemetry Telemetry) (*BlockState, error) {
+func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*BlockState, error) {
 	bs := &BlockState{
 		dbPath:                     db.Path(),
 		baseState:                  NewBaseState(db),
-		db:                         chaindb.NewTable(db, blockPrefix),
+		db:                         database.NewTable(db, blockPrefix),
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
 		imported:                   make(map[chan *types.Block]struct{}),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
 		telemetry:                  telemetry,
+		pruner:                     &pruner.ArchiveNode{},
 	}
 
 	gh, err := bs.db.Get(headerHashKey(0))
@@ -97,6 +125,11 @@ func NewBlockState(db *chaindb.BadgerDB, trs *Tries, telemetry Telemetry) (*Bloc
 		return nil, fmt.Errorf("failed to get last finalised header: %w", err)
 	}
 
+	bs.snapshotNumber, err = bs.baseState.loadSnapshotBlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("loading snapshot block number: %w", err)
+	}
+
 	bs.genesisHash = genesisHash
 	bs.lastFinalised = header.Hash()
 	bs.bt = blocktree.NewBlockTreeFromRoot(header)
@@ -105,12 +138,12 @@ func NewBlockState(db *chaindb.BadgerDB, trs *Tries, telemetry Telemetry) (*Bloc
 
 // NewBlockStateFromGenesis initialises a BlockState from a genesis header,
 // saving it to the database located at basePath
-func NewBlockStateFromGenesis(db *chaindb.BadgerDB, trs *Tries, header *types.Header,
+func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.Header,
 	telemetryMailer Telemetry) (*BlockState, error) {
 	bs := &BlockState{
 		bt:                         blocktree.NewBlockTreeFromRoot(header),
 		baseState:                  NewBaseState(db),
-		db:                         chaindb.NewTable(db, blockPrefix),
+		db:                         database.NewTable(db, blockPrefix),
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
 		imported:                   make(map[chan *types.Block]struct{}),
@@ -119,6 +152,7 @@ func NewBlockStateFromGenesis(db *chaindb.BadgerDB, trs *Tries, header *types.He
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
 		telemetry:                  telemetryMailer,
+		pruner:                     &pruner.ArchiveNode{},
 	}
 
 	if err := bs.setArrivalTime(header.Hash(), time.Now()); err != nil {
@@ -842,8 +876,10 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 
 	logger.Infof("🔄 detected runtime code change, upgrading with block %s from previous code hash %s to new code hash %s...", //nolint:lll
 		bHash, codeHash, currCodeHash)
-	code := newState.LoadCode()
-	if len(code) == 0 {
+	code, err := newState.LoadCode()
+	if err != nil {
+		return fmt.Errorf("loading code: %w", err)
+	} else if len(code) == 0 {
 		return errors.New("new :code is empty")
 	}
 
@@ -152,6 +152,26 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 
 		bs.tries.delete(blockHeader.StateRoot)
 
+		err = bs.pruner.DiscardState(hash)
+		if err != nil {
+			return fmt.Errorf("discarding state of pruned block %s: %w", hash, err)
+		}
+
+		err = bs.deleteStorageChanges(hash)
+		if err != nil {
+			return fmt.Errorf("deleting storage changes of pruned block %s: %w", hash, err)
+		}
+
+		err = bs.releaseIndexedTransactions(hash)
+		if err != nil {
+			return fmt.Errorf("releasing indexed transactions of pruned block %s: %w", hash, err)
+		}
+
+		err = bs.deleteForkBlockData(hash)
+		if err != nil {
+			return fmt.Errorf("deleting data of pruned block %s: %w", hash, err)
+		}
+
 		logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
 	}
 
@@ -175,6 +195,26 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		),
 	)
 
+	lastFinalisedHeader, err := bs.GetHeader(bs.lastFinalised)
+	if err != nil {
+		return fmt.Errorf("failed to get last finalised header, hash: %s, error: %s", bs.lastFinalised, err)
+	}
+
+	err = bs.pruneIndexedTransactions(lastFinalisedHeader.Number, header.Number)
+	if err != nil {
+		return fmt.Errorf("pruning indexed transactions: %w", err)
+	}
+
+	err = bs.pruneStates(lastFinalisedHeader.Number, header.Number)
+	if err != nil {
+		return fmt.Errorf("pruning states: %w", err)
+	}
+
+	err = bs.pruneBlocks(lastFinalisedHeader.Number, header.Number)
+	if err != nil {
+		return fmt.Errorf("pruning blocks: %w", err)
+	}
+
 	if bs.lastFinalised != hash {
 		defer func(lastFinalised common.Hash) {
 			err := bs.deleteFromTries(lastFinalised)
@@ -188,6 +228,44 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	return nil
 }
 
+// pruneStates discards the states, and the storage changes, of the finalised
+// blocks that are no longer retained by the pruner when finalising the blocks
+// numbered from previousFinalised+1 to finalised.
+func (bs *BlockState) pruneStates(previousFinalised, finalised uint) error {
+	retained := uint(bs.pruner.RetainedBlocks())
+	if retained == 0 || finalised < retained {
+		return nil
+	}
+
+	start := uint(0)
+	if previousFinalised >= retained {
+		start = previousFinalised - retained + 1
+	}
+	if start < bs.snapshotNumber {
+		// blocks before an imported snapshot block are not in the database
+		start = bs.snapshotNumber
+	}
+
+	for number := start; number <= finalised-retained; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		if err != nil {
+			return fmt.Errorf("getting hash of block number %d: %w", number, err)
+		}
+
+		err = bs.pruner.DiscardState(hash)
+		if err != nil {
+			return fmt.Errorf("discarding state of block %s: %w", hash, err)
+		}
+
+		err = bs.deleteStorageChanges(hash)
+		if err != nil {
+			return fmt.Errorf("deleting storage changes of block %s: %w", hash, err)
+		}
+	}
+
+	return nil
+}
+
 func (bs *BlockState) deleteFromTries(lastFinalised common.Hash) error {
 	lastFinalisedHeader, err := bs.GetHeader(lastFinalised)
 	if err != nil {
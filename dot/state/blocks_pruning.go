@@ -0,0 +1,172 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// BlocksPruningMode is the mode of pruning of the block bodies, receipts,
+// message queues and justifications.
+type BlocksPruningMode uint8
+
+const (
+	// BlocksPruningArchive keeps the data of all the blocks,
+	// including the receipts and message queues of pruned forks.
+	BlocksPruningArchive BlocksPruningMode = iota
+	// BlocksPruningArchiveCanonical keeps the data of all the finalised
+	// blocks, and discards the data of the pruned forks.
+	BlocksPruningArchiveCanonical
+	// BlocksPruningSome keeps the data of the last retained finalised
+	// blocks only, and discards the data of the pruned forks.
+	BlocksPruningSome
+)
+
+const (
+	blocksPruningArchive          = "archive"
+	blocksPruningArchiveCanonical = "archive-canonical"
+)
+
+// ErrBlocksPruningNotValid is returned when parsing a blocks pruning value
+// which is neither a strictly positive number of blocks, archive nor
+// archive-canonical.
+var ErrBlocksPruningNotValid = errors.New("blocks pruning is not valid")
+
+// BlocksPruning is the blocks pruning configuration. Block headers are never
+// pruned, and neither are the justifications of the blocks changing the
+// GRANDPA authority set, since they are needed to warp sync.
+type BlocksPruning struct {
+	Mode BlocksPruningMode
+	// Retained is the number of finalised blocks for which the bodies,
+	// receipts, message queues and justifications are retained in the
+	// BlocksPruningSome mode.
+	Retained uint
+}
+
+// ParseBlocksPruning parses a blocks pruning value which is either
+// archive, archive-canonical or a strictly positive number of blocks.
+func ParseBlocksPruning(s string) (pruning BlocksPruning, err error) {
+	switch s {
+	case blocksPruningArchive:
+		return BlocksPruning{Mode: BlocksPruningArchive}, nil
+	case blocksPruningArchiveCanonical:
+		return BlocksPruning{Mode: BlocksPruningArchiveCanonical}, nil
+	}
+
+	retained, err := strconv.ParseUint(s, 10, 0)
+	if err != nil || retained == 0 {
+		return pruning, fmt.Errorf("%w: %q must be %s, %s or a number of blocks greater than 0",
+			ErrBlocksPruningNotValid, s, blocksPruningArchive, blocksPruningArchiveCanonical)
+	}
+
+	return BlocksPruning{Mode: BlocksPruningSome, Retained: uint(retained)}, nil
+}
+
+// String returns the blocks pruning value as parsed by ParseBlocksPruning.
+func (p BlocksPruning) String() string {
+	switch p.Mode {
+	case BlocksPruningArchive:
+		return blocksPruningArchive
+	case BlocksPruningArchiveCanonical:
+		return blocksPruningArchiveCanonical
+	default:
+		return fmt.Sprint(p.Retained)
+	}
+}
+
+// SetBlocksPruning sets the blocks pruning configuration. The GRANDPA state
+// given is used to retain the justifications of the blocks changing the
+// authority set, and can be nil if the mode is not BlocksPruningSome.
+func (bs *BlockState) SetBlocksPruning(pruning BlocksPruning, grandpaState *GrandpaState) {
+	bs.Lock()
+	defer bs.Unlock()
+	bs.blocksPruning = pruning
+	bs.grandpaState = grandpaState
+}
+
+// deleteForkBlockData deletes the receipt, message queue and justification
+// of the pruned fork block with the given hash, unless all the block data
+// is kept. The header and body of fork blocks are never stored in the database.
+func (bs *BlockState) deleteForkBlockData(hash common.Hash) error {
+	if bs.blocksPruning.Mode == BlocksPruningArchive {
+		return nil
+	}
+
+	const deleteBody, deleteJustification = false, true
+	return bs.deleteBlockData(hash, deleteBody, deleteJustification)
+}
+
+// deleteBlockData deletes the receipt and message queue of the block with the
+// given hash, as well as its body and its justification if requested.
+func (bs *BlockState) deleteBlockData(hash common.Hash, deleteBody, deleteJustification bool) error {
+	keys := [][]byte{
+		prefixKey(hash, receiptPrefix),
+		prefixKey(hash, messageQueuePrefix),
+	}
+	if deleteBody {
+		keys = append(keys, blockBodyKey(hash))
+	}
+	if deleteJustification {
+		keys = append(keys, prefixKey(hash, justificationPrefix))
+	}
+
+	batch := bs.db.NewBatch()
+	for _, key := range keys {
+		err := batch.Del(key)
+		if err != nil {
+			return fmt.Errorf("deleting key 0x%x: %w", key, err)
+		}
+	}
+
+	return batch.Flush()
+}
+
+// pruneBlocks deletes the bodies, receipts and message queues of the finalised
+// blocks that fell out of the retained blocks when finalising the blocks
+// numbered from previousFinalised+1 to finalised. Their justifications are
+// also deleted, unless the block is the last block of a GRANDPA authority set.
+func (bs *BlockState) pruneBlocks(previousFinalised, finalised uint) error {
+	if bs.blocksPruning.Mode != BlocksPruningSome {
+		return nil
+	}
+
+	retained := bs.blocksPruning.Retained
+	if finalised <= retained {
+		return nil
+	}
+
+	// the genesis block data is never pruned
+	start := uint(1)
+	if previousFinalised+1 > retained+start {
+		start = previousFinalised + 1 - retained
+	}
+	if start < bs.snapshotNumber {
+		// blocks before an imported snapshot block are not in the database
+		start = bs.snapshotNumber
+	}
+
+	for number := start; number <= finalised-retained; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		if err != nil {
+			return fmt.Errorf("getting hash of block number %d: %w", number, err)
+		}
+
+		isSetIDChange, err := bs.grandpaState.isSetIDChangeBlock(number)
+		if err != nil {
+			return fmt.Errorf("checking if block number %d changes the authority set: %w", number, err)
+		}
+
+		const deleteBody = true
+		err = bs.deleteBlockData(hash, deleteBody, !isSetIDChange)
+		if err != nil {
+			return fmt.Errorf("deleting data of block %s: %w", hash, err)
+		}
+	}
+
+	return nil
+}
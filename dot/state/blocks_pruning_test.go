@@ -0,0 +1,153 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseBlocksPruning(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s          string
+		pruning    BlocksPruning
+		errWrapped error
+		errMessage string
+	}{
+		"archive": {
+			s:       "archive",
+			pruning: BlocksPruning{Mode: BlocksPruningArchive},
+		},
+		"archive_canonical": {
+			s:       "archive-canonical",
+			pruning: BlocksPruning{Mode: BlocksPruningArchiveCanonical},
+		},
+		"retained_blocks": {
+			s:       "256",
+			pruning: BlocksPruning{Mode: BlocksPruningSome, Retained: 256},
+		},
+		"zero_retained_blocks": {
+			s:          "0",
+			errWrapped: ErrBlocksPruningNotValid,
+			errMessage: `blocks pruning is not valid: "0" must be archive, ` +
+				`archive-canonical or a number of blocks greater than 0`,
+		},
+		"invalid": {
+			s:          "full",
+			errWrapped: ErrBlocksPruningNotValid,
+			errMessage: `blocks pruning is not valid: "full" must be archive, ` +
+				`archive-canonical or a number of blocks greater than 0`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pruning, err := ParseBlocksPruning(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.Equal(t, testCase.pruning, pruning)
+			assert.Equal(t, testCase.s, pruning.String())
+		})
+	}
+}
+
+func TestBlockState_SetFinalisedHash_prunesBlocks(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	grandpaState, err := NewGrandpaStateFromGenesis(NewInMemoryDB(t), nil, testAuths, nil)
+	require.NoError(t, err)
+	// block number 2 is the last block of the genesis authority set
+	err = grandpaState.SetNextChange(testAuths, 2)
+	require.NoError(t, err)
+
+	bs.SetBlocksPruning(BlocksPruning{Mode: BlocksPruningSome, Retained: 1}, grandpaState)
+
+	headers, _ := AddBlocksToState(t, bs, 4, false)
+
+	preDigest, err := types.NewBabePrimaryPreDigest(1, 1, [32]byte{}, [64]byte{}).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(*preDigest)
+	require.NoError(t, err)
+	fork := &types.Block{
+		Header: types.Header{
+			ParentHash: bs.GenesisHash(),
+			Number:     1,
+			StateRoot:  trie.EmptyHash,
+			Digest:     digest,
+		},
+		Body: types.Body{},
+	}
+	err = bs.AddBlock(fork)
+	require.NoError(t, err)
+
+	blocks := []struct {
+		hash          common.Hash
+		pruned        bool
+		justification bool
+	}{
+		{hash: headers[0].Hash(), pruned: true},
+		{hash: headers[1].Hash(), pruned: true, justification: true},
+		{hash: headers[2].Hash(), pruned: true},
+		{hash: headers[3].Hash(), justification: true},
+		{hash: fork.Header.Hash(), pruned: true},
+	}
+
+	for _, block := range blocks {
+		err = bs.SetReceipt(block.hash, []byte{1})
+		require.NoError(t, err)
+		err = bs.SetMessageQueue(block.hash, []byte{2})
+		require.NoError(t, err)
+		err = bs.SetJustification(block.hash, []byte{3})
+		require.NoError(t, err)
+	}
+
+	err = bs.SetFinalisedHash(headers[3].Hash(), 1, 0)
+	require.NoError(t, err)
+
+	for i, block := range blocks {
+		_, err = bs.GetReceipt(block.hash)
+		assertPruned(t, block.pruned, err, "receipt of block %d", i)
+
+		_, err = bs.GetMessageQueue(block.hash)
+		assertPruned(t, block.pruned, err, "message queue of block %d", i)
+
+		_, err = bs.GetJustification(block.hash)
+		assertPruned(t, block.pruned && !block.justification, err, "justification of block %d", i)
+
+		if i < len(headers) {
+			_, err = bs.GetHeader(block.hash)
+			assert.NoError(t, err, "header of block %d", i)
+
+			_, err = bs.GetBlockBody(block.hash)
+			assertPruned(t, block.pruned, err, "body of block %d", i)
+		}
+	}
+}
+
+func assertPruned(t *testing.T, pruned bool, err error, msgAndArgs ...interface{}) {
+	t.Helper()
+	if pruned {
+		assert.ErrorIs(t, err, chaindb.ErrKeyNotFound, msgAndArgs...)
+	} else {
+		assert.NoError(t, err, msgAndArgs...)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+// archiveCheckedStates is the number of latest finalised block
+// states checked when the node does not prune its state tries.
+const archiveCheckedStates = 256
+
+var (
+	ErrHeaderNotFound         = errors.New("header not found")
+	ErrHeaderNumberMismatch   = errors.New("header number mismatch")
+	ErrHeaderHashMismatch     = errors.New("header hash mismatch")
+	ErrParentHashMismatch     = errors.New("parent hash mismatch")
+	ErrStateIncomplete        = errors.New("state trie does not fully resolve")
+	ErrEpochInconsistent      = errors.New("epoch metadata is inconsistent")
+	ErrGrandpaInconsistent    = errors.New("grandpa metadata is inconsistent")
+	ErrNoConsistentBlockFound = errors.New("no consistent block found")
+	ErrNotRepairable          = errors.New("database is not repairable")
+)
+
+// CheckReport is the result of a database integrity check.
+type CheckReport struct {
+	// FinalisedHeader is the highest finalised header.
+	FinalisedHeader *types.Header
+	// HeadersChecked is the number of finalised headers checked.
+	HeadersChecked uint
+	// StatesChecked is the number of finalised block states checked.
+	StatesChecked uint
+	// LastConsistentHeader is the header of the highest finalised block
+	// for which the block and all its ancestors have valid headers,
+	// and its state trie fully resolves from the database.
+	// It is nil if no such block is found.
+	LastConsistentHeader *types.Header
+	// Repairable is true if headers or states of blocks above the last
+	// consistent block are inconsistent, such that rewinding the chain
+	// to the last consistent block repairs the database.
+	Repairable bool
+	// Issues are the inconsistencies found in the database.
+	Issues []error
+}
+
+// Check checks the integrity of the database. It walks the finalised
+// headers from genesis, or from the block of the last snapshot imported,
+// verifying each header hash and parent hash, verifies the state tries
+// of the finalised blocks retained by the pruner fully resolve from the
+// database, and checks the epoch and grandpa metadata are consistent
+// with the highest finalised block.
+// It should be called after SetupBase instead of Start, since Start
+// fails if the state trie of the highest finalised block is incomplete.
+func (s *Service) Check() (report *CheckReport, err error) {
+	err = s.createStates()
+	if err != nil {
+		return nil, err
+	}
+
+	finalisedHeader, err := s.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	report = &CheckReport{
+		FinalisedHeader: finalisedHeader,
+	}
+
+	headers, headerIssue := s.checkHeaders(finalisedHeader.Number)
+	report.HeadersChecked = uint(len(headers))
+	if headerIssue != nil {
+		report.Issues = append(report.Issues, headerIssue)
+	}
+
+	checkedStates := uint(s.Block.pruner.RetainedBlocks())
+	if checkedStates == 0 {
+		checkedStates = archiveCheckedStates
+	}
+
+	// Check the states from the highest finalised block, such that
+	// the last consistent block is the first block found with a valid
+	// header and a state trie fully resolving.
+	stateIssueAboveConsistent := false
+	for i := len(headers) - 1; i >= 0 && report.StatesChecked < checkedStates; i-- {
+		header := headers[i]
+		report.StatesChecked++
+
+		issue := s.checkState(header)
+		if issue != nil {
+			report.Issues = append(report.Issues, issue)
+			if report.LastConsistentHeader == nil {
+				stateIssueAboveConsistent = true
+			}
+			continue
+		}
+
+		if report.LastConsistentHeader == nil {
+			report.LastConsistentHeader = header
+		}
+	}
+
+	// A header issue is always above the last consistent block, since
+	// the states are only checked for the valid headers before it.
+	report.Repairable = report.LastConsistentHeader != nil &&
+		(headerIssue != nil || stateIssueAboveConsistent)
+
+	report.Issues = append(report.Issues, s.checkEpochMetadata(finalisedHeader)...)
+	report.Issues = append(report.Issues, s.checkGrandpaMetadata()...)
+
+	return report, nil
+}
+
+// checkHeaders walks the finalised headers from genesis, or from the block
+// of the last snapshot imported since the blocks before it are not in the
+// database, to the finalised number given, and returns the valid headers
+// found before the first inconsistency, and an error describing the
+// inconsistency if any.
+func (s *Service) checkHeaders(finalisedNumber uint) (headers []*types.Header, issue error) {
+	start := s.Block.snapshotNumber
+	headers = make([]*types.Header, 0, finalisedNumber-start+1)
+
+	var parentHash common.Hash
+	for number := start; number <= finalisedNumber; number++ {
+		hash, err := s.Block.GetHashByNumber(number)
+		if err != nil {
+			return headers, fmt.Errorf("%w: for block number %d: %s", ErrHeaderNotFound, number, err)
+		}
+
+		header, err := s.Block.GetHeader(hash)
+		if err != nil {
+			return headers, fmt.Errorf("%w: for block %s: %s", ErrHeaderNotFound, hash, err)
+		}
+
+		switch {
+		case header.Number != number:
+			return headers, fmt.Errorf("%w: header of block %s has number %d instead of %d",
+				ErrHeaderNumberMismatch, hash, header.Number, number)
+		case header.Hash() != hash:
+			return headers, fmt.Errorf("%w: header of block number %d hashes to %s instead of %s",
+				ErrHeaderHashMismatch, number, header.Hash(), hash)
+		case number > start && header.ParentHash != parentHash:
+			return headers, fmt.Errorf("%w: block %s has parent hash %s instead of %s",
+				ErrParentHashMismatch, hash, header.ParentHash, parentHash)
+		}
+
+		if number > 0 && number%100000 == 0 {
+			logger.Infof("checked finalised headers up to block number %d", number)
+		}
+
+		headers = append(headers, header)
+		parentHash = hash
+	}
+
+	return headers, nil
+}
+
+// checkState checks the state trie of the block with the header given
+// fully resolves from the database, including its child tries.
+func (s *Service) checkState(header *types.Header) (issue error) {
+	stateTrie := trie.NewEmptyTrie()
+	err := stateTrie.Load(s.Storage.db, header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("%w: for block %s with state root %s: %s",
+			ErrStateIncomplete, header.Hash(), header.StateRoot, err)
+	}
+	return nil
+}
+
+// checkEpochMetadata checks the current epoch, and the epoch data and
+// configuration data of the highest finalised block epoch are present.
+func (s *Service) checkEpochMetadata(finalisedHeader *types.Header) (issues []error) {
+	currentEpoch, err := s.Epoch.GetCurrentEpoch()
+	if err != nil {
+		issues = append(issues, fmt.Errorf("%w: getting current epoch: %s", ErrEpochInconsistent, err))
+	}
+
+	var finalisedEpoch uint64
+	if finalisedHeader.Number > 0 {
+		finalisedEpoch, err = s.Epoch.GetEpochForBlock(finalisedHeader)
+		if err != nil {
+			return append(issues, fmt.Errorf("%w: getting epoch of finalised block %s: %s",
+				ErrEpochInconsistent, finalisedHeader.Hash(), err))
+		}
+	}
+
+	if len(issues) == 0 && finalisedEpoch > currentEpoch {
+		issues = append(issues, fmt.Errorf("%w: finalised block epoch %d is after current epoch %d",
+			ErrEpochInconsistent, finalisedEpoch, currentEpoch))
+	}
+
+	_, err = s.Epoch.GetEpochData(finalisedEpoch, finalisedHeader)
+	if err != nil {
+		issues = append(issues, fmt.Errorf("%w: getting data of epoch %d: %s",
+			ErrEpochInconsistent, finalisedEpoch, err))
+	}
+
+	_, err = s.Epoch.GetConfigData(finalisedEpoch, finalisedHeader)
+	if err != nil {
+		issues = append(issues, fmt.Errorf("%w: getting configuration data of epoch %d: %s",
+			ErrEpochInconsistent, finalisedEpoch, err))
+	}
+
+	return issues
+}
+
+// checkGrandpaMetadata checks the authorities of each set up to the current
+// set are present, and the set ID changes are in increasing block order.
+func (s *Service) checkGrandpaMetadata() (issues []error) {
+	currentSetID, err := s.Grandpa.GetCurrentSetID()
+	if err != nil {
+		return []error{fmt.Errorf("%w: getting current set id: %s", ErrGrandpaInconsistent, err)}
+	}
+
+	var previousChange uint
+	for setID := uint64(0); setID <= currentSetID; setID++ {
+		_, err = s.Grandpa.GetAuthorities(setID)
+		if err != nil {
+			issues = append(issues, fmt.Errorf("%w: getting authorities of set id %d: %s",
+				ErrGrandpaInconsistent, setID, err))
+		}
+
+		change, err := s.Grandpa.GetSetIDChange(setID)
+		if err != nil {
+			issues = append(issues, fmt.Errorf("%w: getting block number of set id %d change: %s",
+				ErrGrandpaInconsistent, setID, err))
+			continue
+		}
+
+		if change < previousChange {
+			issues = append(issues, fmt.Errorf(
+				"%w: set id %d changed at block number %d before set id %d changed at block number %d",
+				ErrGrandpaInconsistent, setID, change, setID-1, previousChange))
+		}
+		previousChange = change
+	}
+
+	return issues
+}
+
+// Repair rewinds the chain to the last consistent block of the check
+// report given, which must have been produced by Check on this service.
+// It returns an error if the report is not repairable, for example if
+// the only issues found are in the epoch or grandpa metadata.
+func (s *Service) Repair(report *CheckReport) error {
+	if report.LastConsistentHeader == nil {
+		return ErrNoConsistentBlockFound
+	}
+
+	if !report.Repairable {
+		return fmt.Errorf("%w: no header or state issue found above the last consistent block #%d",
+			ErrNotRepairable, report.LastConsistentHeader.Number)
+	}
+
+	err := s.Rewind(report.LastConsistentHeader.Number)
+	if err != nil {
+		return fmt.Errorf("rewinding to block number %d: %w",
+			report.LastConsistentHeader.Number, err)
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
@@ -67,7 +68,7 @@ type EpochState struct {
 }
 
 // NewEpochStateFromGenesis returns a new EpochState given information for the first epoch, fetched from the runtime
-func NewEpochStateFromGenesis(db *chaindb.BadgerDB, blockState *BlockState,
+func NewEpochStateFromGenesis(db database.Database, blockState *BlockState,
 	genesisConfig *types.BabeConfiguration) (*EpochState, error) {
 	baseState := NewBaseState(db)
 
@@ -76,7 +77,7 @@ func NewEpochStateFromGenesis(db *chaindb.BadgerDB, blockState *BlockState,
 		return nil, err
 	}
 
-	epochDB := chaindb.NewTable(db, epochPrefix)
+	epochDB := database.NewTable(db, epochPrefix)
 	err = epochDB.Put(currentEpochKey, []byte{0, 0, 0, 0, 0, 0, 0, 0})
 	if err != nil {
 		return nil, err
@@ -133,7 +134,7 @@ func NewEpochStateFromGenesis(db *chaindb.BadgerDB, blockState *BlockState,
 }
 
 // NewEpochState returns a new EpochState
-func NewEpochState(db *chaindb.BadgerDB, blockState *BlockState) (*EpochState, error) {
+func NewEpochState(db database.Database, blockState *BlockState) (*EpochState, error) {
 	baseState := NewBaseState(db)
 
 	epochLength, err := baseState.loadEpochLength()
@@ -149,7 +150,7 @@ func NewEpochState(db *chaindb.BadgerDB, blockState *BlockState) (*EpochState, e
 	return &EpochState{
 		baseState:      baseState,
 		blockState:     blockState,
-		db:             chaindb.NewTable(db, epochPrefix),
+		db:             database.NewTable(db, epochPrefix),
 		epochLength:    epochLength,
 		skipToEpoch:    skipToEpoch,
 		nextEpochData:  make(nextEpochMap[types.NextEpochData]),
@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
@@ -46,9 +47,9 @@ type GrandpaState struct {
 }
 
 // NewGrandpaStateFromGenesis returns a new GrandpaState given the grandpa genesis authorities
-func NewGrandpaStateFromGenesis(db *chaindb.BadgerDB, bs *BlockState,
+func NewGrandpaStateFromGenesis(db database.Database, bs *BlockState,
 	genesisAuthorities []types.GrandpaVoter, telemetry Telemetry) (*GrandpaState, error) {
-	grandpaDB := chaindb.NewTable(db, grandpaPrefix)
+	grandpaDB := database.NewTable(db, grandpaPrefix)
 	s := &GrandpaState{
 		db:                   grandpaDB,
 		blockState:           bs,
@@ -77,9 +78,9 @@ func NewGrandpaStateFromGenesis(db *chaindb.BadgerDB, bs *BlockState,
 }
 
 // NewGrandpaState returns a new GrandpaState
-func NewGrandpaState(db *chaindb.BadgerDB, bs *BlockState, telemetry Telemetry) *GrandpaState {
+func NewGrandpaState(db database.Database, bs *BlockState, telemetry Telemetry) *GrandpaState {
 	return &GrandpaState{
-		db:                   chaindb.NewTable(db, grandpaPrefix),
+		db:                   database.NewTable(db, grandpaPrefix),
 		blockState:           bs,
 		scheduledChangeRoots: new(changeTree),
 		forcedChanges:        new(orderedPendingChanges),
@@ -450,6 +451,24 @@ func (s *GrandpaState) GetSetIDChange(setID uint64) (blockNumber uint, err error
 	return common.BytesToUint(num), nil
 }
 
+// isSetIDChangeBlock returns true if the given block number is the last
+// block of an authority set, whose justification proves the set change.
+func (s *GrandpaState) isSetIDChangeBlock(blockNumber uint) (bool, error) {
+	setID, err := s.GetSetIDByBlockNumber(blockNumber)
+	if err != nil {
+		return false, fmt.Errorf("getting set id of block number %d: %w", blockNumber, err)
+	}
+
+	changeNumber, err := s.GetSetIDChange(setID + 1)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting block number of set id %d change: %w", setID+1, err)
+	}
+
+	return changeNumber == blockNumber, nil
+}
+
 // GetSetIDByBlockNumber returns the set ID for a given block number
 func (s *GrandpaState) GetSetIDByBlockNumber(blockNumber uint) (uint64, error) {
 	curr, err := s.GetCurrentSetID()
@@ -0,0 +1,288 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// indexedTransaction is the data indexed by the runtime through the
+// transaction index host functions, along with the number of index
+// operations (insertions and renewals) still referencing it.
+type indexedTransaction struct {
+	References uint32
+	Data       []byte
+}
+
+// SetTransactionStoragePeriod sets the number of finalised blocks for which
+// the transaction data indexed by a block is retained. Zero disables pruning.
+func (bs *BlockState) SetTransactionStoragePeriod(period uint) {
+	bs.indexedTransactionsLock.Lock()
+	defer bs.indexedTransactionsLock.Unlock()
+	bs.transactionStoragePeriod = period
+}
+
+// StoreIndexedTransactions stores the extrinsic data indexed by the runtime
+// while executing the given block, and records the index operations of the
+// block so that the data can be released once the block is pruned.
+// Storing the index operations of a block already stored does nothing,
+// so the references are only counted once when a block is imported again.
+func (bs *BlockState) StoreIndexedTransactions(block *types.Block, operations []rtstorage.IndexOperation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	bs.indexedTransactionsLock.Lock()
+	defer bs.indexedTransactionsLock.Unlock()
+
+	blockHash := block.Header.Hash()
+	stored, err := bs.db.Has(prefixKey(blockHash, indexOperationsPrefix))
+	if err != nil {
+		return fmt.Errorf("checking index operations for block %s: %w", blockHash, err)
+	} else if stored {
+		return nil
+	}
+
+	records := make(map[common.Hash]*indexedTransaction)
+	applied := make([]rtstorage.IndexOperation, 0, len(operations))
+
+	for _, operation := range operations {
+		if int(operation.Extrinsic) >= len(block.Body) {
+			logger.Warnf("ignoring index operation for extrinsic %d of block %s with %d extrinsics",
+				operation.Extrinsic, blockHash, len(block.Body))
+			continue
+		}
+
+		record, ok := records[operation.Hash]
+		if !ok {
+			record, err = bs.getIndexedTransaction(operation.Hash)
+			if err != nil && !errors.Is(err, chaindb.ErrKeyNotFound) {
+				return fmt.Errorf("getting indexed transaction %s: %w", operation.Hash, err)
+			}
+		}
+
+		if operation.Renew {
+			if record == nil {
+				logger.Debugf("cannot renew unknown indexed transaction %s in block %s",
+					operation.Hash, blockHash)
+				continue
+			}
+		} else if record == nil {
+			extrinsic := block.Body[operation.Extrinsic]
+			if int(operation.Size) > len(extrinsic) {
+				logger.Warnf("ignoring index operation of %d bytes for extrinsic %d of %d bytes in block %s",
+					operation.Size, operation.Extrinsic, len(extrinsic), blockHash)
+				continue
+			}
+
+			record = &indexedTransaction{
+				Data: extrinsic[len(extrinsic)-int(operation.Size):],
+			}
+		}
+
+		record.References++
+		records[operation.Hash] = record
+		applied = append(applied, operation)
+	}
+
+	batch := bs.db.NewBatch()
+	for hash, record := range records {
+		encoded, err := scale.Marshal(*record)
+		if err != nil {
+			return fmt.Errorf("encoding indexed transaction %s: %w", hash, err)
+		}
+
+		err = batch.Put(prefixKey(hash, indexedTransactionPrefix), encoded)
+		if err != nil {
+			return fmt.Errorf("putting indexed transaction %s: %w", hash, err)
+		}
+	}
+
+	encoded, err := scale.Marshal(applied)
+	if err != nil {
+		return fmt.Errorf("encoding index operations: %w", err)
+	}
+
+	err = batch.Put(prefixKey(blockHash, indexOperationsPrefix), encoded)
+	if err != nil {
+		return fmt.Errorf("putting index operations for block %s: %w", blockHash, err)
+	}
+
+	return batch.Flush()
+}
+
+// HasIndexedTransaction returns true if data is indexed for the given hash.
+func (bs *BlockState) HasIndexedTransaction(hash common.Hash) (bool, error) {
+	return bs.db.Has(prefixKey(hash, indexedTransactionPrefix))
+}
+
+// GetIndexedTransaction returns the data indexed for the given hash.
+func (bs *BlockState) GetIndexedTransaction(hash common.Hash) ([]byte, error) {
+	record, err := bs.getIndexedTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Data, nil
+}
+
+// GetIndexedBody returns the data indexed by the extrinsics of the block
+// with the given hash, in the order the extrinsics appear in the block body.
+// Renewals are not part of the indexed body.
+func (bs *BlockState) GetIndexedBody(hash common.Hash) ([][]byte, error) {
+	operations, err := bs.getIndexOperations(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	indexedBody := make([][]byte, 0, len(operations))
+	for _, operation := range operations {
+		if operation.Renew {
+			continue
+		}
+
+		data, err := bs.GetIndexedTransaction(operation.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting indexed transaction %s: %w", operation.Hash, err)
+		}
+
+		indexedBody = append(indexedBody, data)
+	}
+
+	return indexedBody, nil
+}
+
+func (bs *BlockState) getIndexedTransaction(hash common.Hash) (*indexedTransaction, error) {
+	encoded, err := bs.db.Get(prefixKey(hash, indexedTransactionPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	record := new(indexedTransaction)
+	err = scale.Unmarshal(encoded, record)
+	if err != nil {
+		return nil, fmt.Errorf("decoding indexed transaction: %w", err)
+	}
+
+	return record, nil
+}
+
+func (bs *BlockState) getIndexOperations(hash common.Hash) ([]rtstorage.IndexOperation, error) {
+	encoded, err := bs.db.Get(prefixKey(hash, indexOperationsPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []rtstorage.IndexOperation
+	err = scale.Unmarshal(encoded, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("decoding index operations: %w", err)
+	}
+
+	return operations, nil
+}
+
+// releaseIndexedTransactions drops the references held by the index operations
+// of the block with the given hash, deleting indexed data no longer referenced.
+func (bs *BlockState) releaseIndexedTransactions(blockHash common.Hash) error {
+	bs.indexedTransactionsLock.Lock()
+	defer bs.indexedTransactionsLock.Unlock()
+
+	operations, err := bs.getIndexOperations(blockHash)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting index operations: %w", err)
+	}
+
+	records := make(map[common.Hash]*indexedTransaction)
+	for _, operation := range operations {
+		record, ok := records[operation.Hash]
+		if !ok {
+			record, err = bs.getIndexedTransaction(operation.Hash)
+			if errors.Is(err, chaindb.ErrKeyNotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("getting indexed transaction %s: %w", operation.Hash, err)
+			}
+			records[operation.Hash] = record
+		}
+
+		if record.References > 0 {
+			record.References--
+		}
+	}
+
+	batch := bs.db.NewBatch()
+	for hash, record := range records {
+		key := prefixKey(hash, indexedTransactionPrefix)
+		if record.References == 0 {
+			err = batch.Del(key)
+			if err != nil {
+				return fmt.Errorf("deleting indexed transaction %s: %w", hash, err)
+			}
+			continue
+		}
+
+		encoded, err := scale.Marshal(*record)
+		if err != nil {
+			return fmt.Errorf("encoding indexed transaction %s: %w", hash, err)
+		}
+
+		err = batch.Put(key, encoded)
+		if err != nil {
+			return fmt.Errorf("putting indexed transaction %s: %w", hash, err)
+		}
+	}
+
+	err = batch.Del(prefixKey(blockHash, indexOperationsPrefix))
+	if err != nil {
+		return fmt.Errorf("deleting index operations: %w", err)
+	}
+
+	return batch.Flush()
+}
+
+// pruneIndexedTransactions releases the indexed transactions of the finalised
+// blocks that fell out of the transaction storage period when finalising the
+// blocks numbered from previousFinalised+1 to finalised.
+func (bs *BlockState) pruneIndexedTransactions(previousFinalised, finalised uint) error {
+	bs.indexedTransactionsLock.Lock()
+	period := bs.transactionStoragePeriod
+	bs.indexedTransactionsLock.Unlock()
+
+	if period == 0 || finalised <= period {
+		return nil
+	}
+
+	start := uint(1)
+	if previousFinalised+1 > period+start {
+		start = previousFinalised + 1 - period
+	}
+	if start < bs.snapshotNumber {
+		// blocks before an imported snapshot block are not in the database
+		start = bs.snapshotNumber
+	}
+
+	for number := start; number <= finalised-period; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		if err != nil {
+			return fmt.Errorf("getting hash of block number %d: %w", number, err)
+		}
+
+		err = bs.releaseIndexedTransactions(hash)
+		if err != nil {
+			return fmt.Errorf("releasing indexed transactions of block %s: %w", hash, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_StoreIndexedTransactions(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	block := &types.Block{
+		Header: types.Header{Number: 1},
+		Body: types.Body{
+			{0x01, 0x02, 0x03, 0x04},
+			{0x05, 0x06},
+		},
+	}
+
+	dataHash := common.Hash{1}
+	unknownHash := common.Hash{2}
+	operations := []rtstorage.IndexOperation{
+		{Extrinsic: 0, Hash: dataHash, Size: 3},
+		// renewal of data not indexed is ignored
+		{Extrinsic: 1, Hash: unknownHash, Renew: true},
+		// size larger than the extrinsic is ignored
+		{Extrinsic: 1, Hash: unknownHash, Size: 3},
+		// extrinsic out of range is ignored
+		{Extrinsic: 2, Hash: unknownHash, Size: 1},
+	}
+
+	err := bs.StoreIndexedTransactions(block, operations)
+	require.NoError(t, err)
+
+	data, err := bs.GetIndexedTransaction(dataHash)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x03, 0x04}, data)
+
+	has, err := bs.HasIndexedTransaction(unknownHash)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	indexedBody, err := bs.GetIndexedBody(block.Header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x02, 0x03, 0x04}}, indexedBody)
+}
+
+func TestBlockState_StoreIndexedTransactions_blockImportedTwice(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	dataHash := common.Hash{1}
+	block := &types.Block{
+		Header: types.Header{Number: 1},
+		Body:   types.Body{{0x01, 0x02}},
+	}
+	operations := []rtstorage.IndexOperation{
+		{Extrinsic: 0, Hash: dataHash, Size: 2},
+	}
+
+	for i := 0; i < 2; i++ {
+		err := bs.StoreIndexedTransactions(block, operations)
+		require.NoError(t, err)
+	}
+
+	record, err := bs.getIndexedTransaction(dataHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), record.References)
+
+	// releasing the block once deletes the indexed data
+	err = bs.releaseIndexedTransactions(block.Header.Hash())
+	require.NoError(t, err)
+
+	has, err := bs.HasIndexedTransaction(dataHash)
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestBlockState_releaseIndexedTransactions(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	dataHash := common.Hash{1}
+	indexingBlock := &types.Block{
+		Header: types.Header{Number: 1},
+		Body:   types.Body{{0x01, 0x02}},
+	}
+	err := bs.StoreIndexedTransactions(indexingBlock, []rtstorage.IndexOperation{
+		{Extrinsic: 0, Hash: dataHash, Size: 2},
+	})
+	require.NoError(t, err)
+
+	renewingBlock := &types.Block{
+		Header: types.Header{Number: 2},
+		Body:   types.Body{{0x03}},
+	}
+	err = bs.StoreIndexedTransactions(renewingBlock, []rtstorage.IndexOperation{
+		{Extrinsic: 0, Hash: dataHash, Renew: true},
+	})
+	require.NoError(t, err)
+
+	indexedBody, err := bs.GetIndexedBody(renewingBlock.Header.Hash())
+	require.NoError(t, err)
+	assert.Empty(t, indexedBody)
+
+	err = bs.releaseIndexedTransactions(indexingBlock.Header.Hash())
+	require.NoError(t, err)
+
+	data, err := bs.GetIndexedTransaction(dataHash)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, data)
+
+	_, err = bs.GetIndexedBody(indexingBlock.Header.Hash())
+	assert.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	err = bs.releaseIndexedTransactions(renewingBlock.Header.Hash())
+	require.NoError(t, err)
+
+	_, err = bs.GetIndexedTransaction(dataHash)
+	assert.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	// releasing a block without index operations is a no-op
+	err = bs.releaseIndexedTransactions(renewingBlock.Header.Hash())
+	require.NoError(t, err)
+}
+
+func TestBlockState_SetFinalisedHash_prunesIndexedTransactions(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	bs.SetTransactionStoragePeriod(1)
+
+	headers, _ := AddBlocksToState(t, bs, 3, false)
+
+	for i, header := range headers {
+		block := &types.Block{
+			Header: *header,
+			Body:   types.Body{{byte(i)}},
+		}
+		err := bs.StoreIndexedTransactions(block, []rtstorage.IndexOperation{
+			{Extrinsic: 0, Hash: common.Hash{byte(i)}, Size: 1},
+		})
+		require.NoError(t, err)
+	}
+
+	err := bs.SetFinalisedHash(headers[1].Hash(), 1, 0)
+	require.NoError(t, err)
+
+	has, err := bs.HasIndexedTransaction(common.Hash{0})
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	has, err = bs.HasIndexedTransaction(common.Hash{1})
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	err = bs.SetFinalisedHash(headers[2].Hash(), 2, 0)
+	require.NoError(t, err)
+
+	has, err = bs.HasIndexedTransaction(common.Hash{1})
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	data, err := bs.GetIndexedTransaction(common.Hash{2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+}
@@ -4,11 +4,13 @@
 package state
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
-	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
@@ -28,8 +30,15 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t *trie
 		return fmt.Errorf("failed to read basepath: %s", err)
 	}
 
+	if !s.isMemDB {
+		err = removeOtherBackendDatabase(filepath.Join(basepath, utils.DefaultDatabaseDir), s.dbBackend)
+		if err != nil {
+			return fmt.Errorf("failed to remove existing database: %w", err)
+		}
+	}
+
 	// initialise database using data directory
-	db, err := utils.SetupDatabase(basepath, s.isMemDB)
+	db, err := utils.SetupDatabaseWithBackend(basepath, s.dbBackend, s.isMemDB)
 	if err != nil {
 		return fmt.Errorf("failed to create database: %s", err)
 	}
@@ -40,12 +49,20 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t *trie
 		return fmt.Errorf("failed to clear database: %s", err)
 	}
 
-	if err = t.WriteDirty(chaindb.NewTable(db, storagePrefix)); err != nil {
+	// store the genesis state with the pruner, so the genesis
+	// trie nodes get reference counted if pruning is enabled.
+	err = newPruner(db, s.PrunerCfg).StoreState(t, header.Hash())
+	if err != nil {
 		return fmt.Errorf("failed to write genesis trie to database: %w", err)
 	}
 
 	s.Base = NewBaseState(db)
 
+	err = s.Base.storePruningConfig(s.PrunerCfg)
+	if err != nil {
+		return fmt.Errorf("failed to store pruning configuration: %w", err)
+	}
+
 	rt, err := s.CreateGenesisRuntime(t, gen)
 	if err != nil {
 		return err
@@ -107,6 +124,25 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t *trie
 	return nil
 }
 
+// removeOtherBackendDatabase removes the database at the given path if
+// it was created with a backend different from the backend given, since
+// the database of the state is cleared when initialising the state.
+func removeOtherBackendDatabase(path string, backend database.Backend) error {
+	existingBackend, err := database.DetectBackend(path)
+	if errors.Is(err, database.ErrDatabaseNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("detecting database backend: %w", err)
+	}
+
+	if existingBackend == backend {
+		return nil
+	}
+
+	logger.Infof("removing existing %s database at %s", existingBackend, path)
+	return os.RemoveAll(path)
+}
+
 func (s *Service) loadBabeConfigurationFromRuntime(r BabeConfigurer) (*types.BabeConfiguration, error) {
 	// load and store initial BABE epoch configuration
 	babeCfg, err := r.BabeConfiguration()
@@ -124,8 +160,10 @@ func (s *Service) loadBabeConfigurationFromRuntime(r BabeConfigurer) (*types.Bab
 
 func loadGrandpaAuthorities(t *trie.Trie) ([]types.GrandpaVoter, error) {
 	key := common.MustHexToBytes(genesis.GrandpaAuthoritiesKeyHex)
-	authsRaw := t.Get(key)
-	if authsRaw == nil {
+	authsRaw, err := t.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("getting grandpa authorities: %w", err)
+	} else if authsRaw == nil {
 		return []types.GrandpaVoter{}, nil
 	}
 
@@ -135,7 +173,7 @@ func loadGrandpaAuthorities(t *trie.Trie) ([]types.GrandpaVoter, error) {
 // storeInitialValues writes initial genesis values to the state database
 func (s *Service) storeInitialValues(data *genesis.Data, t *trie.Trie) error {
 	// write genesis trie to database
-	if err := t.WriteDirty(chaindb.NewTable(s.db, storagePrefix)); err != nil {
+	if err := t.WriteDirty(database.NewTable(s.db, storagePrefix)); err != nil {
 		return fmt.Errorf("failed to write trie to database: %s", err)
 	}
 
@@ -80,6 +80,7 @@ type Runtime interface {
 	Metadata() (metadata []byte, err error)
 	BabeConfigurer
 	GrandpaAuthorities() ([]types.Authority, error)
+	AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error)
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -5,10 +5,12 @@
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/lib/utils"
@@ -16,12 +18,15 @@
 	"github.com/dgraph-io/badger/v2/pb"
 )
 
+// ErrOfflinePruningBackend is returned when the database to prune offline is not a badger database.
+var ErrOfflinePruningBackend = errors.New("offline pruning is only supported for the badger database backend")
+
 // OfflinePruner is a tool to prune the stale state with the help of
 // bloom filter, The workflow of Pruner is very simple:
 // - iterate the storage state, reconstruct the relevant state tries
 // - iterate the database, stream all the targeted keys to new DB
 type OfflinePruner struct {
-	inputDB        *chaindb.BadgerDB
+	inputDB        database.Database
 	storageState   *StorageState
 	blockState     *BlockState
 	filterDatabase *chaindb.BadgerDB
@@ -34,6 +39,13 @@ type OfflinePruner struct {
 // NewOfflinePruner creates an instance of OfflinePruner.
 func NewOfflinePruner(inputDBPath string,
 	retainBlockNum uint32) (pruner *OfflinePruner, err error) {
+	backend, err := database.DetectBackend(inputDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("detecting database backend: %w", err)
+	} else if backend != database.Badger {
+		return nil, fmt.Errorf("%w: %s", ErrOfflinePruningBackend, backend)
+	}
+
 	db, err := utils.LoadChainDB(inputDBPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load DB %w", err)
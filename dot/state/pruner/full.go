@@ -0,0 +1,406 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/internal/trie/codec"
+	"github.com/ChainSafe/gossamer/internal/trie/node"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+var (
+	referenceCountPrefix = []byte("rc") // referenceCountPrefix + node hash -> reference count
+	stateRootPrefix      = []byte("sr") // stateRootPrefix + block hash -> recorded state root hash
+)
+
+var childStorageKeyPrefixNibbles = codec.KeyLEToNibbles(trie.ChildStorageKeyPrefix)
+
+// Database is the database interface used by the full node pruner.
+type Database interface {
+	Get(key []byte) (value []byte, err error)
+	Has(key []byte) (has bool, err error)
+	NewBatch() chaindb.Batch
+}
+
+// Table is a table of the database used by the full node pruner.
+type Table interface {
+	Database
+	// WrapBatch returns a batch writing to the table using the given database batch.
+	WrapBatch(batch chaindb.Batch) chaindb.Batch
+}
+
+// FullNode prunes the trie nodes of discarded states online.
+// It maintains, for each trie node it saw written to the database,
+// the number of references to the node from its parent nodes and
+// from the recorded block states. A node is deleted once its reference
+// count drops to zero, so nodes shared between states of different
+// blocks, for example across forks, are kept as long as one of these
+// states is retained.
+// Trie nodes written to the database before reference counting was
+// enabled are never counted and never deleted.
+type FullNode struct {
+	db             Database
+	nodes          Table
+	references     Table
+	retainedBlocks uint32
+	mutex          sync.Mutex
+}
+
+// NewFullNode creates a full node pruner using the trie nodes table
+// and the reference counts table of the database given. The retained blocks
+// is the number of finalised block states kept before being discarded.
+func NewFullNode(db Database, nodes, references Table, retainedBlocks uint32) *FullNode {
+	return &FullNode{
+		db:             db,
+		nodes:          nodes,
+		references:     references,
+		retainedBlocks: retainedBlocks,
+	}
+}
+
+// RetainedBlocks returns the number of finalised block states retained.
+func (p *FullNode) RetainedBlocks() uint32 {
+	return p.retainedBlocks
+}
+
+// StoreState references the state trie given as the state of the block
+// with the given hash, incrementing the reference count of its root node
+// and of the nodes newly referenced by its dirty nodes, and writes the
+// reference counts and the dirty nodes of the trie in a single batch.
+func (p *FullNode) StoreState(stateTrie *trie.Trie, blockHash common.Hash) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	batch := p.db.NewBatch()
+	err := p.recordState(p.references.WrapBatch(batch), stateTrie, blockHash)
+	if err != nil {
+		batch.Reset()
+		return err
+	}
+
+	err = stateTrie.PutDirty(p.nodes.WrapBatch(batch))
+	if err != nil {
+		batch.Reset()
+		return fmt.Errorf("putting dirty trie nodes: %w", err)
+	}
+
+	return batch.Flush()
+}
+
+// recordState puts in the references batch the reference count changes
+// of the state trie of the block with the given hash.
+// It must be called before the dirty nodes of the trie are written to the database.
+func (p *FullNode) recordState(referencesBatch chaindb.Batch, stateTrie *trie.Trie,
+	blockHash common.Hash) error {
+	root := stateTrie.RootNode()
+	if root == nil {
+		return nil
+	}
+
+	stateKey := concatBytes(stateRootPrefix, blockHash[:])
+	recorded, err := p.references.Has(stateKey)
+	if err != nil {
+		return fmt.Errorf("checking if state is recorded: %w", err)
+	} else if recorded {
+		return nil
+	}
+
+	rootHash, err := root.CalculateRootMerkleValue()
+	if err != nil {
+		return fmt.Errorf("calculating root hash: %w", err)
+	}
+
+	changes := newReferenceChanges(p.nodes, p.references)
+	err = changes.reference(stateTrie, root, true, nil)
+	if err != nil {
+		return fmt.Errorf("referencing trie nodes: %w", err)
+	}
+
+	err = changes.writeCounts(referencesBatch)
+	if err != nil {
+		return fmt.Errorf("writing reference counts: %w", err)
+	}
+
+	err = referencesBatch.Put(stateKey, rootHash)
+	if err != nil {
+		return fmt.Errorf("putting state root hash: %w", err)
+	}
+
+	return nil
+}
+
+// DiscardState drops the reference to the state recorded for the block
+// with the given hash, and deletes from the database the trie nodes
+// no longer referenced. It is a no-op if no state was recorded for the block.
+func (p *FullNode) DiscardState(blockHash common.Hash) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stateKey := concatBytes(stateRootPrefix, blockHash[:])
+	rootHash, err := p.references.Get(stateKey)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting state root hash: %w", err)
+	}
+
+	changes := newReferenceChanges(p.nodes, p.references)
+	err = changes.release(common.BytesToHash(rootHash), nil)
+	if err != nil {
+		return fmt.Errorf("releasing trie nodes: %w", err)
+	}
+
+	// Reference counts are written before deleting nodes, so an
+	// interruption in-between leaves unreferenced nodes in the database
+	// instead of referenced nodes missing from the database.
+	referencesBatch := p.references.NewBatch()
+	err = changes.writeCounts(referencesBatch)
+	if err != nil {
+		referencesBatch.Reset()
+		return fmt.Errorf("writing reference counts: %w", err)
+	}
+
+	err = referencesBatch.Del(stateKey)
+	if err != nil {
+		referencesBatch.Reset()
+		return fmt.Errorf("deleting state root hash: %w", err)
+	}
+
+	err = referencesBatch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing reference counts: %w", err)
+	}
+
+	nodesBatch := p.nodes.NewBatch()
+	for _, nodeHash := range changes.deleted {
+		err = nodesBatch.Del(nodeHash[:])
+		if err != nil {
+			nodesBatch.Reset()
+			return fmt.Errorf("deleting node with hash %s: %w", nodeHash, err)
+		}
+	}
+
+	return nodesBatch.Flush()
+}
+
+// referenceChanges holds the reference count changes of a single
+// record or discard operation, before they are written to the database.
+type referenceChanges struct {
+	nodes      Database
+	references Database
+	counts     map[common.Hash]uint32
+	deleted    []common.Hash
+}
+
+func newReferenceChanges(nodes, references Database) *referenceChanges {
+	return &referenceChanges{
+		nodes:      nodes,
+		references: references,
+		counts:     make(map[common.Hash]uint32),
+	}
+}
+
+func (c *referenceChanges) getCount(nodeHash common.Hash) (count uint32, err error) {
+	count, ok := c.counts[nodeHash]
+	if ok {
+		return count, nil
+	}
+
+	encoded, err := c.references.Get(concatBytes(referenceCountPrefix, nodeHash[:]))
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("getting reference count of node with hash %s: %w", nodeHash, err)
+	}
+
+	return binary.LittleEndian.Uint32(encoded), nil
+}
+
+func (c *referenceChanges) writeCounts(batch chaindb.Batch) (err error) {
+	for nodeHash, count := range c.counts {
+		key := concatBytes(referenceCountPrefix, nodeHash[:])
+		if count == 0 {
+			err = batch.Del(key)
+		} else {
+			encoded := make([]byte, 4)
+			binary.LittleEndian.PutUint32(encoded, count)
+			err = batch.Put(key, encoded)
+		}
+		if err != nil {
+			return fmt.Errorf("writing reference count of node with hash %s: %w", nodeHash, err)
+		}
+	}
+	return nil
+}
+
+// reference increments the reference count of the node given. If the node
+// was not referenced before, it also references its children and the root
+// of the child trie it eventually stores, since the node is about to be
+// written to the database. The key prefix is the key in nibbles of the
+// node excluding its partial key.
+func (c *referenceChanges) reference(stateTrie *trie.Trie, n *node.Node,
+	isRoot bool, keyPrefix []byte) (err error) {
+	var merkleValue []byte
+	if isRoot {
+		merkleValue, err = n.CalculateRootMerkleValue()
+	} else {
+		merkleValue, err = n.CalculateMerkleValue()
+	}
+	if err != nil {
+		return fmt.Errorf("calculating Merkle value: %w", err)
+	}
+
+	if len(merkleValue) < common.HashLength {
+		// Inlined node encoded in its parent node encoding,
+		// so it is not written to the database and all its
+		// descendants are inlined as well.
+		return nil
+	}
+
+	nodeHash := common.BytesToHash(merkleValue)
+	count, err := c.getCount(nodeHash)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if !n.Dirty {
+			// Node already in the database but written
+			// before reference counting was enabled.
+			return nil
+		}
+
+		inDatabase, err := c.nodes.Has(nodeHash[:])
+		if err != nil {
+			return fmt.Errorf("checking if node with hash %s is in database: %w", nodeHash, err)
+		} else if inDatabase {
+			return nil
+		}
+	}
+
+	c.counts[nodeHash] = count + 1
+	if count > 0 {
+		// The descendants are already referenced by the
+		// node encoding already in the database.
+		return nil
+	}
+
+	fullKey := concatBytes(keyPrefix, n.PartialKey)
+	keyToChild, isChildTrieRoot := getKeyToChild(fullKey, n.StorageValue)
+	if isChildTrieRoot {
+		childTrie, err := stateTrie.GetChild(keyToChild)
+		if err != nil {
+			return fmt.Errorf("getting child trie: %w", err)
+		}
+
+		if childTrie != nil && childTrie.RootNode() != nil {
+			err = c.reference(childTrie, childTrie.RootNode(), true, nil)
+			if err != nil {
+				// Note: do not wrap error since this is recursive.
+				return err
+			}
+		}
+	}
+
+	for i, child := range n.Children {
+		if child == nil {
+			continue
+		}
+
+		err = c.reference(stateTrie, child, false, concatBytes(fullKey, []byte{byte(i)}))
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return err
+		}
+	}
+
+	return nil
+}
+
+// release decrements the reference count of the node with the given hash.
+// If the node is no longer referenced, it is marked for deletion and its
+// children and the root of the child trie it eventually stores are released
+// as well. The key prefix is the key in nibbles of the node excluding its
+// partial key.
+func (c *referenceChanges) release(nodeHash common.Hash, keyPrefix []byte) (err error) {
+	count, err := c.getCount(nodeHash)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		// Node written before reference counting was enabled.
+		return nil
+	}
+
+	count--
+	c.counts[nodeHash] = count
+	if count > 0 {
+		return nil
+	}
+
+	encoding, err := c.nodes.Get(nodeHash[:])
+	if err != nil {
+		return fmt.Errorf("getting node with hash %s from database: %w", nodeHash, err)
+	}
+	c.deleted = append(c.deleted, nodeHash)
+
+	decoded, err := node.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return fmt.Errorf("decoding node with hash %s: %w", nodeHash, err)
+	}
+
+	fullKey := concatBytes(keyPrefix, decoded.PartialKey)
+	_, isChildTrieRoot := getKeyToChild(fullKey, decoded.StorageValue)
+	if isChildTrieRoot {
+		err = c.release(common.BytesToHash(decoded.StorageValue), nil)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return err
+		}
+	}
+
+	for i, child := range decoded.Children {
+		if child == nil || len(child.MerkleValue) != common.HashLength {
+			// Inlined children are encoded in the node encoding.
+			continue
+		}
+
+		err = c.release(common.BytesToHash(child.MerkleValue), concatBytes(fullKey, []byte{byte(i)}))
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getKeyToChild returns the key to the child trie and true if the node
+// with the full key in nibbles and storage value given stores the root
+// hash of a child trie.
+func getKeyToChild(fullKey, storageValue []byte) (keyToChild []byte, ok bool) {
+	if len(storageValue) != common.HashLength ||
+		len(fullKey)%2 != 0 ||
+		!bytes.HasPrefix(fullKey, childStorageKeyPrefixNibbles) {
+		return nil, false
+	}
+
+	keyToChild = codec.NibblesToKeyLE(fullKey[len(childStorageKeyPrefixNibbles):])
+	return keyToChild, true
+}
+
+func concatBytes(a, b []byte) (concatenated []byte) {
+	concatenated = make([]byte, 0, len(a)+len(b))
+	concatenated = append(concatenated, a...)
+	return append(concatenated, b...)
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+)
+
+func newTestFullNode(t *testing.T) (pruner *FullNode, nodes *database.Table) {
+	t.Helper()
+
+	db, err := database.NewBadger("", true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+
+	nodes = database.NewTable(db, "storage")
+	references := database.NewTable(db, "noderefs")
+	return NewFullNode(db, nodes, references, 2), nodes
+}
+
+func newTestTrie(t *testing.T, size int) *trie.Trie {
+	t.Helper()
+
+	stateTrie := trie.NewEmptyTrie()
+	for i := 0; i < size; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		value := bytes.Repeat([]byte{byte(i)}, 40)
+		stateTrie.Put(key, value)
+	}
+	return stateTrie
+}
+
+// storeState stores the state trie given as it is done when storing
+// the state of a block, and returns the hashes of the nodes written
+// to the database.
+func storeState(t *testing.T, pruner *FullNode,
+	stateTrie *trie.Trie, blockHash common.Hash) (written map[common.Hash]struct{}) {
+	t.Helper()
+
+	written, _, err := stateTrie.GetChangedNodeHashes()
+	require.NoError(t, err)
+
+	err = pruner.StoreState(stateTrie, blockHash)
+	require.NoError(t, err)
+
+	return written
+}
+
+func loadTrie(nodes *database.Table, rootHash common.Hash) (err error) {
+	return trie.NewEmptyTrie().Load(nodes, rootHash)
+}
+
+func Test_Mode_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Archive.IsValid())
+	assert.True(t, Full.IsValid())
+	assert.False(t, Mode("other").IsValid())
+}
+
+func Test_Config_Equal(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		a, b  Config
+		equal bool
+	}{
+		"archive": {
+			a:     Config{Mode: Archive, RetainedBlocks: 1},
+			b:     Config{Mode: Archive, RetainedBlocks: 2},
+			equal: true,
+		},
+		"empty mode is archive": {
+			a:     Config{},
+			b:     Config{Mode: Archive},
+			equal: true,
+		},
+		"different modes": {
+			a: Config{Mode: Archive},
+			b: Config{Mode: Full},
+		},
+		"full": {
+			a:     Config{Mode: Full, RetainedBlocks: 512},
+			b:     Config{Mode: Full, RetainedBlocks: 512},
+			equal: true,
+		},
+		"full with different retained blocks": {
+			a: Config{Mode: Full, RetainedBlocks: 512},
+			b: Config{Mode: Full, RetainedBlocks: 256},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.equal, testCase.a.Equal(testCase.b))
+			assert.Equal(t, testCase.equal, testCase.b.Equal(testCase.a))
+		})
+	}
+}
+
+func Test_Config_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "pruning mode archive", Config{Mode: Archive, RetainedBlocks: 1}.String())
+	assert.Equal(t, "pruning mode full retaining 512 blocks", Config{Mode: Full, RetainedBlocks: 512}.String())
+}
+
+func Test_FullNode_forks(t *testing.T) {
+	t.Parallel()
+
+	pruner, nodes := newTestFullNode(t)
+
+	parentTrie := newTestTrie(t, 100)
+	parentRoot := parentTrie.MustHash()
+	written := storeState(t, pruner, parentTrie, common.Hash{1})
+
+	// Two forks modifying a single key each, sharing most nodes.
+	forkA := parentTrie.Snapshot()
+	forkA.Put([]byte("key1"), bytes.Repeat([]byte{0xa}, 40))
+	forkARoot := forkA.MustHash()
+	maps.Copy(written, storeState(t, pruner, forkA, common.Hash{2}))
+
+	forkB := parentTrie.Snapshot()
+	forkB.Put([]byte("key50"), bytes.Repeat([]byte{0xb}, 40))
+	forkBRoot := forkB.MustHash()
+	maps.Copy(written, storeState(t, pruner, forkB, common.Hash{3}))
+
+	err := pruner.DiscardState(common.Hash{1})
+	require.NoError(t, err)
+	err = loadTrie(nodes, parentRoot)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	err = pruner.DiscardState(common.Hash{2})
+	require.NoError(t, err)
+	err = loadTrie(nodes, forkARoot)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	loaded := trie.NewEmptyTrie()
+	err = loaded.Load(nodes, forkBRoot)
+	require.NoError(t, err)
+	forkBEntries, err := forkB.Entries()
+	require.NoError(t, err)
+	loadedEntries, err := loaded.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, forkBEntries, loadedEntries)
+
+	err = pruner.DiscardState(common.Hash{3})
+	require.NoError(t, err)
+
+	for nodeHash := range written {
+		has, err := nodes.Has(nodeHash[:])
+		require.NoError(t, err)
+		assert.Falsef(t, has, "node with hash %s is still in the database", nodeHash)
+	}
+}
+
+func Test_FullNode_RecordState_sameState(t *testing.T) {
+	t.Parallel()
+
+	pruner, nodes := newTestFullNode(t)
+
+	stateTrie := newTestTrie(t, 10)
+	rootHash := stateTrie.MustHash()
+	storeState(t, pruner, stateTrie, common.Hash{1})
+	// Recording the same block again is a no-op.
+	storeState(t, pruner, stateTrie, common.Hash{1})
+	// A different block with the same state root.
+	storeState(t, pruner, stateTrie, common.Hash{2})
+
+	err := pruner.DiscardState(common.Hash{1})
+	require.NoError(t, err)
+	err = loadTrie(nodes, rootHash)
+	require.NoError(t, err)
+
+	err = pruner.DiscardState(common.Hash{2})
+	require.NoError(t, err)
+	err = loadTrie(nodes, rootHash)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+
+	// Discarding an unknown state is a no-op.
+	err = pruner.DiscardState(common.Hash{3})
+	require.NoError(t, err)
+}
+
+func Test_FullNode_uncountedNodes(t *testing.T) {
+	t.Parallel()
+
+	pruner, nodes := newTestFullNode(t)
+
+	legacyTrie := newTestTrie(t, 10)
+	legacyRoot := legacyTrie.MustHash()
+	err := legacyTrie.WriteDirty(nodes)
+	require.NoError(t, err)
+
+	stateTrie := legacyTrie.Snapshot()
+	stateTrie.Put([]byte("key1"), bytes.Repeat([]byte{0xa}, 40))
+	storeState(t, pruner, stateTrie, common.Hash{1})
+
+	err = pruner.DiscardState(common.Hash{1})
+	require.NoError(t, err)
+
+	err = loadTrie(nodes, stateTrie.MustHash())
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+	err = loadTrie(nodes, legacyRoot)
+	require.NoError(t, err)
+}
+
+func Test_FullNode_childTrie(t *testing.T) {
+	t.Parallel()
+
+	pruner, nodes := newTestFullNode(t)
+
+	stateTrie := newTestTrie(t, 10)
+	childTrie := newTestTrie(t, 20)
+	childRoot := childTrie.MustHash()
+	err := stateTrie.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+	storeState(t, pruner, stateTrie, common.Hash{1})
+
+	err = loadTrie(nodes, childRoot)
+	require.NoError(t, err)
+
+	err = pruner.DiscardState(common.Hash{1})
+	require.NoError(t, err)
+
+	err = loadTrie(nodes, childRoot)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+}
@@ -4,12 +4,17 @@
 package pruner
 
 import (
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
 const (
 	// Archive pruner mode.
 	Archive = Mode("archive")
+	// Full pruner mode.
+	Full = Mode("full")
 )
 
 // Mode online pruning mode of historical state tries
@@ -18,7 +23,7 @@
 // IsValid checks whether the pruning mode is valid
 func (p Mode) IsValid() bool {
 	switch p {
-	case Archive:
+	case Archive, Full:
 		return true
 	default:
 		return false
@@ -31,17 +36,55 @@ type Config struct {
 	RetainedBlocks uint32
 }
 
+// Equal returns true if the configurations prune the same states, where an
+// empty mode is the archive mode and the retained blocks are ignored in archive mode.
+func (c Config) Equal(other Config) bool {
+	if (c.Mode == Full) != (other.Mode == Full) {
+		return false
+	}
+	return c.Mode != Full || c.RetainedBlocks == other.RetainedBlocks
+}
+
+// String returns the pruning mode and the retained blocks in full mode.
+func (c Config) String() string {
+	if c.Mode != Full {
+		return fmt.Sprintf("pruning mode %s", c.Mode)
+	}
+	return fmt.Sprintf("pruning mode %s retaining %d blocks", c.Mode, c.RetainedBlocks)
+}
+
 // Pruner is implemented by FullNode and ArchiveNode.
 type Pruner interface {
-	StoreJournalRecord(deletedNodeHashes, insertedNodeHashes map[common.Hash]struct{},
-		blockHash common.Hash, blockNum int64) error
+	// StoreState writes the dirty nodes of the state trie of the block with the given hash
+	// to the database, together with the records needed to prune the state later.
+	StoreState(stateTrie *trie.Trie, blockHash common.Hash) error
+	DiscardState(blockHash common.Hash) error
+	RetainedBlocks() uint32
 }
 
-// ArchiveNode is a no-op since we don't prune nodes in archive mode.
-type ArchiveNode struct{}
+// ArchiveNode does not prune nodes, since all states are retained in archive mode.
+type ArchiveNode struct {
+	nodes trie.NewBatcher
+}
+
+// NewArchiveNode creates an archive node pruner writing trie nodes to the given database.
+func NewArchiveNode(nodes trie.NewBatcher) *ArchiveNode {
+	return &ArchiveNode{
+		nodes: nodes,
+	}
+}
 
-// StoreJournalRecord for archive node doesn't do anything.
-func (*ArchiveNode) StoreJournalRecord(_, _ map[common.Hash]struct{},
-	_ common.Hash, _ int64) error {
+// StoreState for archive node only writes the dirty nodes of the state trie.
+func (a *ArchiveNode) StoreState(stateTrie *trie.Trie, _ common.Hash) error {
+	return stateTrie.WriteDirty(a.nodes)
+}
+
+// DiscardState for archive node doesn't do anything.
+func (*ArchiveNode) DiscardState(_ common.Hash) error {
 	return nil
 }
+
+// RetainedBlocks returns zero since all states are retained in archive mode.
+func (*ArchiveNode) RetainedBlocks() uint32 {
+	return 0
+}
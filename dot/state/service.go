@@ -4,11 +4,13 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
@@ -22,11 +24,16 @@
 	log.AddContext("pkg", "state"),
 )
 
+// ErrPruningConfigMismatch is returned when the pruning configuration given
+// differs from the pruning configuration the database was initialised with.
+var ErrPruningConfigMismatch = errors.New("pruning configuration does not match the database pruning configuration")
+
 // Service is the struct that holds storage, block and network states
 type Service struct {
 	dbPath      string
 	logLvl      log.Level
-	db          *chaindb.BadgerDB
+	db          database.Database
+	dbBackend   database.Backend
 	isMemDB     bool // set to true if using an in-memory database; only used for testing.
 	Base        *BaseState
 	Storage     *StorageState
@@ -39,6 +46,10 @@ type Service struct {
 	PrunerCfg pruner.Config
 	Telemetry Telemetry
 
+	transactionStoragePeriod uint
+	trieCacheSize            uint
+	blocksPruning            BlocksPruning
+
 	// Below are for testing only.
 	BabeThresholdNumerator   uint64
 	BabeThresholdDenominator uint64
@@ -46,27 +57,52 @@ type Service struct {
 
 // Config is the default configuration used by state service.
 type Config struct {
-	Path      string
-	LogLevel  log.Level
-	PrunerCfg pruner.Config
-	Telemetry Telemetry
-	Metrics   metrics.IntervalConfig
+	Path     string
+	LogLevel log.Level
+	// DatabaseBackend is the backend of the database created when
+	// initialising the state. It defaults to badger if left empty.
+	// The database is opened with the backend it was created with
+	// when starting the state.
+	DatabaseBackend database.Backend
+	PrunerCfg       pruner.Config
+	Telemetry       Telemetry
+	Metrics         metrics.IntervalConfig
+	// TransactionStoragePeriod is the number of finalised blocks for which
+	// indexed transaction data is retained. Zero retains it forever.
+	TransactionStoragePeriod uint
+	// TrieCacheSize is the maximum number of trie nodes kept in the
+	// node cache shared by state tries loaded lazily from the database.
+	// Zero disables lazy loading and state tries are fully loaded in memory.
+	TrieCacheSize uint
+	// BlocksPruning is the pruning of the bodies, receipts, message
+	// queues and justifications of the finalised and pruned blocks.
+	BlocksPruning BlocksPruning
 }
 
 // NewService create a new instance of Service
 func NewService(config Config) *Service {
 	logger.Patch(log.SetLevel(config.LogLevel))
 
+	dbBackend := config.DatabaseBackend
+	if dbBackend == "" {
+		dbBackend = database.Badger
+	}
+
 	return &Service{
 		dbPath:    config.Path,
 		logLvl:    config.LogLevel,
 		db:        nil,
+		dbBackend: dbBackend,
 		isMemDB:   false,
 		Storage:   nil,
 		Block:     nil,
 		closeCh:   make(chan interface{}),
 		PrunerCfg: config.PrunerCfg,
 		Telemetry: config.Telemetry,
+
+		transactionStoragePeriod: config.TransactionStoragePeriod,
+		trieCacheSize:            config.TrieCacheSize,
+		blocksPruning:            config.BlocksPruning,
 	}
 }
 
@@ -78,7 +114,7 @@ func (s *Service) UseMemDB() {
 }
 
 // DB returns the Service's database
-func (s *Service) DB() *chaindb.BadgerDB {
+func (s *Service) DB() database.Database {
 	return s.db
 }
 
@@ -112,13 +148,9 @@ func (s *Service) Start() (err error) {
 		return nil
 	}
 
-	tries := NewTries()
-	tries.SetEmptyTrie()
-
-	// create block state
-	s.Block, err = NewBlockState(s.db, tries, s.Telemetry)
+	err = s.createStates()
 	if err != nil {
-		return fmt.Errorf("failed to create block state: %w", err)
+		return err
 	}
 
 	// retrieve latest header
@@ -130,16 +162,63 @@ func (s *Service) Start() (err error) {
 	stateRoot := bestHeader.StateRoot
 	logger.Debugf("start with latest state root: %s", stateRoot)
 
+	// load current storage state trie into memory
+	_, err = s.Storage.LoadFromDB(stateRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load storage trie from database: %w", err)
+	}
+
+	num, _ := s.Block.BestBlockNumber()
+	logger.Infof(
+		"created state service with head %s, highest number %d and genesis hash %s",
+		s.Block.BestBlockHash(), num, s.Block.genesisHash.String())
+
+	return nil
+}
+
+// createStates creates the block, storage, transaction, epoch and grandpa
+// states from the database, without loading any state trie.
+func (s *Service) createStates() (err error) {
+	tries := NewTries()
+	tries.SetEmptyTrie()
+
+	// create block state
+	s.Block, err = NewBlockState(s.db, tries, s.Telemetry)
+	if err != nil {
+		return fmt.Errorf("failed to create block state: %w", err)
+	}
+	s.Block.SetTransactionStoragePeriod(s.transactionStoragePeriod)
+
 	// create storage state
 	s.Storage, err = NewStorageState(s.db, s.Block, tries)
 	if err != nil {
 		return fmt.Errorf("failed to create storage state: %w", err)
 	}
 
-	// load current storage state trie into memory
-	_, err = s.Storage.LoadFromDB(stateRoot)
-	if err != nil {
-		return fmt.Errorf("failed to load storage trie from database: %w", err)
+	// the pruning configuration is chosen when initialising the node,
+	// and the pruning configuration given, if any, must match it.
+	prunerConfig, err := s.Base.loadPruningConfig()
+	switch {
+	case errors.Is(err, chaindb.ErrKeyNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to load pruning configuration: %w", err)
+	case s.PrunerCfg.Mode != "" && !s.PrunerCfg.Equal(prunerConfig):
+		return fmt.Errorf("%w: %s given but database initialised with %s",
+			ErrPruningConfigMismatch, s.PrunerCfg, prunerConfig)
+	default:
+		s.PrunerCfg = prunerConfig
+	}
+
+	statePruner := newPruner(s.db, s.PrunerCfg)
+	s.Block.pruner = statePruner
+	s.Storage.pruner = statePruner
+
+	if s.trieCacheSize > 0 {
+		nodeCache, err := trie.NewNodeCache(int(s.trieCacheSize))
+		if err != nil {
+			return fmt.Errorf("creating trie node cache: %w", err)
+		}
+		s.Storage.SetNodeCache(nodeCache)
 	}
 
 	// create transaction queue
@@ -152,14 +231,22 @@ func (s *Service) Start() (err error) {
 	}
 
 	s.Grandpa = NewGrandpaState(s.db, s.Block, s.Telemetry)
-	num, _ := s.Block.BestBlockNumber()
-	logger.Infof(
-		"created state service with head %s, highest number %d and genesis hash %s",
-		s.Block.BestBlockHash(), num, s.Block.genesisHash.String())
-
+	s.Block.SetBlocksPruning(s.blocksPruning, s.Grandpa)
 	return nil
 }
 
+// newPruner creates the state trie pruner for the given pruner configuration.
+func newPruner(db database.Database, config pruner.Config) pruner.Pruner {
+	nodes := database.NewTable(db, storagePrefix)
+	if config.Mode != pruner.Full {
+		return pruner.NewArchiveNode(nodes)
+	}
+
+	return pruner.NewFullNode(db, nodes,
+		database.NewTable(db, nodeReferencesPrefix),
+		config.RetainedBlocks)
+}
+
 // Rewind rewinds the chain to the given block number.
 // If the given number of blocks is greater than the chain height, it will rewind to genesis.
 func (s *Service) Rewind(toBlock uint) error {
@@ -267,11 +354,11 @@ func (s *Service) Import(header *types.Header, t *trie.Trie, firstSlot uint64) e
 	}
 
 	block := &BlockState{
-		db: chaindb.NewTable(s.db, blockPrefix),
+		db: database.NewTable(s.db, blockPrefix),
 	}
 
 	storage := &StorageState{
-		db: chaindb.NewTable(s.db, storagePrefix),
+		db: database.NewTable(s.db, storagePrefix),
 	}
 
 	epoch, err := NewEpochState(s.db, block)
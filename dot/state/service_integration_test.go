@@ -6,19 +6,25 @@
 package state
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/golang/mock/gomock"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,6 +111,40 @@ func TestService_Initialise(t *testing.T) {
 	require.Equal(t, genesisHeaderPtr, head)
 }
 
+func TestService_Initialise_databaseBackend(t *testing.T) {
+	state := newTestService(t)
+
+	genData, genTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+	genTrieCopy := genTrie.DeepCopy()
+
+	err := state.Initialise(&genData, &genesisHeader, &genTrie)
+	require.NoError(t, err)
+
+	databasePath := filepath.Join(state.dbPath, utils.DefaultDatabaseDir)
+	backend, err := database.DetectBackend(databasePath)
+	require.NoError(t, err)
+	require.Equal(t, database.Badger, backend)
+
+	// Re-initialising with another backend replaces the database.
+	state.dbBackend = database.LevelDB
+	err = state.Initialise(&genData, &genesisHeader, genTrieCopy)
+	require.NoError(t, err)
+
+	backend, err = database.DetectBackend(databasePath)
+	require.NoError(t, err)
+	require.Equal(t, database.LevelDB, backend)
+
+	err = state.SetupBase()
+	require.NoError(t, err)
+
+	err = state.Start()
+	require.NoError(t, err)
+
+	head, err := state.Block.BestBlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, genesisHeader.Hash(), head.Hash())
+}
+
 func TestMemDB_Start(t *testing.T) {
 	state := newTestMemDBService(t)
 
@@ -168,8 +208,6 @@ func TestService_BlockTree(t *testing.T) {
 }
 
 func TestService_StorageTriePruning(t *testing.T) {
-	t.Skip() // Unskip once https://github.com/ChainSafe/gossamer/pull/2831 is done
-
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
@@ -179,7 +217,7 @@ func TestService_StorageTriePruning(t *testing.T) {
 		Path:     t.TempDir(),
 		LogLevel: log.Info,
 		PrunerCfg: pruner.Config{
-			// Mode:           pruner.Full,
+			Mode:           pruner.Full,
 			RetainedBlocks: uint32(retainBlocks),
 		},
 		Telemetry: telemetryMock,
@@ -207,15 +245,19 @@ func TestService_StorageTriePruning(t *testing.T) {
 		err = serv.Storage.StoreTrie(trieState, &block.Header)
 		require.NoError(t, err)
 
+		err = serv.Block.SetFinalisedHash(block.Header.Hash(), 0, 0)
+		require.NoError(t, err)
+
 		blocks = append(blocks, block)
 		parentHash = block.Header.Hash()
 	}
 
-	time.Sleep(2 * time.Second)
+	_, err = serv.Storage.LoadFromDB(genesisHeader.StateRoot)
+	require.ErrorIs(t, err, chaindb.ErrKeyNotFound)
 
 	for _, b := range blocks {
 		_, err := serv.Storage.LoadFromDB(b.Header.StateRoot)
-		if b.Header.Number >= totalBlock-retainBlocks-1 {
+		if b.Header.Number >= totalBlock-retainBlocks {
 			require.NoError(t, err, fmt.Sprintf("Got error for block %d", b.Header.Number))
 			continue
 		}
@@ -223,6 +265,53 @@ func TestService_StorageTriePruning(t *testing.T) {
 	}
 }
 
+func TestService_Start_pruningConfigMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	config := Config{
+		Path:     t.TempDir(),
+		LogLevel: log.Info,
+		PrunerCfg: pruner.Config{
+			Mode:           pruner.Full,
+			RetainedBlocks: 512,
+		},
+		Telemetry: telemetryMock,
+	}
+	serv := NewService(config)
+
+	genData, genTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+	err := serv.Initialise(&genData, &genesisHeader, &genTrie)
+	require.NoError(t, err)
+
+	config.PrunerCfg = pruner.Config{Mode: pruner.Archive}
+	serv = NewService(config)
+	err = serv.SetupBase()
+	require.NoError(t, err)
+
+	err = serv.Start()
+	assert.ErrorIs(t, err, ErrPruningConfigMismatch)
+	assert.EqualError(t, err, "pruning configuration does not match the database pruning configuration: "+
+		"pruning mode archive given but database initialised with pruning mode full retaining 512 blocks")
+
+	err = serv.db.Close()
+	require.NoError(t, err)
+
+	// the pruning configuration of the database is used if none is given
+	config.PrunerCfg = pruner.Config{}
+	serv = NewService(config)
+	err = serv.SetupBase()
+	require.NoError(t, err)
+
+	err = serv.Start()
+	require.NoError(t, err)
+	assert.Equal(t, pruner.Config{Mode: pruner.Full, RetainedBlocks: 512}, serv.PrunerCfg)
+
+	err = serv.Stop()
+	require.NoError(t, err)
+}
+
 func TestService_PruneStorage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
@@ -362,6 +451,232 @@ func TestService_Rewind(t *testing.T) {
 	require.Equal(t, chaindb.ErrKeyNotFound, err)
 }
 
+func TestService_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		corrupt              func(t *testing.T, serv *Service, headers []*types.Header)
+		issues               []error
+		headersChecked       uint
+		lastConsistentNumber uint
+		repairable           bool
+		repairErrWrapped     error
+	}{
+		"consistent database": {
+			corrupt:              func(*testing.T, *Service, []*types.Header) {},
+			headersChecked:       14,
+			lastConsistentNumber: 13,
+			repairErrWrapped:     ErrNotRepairable,
+		},
+		"grandpa metadata only": {
+			corrupt: func(t *testing.T, serv *Service, headers []*types.Header) {
+				grandpaTable := database.NewTable(serv.db, grandpaPrefix)
+				err := grandpaTable.Del(setIDChangeKey(0))
+				require.NoError(t, err)
+			},
+			issues:               []error{ErrGrandpaInconsistent},
+			headersChecked:       14,
+			lastConsistentNumber: 13,
+			repairErrWrapped:     ErrNotRepairable,
+		},
+		"missing header": {
+			corrupt: func(t *testing.T, serv *Service, headers []*types.Header) {
+				err := serv.Block.db.Del(headerKey(headers[7].Hash()))
+				require.NoError(t, err)
+			},
+			issues:               []error{ErrHeaderNotFound},
+			headersChecked:       8,
+			lastConsistentNumber: 7,
+			repairable:           true,
+		},
+		"incomplete state": {
+			corrupt: func(t *testing.T, serv *Service, headers []*types.Header) {
+				storageTable := database.NewTable(serv.db, storagePrefix)
+				err := storageTable.Del(headers[len(headers)-1].StateRoot.ToBytes())
+				require.NoError(t, err)
+			},
+			issues:               []error{ErrStateIncomplete, ErrStateIncomplete},
+			headersChecked:       14,
+			lastConsistentNumber: 12,
+			repairable:           true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			serv := newTestMemDBService(t)
+			genData, genTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+			err := serv.Initialise(&genData, &genesisHeader, &genTrie)
+			require.NoError(t, err)
+			err = serv.Start()
+			require.NoError(t, err)
+
+			headers, _ := AddBlocksToState(t, serv.Block, 12, false)
+
+			// the last block uses the genesis state
+			block := &types.Block{
+				Header: types.Header{
+					ParentHash: headers[len(headers)-1].Hash(),
+					Number:     13,
+					StateRoot:  genesisHeader.StateRoot,
+					Digest:     headers[len(headers)-1].Digest,
+				},
+				Body: types.Body{},
+			}
+			err = serv.Block.AddBlock(block)
+			require.NoError(t, err)
+			headers = append(headers, &block.Header)
+
+			err = serv.Block.SetFinalisedHash(block.Header.Hash(), 0, 0)
+			require.NoError(t, err)
+
+			testCase.corrupt(t, serv, headers)
+
+			report, err := serv.Check()
+			require.NoError(t, err)
+
+			require.Len(t, report.Issues, len(testCase.issues))
+			for i, issue := range testCase.issues {
+				require.ErrorIs(t, report.Issues[i], issue)
+			}
+			require.Equal(t, uint(13), report.FinalisedHeader.Number)
+			require.Equal(t, testCase.headersChecked, report.HeadersChecked)
+			require.NotNil(t, report.LastConsistentHeader)
+			require.Equal(t, testCase.lastConsistentNumber, report.LastConsistentHeader.Number)
+			require.Equal(t, testCase.repairable, report.Repairable)
+
+			err = serv.Repair(report)
+			require.ErrorIs(t, err, testCase.repairErrWrapped)
+			if testCase.repairErrWrapped != nil {
+				return
+			}
+
+			number, err := serv.Block.BestBlockNumber()
+			require.NoError(t, err)
+			require.Equal(t, testCase.lastConsistentNumber, number)
+		})
+	}
+}
+
+func TestService_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	source := newTestMemDBService(t)
+	genData, genTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+	err := source.Initialise(&genData, &genesisHeader, &genTrie)
+	require.NoError(t, err)
+	err = source.Start()
+	require.NoError(t, err)
+
+	headers, _ := AddBlocksToState(t, source.Block, 12, false)
+
+	// the snapshot block uses the genesis state
+	block := &types.Block{
+		Header: types.Header{
+			ParentHash: headers[len(headers)-1].Hash(),
+			Number:     13,
+			StateRoot:  genesisHeader.StateRoot,
+			Digest:     headers[len(headers)-1].Digest,
+		},
+		Body: types.Body{{1, 2}},
+	}
+	err = source.Block.AddBlock(block)
+	require.NoError(t, err)
+	hash := block.Header.Hash()
+
+	const round = 5
+	justification := []byte{round, 0, 0, 0, 0, 0, 0, 0, 9}
+	err = source.Block.SetJustification(hash, justification)
+	require.NoError(t, err)
+	err = source.Block.SetFinalisedHash(hash, round, 0)
+	require.NoError(t, err)
+
+	snapshot := bytes.NewBuffer(nil)
+	err = source.ExportSnapshot(hash, snapshot)
+	require.NoError(t, err)
+
+	destination := newTestMemDBService(t)
+	err = destination.Initialise(&genData, &genesisHeader, &genTrie)
+	require.NoError(t, err)
+
+	expectedAuthorities, err := source.Grandpa.GetAuthorities(0)
+	require.NoError(t, err)
+
+	errTest := errors.New("test error")
+	failingVerifier := func([]byte, common.Hash, uint, uint64, []types.GrandpaVoter) error {
+		return errTest
+	}
+	_, err = destination.ImportSnapshot(bytes.NewReader(snapshot.Bytes()), failingVerifier)
+	require.ErrorIs(t, err, ErrSnapshotNotValid)
+	require.ErrorContains(t, err, errTest.Error())
+
+	verifier := func(verifiedJustification []byte, verifiedHash common.Hash, number uint,
+		setID uint64, authorities []types.GrandpaVoter) error {
+		require.Equal(t, justification, verifiedJustification)
+		require.Equal(t, hash, verifiedHash)
+		require.Equal(t, uint(13), number)
+		require.Equal(t, uint64(0), setID)
+		require.Equal(t, expectedAuthorities, authorities)
+		return nil
+	}
+	header, err := destination.ImportSnapshot(snapshot, verifier)
+	require.NoError(t, err)
+	require.Equal(t, hash, header.Hash())
+
+	// blocks before the snapshot block are not in the database
+	report, err := destination.Check()
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+	require.Equal(t, uint(1), report.HeadersChecked)
+
+	err = destination.Start()
+	require.NoError(t, err)
+
+	finalisedHash, err := destination.Block.GetHighestFinalisedHash()
+	require.NoError(t, err)
+	require.Equal(t, hash, finalisedHash)
+
+	highestRound, highestSetID, err := destination.Block.GetHighestRoundAndSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(round), highestRound)
+	require.Equal(t, uint64(0), highestSetID)
+
+	importedBlock, err := destination.Block.GetBlockByNumber(13)
+	require.NoError(t, err)
+	require.Equal(t, block.Body, importedBlock.Body)
+
+	importedJustification, err := destination.Block.GetJustification(hash)
+	require.NoError(t, err)
+	require.Equal(t, justification, importedJustification)
+
+	root, err := destination.Storage.StorageRoot()
+	require.NoError(t, err)
+	require.Equal(t, genesisHeader.StateRoot, root)
+
+	epoch, err := destination.Epoch.GetEpochForBlock(header)
+	require.NoError(t, err)
+	_, err = destination.Epoch.GetEpochData(epoch, nil)
+	require.NoError(t, err)
+	_, err = destination.Epoch.GetConfigData(epoch, nil)
+	require.NoError(t, err)
+
+	authorities, err := destination.Grandpa.GetAuthorities(0)
+	require.NoError(t, err)
+	require.Equal(t, expectedAuthorities, authorities)
+
+	snapshot.Reset()
+	err = source.ExportSnapshot(hash, snapshot)
+	require.NoError(t, err)
+	_, err = destination.ImportSnapshot(snapshot, verifier)
+	require.ErrorIs(t, err, ErrSnapshotBlockNotAhead)
+
+	_, err = destination.ImportSnapshot(bytes.NewBufferString("not a snapshot"), verifier)
+	require.ErrorIs(t, err, ErrSnapshotNotValid)
+}
+
 func TestService_Import(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
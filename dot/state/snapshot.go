@@ -0,0 +1,677 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// snapshotMagic prefixes the uncompressed content of a snapshot file,
+// followed by the snapshot format version.
+var snapshotMagic = []byte("gossamer-snapshot")
+
+const snapshotVersion byte = 1
+
+// maxImportBatchSize is the batched value size above which
+// the imported trie nodes are flushed to the database.
+const maxImportBatchSize = 64 * 1024 * 1024
+
+// maxSnapshotRecordSize is the maximum size of a snapshot record,
+// to bound the memory allocated when reading an invalid snapshot.
+const maxSnapshotRecordSize = 64 * 1024 * 1024
+
+// snapshotBlockNumberKey is the key of the number of the block of the
+// last snapshot imported, below which blocks are not in the database.
+var snapshotBlockNumberKey = []byte("snapshot_block_number")
+
+var (
+	ErrSnapshotNotValid           = errors.New("snapshot is not valid")
+	ErrSnapshotBlockNotFinalised  = errors.New("snapshot block is not finalised")
+	ErrSnapshotGenesisBlock       = errors.New("snapshot of the genesis block is not supported")
+	ErrSnapshotGenesisHashInvalid = errors.New("snapshot genesis hash does not match the node genesis hash")
+	ErrSnapshotBlockNotAhead      = errors.New("snapshot block is not after the highest finalised block")
+)
+
+// JustificationVerifier verifies the GRANDPA justification given finalises
+// the block with the hash and number given, and is signed by the authorities
+// given of the set with the id given.
+type JustificationVerifier func(justification []byte, hash common.Hash, number uint,
+	setID uint64, authorities []types.GrandpaVoter) error
+
+// snapshotMetadata is the block, BABE and GRANDPA metadata of a snapshot.
+type snapshotMetadata struct {
+	GenesisHash   common.Hash
+	Header        types.Header
+	Body          types.Body
+	Justification []byte
+	FirstSlot     uint64
+	// Epoch is the BABE epoch of the snapshot block.
+	Epoch      uint64
+	EpochData  []snapshotEpochData
+	ConfigData []snapshotConfigData
+	// GrandpaRound is the round of the snapshot block justification.
+	GrandpaRound uint64
+	// GrandpaSetID is the authority set ID of the snapshot block.
+	GrandpaSetID uint64
+	GrandpaSets  []snapshotGrandpaSet
+}
+
+type snapshotEpochData struct {
+	Epoch uint64
+	Data  types.EpochDataRaw
+}
+
+type snapshotConfigData struct {
+	Epoch uint64
+	Data  types.ConfigData
+}
+
+type snapshotGrandpaSet struct {
+	SetID uint64
+	// ChangeNumber is the number of the last block of the previous set.
+	ChangeNumber uint
+	Authorities  []types.GrandpaAuthoritiesRaw
+}
+
+// ExportSnapshot writes a snapshot of the finalised block with the hash given
+// to the writer given. The snapshot is gzip compressed and contains the block
+// header, body and justification, the BABE epoch and configuration data of the
+// block epoch and of the next epoch if known, the GRANDPA authority sets up to
+// the set of the block, and all the nodes of the block state trie.
+// The service must be started.
+func (s *Service) ExportSnapshot(blockHash common.Hash, w io.Writer) (err error) {
+	metadata, err := s.snapshotMetadata(blockHash)
+	if err != nil {
+		return err
+	}
+
+	stateTrie := trie.NewEmptyTrie()
+	err = stateTrie.Load(s.Storage.db, metadata.Header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("loading state trie with root %s: %w", metadata.Header.StateRoot, err)
+	}
+
+	nodeHashes := make(map[common.Hash]struct{})
+	trie.PopulateNodeHashes(stateTrie.RootNode(), nodeHashes)
+	childTrieKeys, err := stateTrie.GetKeysWithPrefix(trie.ChildStorageKeyPrefix)
+	if err != nil {
+		return fmt.Errorf("getting child trie keys: %w", err)
+	}
+
+	for _, key := range childTrieKeys {
+		childTrie, err := stateTrie.GetChild(key[len(trie.ChildStorageKeyPrefix):])
+		if err != nil {
+			return fmt.Errorf("getting child trie at key 0x%x: %w", key, err)
+		}
+		trie.PopulateNodeHashes(childTrie.RootNode(), nodeHashes)
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	writer := bufio.NewWriter(gzipWriter)
+
+	_, err = writer.Write(append(snapshotMagic, snapshotVersion))
+	if err != nil {
+		return fmt.Errorf("writing snapshot header: %w", err)
+	}
+
+	encodedMetadata, err := scale.Marshal(*metadata)
+	if err != nil {
+		return fmt.Errorf("scale encoding snapshot metadata: %w", err)
+	}
+
+	err = writeSnapshotRecord(writer, encodedMetadata)
+	if err != nil {
+		return fmt.Errorf("writing snapshot metadata: %w", err)
+	}
+
+	for nodeHash := range nodeHashes {
+		encodedNode, err := s.Storage.db.Get(nodeHash[:])
+		if err != nil {
+			return fmt.Errorf("getting node with hash %s: %w", nodeHash, err)
+		}
+
+		err = writeSnapshotRecord(writer, encodedNode)
+		if err != nil {
+			return fmt.Errorf("writing node with hash %s: %w", nodeHash, err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing snapshot: %w", err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return fmt.Errorf("closing gzip writer: %w", err)
+	}
+
+	logger.Infof("exported snapshot of block number %d with hash %s and %d trie nodes",
+		metadata.Header.Number, blockHash, len(nodeHashes))
+	return nil
+}
+
+// snapshotMetadata returns the snapshot metadata of the
+// finalised block with the hash given.
+func (s *Service) snapshotMetadata(blockHash common.Hash) (metadata *snapshotMetadata, err error) {
+	header, err := s.Block.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	if header.Number == 0 {
+		return nil, ErrSnapshotGenesisBlock
+	}
+
+	finalisedHeader, err := s.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if header.Number > finalisedHeader.Number {
+		return nil, fmt.Errorf("%w: block number %d is after the highest finalised block number %d",
+			ErrSnapshotBlockNotFinalised, header.Number, finalisedHeader.Number)
+	}
+
+	canonicalHash, err := s.Block.GetHashByNumber(header.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting hash of block number %d: %w", header.Number, err)
+	} else if canonicalHash != blockHash {
+		return nil, fmt.Errorf("%w: block %s is not on the finalised chain", ErrSnapshotBlockNotFinalised, blockHash)
+	}
+
+	body, err := s.Block.GetBlockBody(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting block body: %w", err)
+	}
+
+	metadata = &snapshotMetadata{
+		GenesisHash: s.Block.GenesisHash(),
+		Header:      *header,
+		Body:        *body,
+	}
+
+	// The justification is required for the snapshot import to verify
+	// the block is finalised.
+	metadata.Justification, err = s.Block.GetJustification(blockHash)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return nil, fmt.Errorf("%w: block %s has no justification", ErrSnapshotBlockNotFinalised, blockHash)
+	} else if err != nil {
+		return nil, fmt.Errorf("getting justification: %w", err)
+	}
+
+	metadata.GrandpaRound, err = justificationRound(metadata.Justification)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata.FirstSlot, err = s.Base.loadFirstSlot()
+	if err != nil {
+		return nil, fmt.Errorf("loading first slot: %w", err)
+	}
+
+	err = s.setSnapshotEpochs(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.setSnapshotGrandpaSets(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// setSnapshotEpochs sets the epoch and the epoch and configuration data
+// of the snapshot block epoch, and of the next epoch if they are known.
+func (s *Service) setSnapshotEpochs(metadata *snapshotMetadata) (err error) {
+	header := &metadata.Header
+	metadata.Epoch, err = s.Epoch.GetEpochForBlock(header)
+	if err != nil {
+		return fmt.Errorf("getting epoch of block: %w", err)
+	}
+
+	epochData, err := s.Epoch.GetEpochData(metadata.Epoch, header)
+	if err != nil {
+		return fmt.Errorf("getting data of epoch %d: %w", metadata.Epoch, err)
+	}
+	metadata.EpochData = append(metadata.EpochData, snapshotEpochData{
+		Epoch: metadata.Epoch,
+		Data:  *epochData.ToEpochDataRaw(),
+	})
+
+	configData, err := s.Epoch.GetConfigData(metadata.Epoch, header)
+	if err != nil {
+		return fmt.Errorf("getting configuration data of epoch %d: %w", metadata.Epoch, err)
+	}
+	metadata.ConfigData = append(metadata.ConfigData, snapshotConfigData{
+		Epoch: metadata.Epoch,
+		Data:  *configData,
+	})
+
+	nextEpoch := metadata.Epoch + 1
+	nextEpochData, err := s.Epoch.getEpochDataFromDatabase(nextEpoch)
+	switch {
+	case err == nil:
+		metadata.EpochData = append(metadata.EpochData, snapshotEpochData{
+			Epoch: nextEpoch,
+			Data:  *nextEpochData.ToEpochDataRaw(),
+		})
+	case !errors.Is(err, chaindb.ErrKeyNotFound):
+		return fmt.Errorf("getting data of epoch %d: %w", nextEpoch, err)
+	}
+
+	nextConfigData, err := s.Epoch.getConfigDataFromDatabase(nextEpoch)
+	switch {
+	case err == nil:
+		metadata.ConfigData = append(metadata.ConfigData, snapshotConfigData{
+			Epoch: nextEpoch,
+			Data:  *nextConfigData,
+		})
+	case !errors.Is(err, chaindb.ErrKeyNotFound):
+		return fmt.Errorf("getting configuration data of epoch %d: %w", nextEpoch, err)
+	}
+
+	return nil
+}
+
+// setSnapshotGrandpaSets sets the authority set ID of the snapshot block,
+// and the authority sets from genesis up to this set. The next authority
+// set is also set if it is already scheduled.
+func (s *Service) setSnapshotGrandpaSets(metadata *snapshotMetadata) (err error) {
+	metadata.GrandpaSetID, err = s.Grandpa.GetSetIDByBlockNumber(metadata.Header.Number)
+	if err != nil {
+		return fmt.Errorf("getting set id of block: %w", err)
+	}
+
+	for setID := uint64(0); setID <= metadata.GrandpaSetID+1; setID++ {
+		authorities, err := s.Grandpa.GetAuthorities(setID)
+		if errors.Is(err, chaindb.ErrKeyNotFound) && setID > metadata.GrandpaSetID {
+			break
+		} else if err != nil {
+			return fmt.Errorf("getting authorities of set id %d: %w", setID, err)
+		}
+
+		changeNumber, err := s.Grandpa.GetSetIDChange(setID)
+		if err != nil {
+			return fmt.Errorf("getting block number of set id %d change: %w", setID, err)
+		}
+
+		set := snapshotGrandpaSet{
+			SetID:        setID,
+			ChangeNumber: changeNumber,
+			Authorities:  make([]types.GrandpaAuthoritiesRaw, len(authorities)),
+		}
+		for i, authority := range authorities {
+			set.Authorities[i] = types.GrandpaAuthoritiesRaw{
+				Key: authority.Key.AsBytes(),
+				ID:  authority.ID,
+			}
+		}
+		metadata.GrandpaSets = append(metadata.GrandpaSets, set)
+	}
+
+	return nil
+}
+
+// ImportSnapshot imports the snapshot read from the reader given, written by
+// ExportSnapshot, in the database of an initialised node of the same chain.
+// The snapshot block becomes the highest finalised block, such that the node
+// produces and finalises blocks from it. The block justification is verified
+// with the verifier given before anything is written to the database.
+// The block header is returned.
+// It should be called after SetupBase instead of Start, and the service
+// should be stopped once the snapshot is imported.
+func (s *Service) ImportSnapshot(r io.Reader, verifyJustification JustificationVerifier) (
+	header *types.Header, err error) {
+	err = s.createStates()
+	if err != nil {
+		return nil, err
+	}
+
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating gzip reader: %s", ErrSnapshotNotValid, err)
+	}
+	reader := bufio.NewReader(gzipReader)
+
+	prefix := make([]byte, len(snapshotMagic)+1)
+	_, err = io.ReadFull(reader, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading snapshot header: %s", ErrSnapshotNotValid, err)
+	} else if !bytes.Equal(prefix[:len(snapshotMagic)], snapshotMagic) {
+		return nil, fmt.Errorf("%w: not a snapshot file", ErrSnapshotNotValid)
+	} else if version := prefix[len(snapshotMagic)]; version != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported snapshot version %d", ErrSnapshotNotValid, version)
+	}
+
+	encodedMetadata, err := readSnapshotRecord(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot metadata: %w", err)
+	}
+
+	// The header is allocated before decoding so its digest
+	// has its varying data type values set for decoding.
+	metadata := &snapshotMetadata{
+		Header: *types.NewEmptyHeader(),
+	}
+	err = scale.Unmarshal(encodedMetadata, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: scale decoding metadata: %s", ErrSnapshotNotValid, err)
+	}
+	header = &metadata.Header
+
+	if metadata.GenesisHash != s.Block.GenesisHash() {
+		return nil, fmt.Errorf("%w: %s instead of %s", ErrSnapshotGenesisHashInvalid,
+			metadata.GenesisHash, s.Block.GenesisHash())
+	}
+
+	finalisedHeader, err := s.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if header.Number <= finalisedHeader.Number {
+		return nil, fmt.Errorf("%w: block number %d is not after block number %d",
+			ErrSnapshotBlockNotAhead, header.Number, finalisedHeader.Number)
+	}
+
+	err = s.verifySnapshotJustification(metadata, verifyJustification)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.importSnapshotNodes(reader, header.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.importSnapshotMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("imported snapshot of block number %d with hash %s and %d trie nodes",
+		header.Number, header.Hash(), nodes)
+	return header, nil
+}
+
+// verifySnapshotJustification verifies the justification of the snapshot
+// block with the authorities of the snapshot block set. The authority sets
+// of the snapshot already known by the node must match the node sets, such
+// that the snapshot block set is trusted if it is known by the node.
+func (s *Service) verifySnapshotJustification(metadata *snapshotMetadata,
+	verifyJustification JustificationVerifier) (err error) {
+	if len(metadata.Justification) == 0 {
+		return fmt.Errorf("%w: block has no justification", ErrSnapshotNotValid)
+	}
+
+	metadata.GrandpaRound, err = justificationRound(metadata.Justification)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotValid, err)
+	}
+
+	var setAuthorities []types.GrandpaVoter
+	for _, set := range metadata.GrandpaSets {
+		authorities, err := types.NewGrandpaVotersFromAuthoritiesRaw(set.Authorities)
+		if err != nil {
+			return fmt.Errorf("%w: converting authorities of set id %d: %s", ErrSnapshotNotValid, set.SetID, err)
+		}
+
+		localAuthorities, err := s.Grandpa.GetAuthorities(set.SetID)
+		switch {
+		case err == nil:
+			if !reflect.DeepEqual(authorities, localAuthorities) {
+				return fmt.Errorf("%w: authorities of set id %d differ from the node authorities",
+					ErrSnapshotNotValid, set.SetID)
+			}
+		case !errors.Is(err, chaindb.ErrKeyNotFound):
+			return fmt.Errorf("getting authorities of set id %d: %w", set.SetID, err)
+		}
+
+		if set.SetID == metadata.GrandpaSetID {
+			setAuthorities = authorities
+		}
+	}
+
+	if setAuthorities == nil {
+		return fmt.Errorf("%w: no authorities for set id %d", ErrSnapshotNotValid, metadata.GrandpaSetID)
+	}
+
+	header := &metadata.Header
+	err = verifyJustification(metadata.Justification, header.Hash(), header.Number,
+		metadata.GrandpaSetID, setAuthorities)
+	if err != nil {
+		return fmt.Errorf("%w: verifying justification: %s", ErrSnapshotNotValid, err)
+	}
+
+	return nil
+}
+
+// justificationRound returns the round of the SCALE encoded
+// justification given, which is its first field.
+func justificationRound(justification []byte) (round uint64, err error) {
+	if len(justification) < 8 {
+		return 0, fmt.Errorf("justification is too short: %d bytes", len(justification))
+	}
+	return binary.LittleEndian.Uint64(justification[:8]), nil
+}
+
+// importSnapshotNodes writes the trie nodes read from the reader to the
+// database, and checks the state trie with the root given fully resolves.
+// Note the trie nodes are not reference counted if pruning is enabled,
+// as for nodes written before reference counting was enabled, so they
+// are never pruned.
+func (s *Service) importSnapshotNodes(reader io.Reader, stateRoot common.Hash) (nodes uint, err error) {
+	batch := s.Storage.db.NewBatch()
+	for {
+		encodedNode, err := readSnapshotRecord(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			batch.Reset()
+			return nodes, fmt.Errorf("reading trie node: %w", err)
+		}
+
+		nodeHash, err := common.Blake2bHash(encodedNode)
+		if err != nil {
+			batch.Reset()
+			return nodes, fmt.Errorf("hashing trie node: %w", err)
+		}
+
+		err = batch.Put(nodeHash[:], encodedNode)
+		if err != nil {
+			batch.Reset()
+			return nodes, fmt.Errorf("putting trie node with hash %s: %w", nodeHash, err)
+		}
+		nodes++
+
+		if batch.ValueSize() < maxImportBatchSize {
+			continue
+		}
+
+		err = batch.Flush()
+		if err != nil {
+			return nodes, fmt.Errorf("writing trie nodes: %w", err)
+		}
+		batch.Reset()
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return nodes, fmt.Errorf("writing trie nodes: %w", err)
+	}
+
+	// Nodes are keyed by the hash of their encoding, so the trie
+	// loading from the database is enough to verify the state.
+	err = trie.NewEmptyTrie().Load(s.Storage.db, stateRoot)
+	if err != nil {
+		return nodes, fmt.Errorf("%w: loading state trie with root %s: %s",
+			ErrSnapshotNotValid, stateRoot, err)
+	}
+
+	return nodes, nil
+}
+
+// importSnapshotMetadata writes the block, BABE and
+// GRANDPA metadata of the snapshot to the database.
+func (s *Service) importSnapshotMetadata(metadata *snapshotMetadata) (err error) {
+	header := &metadata.Header
+	hash := header.Hash()
+
+	err = s.Block.SetHeader(header)
+	if err != nil {
+		return fmt.Errorf("setting header: %w", err)
+	}
+
+	err = s.Block.SetBlockBody(hash, &metadata.Body)
+	if err != nil {
+		return fmt.Errorf("setting block body: %w", err)
+	}
+
+	err = s.Block.db.Put(headerHashKey(uint64(header.Number)), hash[:])
+	if err != nil {
+		return fmt.Errorf("setting hash of block number %d: %w", header.Number, err)
+	}
+
+	err = s.Block.SetJustification(hash, metadata.Justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	err = s.Base.storeFirstSlot(metadata.FirstSlot)
+	if err != nil {
+		return fmt.Errorf("storing first slot: %w", err)
+	}
+
+	for _, epochData := range metadata.EpochData {
+		data, err := epochData.Data.ToEpochData()
+		if err != nil {
+			return fmt.Errorf("converting data of epoch %d: %w", epochData.Epoch, err)
+		}
+
+		err = s.Epoch.SetEpochData(epochData.Epoch, data)
+		if err != nil {
+			return fmt.Errorf("setting data of epoch %d: %w", epochData.Epoch, err)
+		}
+	}
+
+	for _, configData := range metadata.ConfigData {
+		err = s.Epoch.SetConfigData(configData.Epoch, &configData.Data)
+		if err != nil {
+			return fmt.Errorf("setting configuration data of epoch %d: %w", configData.Epoch, err)
+		}
+	}
+
+	err = s.Epoch.SetCurrentEpoch(metadata.Epoch)
+	if err != nil {
+		return fmt.Errorf("setting current epoch: %w", err)
+	}
+
+	for _, set := range metadata.GrandpaSets {
+		authorities, err := types.NewGrandpaVotersFromAuthoritiesRaw(set.Authorities)
+		if err != nil {
+			return fmt.Errorf("converting authorities of set id %d: %w", set.SetID, err)
+		}
+
+		err = s.Grandpa.setAuthorities(set.SetID, authorities)
+		if err != nil {
+			return fmt.Errorf("setting authorities of set id %d: %w", set.SetID, err)
+		}
+
+		err = s.Grandpa.setChangeSetIDAtBlock(set.SetID, set.ChangeNumber)
+		if err != nil {
+			return fmt.Errorf("setting block number of set id %d change: %w", set.SetID, err)
+		}
+	}
+
+	err = s.Grandpa.setCurrentSetID(metadata.GrandpaSetID)
+	if err != nil {
+		return fmt.Errorf("setting current set id: %w", err)
+	}
+
+	err = s.Grandpa.SetLatestRound(metadata.GrandpaRound)
+	if err != nil {
+		return fmt.Errorf("setting latest round: %w", err)
+	}
+
+	err = s.Block.db.Put(finalisedHashKey(metadata.GrandpaRound, metadata.GrandpaSetID), hash[:])
+	if err != nil {
+		return fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	err = s.Block.setHighestRoundAndSetID(metadata.GrandpaRound, metadata.GrandpaSetID)
+	if err != nil {
+		return fmt.Errorf("setting highest round and set id: %w", err)
+	}
+
+	// Blocks before the snapshot block are not in the database, so the
+	// database check and the pruning of finalised blocks start from it.
+	err = s.Base.storeSnapshotBlockNumber(header.Number)
+	if err != nil {
+		return fmt.Errorf("storing snapshot block number: %w", err)
+	}
+
+	return nil
+}
+
+// writeSnapshotRecord writes the data given prefixed
+// with its length as a 32 bit little endian integer.
+func writeSnapshotRecord(w io.Writer, data []byte) (err error) {
+	if uint64(len(data)) > math.MaxUint32 {
+		return fmt.Errorf("record is too large: %d bytes", len(data))
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(data)))
+	_, err = w.Write(length[:])
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// readSnapshotRecord reads a record written by writeSnapshotRecord,
+// and returns io.EOF if there is no record left to read.
+func readSnapshotRecord(r io.Reader) (data []byte, err error) {
+	var length [4]byte
+	_, err = io.ReadFull(r, length[:])
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: reading record length: %s", ErrSnapshotNotValid, err)
+	}
+
+	size := binary.LittleEndian.Uint32(length[:])
+	if size > maxSnapshotRecordSize {
+		return nil, fmt.Errorf("%w: record size %d is larger than the maximum size %d",
+			ErrSnapshotNotValid, size, maxSnapshotRecordSize)
+	}
+
+	data = make([]byte, size)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading record: %s", ErrSnapshotNotValid, err)
+	}
+
+	return data, nil
+}
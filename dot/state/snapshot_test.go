@@ -0,0 +1,63 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readSnapshotRecord(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded    []byte
+		data       []byte
+		errWrapped error
+		errMessage string
+	}{
+		"no record": {
+			errWrapped: io.EOF,
+			errMessage: "EOF",
+		},
+		"truncated length": {
+			encoded:    []byte{1, 0},
+			errWrapped: ErrSnapshotNotValid,
+			errMessage: "snapshot is not valid: reading record length: unexpected EOF",
+		},
+		"record too large": {
+			encoded:    []byte{0, 0, 0, 0xff},
+			errWrapped: ErrSnapshotNotValid,
+			errMessage: "snapshot is not valid: record size 4278190080 " +
+				"is larger than the maximum size 67108864",
+		},
+		"truncated record": {
+			encoded:    []byte{2, 0, 0, 0, 1},
+			errWrapped: ErrSnapshotNotValid,
+			errMessage: "snapshot is not valid: reading record: unexpected EOF",
+		},
+		"record": {
+			encoded: []byte{2, 0, 0, 0, 1, 2},
+			data:    []byte{1, 2},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := readSnapshotRecord(bytes.NewReader(testCase.encoded))
+
+			assert.Equal(t, testCase.data, data)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
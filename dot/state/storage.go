@@ -8,9 +8,9 @@
 	"fmt"
 	"sync"
 
-	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/trie"
@@ -19,6 +19,9 @@
 
 // storagePrefix storage key prefix.
 var storagePrefix = "storage"
+
+// nodeReferencesPrefix trie node reference counts key prefix.
+var nodeReferencesPrefix = "noderefs"
 var codeKey = common.CodeKey
 
 // ErrTrieDoesNotExist is returned when attempting to interact with a trie that is not stored in the StorageState
@@ -34,26 +37,34 @@ type StorageState struct {
 	tries      *Tries
 
 	db GetNewBatcher
+	// nodeCache is set to load state tries lazily from the database,
+	// see SetNodeCache.
+	nodeCache *trie.NodeCache
 	sync.RWMutex
 
 	// change notifiers
 	observerListMutex sync.RWMutex
 	observerList      []Observer
-	pruner            pruner.Pruner
+	// observerRoots maps each observer to the state root
+	// its filter cached values were last updated from.
+	observerRoots      map[Observer]common.Hash
+	observerRootsMutex sync.Mutex
+	pruner             pruner.Pruner
 }
 
 // NewStorageState creates a new StorageState backed by the given block state
 // and database located at basePath.
-func NewStorageState(db *chaindb.BadgerDB, blockState *BlockState,
+func NewStorageState(db database.Database, blockState *BlockState,
 	tries *Tries) (*StorageState, error) {
-	storageTable := chaindb.NewTable(db, storagePrefix)
+	storageTable := database.NewTable(db, storagePrefix)
 
 	return &StorageState{
-		blockState:   blockState,
-		tries:        tries,
-		db:           storageTable,
-		observerList: []Observer{},
-		pruner:       &pruner.ArchiveNode{},
+		blockState:    blockState,
+		tries:         tries,
+		db:            storageTable,
+		observerList:  []Observer{},
+		observerRoots: make(map[Observer]common.Hash),
+		pruner:        pruner.NewArchiveNode(storageTable),
 	}, nil
 }
 
@@ -63,26 +74,41 @@ func (s *StorageState) StoreTrie(ts *rtstorage.TrieState, header *types.Header)
 
 	s.tries.softSet(root, ts.Trie())
 
+	// changedKeys is left nil if the changes from the parent state are
+	// not known, in which case all the observed keys are compared.
+	var changedKeys map[string]struct{}
+	var parentRoot common.Hash
 	if header != nil {
-		insertedNodeHashes, deletedNodeHashes, err := ts.GetChangedNodeHashes()
+		keys := ts.ChangedKeys()
+
+		err := s.pruner.StoreState(ts.Trie(), header.Hash())
 		if err != nil {
-			return fmt.Errorf("getting trie changed node hashes for block hash %s: %w", header.Hash(), err)
+			return fmt.Errorf("storing state of block hash %s: %w", header.Hash(), err)
 		}
 
-		err = s.pruner.StoreJournalRecord(deletedNodeHashes, insertedNodeHashes, header.Hash(), int64(header.Number))
+		err = s.blockState.storeStorageChanges(header.Hash(), keys)
 		if err != nil {
-			return fmt.Errorf("storing journal record: %w", err)
+			return fmt.Errorf("storing storage changes of block hash %s: %w", header.Hash(), err)
 		}
-	}
 
-	logger.Tracef("cached trie in storage state: %s", root)
-
-	if err := ts.Trie().WriteDirty(s.db); err != nil {
+		parentHeader, err := s.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			logger.Debugf("cannot get parent header of block hash %s: %s", header.Hash(), err)
+		} else {
+			parentRoot = parentHeader.StateRoot
+			changedKeys = make(map[string]struct{}, len(keys))
+			for _, key := range keys {
+				changedKeys[string(key)] = struct{}{}
+			}
+		}
+	} else if err := ts.Trie().WriteDirty(s.db); err != nil {
 		logger.Warnf("failed to write trie with root %s to database: %s", root, err)
 		return err
 	}
 
-	go s.notifyAll(root)
+	logger.Tracef("cached trie in storage state: %s", root)
+
+	go s.notifyAll(parentRoot, root, changedKeys)
 	return nil
 }
 
@@ -117,10 +143,22 @@ func (s *StorageState) TrieState(root *common.Hash) (*rtstorage.TrieState, error
 	return next, nil
 }
 
-// LoadFromDB loads an encoded trie from the DB where the key is `root`
-func (s *StorageState) LoadFromDB(root common.Hash) (*trie.Trie, error) {
-	t := trie.NewEmptyTrie()
-	err := t.Load(s.db, root)
+// SetNodeCache sets the trie node cache to use to load state tries
+// lazily from the database, instead of loading them fully in memory.
+func (s *StorageState) SetNodeCache(nodeCache *trie.NodeCache) {
+	s.nodeCache = nodeCache
+}
+
+// LoadFromDB loads an encoded trie from the DB where the key is `root`.
+// If a node cache is set, only the root node is loaded and other nodes
+// are loaded from the database on demand.
+func (s *StorageState) LoadFromDB(root common.Hash) (t *trie.Trie, err error) {
+	if s.nodeCache != nil {
+		t, err = trie.NewLazyTrie(s.db, root, s.nodeCache)
+	} else {
+		t = trie.NewEmptyTrie()
+		err = t.Load(s.db, root)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -171,8 +209,15 @@ func (s *StorageState) GetStorage(root *common.Hash, key []byte) ([]byte, error)
 
 	t := s.tries.get(*root)
 	if t != nil {
-		val := t.Get(key)
-		return val, nil
+		return t.Get(key)
+	}
+
+	if s.nodeCache != nil {
+		t, err := trie.NewLazyTrie(s.db, *root, s.nodeCache)
+		if err != nil {
+			return nil, err
+		}
+		return t.Get(key)
 	}
 
 	return trie.GetFromDB(s.db, *root, key)
@@ -229,7 +274,7 @@ func (s *StorageState) Entries(root *common.Hash) (map[string][]byte, error) {
 		return nil, err
 	}
 
-	return tr.Entries(), nil
+	return tr.Entries()
 }
 
 // GetKeysWithPrefix returns all that match the given prefix for the given hash
@@ -240,7 +285,7 @@ func (s *StorageState) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]
 		return nil, err
 	}
 
-	return tr.GetKeysWithPrefix(prefix), nil
+	return tr.GetKeysWithPrefix(prefix)
 }
 
 // GetStorageChild returns a child trie, if it exists
@@ -283,3 +328,20 @@ func (s *StorageState) GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (
 	encodedProofNodes [][]byte, err error) {
 	return proof.Generate(stateRoot[:], keys, s.db)
 }
+
+// GenerateChildTrieProof returns the proofs related to the keys on the child trie
+// at the key to child given, for the given main trie state root.
+func (s *StorageState) GenerateChildTrieProof(stateRoot common.Hash, keyToChild []byte,
+	keys [][]byte) (encodedProofNodes [][]byte, err error) {
+	childStorageKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...)
+	childRootHash, err := s.GetStorage(&stateRoot, childStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("getting child trie root hash: %w", err)
+	}
+
+	if childRootHash == nil {
+		return nil, fmt.Errorf("%w at key 0x%x", trie.ErrChildTrieDoesNotExist, childStorageKey)
+	}
+
+	return proof.Generate(childRootHash, keys, s.db)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// storeStorageChanges records the keys of the state changed by the block
+// with the given hash, so historical storage queries can skip the blocks
+// not changing the keys queried.
+func (bs *BlockState) storeStorageChanges(blockHash common.Hash, keys [][]byte) error {
+	encoded, err := scale.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("encoding keys: %w", err)
+	}
+
+	return bs.db.Put(prefixKey(blockHash, storageChangesPrefix), encoded)
+}
+
+// HasStorageChanges returns true if the keys of the state changed by the
+// block with the given hash are recorded. They are not recorded for blocks
+// imported before the storage changes were recorded, nor for blocks with a
+// pruned state.
+func (bs *BlockState) HasStorageChanges(blockHash common.Hash) (bool, error) {
+	return bs.db.Has(prefixKey(blockHash, storageChangesPrefix))
+}
+
+// GetStorageChanges returns the keys of the state changed by the block with
+// the given hash, in lexicographic order. Keys cleared by prefix may be
+// included even if they did not exist.
+func (bs *BlockState) GetStorageChanges(blockHash common.Hash) (keys [][]byte, err error) {
+	encoded, err := bs.db.Get(prefixKey(blockHash, storageChangesPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	err = scale.Unmarshal(encoded, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("decoding keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// deleteStorageChanges deletes the keys of the state changed by the block
+// with the given hash, once the state of the block is discarded.
+func (bs *BlockState) deleteStorageChanges(blockHash common.Hash) error {
+	return bs.db.Del(prefixKey(blockHash, storageChangesPrefix))
+}
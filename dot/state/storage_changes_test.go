@@ -0,0 +1,51 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageState_StoreTrie_storageChanges(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorageState(t)
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	err = ts.Put([]byte("b"), []byte("1"))
+	require.NoError(t, err)
+	err = ts.Put([]byte("a"), []byte("2"))
+	require.NoError(t, err)
+
+	header := &types.Header{Number: 1}
+	blockHash := header.Hash()
+
+	has, err := storage.blockState.HasStorageChanges(blockHash)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	err = storage.StoreTrie(ts, header)
+	require.NoError(t, err)
+
+	has, err = storage.blockState.HasStorageChanges(blockHash)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	keys, err := storage.blockState.GetStorageChanges(blockHash)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, keys)
+
+	err = storage.blockState.deleteStorageChanges(blockHash)
+	require.NoError(t, err)
+
+	_, err = storage.blockState.GetStorageChanges(blockHash)
+	assert.ErrorIs(t, err, chaindb.ErrKeyNotFound)
+}
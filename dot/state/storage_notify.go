@@ -4,11 +4,14 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
 // KeyValue struct to hold key value pairs
@@ -21,10 +24,17 @@ func (kv KeyValue) String() string {
 	return fmt.Sprintf("{Key: 0x%x, Value: 0x%x}", kv.Key, kv.Value)
 }
 
+// ChildChanges holds the changes of a child storage
+type ChildChanges struct {
+	KeyToChild []byte
+	Changes    []KeyValue
+}
+
 // SubscriptionResult holds results of storage changes
 type SubscriptionResult struct {
-	Hash    common.Hash
-	Changes []KeyValue
+	Hash         common.Hash
+	Changes      []KeyValue
+	ChildChanges []ChildChanges
 }
 
 // String serialises the subscription result changes
@@ -44,6 +54,14 @@ type Observer interface {
 	GetFilter() map[string][]byte
 }
 
+// ChildObserver is an Observer also interested in child storage changes.
+// Its child filter maps hex encoded keys to child tries to the filter of
+// each child trie, where an empty filter means all the child trie entries.
+type ChildObserver interface {
+	Observer
+	GetChildFilter() map[string]map[string][]byte
+}
+
 // RegisterStorageObserver to add abserver to notification list
 func (s *StorageState) RegisterStorageObserver(o Observer) {
 	s.observerListMutex.Lock()
@@ -57,7 +75,7 @@ func (s *StorageState) RegisterStorageObserver(o Observer) {
 		return
 	}
 	go func() {
-		if err := s.notifyObserver(sr, o); err != nil {
+		if err := s.notifyObserver(o, common.Hash{}, sr, nil); err != nil {
 			logger.Warnf("failed to notify storage subscriptions: %s", err)
 		}
 	}()
@@ -69,20 +87,40 @@ func (s *StorageState) UnregisterStorageObserver(o Observer) {
 	s.observerListMutex.Lock()
 	defer s.observerListMutex.Unlock()
 	s.observerList = s.removeFromSlice(s.observerList, o)
+
+	s.observerRootsMutex.Lock()
+	defer s.observerRootsMutex.Unlock()
+	delete(s.observerRoots, o)
 }
 
-func (s *StorageState) notifyAll(root common.Hash) {
+// notifyAll notifies all the observers of the changes of the state with the
+// given root. changedKeys are the keys changed from the parent state with the
+// given parent root, or nil if they are not known.
+func (s *StorageState) notifyAll(parentRoot, root common.Hash, changedKeys map[string]struct{}) {
 	s.observerListMutex.RLock()
 	defer s.observerListMutex.RUnlock()
 	for _, observer := range s.observerList {
-		err := s.notifyObserver(root, observer)
+		err := s.notifyObserver(observer, parentRoot, root, changedKeys)
 		if err != nil {
 			logger.Warnf("failed to notify storage subscriptions: %s", err)
 		}
 	}
 }
 
-func (s *StorageState) notifyObserver(root common.Hash, o Observer) error {
+// notifyObserver notifies the observer of the changes of the state with the
+// given root from the values cached in its filters. Only the observed keys found
+// in changedKeys are compared with their cached values if the cached values are
+// from the parent state with the given parent root, since the cached values may
+// be from another block or fork otherwise.
+func (s *StorageState) notifyObserver(o Observer, parentRoot, root common.Hash,
+	changedKeys map[string]struct{}) (err error) {
+	s.observerRootsMutex.Lock()
+	cachedRoot, ok := s.observerRoots[o]
+	s.observerRootsMutex.Unlock()
+	if !ok || cachedRoot != parentRoot {
+		changedKeys = nil
+	}
+
 	t, err := s.TrieState(&root)
 	if err != nil {
 		return err
@@ -95,9 +133,19 @@ func (s *StorageState) notifyObserver(root common.Hash, o Observer) error {
 	subRes := &SubscriptionResult{
 		Hash: root,
 	}
-	if len(o.GetFilter()) == 0 {
+
+	var childFilter map[string]map[string][]byte
+	if childObserver, ok := o.(ChildObserver); ok {
+		childFilter = childObserver.GetChildFilter()
+	}
+
+	if len(o.GetFilter()) == 0 && len(childFilter) == 0 {
 		// no filter, so send all changes
-		ent := t.TrieEntries()
+		ent, err := t.TrieEntries()
+		if err != nil {
+			return fmt.Errorf("getting trie entries: %w", err)
+		}
+
 		for k, v := range ent {
 			if k != ":code" {
 				// currently we're ignoring :code since this is a lot of data
@@ -111,10 +159,21 @@ func (s *StorageState) notifyObserver(root common.Hash, o Observer) error {
 	} else {
 		// filter result to include only interested keys
 		for k, cachedValue := range o.GetFilter() {
-			value := t.Get(common.MustHexToBytes(k))
+			key := common.MustHexToBytes(k)
+			if changedKeys != nil {
+				if _, changed := changedKeys[string(key)]; !changed {
+					continue
+				}
+			}
+
+			value, err := t.Get(key)
+			if err != nil {
+				return fmt.Errorf("getting value at key 0x%x: %w", key, err)
+			}
+
 			if !reflect.DeepEqual(cachedValue, value) {
 				kv := &KeyValue{
-					Key:   common.MustHexToBytes(k),
+					Key:   key,
 					Value: value,
 				}
 				subRes.Changes = append(subRes.Changes, *kv)
@@ -123,8 +182,28 @@ func (s *StorageState) notifyObserver(root common.Hash, o Observer) error {
 		}
 	}
 
-	if len(subRes.Changes) > 0 {
-		logger.Tracef("update observer, changes are %v", subRes.Changes)
+	for hexKeyToChild, filter := range childFilter {
+		keyToChild := common.MustHexToBytes(hexKeyToChild)
+		changes, err := childTrieChanges(t, keyToChild, filter)
+		if err != nil {
+			return fmt.Errorf("getting changes of child trie 0x%x: %w", keyToChild, err)
+		}
+
+		if len(changes) > 0 {
+			subRes.ChildChanges = append(subRes.ChildChanges, ChildChanges{
+				KeyToChild: keyToChild,
+				Changes:    changes,
+			})
+		}
+	}
+
+	s.observerRootsMutex.Lock()
+	s.observerRoots[o] = root
+	s.observerRootsMutex.Unlock()
+
+	if len(subRes.Changes) > 0 || len(subRes.ChildChanges) > 0 {
+		logger.Tracef("update observer, changes are %v and child changes are %v",
+			subRes.Changes, subRes.ChildChanges)
 		go func() {
 			o.Update(subRes)
 		}()
@@ -133,6 +212,73 @@ func (s *StorageState) notifyObserver(root common.Hash, o Observer) error {
 	return nil
 }
 
+// childTrieChanges returns the entries of the child trie at the key given
+// differing from the cached values of the filter given, and updates the
+// cached values. An empty filter tracks all the entries of the child trie,
+// including the removed ones. A missing child trie has no entries.
+func childTrieChanges(t *rtstorage.TrieState, keyToChild []byte, filter map[string][]byte) (
+	changes []KeyValue, err error) {
+	child, err := t.GetChild(keyToChild)
+	if errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+		child = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	get := func(key []byte) (value []byte, err error) {
+		if child == nil {
+			return nil, nil
+		}
+		return child.Get(key)
+	}
+
+	if len(filter) == 0 {
+		// mark the filter as tracking all entries with the empty key,
+		// since it is no longer empty once the entries are cached
+		filter[""] = nil
+	}
+
+	if isTrackingAllEntries(filter) && child != nil {
+		entries, err := child.Entries()
+		if err != nil {
+			return nil, fmt.Errorf("getting child trie entries: %w", err)
+		}
+
+		for key := range entries {
+			hexKey := common.BytesToHex([]byte(key))
+			if _, has := filter[hexKey]; !has {
+				filter[hexKey] = []byte{}
+			}
+		}
+	}
+
+	for hexKey, cachedValue := range filter {
+		if hexKey == "" {
+			continue
+		}
+
+		key := common.MustHexToBytes(hexKey)
+		value, err := get(key)
+		if err != nil {
+			return nil, fmt.Errorf("getting value at key 0x%x: %w", key, err)
+		}
+
+		if reflect.DeepEqual(cachedValue, value) {
+			continue
+		}
+
+		changes = append(changes, KeyValue{Key: key, Value: value})
+		filter[hexKey] = value
+	}
+
+	return changes, nil
+}
+
+func isTrackingAllEntries(filter map[string][]byte) bool {
+	_, ok := filter[""]
+	return ok
+}
+
 func (s *StorageState) removeFromSlice(observerList []Observer, observerToRemove Observer) []Observer {
 	observerListLength := len(observerList)
 	for i, observer := range observerList {
@@ -13,7 +13,10 @@
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -135,6 +138,158 @@ func TestStorageState_RegisterStorageObserver_Multi_Filter(t *testing.T) {
 	}
 }
 
+func TestStorageState_notifyObserver_forks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	ss := newTestStorageState(t)
+	key := []byte("key")
+	otherKey := []byte("other")
+
+	newState := func(t *testing.T, value []byte, otherValue []byte) common.Hash {
+		t.Helper()
+		ts, err := ss.TrieState(nil)
+		require.NoError(t, err)
+		ts.Put(key, value)
+		ts.Put(otherKey, otherValue)
+		err = ss.StoreTrie(ts, nil)
+		require.NoError(t, err)
+		return ts.MustRoot()
+	}
+
+	parentRoot := newState(t, []byte("a"), []byte("x"))
+	forkOneRoot := newState(t, []byte("b"), []byte("x"))
+	forkTwoRoot := newState(t, []byte("a"), []byte("y"))
+
+	filter := map[string][]byte{common.BytesToHex(key): nil}
+	updates := make(chan *SubscriptionResult, 1)
+	observer := NewMockObserver(ctrl)
+	observer.EXPECT().GetFilter().Return(filter).AnyTimes()
+	observer.EXPECT().Update(gomock.Any()).DoAndReturn(func(result *SubscriptionResult) {
+		updates <- result
+	}).AnyTimes()
+
+	nextChanges := func(t *testing.T) []KeyValue {
+		t.Helper()
+		select {
+		case result := <-updates:
+			return result.Changes
+		case <-time.After(time.Second):
+			t.Fatal("observer not updated")
+			return nil
+		}
+	}
+
+	err := ss.notifyObserver(observer, common.Hash{}, parentRoot, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyValue{{Key: key, Value: []byte("a")}}, nextChanges(t))
+
+	changedKeys := map[string]struct{}{string(key): {}}
+	err = ss.notifyObserver(observer, parentRoot, forkOneRoot, changedKeys)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyValue{{Key: key, Value: []byte("b")}}, nextChanges(t))
+
+	// the observed key is not changed from the parent state by the block of
+	// the second fork, but it differs from the value cached from the first fork.
+	changedKeys = map[string]struct{}{string(otherKey): {}}
+	err = ss.notifyObserver(observer, parentRoot, forkTwoRoot, changedKeys)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyValue{{Key: key, Value: []byte("a")}}, nextChanges(t))
+}
+
+func Test_childTrieChanges(t *testing.T) {
+	t.Parallel()
+
+	newTrieState := func(t *testing.T) *rtstorage.TrieState {
+		t.Helper()
+		childTrie := trie.NewEmptyTrie()
+		childTrie.Put([]byte("a"), []byte("1"))
+		childTrie.Put([]byte("b"), []byte("2"))
+		trieState := rtstorage.NewTrieState(trie.NewEmptyTrie())
+		err := trieState.SetChild([]byte("child"), childTrie)
+		require.NoError(t, err)
+		return trieState
+	}
+
+	testCases := map[string]struct {
+		keyToChild  []byte
+		filter      map[string][]byte
+		changes     []KeyValue
+		filterAfter map[string][]byte
+	}{
+		"missing_child_trie": {
+			keyToChild:  []byte("unknown"),
+			filter:      map[string][]byte{"0x61": {}},
+			changes:     []KeyValue{{Key: []byte("a")}},
+			filterAfter: map[string][]byte{"0x61": nil},
+		},
+		"filtered_keys": {
+			keyToChild:  []byte("child"),
+			filter:      map[string][]byte{"0x61": {}},
+			changes:     []KeyValue{{Key: []byte("a"), Value: []byte("1")}},
+			filterAfter: map[string][]byte{"0x61": []byte("1")},
+		},
+		"all_keys": {
+			keyToChild: []byte("child"),
+			filter:     map[string][]byte{},
+			changes: []KeyValue{
+				{Key: []byte("a"), Value: []byte("1")},
+				{Key: []byte("b"), Value: []byte("2")},
+			},
+			filterAfter: map[string][]byte{
+				"":     nil,
+				"0x61": []byte("1"),
+				"0x62": []byte("2"),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := childTrieChanges(newTrieState(t), testCase.keyToChild, testCase.filter)
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, testCase.changes, changes)
+			assert.Equal(t, testCase.filterAfter, testCase.filter)
+		})
+	}
+}
+
+func Test_childTrieChanges_allKeysUpdates(t *testing.T) {
+	t.Parallel()
+
+	childTrie := trie.NewEmptyTrie()
+	childTrie.Put([]byte("a"), []byte("1"))
+	trieState := rtstorage.NewTrieState(trie.NewEmptyTrie())
+	err := trieState.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+
+	filter := map[string][]byte{}
+	changes, err := childTrieChanges(trieState, []byte("child"), filter)
+	require.NoError(t, err)
+	assert.Equal(t, []KeyValue{{Key: []byte("a"), Value: []byte("1")}}, changes)
+
+	changes, err = childTrieChanges(trieState, []byte("child"), filter)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	err = trieState.SetChildStorage([]byte("child"), []byte("b"), []byte("2"))
+	require.NoError(t, err)
+	err = trieState.ClearChildStorage([]byte("child"), []byte("a"))
+	require.NoError(t, err)
+
+	changes, err = childTrieChanges(trieState, []byte("child"), filter)
+	require.NoError(t, err)
+	expectedChanges := []KeyValue{
+		{Key: []byte("a")},
+		{Key: []byte("b"), Value: []byte("2")},
+	}
+	assert.ElementsMatch(t, expectedChanges, changes)
+}
+
 func Test_Example(t *testing.T) {
 	// this is a working example of how to use db.Subscribe taken from
 	// https://github.com/dgraph-io/badger/blob/f50343ff404d8198df6dc83755ec2eab863d5ff2/db_test.go#L1939-L1948
@@ -149,7 +304,12 @@ func Test_Example(t *testing.T) {
 	bValue := []byte("b-value")
 
 	// Open the DB.
-	db := NewInMemoryDB(t)
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{InMemory: true})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
 
 	// Create the context here so we can cancel it after sending the writes.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -173,7 +333,7 @@ func Test_Example(t *testing.T) {
 	}()
 
 	// Write both keys, but only one should be printed in the Output.
-	err := db.Put(aKey, aValue)
+	err = db.Put(aKey, aValue)
 	if err != nil {
 		log.Fatal(err)
 	}
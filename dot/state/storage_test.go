@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/trie/proof"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/golang/mock/gomock"
 
@@ -150,6 +153,130 @@ func TestStorage_LoadFromDB(t *testing.T) {
 	require.Equal(t, 4, len(entries))
 }
 
+func TestStorage_LoadFromDB_nodeCache(t *testing.T) {
+	storage := newTestStorageState(t)
+	nodeCache, err := trie.NewNodeCache(10)
+	require.NoError(t, err)
+	storage.SetNodeCache(nodeCache)
+
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		value := bytes.Repeat([]byte{byte(i)}, 40)
+		ts.Put(key, value)
+	}
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	storage.blockState.tries.delete(root)
+
+	data, err := storage.GetStorage(&root, []byte("key42"))
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{42}, 40), data)
+	require.Greater(t, nodeCache.Len(), 0)
+
+	prefixKeys, err := storage.GetKeysWithPrefix(&root, []byte("key1"))
+	require.NoError(t, err)
+	require.Len(t, prefixKeys, 11)
+
+	ts, err = storage.TrieState(&root)
+	require.NoError(t, err)
+	ts.Delete([]byte("key42"))
+	ts.Put([]byte("key100"), []byte("value"))
+
+	newRoot, err := ts.Root()
+	require.NoError(t, err)
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	storage.blockState.tries.delete(newRoot)
+
+	entries, err := storage.Entries(&newRoot)
+	require.NoError(t, err)
+	require.Len(t, entries, 100)
+	require.Equal(t, []byte("value"), entries["key100"])
+}
+
+func TestStorage_nodeCache_missingNode(t *testing.T) {
+	storage := newTestStorageState(t)
+
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+		value := bytes.Repeat([]byte{byte(i)}, 40)
+		ts.Put(key, value)
+	}
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	// delete the nodes below the root node, as if the state was pruned
+	batch := storage.db.NewBatch()
+	for _, child := range ts.Trie().RootNode().Children {
+		if child != nil {
+			err = batch.Del(child.MerkleValue)
+			require.NoError(t, err)
+		}
+	}
+	err = batch.Flush()
+	require.NoError(t, err)
+
+	nodeCache, err := trie.NewNodeCache(10)
+	require.NoError(t, err)
+	storage.SetNodeCache(nodeCache)
+	storage.blockState.tries.delete(root)
+
+	_, err = storage.GetStorage(&root, []byte("key42"))
+	require.ErrorContains(t, err, "from database: Key not found")
+
+	_, err = storage.GetKeysWithPrefix(&root, []byte("key1"))
+	require.ErrorContains(t, err, "from database: Key not found")
+
+	_, err = storage.Entries(&root)
+	require.ErrorContains(t, err, "from database: Key not found")
+}
+
+func TestStorage_GenerateChildTrieProof(t *testing.T) {
+	storage := newTestStorageState(t)
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	ts.Put([]byte("key1"), []byte("value1"))
+	ts.Put([]byte("key2"), []byte("value2"))
+
+	keyToChild := []byte("keyToChild")
+	childValue := bytes.Repeat([]byte{1}, 40)
+	childTrie := trie.NewEmptyTrie()
+	childTrie.Put([]byte("key"), childValue)
+	err = ts.SetChild(keyToChild, childTrie)
+	require.NoError(t, err)
+	childRoot := childTrie.MustHash()
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	encodedProofNodes, err := storage.GenerateChildTrieProof(root, keyToChild, [][]byte{[]byte("key")})
+	require.NoError(t, err)
+	err = proof.Verify(encodedProofNodes, childRoot[:], []byte("key"), childValue)
+	require.NoError(t, err)
+
+	_, err = storage.GenerateChildTrieProof(root, []byte("otherChild"), [][]byte{[]byte("key")})
+	require.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+}
+
 func TestStorage_StoreTrie_NotSyncing(t *testing.T) {
 	storage := newTestStorageState(t)
 	ts, err := storage.TrieState(&trie.EmptyHash)
@@ -0,0 +1,50 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+// BlockImporterConfig is the configuration for the BlockImporter.
+type BlockImporterConfig struct {
+	BlockState         BlockState
+	StorageState       StorageState
+	TransactionState   TransactionState
+	BabeVerifier       BabeVerifier
+	FinalityGadget     FinalityGadget
+	BlockImportHandler BlockImportHandler
+	Telemetry          Telemetry
+}
+
+// BlockImporter imports blocks through the same verification and execution
+// path as blocks synced from the network, without requiring a network.
+// It is used to import blocks read from a file.
+type BlockImporter struct {
+	chainProcessor *chainProcessor
+}
+
+// NewBlockImporter creates a new block importer. Its chain processor has no
+// chain sync, such that imported blocks are not announced.
+func NewBlockImporter(cfg BlockImporterConfig) *BlockImporter {
+	return &BlockImporter{
+		chainProcessor: newChainProcessor(chainProcessorConfig{
+			blockState:         cfg.BlockState,
+			storageState:       cfg.StorageState,
+			transactionState:   cfg.TransactionState,
+			babeVerifier:       cfg.BabeVerifier,
+			finalityGadget:     cfg.FinalityGadget,
+			blockImportHandler: cfg.BlockImportHandler,
+			telemetry:          cfg.Telemetry,
+		}),
+	}
+}
+
+// ImportBlock verifies the BABE header of the block data given, executes
+// the block on top of its parent state and imports it, and verifies its
+// justification if any. The parent block must be imported already.
+// Imported blocks are not announced to peers.
+func (b *BlockImporter) ImportBlock(blockData types.BlockData) error {
+	return b.chainProcessor.processBlockData(blockData)
+}
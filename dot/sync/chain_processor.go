@@ -125,8 +125,9 @@ func (c *chainProcessor) processBlockData(blockData types.BlockData) error { //n
 		return fmt.Errorf("checking if block state has body: %w", err)
 	}
 
-	// while in bootstrap mode we don't need to broadcast block announcements
-	announceImportedBlock := c.chainSync.syncState() == tip
+	// while in bootstrap mode or importing blocks without a network,
+	// we don't need to broadcast block announcements
+	announceImportedBlock := c.chainSync != nil && c.chainSync.syncState() == tip
 	if headerInState && bodyInState {
 		err = c.processBlockDataWithStateHeaderAndBody(blockData, announceImportedBlock)
 		if err != nil {
@@ -5,7 +5,6 @@
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
@@ -101,11 +100,20 @@ type ChainSync interface {
 	// called upon receiving a BlockAnnounceHandshake
 	setPeerHead(p peer.ID, hash common.Hash, number uint) error
 
+	// called upon receiving a BlockAnnounceHandshake, before setPeerHead
+	setPeerRoles(p peer.ID, roles common.Roles)
+
+	// called when the connection to a peer is closed
+	removePeer(p peer.ID)
+
 	// syncState returns the current syncing state
 	syncState() chainSyncState
 
 	// getHighestBlock returns the highest block or an error
 	getHighestBlock() (highestBlock uint, err error)
+
+	// getPeerSyncStats returns the statistics of the block requests sent to each peer
+	getPeerSyncStats() []common.PeerSyncStats
 }
 
 type chainSync struct {
@@ -126,6 +134,11 @@ type chainSync struct {
 	sync.RWMutex
 	peerState   map[peer.ID]*peerState
 	ignorePeers map[peer.ID]struct{}
+	// light clients are never picked as sync targets
+	lightClients map[peer.ID]struct{}
+
+	// statistics of the block requests sent to each peer, used to pick the peers to sync from
+	peerStats *peersSyncStats
 
 	// current workers that are attempting to obtain blocks
 	workerState *workerState
@@ -194,6 +207,8 @@ func newChainSync(cfg chainSyncConfig) *chainSync {
 		resultQueue:      make(chan *worker, 1024),
 		peerState:        make(map[peer.ID]*peerState),
 		ignorePeers:      make(map[peer.ID]struct{}),
+		lightClients:     make(map[peer.ID]struct{}),
+		peerStats:        newPeersSyncStats(),
 		workerState:      newWorkerState(),
 		readyBlocks:      cfg.readyBlocks,
 		pendingBlocks:    cfg.pendingBlocks,
@@ -270,7 +285,29 @@ func (cs *chainSync) setBlockAnnounce(from peer.ID, header *types.Header) error
 	return cs.setPeerHead(from, header.Hash(), header.Number)
 }
 
-// setPeerHead sets a peer's best known block and potentially adds the peer's state to the workQueue
+// setPeerRoles records whether the peer is a light client, according to the roles of its handshake.
+func (cs *chainSync) setPeerRoles(p peer.ID, roles common.Roles) {
+	cs.Lock()
+	defer cs.Unlock()
+
+	if roles == common.LightClientRole {
+		cs.lightClients[p] = struct{}{}
+		return
+	}
+	delete(cs.lightClients, p)
+}
+
+// removePeer forgets about the roles and the sync statistics of a disconnected peer.
+func (cs *chainSync) removePeer(p peer.ID) {
+	cs.Lock()
+	delete(cs.lightClients, p)
+	cs.Unlock()
+
+	cs.peerStats.remove(p)
+}
+
+// setPeerHead sets a peer's best known block and potentially adds the peer's state to the workQueue.
+// The best block of light clients is ignored, since they are never picked as sync targets.
 func (cs *chainSync) setPeerHead(p peer.ID, hash common.Hash, number uint) error {
 	ps := &peerState{
 		who:    p,
@@ -278,6 +315,10 @@ func (cs *chainSync) setPeerHead(p peer.ID, hash common.Hash, number uint) error
 		number: number,
 	}
 	cs.Lock()
+	if _, has := cs.lightClients[p]; has {
+		cs.Unlock()
+		return nil
+	}
 	cs.peerState[p] = ps
 	cs.Unlock()
 
@@ -692,18 +733,26 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 	// send out request and potentially receive response, error if timeout
 	logger.Tracef("sending out block request: %s", req)
 
-	// TODO: use scoring to determine what peer to try to sync from first (#1399)
-	idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(peers))))
-	who := peers[idx.Int64()]
+	// peers answering quickly with valid responses are more likely to be picked
+	who := cs.peerStats.pickPeer(peers)
+	requestStart := time.Now()
 	resp, err := cs.network.DoBlockRequest(who, req)
 	if err != nil {
+		cs.peerStats.recordFailure(who)
 		return &workerError{
 			err: err,
 			who: who,
 		}
 	}
 
+	responseTime := time.Since(requestStart)
+
 	if resp == nil {
+		cs.peerStats.recordEmptyResponse(who)
+		cs.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.EmptyBlockResponseValue,
+			Reason: peerset.EmptyBlockResponseReason,
+		}, who)
 		return &workerError{
 			err: errNilResponse,
 			who: who,
@@ -717,12 +766,19 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 
 	// perform some pre-validation of response, error if failure
 	if err := cs.validateResponse(req, resp, who); err != nil {
+		cs.recordInvalidResponse(who, err)
 		return &workerError{
 			err: err,
 			who: who,
 		}
 	}
 
+	cs.peerStats.recordResponse(who, responseTime, len(resp.BlockData))
+	cs.network.ReportPeer(peerset.ReputationChange{
+		Value:  peerset.GoodBlockResponseValue,
+		Reason: peerset.GoodBlockResponseReason,
+	}, who)
+
 	logger.Trace("success! placing block response data in ready queue")
 
 	// response was validated! place into ready block queue
@@ -731,9 +787,88 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 		cs.handleReadyBlock(bd)
 	}
 
+	// the response may be truncated by the peer to stay within the response size limit,
+	// in which case the remaining blocks are requested.
+	if followUp := cs.followUpRequest(req, resp, who); followUp != nil {
+		logger.Debugf("requesting remaining blocks of truncated block response: %s", followUp)
+		return cs.doSync(followUp, peersTried)
+	}
+
 	return nil
 }
 
+// followUpRequest returns the request for the blocks missing from the response of the peer
+// to the request given, or nil if the response is complete.
+// The response must be validated and in ascending order.
+func (cs *chainSync) followUpRequest(req *network.BlockRequestMessage,
+	resp *network.BlockResponseMessage, who peer.ID) *network.BlockRequestMessage {
+	received := uint32(len(resp.BlockData))
+	// the headers are needed to know where the response stopped
+	headerRequested := (req.RequestedData & network.RequestedDataHeader) == 1
+	if req.Max == nil || received >= *req.Max || !headerRequested {
+		return nil
+	}
+
+	var start *variadic.Uint32OrHash
+	switch req.Direction {
+	case network.Ascending:
+		next := resp.BlockData[len(resp.BlockData)-1].Number() + 1
+
+		// a peer responds with less blocks than requested when it reached its best block
+		cs.RLock()
+		state, ok := cs.peerState[who]
+		cs.RUnlock()
+		if !ok || state.number < next {
+			return nil
+		}
+
+		start = variadic.MustNewUint32OrHash(uint32(next))
+	case network.Descending:
+		first := resp.BlockData[0].Header
+		if first.Number <= 1 {
+			return nil
+		}
+
+		start = variadic.MustNewUint32OrHash(first.ParentHash)
+	default:
+		return nil
+	}
+
+	max := *req.Max - received
+	return &network.BlockRequestMessage{
+		RequestedData:                 req.RequestedData,
+		StartingBlock:                 *start,
+		Direction:                     req.Direction,
+		Max:                           &max,
+		SupportMultipleJustifications: req.SupportMultipleJustifications,
+	}
+}
+
+// recordInvalidResponse records the response of the peer which failed validation with the error given
+// in its sync statistics, and reports the peer if the error is not already reported during validation.
+func (cs *chainSync) recordInvalidResponse(who peer.ID, err error) {
+	switch {
+	case errors.Is(err, errUnknownParent):
+		// the peer is on a fork we do not know about yet, which is not a fault of the peer
+		return
+	case errors.Is(err, errEmptyBlockData):
+		cs.peerStats.recordEmptyResponse(who)
+		cs.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.EmptyBlockResponseValue,
+			Reason: peerset.EmptyBlockResponseReason,
+		}, who)
+		return
+	case errors.Is(err, errResponseIsNotChain), errors.Is(err, errNilBodyInResponse),
+		errors.Is(err, errBadBlock):
+		cs.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadBlockResponseValue,
+			Reason: peerset.BadBlockResponseReason,
+		}, who)
+	}
+
+	cs.peerStats.recordInvalidResponse(who)
+}
+
 func (cs *chainSync) handleReadyBlock(bd *types.BlockData) {
 	if cs.readyBlocks.has(bd.Hash) {
 		logger.Tracef("ignoring block %s in response, already in ready queue", bd.Hash)
@@ -816,6 +951,11 @@ func (cs *chainSync) determineSyncPeers(req *network.BlockRequestMessage, peersT
 			continue
 		}
 
+		// light clients do not store past blocks
+		if _, has := cs.lightClients[p]; has {
+			continue
+		}
+
 		// if peer definitely doesn't have any blocks we want in the request,
 		// don't request from them
 		if start > 0 && uint32(state.number) < start {
@@ -973,6 +1113,10 @@ func (cs *chainSync) validateJustification(bd *types.BlockData) error {
 	return nil
 }
 
+func (cs *chainSync) getPeerSyncStats() []common.PeerSyncStats {
+	return cs.peerStats.stats()
+}
+
 func (cs *chainSync) getHighestBlock() (highestBlock uint, err error) {
 	cs.RLock()
 	defer cs.RUnlock()
@@ -1049,10 +1193,11 @@ func workerToRequests(w *worker) ([]*network.BlockRequestMessage, error) {
 		}
 
 		reqs[i] = &network.BlockRequestMessage{
-			RequestedData: w.requestData,
-			StartingBlock: *start,
-			Direction:     w.direction,
-			Max:           &max,
+			RequestedData:                 w.requestData,
+			StartingBlock:                 *start,
+			Direction:                     w.direction,
+			Max:                           &max,
+			SupportMultipleJustifications: true,
 		}
 
 		switch w.direction {
@@ -75,6 +75,18 @@ func Test_chainSync_setPeerHead(t *testing.T) {
 		expectedPeerIDToPeerState map[peer.ID]*peerState
 		expectedQueuedPeerStates  []*peerState
 	}{
+		"light_client": {
+			chainSyncBuilder: func(ctrl *gomock.Controller) *chainSync {
+				return &chainSync{
+					peerState:    map[peer.ID]*peerState{},
+					lightClients: map[peer.ID]struct{}{somePeer: {}},
+				}
+			},
+			peerID:                    somePeer,
+			hash:                      someHash,
+			number:                    1,
+			expectedPeerIDToPeerState: map[peer.ID]*peerState{},
+		},
 		"best_block_header_error": {
 			chainSyncBuilder: func(ctrl *gomock.Controller) *chainSync {
 				blockState := NewMockBlockState(ctrl)
@@ -399,11 +411,16 @@ func TestChainSync_sync_bootstrap_withWorkerError(t *testing.T) {
 	startingBlock := variadic.MustNewUint32OrHash(1)
 	max := uint32(128)
 	mockNetwork.EXPECT().DoBlockRequest(peer.ID("noot"), &network.BlockRequestMessage{
-		RequestedData: 19,
-		StartingBlock: *startingBlock,
-		Direction:     0,
-		Max:           &max,
+		RequestedData:                 19,
+		StartingBlock:                 *startingBlock,
+		Direction:                     0,
+		Max:                           &max,
+		SupportMultipleJustifications: true,
 	})
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.EmptyBlockResponseValue,
+		Reason: peerset.EmptyBlockResponseReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 
 	go cs.sync()
@@ -535,10 +552,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -551,16 +569,19 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 				{
-					RequestedData: network.RequestedDataHeader + network.RequestedDataBody + network.RequestedDataJustification,
-					StartingBlock: *variadic.MustNewUint32OrHash(1 + maxResponseSize),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData: network.RequestedDataHeader + network.RequestedDataBody +
+						network.RequestedDataJustification,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1 + maxResponseSize),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -573,10 +594,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -589,10 +611,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(10),
-					Direction:     network.Descending,
-					Max:           &max9,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(10),
+					Direction:                     network.Descending,
+					Max:                           &max9,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -605,16 +628,19 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 				{
-					RequestedData: network.RequestedDataHeader + network.RequestedDataBody + network.RequestedDataJustification,
-					StartingBlock: *variadic.MustNewUint32OrHash(1 + maxResponseSize),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData: network.RequestedDataHeader + network.RequestedDataBody +
+						network.RequestedDataJustification,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1 + maxResponseSize),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -628,10 +654,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -646,10 +673,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{0xb}),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(common.Hash{0xb}),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -662,10 +690,11 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(10),
-					Direction:     network.Ascending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(10),
+					Direction:                     network.Ascending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -678,16 +707,19 @@ type testCase struct {
 			},
 			expected: []*network.BlockRequestMessage{
 				{
-					RequestedData: network.RequestedDataHeader + network.RequestedDataBody + network.RequestedDataJustification,
-					StartingBlock: *variadic.MustNewUint32OrHash(1 + (maxResponseSize / 2)),
-					Direction:     network.Descending,
-					Max:           &max64,
+					RequestedData: network.RequestedDataHeader + network.RequestedDataBody +
+						network.RequestedDataJustification,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1 + (maxResponseSize / 2)),
+					Direction:                     network.Descending,
+					Max:                           &max64,
+					SupportMultipleJustifications: true,
 				},
 				{
-					RequestedData: bootstrapRequestData,
-					StartingBlock: *variadic.MustNewUint32OrHash(1 + maxResponseSize + (maxResponseSize / 2)),
-					Direction:     network.Descending,
-					Max:           &max128,
+					RequestedData:                 bootstrapRequestData,
+					StartingBlock:                 *variadic.MustNewUint32OrHash(1 + maxResponseSize + (maxResponseSize / 2)),
+					Direction:                     network.Descending,
+					Max:                           &max128,
+					SupportMultipleJustifications: true,
 				},
 			},
 		},
@@ -937,6 +969,10 @@ func TestChainSync_doSync(t *testing.T) {
 		Direction:     0,
 		Max:           &max1,
 	})
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.EmptyBlockResponseValue,
+		Reason: peerset.EmptyBlockResponseReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 
 	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
@@ -962,6 +998,10 @@ func TestChainSync_doSync(t *testing.T) {
 		Direction:     0,
 		Max:           &max1,
 	}).Return(resp, nil)
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.GoodBlockResponseValue,
+		Reason: peerset.GoodBlockResponseReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 
 	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
@@ -1003,6 +1043,10 @@ func TestChainSync_doSync(t *testing.T) {
 		Direction:     1,
 		Max:           &max1,
 	}).Return(resp, nil)
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.GoodBlockResponseValue,
+		Reason: peerset.GoodBlockResponseReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
 	require.Nil(t, workerErr)
@@ -1136,6 +1180,23 @@ func TestChainSync_determineSyncPeers(t *testing.T) {
 	peers = cs.determineSyncPeers(req, peersTried)
 	require.Equal(t, 1, len(peers))
 	require.Equal(t, []peer.ID{testPeerB}, peers)
+
+	// test light client case, should ignore light clients
+	cs.setPeerRoles(testPeerB, common.LightClientRole)
+	peers = cs.determineSyncPeers(req, map[peer.ID]struct{}{})
+	require.Equal(t, []peer.ID{testPeerA}, peers)
+
+	cs.setPeerRoles(testPeerB, common.FullNodeRole)
+	peers = cs.determineSyncPeers(req, map[peer.ID]struct{}{})
+	require.Equal(t, 2, len(peers))
+
+	// test disconnected light client case, should forget the peer is a light client
+	// and its sync statistics
+	cs.setPeerRoles(testPeerB, common.LightClientRole)
+	cs.peerStats.recordFailure(testPeerB)
+	cs.removePeer(testPeerB)
+	require.Empty(t, cs.lightClients)
+	require.Empty(t, cs.peerStats.peers)
 }
 
 func Test_chainSync_logSyncSpeed(t *testing.T) {
@@ -1642,3 +1703,117 @@ func newTestChainSync(ctrl *gomock.Controller) *chainSync {
 	readyBlocks := newBlockQueue(maxResponseSize)
 	return newTestChainSyncWithReadyBlocks(ctrl, readyBlocks)
 }
+
+func TestChainSync_followUpRequest(t *testing.T) {
+	t.Parallel()
+
+	max2 := uint32(2)
+	max128 := uint32(128)
+	max126 := uint32(126)
+	blockData := []*types.BlockData{
+		{Header: &types.Header{ParentHash: common.Hash{1}, Number: 10}},
+		{Header: &types.Header{ParentHash: common.Hash{2}, Number: 11}},
+	}
+
+	testCases := map[string]struct {
+		req      *network.BlockRequestMessage
+		resp     *network.BlockResponseMessage
+		expected *network.BlockRequestMessage
+	}{
+		"complete_response": {
+			req: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				Direction:     network.Ascending,
+				Max:           &max2,
+			},
+			resp: &network.BlockResponseMessage{BlockData: blockData},
+		},
+		"no_max": {
+			req: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				Direction:     network.Ascending,
+			},
+			resp: &network.BlockResponseMessage{BlockData: blockData},
+		},
+		"header_not_requested": {
+			req: &network.BlockRequestMessage{
+				RequestedData: network.RequestedDataJustification,
+				Direction:     network.Ascending,
+				Max:           &max128,
+			},
+			resp: &network.BlockResponseMessage{BlockData: blockData},
+		},
+		"ascending_truncated": {
+			req: &network.BlockRequestMessage{
+				RequestedData:                 bootstrapRequestData,
+				StartingBlock:                 *variadic.MustNewUint32OrHash(10),
+				Direction:                     network.Ascending,
+				Max:                           &max128,
+				SupportMultipleJustifications: true,
+			},
+			resp: &network.BlockResponseMessage{BlockData: blockData},
+			expected: &network.BlockRequestMessage{
+				RequestedData:                 bootstrapRequestData,
+				StartingBlock:                 *variadic.MustNewUint32OrHash(12),
+				Direction:                     network.Ascending,
+				Max:                           &max126,
+				SupportMultipleJustifications: true,
+			},
+		},
+		"ascending_peer_best_block_reached": {
+			req: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				StartingBlock: *variadic.MustNewUint32OrHash(10),
+				Direction:     network.Ascending,
+				Max:           &max128,
+			},
+			resp: &network.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Header: &types.Header{Number: 99}},
+				{Header: &types.Header{Number: 100}},
+			}},
+		},
+		"descending_truncated": {
+			req: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{3}),
+				Direction:     network.Descending,
+				Max:           &max128,
+			},
+			resp: &network.BlockResponseMessage{BlockData: blockData},
+			expected: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{1}),
+				Direction:     network.Descending,
+				Max:           &max126,
+			},
+		},
+		"descending_first_block_reached": {
+			req: &network.BlockRequestMessage{
+				RequestedData: bootstrapRequestData,
+				StartingBlock: *variadic.MustNewUint32OrHash(2),
+				Direction:     network.Descending,
+				Max:           &max128,
+			},
+			resp: &network.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Header: &types.Header{Number: 1}},
+				{Header: &types.Header{Number: 2}},
+			}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cs := &chainSync{
+				peerState: map[peer.ID]*peerState{
+					"noot": {number: 100},
+				},
+			}
+
+			followUp := cs.followUpRequest(testCase.req, testCase.resp, "noot")
+			assert.Equal(t, testCase.expected, followUp)
+		})
+	}
+}
@@ -18,6 +18,7 @@
 	ErrInvalidBlockRequest     = errors.New("invalid block request")
 	errInvalidRequestDirection = errors.New("invalid request direction")
 	errRequestStartTooHigh     = errors.New("request start number is higher than our best block")
+	errBlockBodyNotFound       = errors.New("block body not found")
 
 	// chainSync errors
 	errEmptyBlockData               = errors.New("empty block data")
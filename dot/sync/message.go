@@ -4,8 +4,10 @@
 package sync
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -14,6 +16,9 @@
 const (
 	// maxResponseSize is maximum number of block data a BlockResponse message can contain
 	maxResponseSize = 128
+
+	// maxResponseBytes is the maximum protobuf encoded size of a BlockResponse message
+	maxResponseBytes = int(network.MaxBlockResponseSize)
 )
 
 // CreateBlockResponse creates a block response message from a block request message
@@ -92,7 +97,7 @@ func (s *Service) handleAscendingRequest(req *network.BlockRequestMessage) (*net
 			"end block number: %d",
 			req.Direction, startNumber, endNumber)
 
-		return s.handleAscendingByNumber(startNumber, endNumber, req.RequestedData)
+		return s.handleAscendingByNumber(startNumber, endNumber, req)
 	}
 
 	logger.Debugf("handling block request: direction %s, "+
@@ -100,7 +105,7 @@ func (s *Service) handleAscendingRequest(req *network.BlockRequestMessage) (*net
 		"end block hash: %s",
 		req.Direction, *startHash, *endHash)
 
-	return s.handleChainByHash(*startHash, *endHash, max, req.RequestedData, req.Direction)
+	return s.handleChainByHash(*startHash, *endHash, max, req)
 }
 
 func (s *Service) handleDescendingRequest(req *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
@@ -163,13 +168,13 @@ func (s *Service) handleDescendingRequest(req *network.BlockRequestMessage) (*ne
 		logger.Debugf("handling BlockRequestMessage with direction %s "+
 			"from start block with number %d to end block with number %d",
 			req.Direction, startNumber, endNumber)
-		return s.handleDescendingByNumber(startNumber, endNumber, req.RequestedData)
+		return s.handleDescendingByNumber(startNumber, endNumber, req)
 	}
 
 	logger.Debugf("handling block request message with direction %s "+
 		"from start block with hash %s to end block with hash %s",
 		req.Direction, *startHash, *endHash)
-	return s.handleChainByHash(*endHash, *startHash, max, req.RequestedData, req.Direction)
+	return s.handleChainByHash(*endHash, *startHash, max, req)
 }
 
 // checkOrGetDescendantHash checks if the provided `descendant` is
@@ -253,44 +258,58 @@ func (s *Service) checkOrGetDescendantHash(ancestor common.Hash,
 }
 
 func (s *Service) handleAscendingByNumber(start, end uint,
-	requestedData byte) (*network.BlockResponseMessage, error) {
-	var err error
-	data := make([]*types.BlockData, (end-start)+1)
+	req *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
+	resp := newBlockResponseBuilder(req, (end-start)+1)
+
+	for blockNumber := start; blockNumber <= end; blockNumber++ {
+		blockData, err := s.getBlockDataByNumber(blockNumber, req.RequestedData)
+		if errors.Is(err, errBlockBodyNotFound) {
+			logger.Debugf("responding with %d blocks: %s", len(resp.data), err)
+			break
+		} else if err != nil {
+			return nil, err
+		}
 
-	for i := uint(0); start+i <= end; i++ {
-		blockNumber := start + i
-		data[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
+		full, err := resp.add(blockData)
 		if err != nil {
 			return nil, err
+		} else if full {
+			break
 		}
 	}
 
-	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+	return resp.message(), nil
 }
 
 func (s *Service) handleDescendingByNumber(start, end uint,
-	requestedData byte) (*network.BlockResponseMessage, error) {
-	var err error
-	data := make([]*types.BlockData, (start-end)+1)
+	req *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
+	resp := newBlockResponseBuilder(req, (start-end)+1)
 
 	for i := uint(0); start-i >= end; i++ {
 		blockNumber := start - i
-		data[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
+		blockData, err := s.getBlockDataByNumber(blockNumber, req.RequestedData)
+		if errors.Is(err, errBlockBodyNotFound) {
+			logger.Debugf("responding with %d blocks: %s", len(resp.data), err)
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		full, err := resp.add(blockData)
 		if err != nil {
 			return nil, err
+		} else if full {
+			break
 		}
 	}
 
-	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+	return resp.message(), nil
 }
 
 func (s *Service) handleChainByHash(ancestor, descendant common.Hash,
-	max uint, requestedData byte, direction network.SyncDirection) (
+	max uint, req *network.BlockRequestMessage) (
 	*network.BlockResponseMessage, error) {
+	direction := req.Direction
 	subchain, err := s.blockState.Range(ancestor, descendant)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving range: %w", err)
@@ -306,23 +325,83 @@ func (s *Service) handleChainByHash(ancestor, descendant common.Hash,
 		}
 	}
 
-	data := make([]*types.BlockData, len(subchain))
+	resp := newBlockResponseBuilder(req, uint(len(subchain)))
+
+	for i := range subchain {
+		// iterate from the end of the subchain if the direction is descending
+		hash := subchain[i]
+		if direction == network.Descending {
+			hash = subchain[len(subchain)-1-i]
+		}
+
+		blockData, err := s.getBlockData(hash, req.RequestedData)
+		if errors.Is(err, errBlockBodyNotFound) {
+			logger.Debugf("responding with %d blocks: %s", len(resp.data), err)
+			break
+		} else if err != nil {
+			return nil, err
+		}
 
-	for i, hash := range subchain {
-		data[i], err = s.getBlockData(hash, requestedData)
+		full, err := resp.add(blockData)
 		if err != nil {
 			return nil, err
+		} else if full {
+			break
 		}
 	}
 
-	// reverse BlockData, if descending request
-	if direction == network.Descending {
-		reverseBlockData(data)
+	return resp.message(), nil
+}
+
+// blockResponseBuilder accumulates the block data of a block response,
+// bounding the encoded size of the response to maxSize bytes.
+type blockResponseBuilder struct {
+	supportMultipleJustifications bool
+	maxSize                       int
+	data                          []*types.BlockData
+	size                          int
+}
+
+func newBlockResponseBuilder(req *network.BlockRequestMessage, capacity uint) *blockResponseBuilder {
+	return &blockResponseBuilder{
+		supportMultipleJustifications: req.SupportMultipleJustifications,
+		maxSize:                       maxResponseBytes,
+		data:                          make([]*types.BlockData, 0, capacity),
+	}
+}
+
+// add adds the block data to the response, unless the response would then exceed
+// its maximum size, in which case the block data is not added and full is returned as true.
+// The first block data is always added so the requester can make progress.
+func (b *blockResponseBuilder) add(blockData *types.BlockData) (full bool, err error) {
+	if b.supportMultipleJustifications && blockData.Justification != nil {
+		blockData.Justifications = &types.Justifications{{
+			EngineID:             types.GrandpaEngineID,
+			EncodedJustification: *blockData.Justification,
+		}}
+		blockData.Justification = nil
+	}
+
+	size, err := network.BlockDataSize(blockData)
+	if err != nil {
+		return false, fmt.Errorf("computing size of block data: %w", err)
 	}
 
+	if len(b.data) > 0 && b.size+size > b.maxSize {
+		logger.Debugf("responding with %d blocks: response size limit of %d bytes reached",
+			len(b.data), b.maxSize)
+		return true, nil
+	}
+
+	b.data = append(b.data, blockData)
+	b.size += size
+	return false, nil
+}
+
+func (b *blockResponseBuilder) message() *network.BlockResponseMessage {
 	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+		BlockData: b.data,
+	}
 }
 
 func (s *Service) getBlockDataByNumber(num uint, requestedData byte) (*types.BlockData, error) {
@@ -353,7 +432,10 @@ func (s *Service) getBlockData(hash common.Hash, requestedData byte) (*types.Blo
 
 	if (requestedData&network.RequestedDataBody)>>1 == 1 {
 		blockData.Body, err = s.blockState.GetBlockBody(hash)
-		if err != nil {
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			// the block body is pruned, so the response stops at this block
+			return nil, fmt.Errorf("%w: for block with hash %s", errBlockBodyNotFound, hash)
+		} else if err != nil {
 			logger.Debugf("failed to get body for block with hash %s: %s", hash, err)
 		}
 	}
@@ -379,5 +461,12 @@ func (s *Service) getBlockData(hash common.Hash, requestedData byte) (*types.Blo
 		}
 	}
 
+	if (requestedData&network.RequestedDataIndexedBody)>>5 == 1 {
+		retData, err := s.blockState.GetIndexedBody(hash)
+		if err == nil && retData != nil {
+			blockData.IndexedBody = &retData
+		}
+	}
+
 	return blockData, nil
 }
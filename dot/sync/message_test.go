@@ -7,12 +7,14 @@
 	"errors"
 	"testing"
 
+	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/common/variadic"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestService_CreateBlockResponse(t *testing.T) {
@@ -138,6 +140,52 @@ type args struct {
 				Hash: common.Hash{1, 2},
 			}}},
 		},
+		"ascending_request_pruned_body": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(3), nil)
+				mockBlockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{1}).Return(&types.Body{{1}}, nil)
+				mockBlockState.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{2}).Return(nil, chaindb.ErrKeyNotFound)
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				RequestedData: network.RequestedDataBody,
+				StartingBlock: *variadic.MustNewUint32OrHash(1),
+				Direction:     network.Ascending,
+			}},
+			want: &network.BlockResponseMessage{BlockData: []*types.BlockData{{
+				Hash: common.Hash{1},
+				Body: &types.Body{{1}},
+			}}},
+		},
+		"descending_request_startHash_pruned_body": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(common.Hash{3}).Return(&types.Header{
+					Number: 3,
+				}, nil)
+				mockBlockState.EXPECT().GetHeaderByNumber(uint(1)).Return(&types.Header{
+					Number: 1,
+				}, nil)
+				endHash := (&types.Header{Number: 1}).Hash()
+				mockBlockState.EXPECT().Range(endHash, common.Hash{3}).
+					Return([]common.Hash{endHash, {2}, {3}}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{3}).Return(&types.Body{{3}}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{2}).Return(nil, chaindb.ErrKeyNotFound)
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				RequestedData: network.RequestedDataBody,
+				StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{3}),
+				Direction:     network.Descending,
+			}},
+			want: &network.BlockResponseMessage{BlockData: []*types.BlockData{{
+				Hash: common.Hash{3},
+				Body: &types.Body{{3}},
+			}}},
+		},
 		"invalid_direction": {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				return nil
@@ -365,6 +413,21 @@ type args struct {
 				Justification: &[]byte{3},
 			},
 		},
+		"requestedData_RequestedDataIndexedBody": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetIndexedBody(common.Hash{4}).Return([][]byte{{4}}, nil)
+				return mockBlockState
+			},
+			args: args{
+				hash:          common.Hash{4},
+				requestedData: network.RequestedDataIndexedBody,
+			},
+			want: &types.BlockData{
+				Hash:        common.Hash{4},
+				IndexedBody: &[][]byte{{4}},
+			},
+		},
 	}
 	for name, tt := range tests {
 		tt := tt
@@ -384,3 +447,79 @@ type args struct {
 		})
 	}
 }
+
+func Test_blockResponseBuilder_add(t *testing.T) {
+	t.Parallel()
+
+	blockDataSize := func(t *testing.T, bd *types.BlockData) int {
+		t.Helper()
+		size, err := network.BlockDataSize(bd)
+		require.NoError(t, err)
+		return size
+	}
+
+	justification := []byte{1, 2}
+	testCases := map[string]struct {
+		builder      *blockResponseBuilder
+		blockData    *types.BlockData
+		full         bool
+		expectedData []*types.BlockData
+	}{
+		"first_block_data_exceeding_max_size": {
+			builder: &blockResponseBuilder{
+				maxSize: 1,
+			},
+			blockData:    &types.BlockData{Hash: common.Hash{1}},
+			expectedData: []*types.BlockData{{Hash: common.Hash{1}}},
+		},
+		"response_full": {
+			builder: &blockResponseBuilder{
+				maxSize: blockDataSize(t, &types.BlockData{}) + 1,
+				data:    []*types.BlockData{{}},
+				size:    blockDataSize(t, &types.BlockData{}),
+			},
+			blockData:    &types.BlockData{Hash: common.Hash{1}},
+			full:         true,
+			expectedData: []*types.BlockData{{}},
+		},
+		"single_justification": {
+			builder: &blockResponseBuilder{
+				maxSize: maxResponseBytes,
+			},
+			blockData: &types.BlockData{Justification: &justification},
+			expectedData: []*types.BlockData{
+				{Justification: &justification},
+			},
+		},
+		"multiple_justifications": {
+			builder: &blockResponseBuilder{
+				supportMultipleJustifications: true,
+				maxSize:                       maxResponseBytes,
+			},
+			blockData: &types.BlockData{Justification: &justification},
+			expectedData: []*types.BlockData{
+				{Justifications: &types.Justifications{
+					{EngineID: types.GrandpaEngineID, EncodedJustification: justification},
+				}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			full, err := testCase.builder.add(testCase.blockData)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.full, full)
+			assert.Equal(t, testCase.expectedData, testCase.builder.data)
+
+			var expectedSize int
+			for _, bd := range testCase.expectedData {
+				expectedSize += blockDataSize(t, bd)
+			}
+			assert.Equal(t, expectedSize, testCase.builder.size)
+		})
+	}
+}
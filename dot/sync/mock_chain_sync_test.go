@@ -133,6 +133,32 @@ func (mr *MockChainSyncMockRecorder) getHighestBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getHighestBlock", reflect.TypeOf((*MockChainSync)(nil).getHighestBlock))
 }
 
+// getPeerSyncStats mocks base method.
+func (m *MockChainSync) getPeerSyncStats() []common.PeerSyncStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getPeerSyncStats")
+	ret0, _ := ret[0].([]common.PeerSyncStats)
+	return ret0
+}
+
+// getPeerSyncStats indicates an expected call of getPeerSyncStats.
+func (mr *MockChainSyncMockRecorder) getPeerSyncStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getPeerSyncStats", reflect.TypeOf((*MockChainSync)(nil).getPeerSyncStats))
+}
+
+// removePeer mocks base method.
+func (m *MockChainSync) removePeer(p peer.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "removePeer", p)
+}
+
+// removePeer indicates an expected call of removePeer.
+func (mr *MockChainSyncMockRecorder) removePeer(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "removePeer", reflect.TypeOf((*MockChainSync)(nil).removePeer), p)
+}
+
 // setBlockAnnounce mocks base method.
 func (m *MockChainSync) setBlockAnnounce(from peer.ID, header *types.Header) error {
 	m.ctrl.T.Helper()
@@ -161,6 +187,18 @@ func (mr *MockChainSyncMockRecorder) setPeerHead(p, hash, number interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setPeerHead", reflect.TypeOf((*MockChainSync)(nil).setPeerHead), p, hash, number)
 }
 
+// setPeerRoles mocks base method.
+func (m *MockChainSync) setPeerRoles(p peer.ID, roles common.Roles) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "setPeerRoles", p, roles)
+}
+
+// setPeerRoles indicates an expected call of setPeerRoles.
+func (mr *MockChainSyncMockRecorder) setPeerRoles(p, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setPeerRoles", reflect.TypeOf((*MockChainSync)(nil).setPeerRoles), p, roles)
+}
+
 // start mocks base method.
 func (m *MockChainSync) start() {
 	m.ctrl.T.Helper()
@@ -54,6 +54,21 @@ func (mr *MockInstanceMockRecorder) ApplyExtrinsic(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyExtrinsic", reflect.TypeOf((*MockInstance)(nil).ApplyExtrinsic), arg0)
 }
 
+// AuthorityDiscoveryAuthorities mocks base method.
+func (m *MockInstance) AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityDiscoveryAuthorities")
+	ret0, _ := ret[0].([]types.AuthorityID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityDiscoveryAuthorities indicates an expected call of AuthorityDiscoveryAuthorities.
+func (mr *MockInstanceMockRecorder) AuthorityDiscoveryAuthorities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityDiscoveryAuthorities", reflect.TypeOf((*MockInstance)(nil).AuthorityDiscoveryAuthorities))
+}
+
 // BabeConfiguration mocks base method.
 func (m *MockInstance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,202 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// responseTimeSmoothing is the weight of the latest response time
+	// in the exponential moving average of the response times of a peer.
+	responseTimeSmoothing = 0.2
+
+	// minPeerScore is the minimum weight of a peer when picking a peer to sync
+	// from, so peers which failed in the past are still tried once in a while.
+	minPeerScore = 0.01
+)
+
+// peerSyncStats are the statistics of the block requests sent to a peer.
+type peerSyncStats struct {
+	requests         uint64
+	failedRequests   uint64
+	emptyResponses   uint64
+	invalidResponses uint64
+
+	// blocks is the number of blocks received in valid responses.
+	blocks uint64
+	// averageResponseTime is the exponential moving average of the response times of the valid responses.
+	averageResponseTime time.Duration
+	// totalResponseTime is the sum of the response times of the valid responses.
+	totalResponseTime time.Duration
+}
+
+func (s *peerSyncStats) validResponses() uint64 {
+	return s.requests - s.failedRequests - s.emptyResponses - s.invalidResponses
+}
+
+// blocksPerSecond returns the number of blocks received per second spent waiting for valid responses.
+func (s *peerSyncStats) blocksPerSecond() float64 {
+	if s.totalResponseTime == 0 {
+		return 0
+	}
+	return float64(s.blocks) / s.totalResponseTime.Seconds()
+}
+
+// score returns a score between 0 and 1, which is higher for peers answering quickly with valid
+// responses. Peers we did not send any request to yet have the highest score so they are tried.
+func (s *peerSyncStats) score() float64 {
+	if s == nil || s.requests == 0 {
+		return 1
+	}
+
+	validRate := float64(s.validResponses()) / float64(s.requests)
+	return validRate / (1 + s.averageResponseTime.Seconds())
+}
+
+// peersSyncStats keeps track of the sync statistics of each peer.
+type peersSyncStats struct {
+	sync.RWMutex
+	peers map[peer.ID]*peerSyncStats
+}
+
+func newPeersSyncStats() *peersSyncStats {
+	return &peersSyncStats{
+		peers: make(map[peer.ID]*peerSyncStats),
+	}
+}
+
+// get returns the statistics of the peer, and must be called with the lock held.
+func (p *peersSyncStats) get(who peer.ID) *peerSyncStats {
+	stats, ok := p.peers[who]
+	if !ok {
+		stats = new(peerSyncStats)
+		p.peers[who] = stats
+	}
+	return stats
+}
+
+// recordResponse records a valid response of the peer with the blocks given.
+func (p *peersSyncStats) recordResponse(who peer.ID, responseTime time.Duration, blocks int) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.get(who)
+	if stats.validResponses() == 0 {
+		stats.averageResponseTime = responseTime
+	} else {
+		stats.averageResponseTime = time.Duration(responseTimeSmoothing*float64(responseTime) +
+			(1-responseTimeSmoothing)*float64(stats.averageResponseTime))
+	}
+	stats.requests++
+	stats.blocks += uint64(blocks)
+	stats.totalResponseTime += responseTime
+}
+
+// recordFailure records a block request to the peer which failed, such as a timeout.
+func (p *peersSyncStats) recordFailure(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.get(who)
+	stats.requests++
+	stats.failedRequests++
+}
+
+// recordEmptyResponse records a response of the peer without any block.
+func (p *peersSyncStats) recordEmptyResponse(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.get(who)
+	stats.requests++
+	stats.emptyResponses++
+}
+
+// recordInvalidResponse records a response of the peer which failed validation.
+func (p *peersSyncStats) recordInvalidResponse(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.get(who)
+	stats.requests++
+	stats.invalidResponses++
+}
+
+// remove removes the statistics of the peer, once it is disconnected.
+func (p *peersSyncStats) remove(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.peers, who)
+}
+
+// pickPeer picks one of the peers given at random, weighting each of them by its score.
+func (p *peersSyncStats) pickPeer(peers []peer.ID) peer.ID {
+	p.RLock()
+	defer p.RUnlock()
+
+	weights := make([]float64, len(peers))
+	var total float64
+	for i, who := range peers {
+		weights[i] = math.Max(p.peers[who].score(), minPeerScore)
+		total += weights[i]
+	}
+
+	n, _ := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	target := float64(n.Int64()) / math.MaxInt64 * total
+	for i, weight := range weights {
+		if target < weight {
+			return peers[i]
+		}
+		target -= weight
+	}
+
+	// only reached due to floating point rounding
+	return peers[len(peers)-1]
+}
+
+// stats returns the sync statistics of all the peers, sorted by decreasing score.
+func (p *peersSyncStats) stats() []common.PeerSyncStats {
+	p.RLock()
+	defer p.RUnlock()
+
+	stats := make([]common.PeerSyncStats, 0, len(p.peers))
+	for who, peerStats := range p.peers {
+		var failureRate, invalidResponseRate float64
+		if peerStats.requests > 0 {
+			failureRate = float64(peerStats.failedRequests) / float64(peerStats.requests)
+			invalidResponseRate = float64(peerStats.emptyResponses+peerStats.invalidResponses) /
+				float64(peerStats.requests)
+		}
+
+		stats = append(stats, common.PeerSyncStats{
+			PeerID:              who.String(),
+			Requests:            peerStats.requests,
+			Blocks:              peerStats.blocks,
+			AverageResponseTime: peerStats.averageResponseTime,
+			BlocksPerSecond:     peerStats.blocksPerSecond(),
+			FailureRate:         failureRate,
+			InvalidResponseRate: invalidResponseRate,
+			Score:               peerStats.score(),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Score != stats[j].Score {
+			return stats[i].Score > stats[j].Score
+		}
+		return stats[i].PeerID < stats[j].PeerID
+	})
+
+	return stats
+}
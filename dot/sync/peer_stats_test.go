@@ -0,0 +1,153 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peerSyncStats_score(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		stats *peerSyncStats
+		score float64
+	}{
+		"nil_stats": {
+			score: 1,
+		},
+		"no_request": {
+			stats: &peerSyncStats{},
+			score: 1,
+		},
+		"all_requests_failed": {
+			stats: &peerSyncStats{
+				requests:       2,
+				failedRequests: 2,
+			},
+			score: 0,
+		},
+		"half_valid_responses": {
+			stats: &peerSyncStats{
+				requests:            4,
+				emptyResponses:      1,
+				invalidResponses:    1,
+				averageResponseTime: time.Second,
+			},
+			score: 0.25,
+		},
+		"all_valid_responses": {
+			stats: &peerSyncStats{
+				requests:            2,
+				averageResponseTime: 500 * time.Millisecond,
+			},
+			score: 1 / 1.5,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			score := testCase.stats.score()
+			assert.InDelta(t, testCase.score, score, 1e-9)
+		})
+	}
+}
+
+func Test_peersSyncStats_recordResponse(t *testing.T) {
+	t.Parallel()
+
+	stats := newPeersSyncStats()
+	stats.recordResponse("a", time.Second, 10)
+	stats.recordResponse("a", 2*time.Second, 20)
+	stats.recordFailure("a")
+	stats.recordEmptyResponse("a")
+	stats.recordInvalidResponse("a")
+
+	expected := &peerSyncStats{
+		requests:            5,
+		failedRequests:      1,
+		emptyResponses:      1,
+		invalidResponses:    1,
+		blocks:              30,
+		averageResponseTime: 1200 * time.Millisecond,
+		totalResponseTime:   3 * time.Second,
+	}
+	assert.Equal(t, expected, stats.peers["a"])
+	assert.InDelta(t, 10, stats.peers["a"].blocksPerSecond(), 1e-9)
+}
+
+func Test_peersSyncStats_remove(t *testing.T) {
+	t.Parallel()
+
+	stats := newPeersSyncStats()
+	stats.recordFailure("a")
+	stats.recordFailure("b")
+
+	stats.remove("a")
+	stats.remove("unknown")
+
+	assert.Equal(t, map[peer.ID]*peerSyncStats{
+		"b": {requests: 1, failedRequests: 1},
+	}, stats.peers)
+}
+
+func Test_peersSyncStats_pickPeer(t *testing.T) {
+	t.Parallel()
+
+	stats := newPeersSyncStats()
+	for i := 0; i < 100; i++ {
+		stats.recordFailure("bad")
+	}
+
+	peers := []peer.ID{"bad", "good"}
+	picks := make(map[peer.ID]int)
+	const draws = 1000
+	for i := 0; i < draws; i++ {
+		picks[stats.pickPeer(peers)]++
+	}
+
+	// the bad peer has a weight of minPeerScore against 1 for the good peer.
+	assert.Less(t, picks["bad"], draws/10)
+	assert.Equal(t, draws, picks["bad"]+picks["good"])
+
+	assert.Equal(t, peer.ID("only"), stats.pickPeer([]peer.ID{"only"}))
+}
+
+func Test_peersSyncStats_stats(t *testing.T) {
+	t.Parallel()
+
+	stats := newPeersSyncStats()
+	stats.recordFailure("b")
+	stats.recordResponse("b", time.Second, 4)
+	stats.recordResponse("a", time.Second, 2)
+
+	expected := []common.PeerSyncStats{
+		{
+			PeerID:              peer.ID("a").String(),
+			Requests:            1,
+			Blocks:              2,
+			AverageResponseTime: time.Second,
+			BlocksPerSecond:     2,
+			Score:               0.5,
+		},
+		{
+			PeerID:              peer.ID("b").String(),
+			Requests:            2,
+			Blocks:              4,
+			AverageResponseTime: time.Second,
+			BlocksPerSecond:     4,
+			FailureRate:         0.5,
+			Score:               0.25,
+		},
+	}
+	assert.Equal(t, expected, stats.stats())
+}
@@ -10,6 +10,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -97,9 +98,15 @@ func (s *Service) Stop() error {
 // HandleBlockAnnounceHandshake notifies the `chainSync` module that
 // we have received a BlockAnnounceHandshake from the given peer.
 func (s *Service) HandleBlockAnnounceHandshake(from peer.ID, msg *network.BlockAnnounceHandshake) error {
+	s.chainSync.setPeerRoles(from, msg.Roles)
 	return s.chainSync.setPeerHead(from, msg.BestBlockHash, uint(msg.BestBlockNumber))
 }
 
+// HandlePeerDisconnected notifies the `chainSync` module that the connection to the given peer is closed.
+func (s *Service) HandlePeerDisconnected(from peer.ID) {
+	s.chainSync.removePeer(from)
+}
+
 // HandleBlockAnnounce notifies the `chainSync` module that we have received a block announcement from the given peer.
 func (s *Service) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMessage) error {
 	logger.Debug("received BlockAnnounceMessage")
@@ -122,6 +129,12 @@ func (s *Service) HighestBlock() uint {
 	return highestBlock
 }
 
+// PeerSyncStats returns the statistics of the block requests sent to each peer,
+// sorted by decreasing score
+func (s *Service) PeerSyncStats() []common.PeerSyncStats {
+	return s.chainSync.getPeerSyncStats()
+}
+
 func reverseBlockData(data []*types.BlockData) {
 	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
 		data[i], data[j] = data[j], data[i]
@@ -141,6 +141,7 @@ func Test_Service_HandleBlockAnnounceHandshake(t *testing.T) {
 		"success": {
 			serviceBuilder: func(ctrl *gomock.Controller) Service {
 				chainSync := NewMockChainSync(ctrl)
+				chainSync.EXPECT().setPeerRoles(peer.ID("abc"), common.FullNodeRole)
 				chainSync.EXPECT().setPeerHead(peer.ID("abc"), common.Hash{1}, uint(2)).
 					Return(nil)
 				return Service{
@@ -149,6 +150,7 @@ func Test_Service_HandleBlockAnnounceHandshake(t *testing.T) {
 			},
 			from: peer.ID("abc"),
 			message: &network.BlockAnnounceHandshake{
+				Roles:           common.FullNodeRole,
 				BestBlockHash:   common.Hash{1},
 				BestBlockNumber: 2,
 			},
@@ -156,6 +158,7 @@ func Test_Service_HandleBlockAnnounceHandshake(t *testing.T) {
 		"failure": {
 			serviceBuilder: func(ctrl *gomock.Controller) Service {
 				chainSync := NewMockChainSync(ctrl)
+				chainSync.EXPECT().setPeerRoles(peer.ID("abc"), common.Roles(0))
 				chainSync.EXPECT().setPeerHead(peer.ID("abc"), common.Hash{1}, uint(2)).
 					Return(errTest)
 				return Service{
@@ -190,6 +193,19 @@ func Test_Service_HandleBlockAnnounceHandshake(t *testing.T) {
 	}
 }
 
+func Test_Service_HandlePeerDisconnected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	chainSync := NewMockChainSync(ctrl)
+	chainSync.EXPECT().removePeer(peer.ID("abc"))
+	service := Service{
+		chainSync: chainSync,
+	}
+
+	service.HandlePeerDisconnected(peer.ID("abc"))
+}
+
 func TestService_IsSynced(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,321 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var (
+	ErrTryRuntimeCodeEmpty = errors.New("runtime code is empty")
+)
+
+// tryRuntimeBlockState is the block state used to dry-run a runtime upgrade.
+type tryRuntimeBlockState interface {
+	BestBlockHash() common.Hash
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetHashByNumber(number uint) (common.Hash, error)
+	GetBlockByHash(hash common.Hash) (*types.Block, error)
+}
+
+// tryRuntimeStorageState is the storage state used to dry-run a runtime upgrade.
+type tryRuntimeStorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+}
+
+// TryRuntimeConfig is the configuration to dry-run a runtime
+// upgrade against the state of a block from the local database.
+type TryRuntimeConfig struct {
+	BasePath string
+	// Code is the wasm runtime code to try.
+	Code []byte
+	// BlockHash is the hash of the block to use the state of.
+	// It defaults to the best block hash if left empty.
+	BlockHash common.Hash
+	// Checks enables the pre and post upgrade checks of the migrations.
+	Checks bool
+	// Blocks is the number of blocks following the block
+	// to re-execute with the runtime code.
+	Blocks uint
+	LogLvl log.Level
+}
+
+// TryRuntimeReport is the result of a runtime upgrade dry-run.
+type TryRuntimeReport struct {
+	BlockHash    common.Hash
+	BlockNumber  uint
+	Version      runtime.Version
+	MetadataSize int
+	// UpgradeWeights is nil if the runtime upgrade failed,
+	// in which case UpgradeErr is set.
+	UpgradeWeights *runtime.UpgradeWeights
+	UpgradeErr     error
+	Blocks         []TryRuntimeBlockResult
+}
+
+// TryRuntimeBlockResult is the result of re-executing a block with the runtime code.
+type TryRuntimeBlockResult struct {
+	Hash      common.Hash
+	Number    uint
+	StateRoot common.Hash
+	// InvalidExtrinsics are the indexes of the block extrinsics
+	// the runtime considers invalid.
+	InvalidExtrinsics []int
+	Err               error
+}
+
+// TryRuntime loads the state at the configured block from the local database,
+// replaces the runtime code with the code given and then calls Core_version,
+// Metadata_metadata and TryRuntime_on_runtime_upgrade on it. It then re-executes
+// the configured number of blocks following the block, on top of the state of the
+// block and using the runtime code, reporting any runtime errors encountered.
+func TryRuntime(cfg TryRuntimeConfig) (report *TryRuntimeReport, err error) {
+	if len(cfg.Code) == 0 {
+		return nil, ErrTryRuntimeCodeEmpty
+	}
+
+	stateSrvc := state.NewService(state.Config{
+		Path:      cfg.BasePath,
+		LogLevel:  log.Warn,
+		Telemetry: telemetry.NewNoopMailer(),
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, fmt.Errorf("cannot setup state database: %w", err)
+	}
+
+	err = stateSrvc.Start()
+	if err != nil {
+		return nil, fmt.Errorf("cannot start state service: %w", err)
+	}
+	defer func() {
+		stopErr := stateSrvc.Stop()
+		if err == nil && stopErr != nil {
+			err = fmt.Errorf("cannot stop state service: %w", stopErr)
+		}
+	}()
+
+	nodeStorage, err := nodeBuilder{}.createRuntimeStorage(stateSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime storage: %w", err)
+	}
+
+	newInstance := func(storage runtime.Storage) (runtimeInterface, error) {
+		instance, err := wasmer.NewInstance(cfg.Code, wasmer.Config{
+			Storage:     storage,
+			LogLvl:      cfg.LogLvl,
+			Keystore:    keystore.NewGlobalKeystore(),
+			NodeStorage: *nodeStorage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return instance, nil
+	}
+
+	return tryRuntime(cfg, stateSrvc.Block, stateSrvc.Storage, newInstance)
+}
+
+// tryRuntime dry-runs the runtime upgrade and re-executes the following blocks
+// using the given block and storage states and the runtime instance constructor.
+func tryRuntime(cfg TryRuntimeConfig, blockState tryRuntimeBlockState, storageState tryRuntimeStorageState,
+	newInstance func(storage runtime.Storage) (runtimeInterface, error)) (report *TryRuntimeReport, err error) {
+	blockHash := cfg.BlockHash
+	if blockHash.IsEmpty() {
+		blockHash = blockState.BestBlockHash()
+	}
+
+	header, err := blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	report = &TryRuntimeReport{
+		BlockHash:   blockHash,
+		BlockNumber: header.Number,
+	}
+
+	// The runtime upgrade runs on its own copy of the block state, since
+	// the migrations run again when the first following block is executed.
+	upgradeState, err := tryRuntimeState(storageState, header.StateRoot, cfg.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := newInstance(upgradeState)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	report.Version, err = instance.Version()
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	metadata, err := instance.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime metadata: %w", err)
+	}
+	report.MetadataSize = len(metadata)
+
+	weights, err := tryRuntimeOnRuntimeUpgrade(instance, cfg.Checks)
+	if err != nil {
+		report.UpgradeErr = err
+	} else {
+		report.UpgradeWeights = &weights
+	}
+
+	if cfg.Blocks == 0 {
+		return report, nil
+	}
+
+	blocksState, err := tryRuntimeState(storageState, header.StateRoot, cfg.Code)
+	if err != nil {
+		return nil, err
+	}
+	instance.SetContextStorage(blocksState)
+
+	for number := header.Number + 1; number <= header.Number+cfg.Blocks; number++ {
+		hash, err := blockState.GetHashByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("getting hash of block number %d: %w", number, err)
+		}
+
+		block, err := blockState.GetBlockByHash(hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting block %s: %w", hash, err)
+		}
+
+		result := TryRuntimeBlockResult{
+			Hash:   hash,
+			Number: number,
+		}
+
+		finalisedHeader, invalidExtrinsics, err := tryRuntimeExecuteBlock(instance, block)
+		result.InvalidExtrinsics = invalidExtrinsics
+		if err != nil {
+			// the block state is left in an unknown state so stop here.
+			result.Err = err
+			report.Blocks = append(report.Blocks, result)
+			break
+		}
+
+		result.StateRoot = finalisedHeader.StateRoot
+		report.Blocks = append(report.Blocks, result)
+	}
+
+	return report, nil
+}
+
+// tryRuntimeState returns the trie state with the given root
+// from the database with its runtime code replaced.
+func tryRuntimeState(storageState tryRuntimeStorageState, root common.Hash, code []byte) (
+	trieState *rtstorage.TrieState, err error) {
+	trieState, err = storageState.TrieState(&root)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	err = trieState.Put(common.CodeKey, code)
+	if err != nil {
+		return nil, fmt.Errorf("replacing runtime code: %w", err)
+	}
+
+	return trieState, nil
+}
+
+// tryRuntimeOnRuntimeUpgrade calls the runtime function TryRuntime_on_runtime_upgrade,
+// running the runtime upgrade migrations with or without their pre and post upgrade checks,
+// and decodes the upgrade weights depending on the TryRuntime API version of the runtime.
+func tryRuntimeOnRuntimeUpgrade(instance runtimeInterface, checks bool) (
+	weights runtime.UpgradeWeights, err error) {
+	version, err := instance.Version()
+	if err != nil {
+		return weights, fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	tryRuntimeVersion, err := version.TryRuntimeVersion()
+	if err != nil {
+		return weights, fmt.Errorf("getting TryRuntime API version: %w", err)
+	}
+
+	encodedChecks, err := scale.Marshal(checks)
+	if err != nil {
+		return weights, fmt.Errorf("encoding checks: %w", err)
+	}
+
+	encodedWeights, err := instance.Exec(runtime.TryRuntimeOnRuntimeUpgrade, encodedChecks)
+	if err != nil {
+		return weights, err
+	}
+
+	return runtime.DecodeUpgradeWeights(encodedWeights, tryRuntimeVersion)
+}
+
+// tryRuntimeExecuteBlock executes the block using the block building runtime
+// calls instead of Core_execute_block, since the resulting state root is expected
+// to differ from the block state root if the runtime code changes the state.
+func tryRuntimeExecuteBlock(instance runtimeInterface, block *types.Block) (
+	header *types.Header, invalidExtrinsics []int, err error) {
+	header = types.NewEmptyHeader()
+	header.ParentHash = block.Header.ParentHash
+	header.Number = block.Header.Number
+
+	// remove seal digest only
+	for _, digestItem := range block.Header.Digest.Types {
+		digestValue, err := digestItem.Value()
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting digest type value: %w", err)
+		}
+
+		_, isSeal := digestValue.(types.SealDigest)
+		if isSeal {
+			continue
+		}
+
+		err = header.Digest.Add(digestValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("adding digest: %w", err)
+		}
+	}
+
+	err = instance.InitializeBlock(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialising block: %w", err)
+	}
+
+	for i, extrinsic := range block.Body {
+		result, err := instance.ApplyExtrinsic(extrinsic)
+		if err != nil {
+			return nil, invalidExtrinsics, fmt.Errorf("applying extrinsic %d: %w", i, err)
+		}
+
+		// the first byte of the ApplyExtrinsicResult is 0 if the extrinsic is valid,
+		// regardless of the result of its dispatch.
+		if len(result) == 0 || result[0] != 0 {
+			invalidExtrinsics = append(invalidExtrinsics, i)
+		}
+	}
+
+	header, err = instance.FinalizeBlock()
+	if err != nil {
+		return nil, invalidExtrinsics, fmt.Errorf("finalising block: %w", err)
+	}
+
+	return header, invalidExtrinsics, nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryRuntime_codeEmpty(t *testing.T) {
+	t.Parallel()
+
+	report, err := TryRuntime(TryRuntimeConfig{BasePath: t.TempDir()})
+
+	assert.ErrorIs(t, err, ErrTryRuntimeCodeEmpty)
+	assert.Nil(t, report)
+}
+
+func tryRuntimeTestVersion(t *testing.T, tryRuntimeVersion uint32) runtime.Version {
+	t.Helper()
+
+	name, err := common.Blake2b8([]byte("TryRuntime"))
+	require.NoError(t, err)
+
+	return runtime.Version{
+		SpecName:    []byte("test"),
+		SpecVersion: 2,
+		APIItems:    []runtime.APIItem{{Name: name, Ver: tryRuntimeVersion}},
+	}
+}
+
+func tryRuntimeTestEncode(t *testing.T, value interface{}) []byte {
+	t.Helper()
+
+	encoded, err := scale.Marshal(value)
+	require.NoError(t, err)
+	return encoded
+}
+
+func Test_tryRuntime(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	code := []byte{1, 2, 3}
+	parentHeader := &types.Header{Number: 5, StateRoot: common.Hash{5}}
+	parentHash := common.Hash{0xaa}
+	encodedChecks := tryRuntimeTestEncode(t, true)
+	encodedV1Weights := tryRuntimeTestEncode(t, [2]uint64{1000, 2000})
+	type weightV2 struct {
+		RefTime   *big.Int
+		ProofSize *big.Int
+	}
+	encodedV2Weights := tryRuntimeTestEncode(t, [2]weightV2{
+		{RefTime: big.NewInt(1000), ProofSize: big.NewInt(10)},
+		{RefTime: big.NewInt(2000000000000), ProofSize: big.NewInt(5242880)},
+	})
+	block6 := &types.Block{
+		Header: types.Header{ParentHash: parentHash, Number: 6},
+		Body:   types.Body{{1}, {2}},
+	}
+	block7 := &types.Block{
+		Header: types.Header{ParentHash: common.Hash{6}, Number: 7},
+	}
+
+	newTrieState := func(root *common.Hash) (*rtstorage.TrieState, error) {
+		return rtstorage.NewTrieState(trie.NewEmptyTrie()), nil
+	}
+
+	testCases := map[string]struct {
+		cfg          TryRuntimeConfig
+		buildState   func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState)
+		buildRuntime func(ctrl *gomock.Controller) *mocksruntime.MockInstance
+		instanceErr  error
+		report       *TryRuntimeReport
+		errWrapped   error
+		errMessage   string
+	}{
+		"header error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(nil, errTest)
+				return blockState, NewMocktryRuntimeStorageState(ctrl)
+			},
+			errWrapped: errTest,
+			errMessage: "getting header: test error",
+		},
+		"trie state error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).Return(nil, errTest)
+				return blockState, storageState
+			},
+			errWrapped: errTest,
+			errMessage: "getting trie state: test error",
+		},
+		"instance error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState)
+				return blockState, storageState
+			},
+			instanceErr: errTest,
+			errWrapped:  errTest,
+			errMessage:  "creating runtime instance: test error",
+		},
+		"metadata error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 1), nil)
+				instance.EXPECT().Metadata().Return(nil, errTest)
+				instance.EXPECT().Stop()
+				return instance
+			},
+			errWrapped: errTest,
+			errMessage: "getting runtime metadata: test error",
+		},
+		"v1 upgrade weights at best block": {
+			cfg: TryRuntimeConfig{Code: code, Checks: true},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().BestBlockHash().Return(parentHash)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 1), nil).Times(2)
+				instance.EXPECT().Metadata().Return([]byte{1, 2}, nil)
+				instance.EXPECT().Exec(runtime.TryRuntimeOnRuntimeUpgrade, encodedChecks).
+					Return(encodedV1Weights, nil)
+				instance.EXPECT().Stop()
+				return instance
+			},
+			report: &TryRuntimeReport{
+				BlockHash:      parentHash,
+				BlockNumber:    5,
+				Version:        tryRuntimeTestVersion(t, 1),
+				MetadataSize:   2,
+				UpgradeWeights: &runtime.UpgradeWeights{Weight: 1000, MaxWeight: 2000},
+			},
+		},
+		"v2 upgrade weights": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash, Checks: true},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 2), nil).Times(2)
+				instance.EXPECT().Metadata().Return([]byte{1}, nil)
+				instance.EXPECT().Exec(runtime.TryRuntimeOnRuntimeUpgrade, encodedChecks).
+					Return(encodedV2Weights, nil)
+				instance.EXPECT().Stop()
+				return instance
+			},
+			report: &TryRuntimeReport{
+				BlockHash:    parentHash,
+				BlockNumber:  5,
+				Version:      tryRuntimeTestVersion(t, 2),
+				MetadataSize: 1,
+				UpgradeWeights: &runtime.UpgradeWeights{
+					Weight:       1000,
+					MaxWeight:    2000000000000,
+					ProofSize:    10,
+					MaxProofSize: 5242880,
+				},
+			},
+		},
+		"upgrade weights decoding error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 1), nil).Times(2)
+				instance.EXPECT().Metadata().Return(nil, nil)
+				instance.EXPECT().Exec(runtime.TryRuntimeOnRuntimeUpgrade, []byte{0}).
+					Return([]byte{1}, nil)
+				instance.EXPECT().Stop()
+				return instance
+			},
+			report: &TryRuntimeReport{
+				BlockHash:   parentHash,
+				BlockNumber: 5,
+				Version:     tryRuntimeTestVersion(t, 1),
+				UpgradeErr:  fmt.Errorf("decoding weights: %w", errors.New("EOF")),
+			},
+		},
+		"upgrade and blocks execution errors": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash, Blocks: 3},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				blockState.EXPECT().GetHashByNumber(uint(6)).Return(common.Hash{6}, nil)
+				blockState.EXPECT().GetBlockByHash(common.Hash{6}).Return(block6, nil)
+				blockState.EXPECT().GetHashByNumber(uint(7)).Return(common.Hash{7}, nil)
+				blockState.EXPECT().GetBlockByHash(common.Hash{7}).Return(block7, nil)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState).Times(2)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 1), nil).Times(2)
+				instance.EXPECT().Metadata().Return(nil, nil)
+				instance.EXPECT().Exec(runtime.TryRuntimeOnRuntimeUpgrade, []byte{0}).
+					Return(nil, errTest)
+				instance.EXPECT().SetContextStorage(gomock.Any())
+				instance.EXPECT().InitializeBlock(gomock.Any()).Return(nil).Times(2)
+				instance.EXPECT().ApplyExtrinsic(types.Extrinsic{1}).Return([]byte{0, 0}, nil)
+				instance.EXPECT().ApplyExtrinsic(types.Extrinsic{2}).Return([]byte{1, 0}, nil)
+				instance.EXPECT().FinalizeBlock().Return(&types.Header{StateRoot: common.Hash{66}}, nil)
+				instance.EXPECT().FinalizeBlock().Return(nil, errTest)
+				instance.EXPECT().Stop()
+				return instance
+			},
+			report: &TryRuntimeReport{
+				BlockHash:   parentHash,
+				BlockNumber: 5,
+				Version:     tryRuntimeTestVersion(t, 1),
+				UpgradeErr:  errTest,
+				Blocks: []TryRuntimeBlockResult{{
+					Hash:              common.Hash{6},
+					Number:            6,
+					StateRoot:         common.Hash{66},
+					InvalidExtrinsics: []int{1},
+				}, {
+					Hash:   common.Hash{7},
+					Number: 7,
+					Err:    fmt.Errorf("finalising block: %w", errTest),
+				}},
+			},
+		},
+		"get block error": {
+			cfg: TryRuntimeConfig{Code: code, BlockHash: parentHash, Blocks: 1},
+			buildState: func(ctrl *gomock.Controller) (tryRuntimeBlockState, tryRuntimeStorageState) {
+				blockState := NewMocktryRuntimeBlockState(ctrl)
+				blockState.EXPECT().GetHeader(parentHash).Return(parentHeader, nil)
+				blockState.EXPECT().GetHashByNumber(uint(6)).Return(common.Hash{6}, nil)
+				blockState.EXPECT().GetBlockByHash(common.Hash{6}).Return(nil, errTest)
+				storageState := NewMocktryRuntimeStorageState(ctrl)
+				storageState.EXPECT().TrieState(&parentHeader.StateRoot).DoAndReturn(newTrieState).Times(2)
+				return blockState, storageState
+			},
+			buildRuntime: func(ctrl *gomock.Controller) *mocksruntime.MockInstance {
+				instance := mocksruntime.NewMockInstance(ctrl)
+				instance.EXPECT().Version().Return(tryRuntimeTestVersion(t, 1), nil).Times(2)
+				instance.EXPECT().Metadata().Return(nil, nil)
+				instance.EXPECT().Exec(runtime.TryRuntimeOnRuntimeUpgrade, []byte{0}).
+					Return(encodedV1Weights, nil)
+				instance.EXPECT().SetContextStorage(gomock.Any())
+				instance.EXPECT().Stop()
+				return instance
+			},
+			errWrapped: errTest,
+			errMessage: "getting block 0x0600000000000000000000000000000000000000000000000000000000000000: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState, storageState := testCase.buildState(ctrl)
+			newInstance := func(storage runtime.Storage) (runtimeInterface, error) {
+				if testCase.instanceErr != nil {
+					return nil, testCase.instanceErr
+				}
+				value, err := storage.Get(common.CodeKey)
+				require.NoError(t, err)
+				assert.Equal(t, code, value)
+				return testCase.buildRuntime(ctrl), nil
+			}
+
+			report, err := tryRuntime(testCase.cfg, blockState, storageState, newInstance)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.report, report)
+		})
+	}
+}
+
+func Test_tryRuntimeExecuteBlock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	preRuntimeDigest := types.PreRuntimeDigest{ConsensusEngineID: types.BabeEngineID, Data: []byte{1}}
+	block := &types.Block{
+		Header: *types.NewEmptyHeader(),
+		Body:   types.Body{{1}},
+	}
+	block.Header.ParentHash = common.Hash{1}
+	block.Header.Number = 2
+	err := block.Header.Digest.Add(preRuntimeDigest,
+		types.SealDigest{ConsensusEngineID: types.BabeEngineID, Data: []byte{2}})
+	require.NoError(t, err)
+
+	// the seal digest is removed from the header given to the runtime.
+	expectedHeader := types.NewEmptyHeader()
+	expectedHeader.ParentHash = common.Hash{1}
+	expectedHeader.Number = 2
+	err = expectedHeader.Digest.Add(preRuntimeDigest)
+	require.NoError(t, err)
+
+	finalisedHeader := &types.Header{Number: 2, StateRoot: common.Hash{3}}
+	instance := mocksruntime.NewMockInstance(ctrl)
+	instance.EXPECT().InitializeBlock(expectedHeader).Return(nil)
+	instance.EXPECT().ApplyExtrinsic(types.Extrinsic{1}).Return([]byte{0, 1}, nil)
+	instance.EXPECT().FinalizeBlock().Return(finalisedHeader, nil)
+
+	header, invalidExtrinsics, err := tryRuntimeExecuteBlock(instance, block)
+
+	require.NoError(t, err)
+	assert.Equal(t, finalisedHeader, header)
+	assert.Empty(t, invalidExtrinsics)
+}
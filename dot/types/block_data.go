@@ -19,6 +19,12 @@ type BlockData struct {
 	Receipt       *[]byte
 	MessageQueue  *[]byte
 	Justification *[]byte
+	// Justifications are only exchanged in block responses, when the requester
+	// supports multiple justifications, and are not part of the SCALE encoding of the block data.
+	Justifications *Justifications `scale:"-"`
+	// IndexedBody is only exchanged in block responses and
+	// is not part of the SCALE encoding of the block data.
+	IndexedBody *[][]byte `scale:"-"`
 }
 
 // NewEmptyBlockData Creates an empty blockData struct
@@ -54,5 +60,37 @@ func (bd *BlockData) String() string {
 		str = str + fmt.Sprintf("Justification=0x%x ", bd.Justification)
 	}
 
+	if bd.Justifications != nil {
+		str = str + fmt.Sprintf("Justifications=%s ", *bd.Justifications)
+	}
+
+	if bd.IndexedBody != nil {
+		str = str + fmt.Sprintf("IndexedBody=0x%x ", *bd.IndexedBody)
+	}
+
 	return str
 }
+
+// Justification is the encoded justification of a block for the consensus engine given.
+type Justification struct {
+	EngineID             ConsensusEngineID
+	EncodedJustification []byte
+}
+
+func (j Justification) String() string {
+	return fmt.Sprintf("%s=0x%x", j.EngineID, j.EncodedJustification)
+}
+
+// Justifications are the justifications of a block, at most one per consensus engine.
+type Justifications []Justification
+
+// Get returns the encoded justification for the consensus engine given,
+// and false if there is no justification for this engine.
+func (j Justifications) Get(engineID ConsensusEngineID) (encodedJustification []byte, ok bool) {
+	for _, justification := range j {
+		if justification.EngineID == engineID {
+			return justification.EncodedJustification, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,289 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+)
+
+var _ Database = (*BadgerDatabase)(nil)
+
+// BadgerDatabase is the badger v2 implementation of Database.
+type BadgerDatabase struct {
+	db   *badger.DB
+	path string
+}
+
+// NewBadger opens the badger database at the directory path given,
+// creating it if it does not exist. If in memory is true, the path
+// is ignored and the database is not persisted.
+func NewBadger(path string, inMemory bool) (database *BadgerDatabase, err error) {
+	var options badger.Options
+	if inMemory {
+		path = ""
+		options = badger.DefaultOptions("").WithInMemory(true)
+	} else {
+		err = os.MkdirAll(path, os.ModePerm)
+		if err != nil {
+			return nil, fmt.Errorf("creating database directory: %w", err)
+		}
+		options = badger.DefaultOptions(path)
+	}
+	options.Logger = nil
+
+	db, err := badger.Open(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadgerDatabase{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// Get returns the value at the given key, or an error
+// wrapping ErrKeyNotFound if the key is not found.
+func (b *BadgerDatabase) Get(key []byte) (value []byte, err error) {
+	err = b.db.View(func(txn *badger.Txn) error {
+		value, err = badgerGet(txn, key)
+		return err
+	})
+	return value, err
+}
+
+// Has returns true if the key given is in the database.
+func (b *BadgerDatabase) Has(key []byte) (has bool, err error) {
+	err = b.db.View(func(txn *badger.Txn) error {
+		has, err = badgerHas(txn, key)
+		return err
+	})
+	return has, err
+}
+
+// Put puts the value at the given key.
+func (b *BadgerDatabase) Put(key, value []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+// Del deletes the given key.
+func (b *BadgerDatabase) Del(key []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// NewBatch returns a new batch for the database.
+func (b *BadgerDatabase) NewBatch() Batch {
+	return &badgerBatch{db: b.db}
+}
+
+// NewPrefixIterator returns an iterator over the keys with the given prefix.
+func (b *BadgerDatabase) NewPrefixIterator(prefix []byte) Iterator {
+	txn := b.db.NewTransaction(false)
+	return newBadgerIterator(txn, prefix, true)
+}
+
+// NewSnapshot returns a read-only consistent view of the database.
+func (b *BadgerDatabase) NewSnapshot() (Snapshot, error) {
+	return &badgerSnapshot{txn: b.db.NewTransaction(false)}, nil
+}
+
+// Flush syncs the database writes to disk.
+func (b *BadgerDatabase) Flush() error {
+	return b.db.Sync()
+}
+
+// ClearAll deletes all the keys of the database.
+func (b *BadgerDatabase) ClearAll() error {
+	return b.db.DropAll()
+}
+
+// Path returns the path of the database directory.
+func (b *BadgerDatabase) Path() string {
+	return b.path
+}
+
+// Close closes the database.
+func (b *BadgerDatabase) Close() error {
+	return b.db.Close()
+}
+
+func badgerGet(txn *badger.Txn, key []byte) (value []byte, err error) {
+	item, err := txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, ErrKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func badgerHas(txn *badger.Txn, key []byte) (has bool, err error) {
+	_, err = txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type batchOperation struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// badgerBatch buffers operations in memory
+// and writes them all at once when flushed.
+type badgerBatch struct {
+	db         *badger.DB
+	operations []batchOperation
+	size       int
+}
+
+func (b *badgerBatch) Put(key, value []byte) error {
+	b.operations = append(b.operations, batchOperation{
+		key:   copyBytes(key),
+		value: copyBytes(value),
+	})
+	b.size += len(value)
+	return nil
+}
+
+func (b *badgerBatch) Del(key []byte) error {
+	b.operations = append(b.operations, batchOperation{
+		key:    copyBytes(key),
+		delete: true,
+	})
+	b.size++
+	return nil
+}
+
+func (b *badgerBatch) Flush() (err error) {
+	writeBatch := b.db.NewWriteBatch()
+	defer writeBatch.Cancel()
+
+	for _, operation := range b.operations {
+		if operation.delete {
+			err = writeBatch.Delete(operation.key)
+		} else {
+			err = writeBatch.Set(operation.key, operation.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeBatch.Flush()
+	if err != nil {
+		return err
+	}
+
+	b.Reset()
+	return nil
+}
+
+func (b *badgerBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *badgerBatch) Reset() {
+	b.operations = nil
+	b.size = 0
+}
+
+type badgerSnapshot struct {
+	txn *badger.Txn
+}
+
+func (s *badgerSnapshot) Get(key []byte) (value []byte, err error) {
+	return badgerGet(s.txn, key)
+}
+
+func (s *badgerSnapshot) Has(key []byte) (has bool, err error) {
+	return badgerHas(s.txn, key)
+}
+
+func (s *badgerSnapshot) NewPrefixIterator(prefix []byte) Iterator {
+	return newBadgerIterator(s.txn, prefix, false)
+}
+
+func (s *badgerSnapshot) Release() {
+	s.txn.Discard()
+}
+
+type badgerIterator struct {
+	txn        *badger.Txn
+	ownsTxn    bool
+	iterator   *badger.Iterator
+	prefix     []byte
+	positioned bool
+	err        error
+}
+
+func newBadgerIterator(txn *badger.Txn, prefix []byte, ownsTxn bool) *badgerIterator {
+	options := badger.DefaultIteratorOptions
+	options.Prefix = prefix
+	return &badgerIterator{
+		txn:      txn,
+		ownsTxn:  ownsTxn,
+		iterator: txn.NewIterator(options),
+		prefix:   prefix,
+	}
+}
+
+func (i *badgerIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	if i.positioned {
+		i.iterator.Next()
+	} else {
+		i.iterator.Seek(i.prefix)
+		i.positioned = true
+	}
+	return i.iterator.ValidForPrefix(i.prefix)
+}
+
+func (i *badgerIterator) Key() []byte {
+	return i.iterator.Item().KeyCopy(nil)
+}
+
+func (i *badgerIterator) Value() []byte {
+	value, err := i.iterator.Item().ValueCopy(nil)
+	if err != nil {
+		i.err = fmt.Errorf("copying value of key 0x%x: %w", i.iterator.Item().Key(), err)
+		return nil
+	}
+	return value
+}
+
+func (i *badgerIterator) Error() error {
+	return i.err
+}
+
+func (i *badgerIterator) Release() {
+	i.iterator.Close()
+	if i.ownsTxn {
+		i.txn.Discard()
+	}
+}
+
+func copyBytes(b []byte) (copied []byte) {
+	if b == nil {
+		return nil
+	}
+	copied = make([]byte, len(b))
+	copy(copied, b)
+	return copied
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import "fmt"
+
+// maxCopyBatchSize is the batched value size above which
+// the batch is flushed to the destination database.
+const maxCopyBatchSize = 64 * 1024 * 1024
+
+// Copy copies all the key value pairs of the source database to the
+// destination database, using a snapshot of the source database.
+// It returns the number of keys copied.
+func Copy(destination, source Database) (keys uint64, err error) {
+	snapshot, err := source.NewSnapshot()
+	if err != nil {
+		return 0, fmt.Errorf("creating source database snapshot: %w", err)
+	}
+	defer snapshot.Release()
+
+	iterator := snapshot.NewPrefixIterator(nil)
+	defer iterator.Release()
+
+	batch := destination.NewBatch()
+	for iterator.Next() {
+		err = batch.Put(iterator.Key(), iterator.Value())
+		if err != nil {
+			batch.Reset()
+			return keys, fmt.Errorf("putting key in batch: %w", err)
+		}
+		keys++
+
+		if batch.ValueSize() < maxCopyBatchSize {
+			continue
+		}
+
+		err = batch.Flush()
+		if err != nil {
+			return keys, fmt.Errorf("flushing batch: %w", err)
+		}
+	}
+
+	err = iterator.Error()
+	if err != nil {
+		batch.Reset()
+		return keys, fmt.Errorf("iterating over source database: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return keys, fmt.Errorf("flushing batch: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/chaindb"
+)
+
+var (
+	// ErrKeyNotFound is returned when a key is not found in the database.
+	// It is the chaindb error so existing not found checks keep working.
+	ErrKeyNotFound = chaindb.ErrKeyNotFound
+
+	ErrBackendNotValid  = errors.New("database backend is not valid")
+	ErrDatabaseNotFound = errors.New("database not found")
+)
+
+// Reader reads values from the database.
+type Reader interface {
+	Get(key []byte) (value []byte, err error)
+	Has(key []byte) (has bool, err error)
+}
+
+// Writer writes values to the database.
+type Writer interface {
+	Put(key, value []byte) error
+	Del(key []byte) error
+}
+
+// Batch is a write-only batch of operations, written
+// atomically to the database when flushed.
+type Batch = chaindb.Batch
+
+// Iterator iterates over key value pairs in ascending key order.
+// It must be released after use.
+type Iterator interface {
+	chaindb.Iterator
+	// Error returns the first error encountered while iterating,
+	// and should be checked once Next returns false.
+	Error() error
+}
+
+// PrefixIterable creates iterators over keys with a given prefix.
+type PrefixIterable interface {
+	NewPrefixIterator(prefix []byte) Iterator
+}
+
+// Snapshot is a read-only consistent view of the database.
+// It must be released after use.
+type Snapshot interface {
+	Reader
+	PrefixIterable
+	Release()
+}
+
+// Database is the key value database interface used by the state services.
+// All methods are safe for concurrent use.
+type Database interface {
+	Reader
+	Writer
+	PrefixIterable
+	NewBatch() Batch
+	NewSnapshot() (Snapshot, error)
+	// Flush syncs the database writes to disk.
+	Flush() error
+	// ClearAll deletes all the keys of the database.
+	ClearAll() error
+	Path() string
+	Close() error
+}
+
+// Backend is a key value database implementation.
+type Backend string
+
+const (
+	// Badger is the badger v2 database backend, used by default.
+	Badger Backend = "badger"
+	// LevelDB is the LevelDB database backend.
+	LevelDB Backend = "leveldb"
+)
+
+// IsValid returns true if the backend is supported.
+func (b Backend) IsValid() bool {
+	switch b {
+	case Badger, LevelDB:
+		return true
+	default:
+		return false
+	}
+}
+
+// New opens the database at the directory path given using the
+// backend given, creating it if it does not exist. If in memory is
+// true, the path is ignored and the database is not persisted.
+func New(backend Backend, path string, inMemory bool) (db Database, err error) {
+	switch backend {
+	case Badger:
+		db, err = NewBadger(path, inMemory)
+	case LevelDB:
+		db, err = NewLevelDB(path, inMemory)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrBackendNotValid, backend)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("opening %s database: %w", backend, err)
+	}
+	return db, nil
+}
+
+// Load opens the database at the directory path given using the backend
+// it was created with. If no database exists at the path, a badger database
+// is created. If in memory is true, an in-memory badger database is returned.
+func Load(path string, inMemory bool) (db Database, err error) {
+	if inMemory {
+		return New(Badger, "", true)
+	}
+
+	backend, err := DetectBackend(path)
+	if errors.Is(err, ErrDatabaseNotFound) {
+		backend = Badger
+	} else if err != nil {
+		return nil, fmt.Errorf("detecting database backend: %w", err)
+	}
+
+	return New(backend, path, false)
+}
+
+// DetectBackend returns the backend of the database at the directory path
+// given, using the files each backend creates. It returns an error wrapping
+// ErrDatabaseNotFound if no database exists at the path.
+func DetectBackend(path string) (backend Backend, err error) {
+	backendToFilename := []struct {
+		backend  Backend
+		filename string
+	}{
+		{backend: Badger, filename: "KEYREGISTRY"},
+		{backend: LevelDB, filename: "CURRENT"},
+	}
+
+	for _, pair := range backendToFilename {
+		_, err = os.Stat(filepath.Join(path, pair.filename))
+		if err == nil {
+			return pair.backend, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("%w: at %s", ErrDatabaseNotFound, path)
+}
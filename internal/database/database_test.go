@@ -0,0 +1,409 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDatabase(t *testing.T, backend Backend) Database {
+	t.Helper()
+
+	db, err := New(backend, "", true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		assert.NoError(t, err)
+	})
+	return db
+}
+
+func iterateAll(t *testing.T, iterator Iterator) (keyValues map[string]string) {
+	t.Helper()
+	defer iterator.Release()
+
+	keyValues = make(map[string]string)
+	var previousKey string
+	for iterator.Next() {
+		key := string(iterator.Key())
+		assert.Greater(t, key, previousKey, "keys are not in ascending order")
+		previousKey = key
+		keyValues[key] = string(iterator.Value())
+	}
+	require.NoError(t, iterator.Error())
+	return keyValues
+}
+
+// errorSnapshotDatabase is a database whose snapshot
+// iterators fail after iterating over their first key.
+type errorSnapshotDatabase struct {
+	Database
+	err error
+}
+
+func (d *errorSnapshotDatabase) NewSnapshot() (Snapshot, error) {
+	snapshot, err := d.Database.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &errorSnapshot{Snapshot: snapshot, err: d.err}, nil
+}
+
+type errorSnapshot struct {
+	Snapshot
+	err error
+}
+
+func (s *errorSnapshot) NewPrefixIterator(prefix []byte) Iterator {
+	return &errorIterator{Iterator: s.Snapshot.NewPrefixIterator(prefix), err: s.err}
+}
+
+type errorIterator struct {
+	Iterator
+	err   error
+	nexts int
+}
+
+func (i *errorIterator) Next() bool {
+	i.nexts++
+	return i.nexts == 1 && i.Iterator.Next()
+}
+
+func (i *errorIterator) Error() error {
+	if i.nexts > 1 {
+		return i.err
+	}
+	return nil
+}
+
+func Test_Backend_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Badger.IsValid())
+	assert.True(t, LevelDB.IsValid())
+	assert.False(t, Backend("pebble").IsValid())
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Backend("pebble"), "", true)
+	assert.ErrorIs(t, err, ErrBackendNotValid)
+	assert.EqualError(t, err, `database backend is not valid: "pebble"`)
+}
+
+func Test_Database(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []Backend{Badger, LevelDB} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDatabase(t, backend)
+
+			_, err := db.Get([]byte("key"))
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+
+			err = db.Put([]byte("key"), []byte("value"))
+			require.NoError(t, err)
+
+			value, err := db.Get([]byte("key"))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("value"), value)
+
+			has, err := db.Has([]byte("key"))
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			err = db.Del([]byte("key"))
+			require.NoError(t, err)
+
+			has, err = db.Has([]byte("key"))
+			require.NoError(t, err)
+			assert.False(t, has)
+		})
+	}
+}
+
+func Test_Database_NewBatch(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []Backend{Badger, LevelDB} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDatabase(t, backend)
+			err := db.Put([]byte("deleted"), []byte("value"))
+			require.NoError(t, err)
+
+			batch := db.NewBatch()
+			err = batch.Put([]byte("a"), []byte("1"))
+			require.NoError(t, err)
+			err = batch.Put([]byte("b"), []byte("22"))
+			require.NoError(t, err)
+			err = batch.Del([]byte("deleted"))
+			require.NoError(t, err)
+			assert.Equal(t, 4, batch.ValueSize())
+
+			has, err := db.Has([]byte("a"))
+			require.NoError(t, err)
+			assert.False(t, has, "batch written before being flushed")
+
+			err = batch.Flush()
+			require.NoError(t, err)
+			assert.Equal(t, 0, batch.ValueSize())
+
+			keyValues := iterateAll(t, db.NewPrefixIterator(nil))
+			expected := map[string]string{"a": "1", "b": "22"}
+			assert.Equal(t, expected, keyValues)
+
+			err = batch.Put([]byte("c"), []byte("3"))
+			require.NoError(t, err)
+			batch.Reset()
+			err = batch.Flush()
+			require.NoError(t, err)
+
+			has, err = db.Has([]byte("c"))
+			require.NoError(t, err)
+			assert.False(t, has, "batch reset is written")
+		})
+	}
+}
+
+func Test_Database_NewPrefixIterator(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []Backend{Badger, LevelDB} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDatabase(t, backend)
+			for _, key := range []string{"a", "ab1", "ab2", "abc", "b"} {
+				err := db.Put([]byte(key), []byte(key+"-value"))
+				require.NoError(t, err)
+			}
+
+			keyValues := iterateAll(t, db.NewPrefixIterator([]byte("ab")))
+			expected := map[string]string{
+				"ab1": "ab1-value",
+				"ab2": "ab2-value",
+				"abc": "abc-value",
+			}
+			assert.Equal(t, expected, keyValues)
+
+			keyValues = iterateAll(t, db.NewPrefixIterator([]byte("c")))
+			assert.Empty(t, keyValues)
+
+			keyValues = iterateAll(t, db.NewPrefixIterator(nil))
+			assert.Len(t, keyValues, 5)
+		})
+	}
+}
+
+func Test_Database_NewSnapshot(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []Backend{Badger, LevelDB} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDatabase(t, backend)
+			err := db.Put([]byte("a"), []byte("1"))
+			require.NoError(t, err)
+
+			snapshot, err := db.NewSnapshot()
+			require.NoError(t, err)
+			defer snapshot.Release()
+
+			err = db.Put([]byte("a"), []byte("2"))
+			require.NoError(t, err)
+			err = db.Put([]byte("b"), []byte("3"))
+			require.NoError(t, err)
+
+			value, err := snapshot.Get([]byte("a"))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("1"), value)
+
+			_, err = snapshot.Get([]byte("b"))
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+
+			has, err := snapshot.Has([]byte("b"))
+			require.NoError(t, err)
+			assert.False(t, has)
+
+			keyValues := iterateAll(t, snapshot.NewPrefixIterator(nil))
+			assert.Equal(t, map[string]string{"a": "1"}, keyValues)
+		})
+	}
+}
+
+func Test_Database_ClearAll(t *testing.T) {
+	t.Parallel()
+
+	for _, backend := range []Backend{Badger, LevelDB} {
+		backend := backend
+		t.Run(string(backend), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDatabase(t, backend)
+			for _, key := range []string{"a", "b", "c"} {
+				err := db.Put([]byte(key), []byte("value"))
+				require.NoError(t, err)
+			}
+
+			err := db.ClearAll()
+			require.NoError(t, err)
+
+			keyValues := iterateAll(t, db.NewPrefixIterator(nil))
+			assert.Empty(t, keyValues)
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		createBackend Backend
+		loadedBackend Backend
+	}{
+		"no database": {
+			loadedBackend: Badger,
+		},
+		"badger database": {
+			createBackend: Badger,
+			loadedBackend: Badger,
+		},
+		"leveldb database": {
+			createBackend: LevelDB,
+			loadedBackend: LevelDB,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := t.TempDir()
+
+			_, err := DetectBackend(path)
+			assert.ErrorIs(t, err, ErrDatabaseNotFound)
+
+			if testCase.createBackend != "" {
+				db, err := New(testCase.createBackend, path, false)
+				require.NoError(t, err)
+				err = db.Put([]byte("key"), []byte("value"))
+				require.NoError(t, err)
+				err = db.Close()
+				require.NoError(t, err)
+			}
+
+			db, err := Load(path, false)
+			require.NoError(t, err)
+			defer func() {
+				err := db.Close()
+				assert.NoError(t, err)
+			}()
+			assert.Equal(t, path, db.Path())
+
+			backend, err := DetectBackend(path)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.loadedBackend, backend)
+
+			if testCase.createBackend != "" {
+				value, err := db.Get([]byte("key"))
+				require.NoError(t, err)
+				assert.Equal(t, []byte("value"), value)
+			}
+		})
+	}
+}
+
+func Test_Copy(t *testing.T) {
+	t.Parallel()
+
+	source := newTestDatabase(t, Badger)
+	expected := map[string]string{"a": "1", "b": "2", "c": "3"}
+	for key, value := range expected {
+		err := source.Put([]byte(key), []byte(value))
+		require.NoError(t, err)
+	}
+
+	destination := newTestDatabase(t, LevelDB)
+	keys, err := Copy(destination, source)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), keys)
+
+	keyValues := iterateAll(t, destination.NewPrefixIterator(nil))
+	assert.Equal(t, expected, keyValues)
+}
+
+func Test_Copy_iteratorError(t *testing.T) {
+	t.Parallel()
+
+	source := newTestDatabase(t, Badger)
+	for _, key := range []string{"a", "b"} {
+		err := source.Put([]byte(key), []byte("value"))
+		require.NoError(t, err)
+	}
+
+	errTest := errors.New("test error")
+	destination := newTestDatabase(t, LevelDB)
+	keys, err := Copy(destination, &errorSnapshotDatabase{Database: source, err: errTest})
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "iterating over source database: test error")
+	assert.Equal(t, uint64(1), keys)
+
+	keyValues := iterateAll(t, destination.NewPrefixIterator(nil))
+	assert.Empty(t, keyValues)
+}
+
+func Test_Migrate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "db")
+	source, err := New(Badger, path, false)
+	require.NoError(t, err)
+	err = source.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = source.Close()
+	require.NoError(t, err)
+
+	_, _, err = Migrate(path, Badger)
+	assert.ErrorIs(t, err, ErrSameBackend)
+
+	backupPath, keys, err := Migrate(path, LevelDB)
+	require.NoError(t, err)
+	assert.Equal(t, path+"-badger", backupPath)
+	assert.Equal(t, uint64(1), keys)
+
+	backend, err := DetectBackend(path)
+	require.NoError(t, err)
+	assert.Equal(t, LevelDB, backend)
+
+	backend, err = DetectBackend(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, Badger, backend)
+
+	migrated, err := Load(path, false)
+	require.NoError(t, err)
+	defer func() {
+		err := migrated.Close()
+		assert.NoError(t, err)
+	}()
+
+	value, err := migrated.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
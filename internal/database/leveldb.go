@@ -0,0 +1,225 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ Database = (*LevelDBDatabase)(nil)
+
+// LevelDBDatabase is the LevelDB implementation of Database.
+type LevelDBDatabase struct {
+	db   *leveldb.DB
+	path string
+}
+
+// NewLevelDB opens the LevelDB database at the directory path given,
+// creating it if it does not exist. If in memory is true, the path
+// is ignored and the database is not persisted.
+func NewLevelDB(path string, inMemory bool) (database *LevelDBDatabase, err error) {
+	var db *leveldb.DB
+	if inMemory {
+		path = ""
+		db, err = leveldb.Open(storage.NewMemStorage(), nil)
+	} else {
+		db, err = leveldb.OpenFile(path, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LevelDBDatabase{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// Get returns the value at the given key, or an error
+// wrapping ErrKeyNotFound if the key is not found.
+func (l *LevelDBDatabase) Get(key []byte) (value []byte, err error) {
+	value, err = l.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+// Has returns true if the key given is in the database.
+func (l *LevelDBDatabase) Has(key []byte) (has bool, err error) {
+	return l.db.Has(key, nil)
+}
+
+// Put puts the value at the given key.
+func (l *LevelDBDatabase) Put(key, value []byte) error {
+	return l.db.Put(key, value, nil)
+}
+
+// Del deletes the given key.
+func (l *LevelDBDatabase) Del(key []byte) error {
+	return l.db.Delete(key, nil)
+}
+
+// NewBatch returns a new batch for the database.
+func (l *LevelDBDatabase) NewBatch() Batch {
+	return &levelDBBatch{
+		db:    l.db,
+		batch: new(leveldb.Batch),
+	}
+}
+
+// NewPrefixIterator returns an iterator over the keys with the given prefix.
+func (l *LevelDBDatabase) NewPrefixIterator(prefix []byte) Iterator {
+	return &levelDBIterator{
+		iterator: l.db.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+// NewSnapshot returns a read-only consistent view of the database.
+func (l *LevelDBDatabase) NewSnapshot() (Snapshot, error) {
+	snapshot, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBSnapshot{snapshot: snapshot}, nil
+}
+
+// Flush is a no-op since writes are written
+// to the LevelDB journal as they are made.
+func (*LevelDBDatabase) Flush() error {
+	return nil
+}
+
+// ClearAll deletes all the keys of the database.
+func (l *LevelDBDatabase) ClearAll() error {
+	const maxBatchLength = 10000
+
+	keysIterator := l.db.NewIterator(nil, nil)
+	defer keysIterator.Release()
+
+	batch := new(leveldb.Batch)
+	for keysIterator.Next() {
+		batch.Delete(copyBytes(keysIterator.Key()))
+		if batch.Len() < maxBatchLength {
+			continue
+		}
+
+		err := l.db.Write(batch, nil)
+		if err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+
+	err := keysIterator.Error()
+	if err != nil {
+		return err
+	}
+
+	return l.db.Write(batch, nil)
+}
+
+// Path returns the path of the database directory.
+func (l *LevelDBDatabase) Path() string {
+	return l.path
+}
+
+// Close closes the database.
+func (l *LevelDBDatabase) Close() error {
+	return l.db.Close()
+}
+
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+	size  int
+}
+
+func (b *levelDBBatch) Put(key, value []byte) error {
+	b.batch.Put(key, value)
+	b.size += len(value)
+	return nil
+}
+
+func (b *levelDBBatch) Del(key []byte) error {
+	b.batch.Delete(key)
+	b.size++
+	return nil
+}
+
+func (b *levelDBBatch) Flush() error {
+	err := b.db.Write(b.batch, nil)
+	if err != nil {
+		return err
+	}
+
+	b.Reset()
+	return nil
+}
+
+func (b *levelDBBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *levelDBBatch) Reset() {
+	b.batch.Reset()
+	b.size = 0
+}
+
+type levelDBSnapshot struct {
+	snapshot *leveldb.Snapshot
+}
+
+func (s *levelDBSnapshot) Get(key []byte) (value []byte, err error) {
+	value, err = s.snapshot.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+func (s *levelDBSnapshot) Has(key []byte) (has bool, err error) {
+	return s.snapshot.Has(key, nil)
+}
+
+func (s *levelDBSnapshot) NewPrefixIterator(prefix []byte) Iterator {
+	return &levelDBIterator{
+		iterator: s.snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+func (s *levelDBSnapshot) Release() {
+	s.snapshot.Release()
+}
+
+// levelDBIterator copies keys and values since the LevelDB
+// iterator reuses their buffers when moving to the next key.
+type levelDBIterator struct {
+	iterator iterator.Iterator
+}
+
+func (i *levelDBIterator) Next() bool {
+	return i.iterator.Next()
+}
+
+func (i *levelDBIterator) Key() []byte {
+	return copyBytes(i.iterator.Key())
+}
+
+func (i *levelDBIterator) Value() []byte {
+	return copyBytes(i.iterator.Value())
+}
+
+func (i *levelDBIterator) Error() error {
+	return i.iterator.Error()
+}
+
+func (i *levelDBIterator) Release() {
+	i.iterator.Release()
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrSameBackend = errors.New("database already uses backend")
+
+// Migrate copies the database at the directory path given to a new database
+// using the backend given, and replaces the database with the new database.
+// The original database is kept at the backup path returned, which is the
+// database path suffixed with the original backend name.
+// It returns the number of keys copied.
+func Migrate(path string, backend Backend) (backupPath string, keys uint64, err error) {
+	if !backend.IsValid() {
+		return "", 0, fmt.Errorf("%w: %q", ErrBackendNotValid, backend)
+	}
+
+	sourceBackend, err := DetectBackend(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("detecting database backend: %w", err)
+	} else if sourceBackend == backend {
+		return "", 0, fmt.Errorf("%w: %s", ErrSameBackend, backend)
+	}
+
+	backupPath = path + "-" + string(sourceBackend)
+	_, err = os.Stat(backupPath)
+	if err == nil {
+		return "", 0, fmt.Errorf("backup path %s already exists", backupPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", 0, fmt.Errorf("checking backup path: %w", err)
+	}
+
+	// Remove any database left by an interrupted migration.
+	migratedPath := path + "-" + string(backend)
+	err = os.RemoveAll(migratedPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("removing previously migrated database: %w", err)
+	}
+
+	keys, err = copyToBackend(path, sourceBackend, migratedPath, backend)
+	if err != nil {
+		// the database is left in place and the partial copy is removed.
+		removeErr := os.RemoveAll(migratedPath)
+		if removeErr != nil {
+			return "", keys, fmt.Errorf("%w (removing partially migrated database: %s)", err, removeErr)
+		}
+		return "", keys, err
+	}
+
+	err = os.Rename(path, backupPath)
+	if err != nil {
+		return "", keys, fmt.Errorf("moving database to backup path: %w", err)
+	}
+
+	err = os.Rename(migratedPath, path)
+	if err != nil {
+		return "", keys, fmt.Errorf("moving migrated database to database path: %w", err)
+	}
+
+	return backupPath, keys, nil
+}
+
+func copyToBackend(sourcePath string, sourceBackend Backend,
+	destinationPath string, destinationBackend Backend) (keys uint64, err error) {
+	source, err := New(sourceBackend, sourcePath, false)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := source.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing source database: %w", closeErr)
+		}
+	}()
+
+	destination, err := New(destinationBackend, destinationPath, false)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := destination.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing destination database: %w", closeErr)
+		}
+	}()
+
+	keys, err = Copy(destination, source)
+	if err != nil {
+		return keys, fmt.Errorf("copying database: %w", err)
+	}
+
+	return keys, nil
+}
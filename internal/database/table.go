@@ -0,0 +1,123 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import "bytes"
+
+// Table is a view of a database where every key
+// is prefixed with the table prefix.
+type Table struct {
+	db     Database
+	prefix []byte
+}
+
+// NewTable returns a table of the database given using the given key prefix.
+func NewTable(db Database, prefix string) *Table {
+	return &Table{
+		db:     db,
+		prefix: []byte(prefix),
+	}
+}
+
+// Get returns the value at the given key of the table.
+func (t *Table) Get(key []byte) (value []byte, err error) {
+	return t.db.Get(t.prefixed(key))
+}
+
+// Has returns true if the key given is in the table.
+func (t *Table) Has(key []byte) (has bool, err error) {
+	return t.db.Has(t.prefixed(key))
+}
+
+// Put puts the value at the given key of the table.
+func (t *Table) Put(key, value []byte) error {
+	return t.db.Put(t.prefixed(key), value)
+}
+
+// Del deletes the given key of the table.
+func (t *Table) Del(key []byte) error {
+	return t.db.Del(t.prefixed(key))
+}
+
+// NewBatch returns a new batch writing to the table.
+func (t *Table) NewBatch() Batch {
+	return &tableBatch{
+		batch: t.db.NewBatch(),
+		table: t,
+	}
+}
+
+// WrapBatch returns a batch writing to the table using the given batch of the
+// table database, so writes to several tables can be flushed atomically.
+func (t *Table) WrapBatch(batch Batch) Batch {
+	return &tableBatch{
+		batch: batch,
+		table: t,
+	}
+}
+
+// NewPrefixIterator returns an iterator over the table keys with the given
+// prefix. The keys returned by the iterator do not contain the table prefix.
+func (t *Table) NewPrefixIterator(prefix []byte) Iterator {
+	return &tableIterator{
+		iterator:    t.db.NewPrefixIterator(t.prefixed(prefix)),
+		tablePrefix: t.prefix,
+	}
+}
+
+func (t *Table) prefixed(key []byte) (prefixedKey []byte) {
+	prefixedKey = make([]byte, 0, len(t.prefix)+len(key))
+	prefixedKey = append(prefixedKey, t.prefix...)
+	return append(prefixedKey, key...)
+}
+
+type tableBatch struct {
+	batch Batch
+	table *Table
+}
+
+func (b *tableBatch) Put(key, value []byte) error {
+	return b.batch.Put(b.table.prefixed(key), value)
+}
+
+func (b *tableBatch) Del(key []byte) error {
+	return b.batch.Del(b.table.prefixed(key))
+}
+
+func (b *tableBatch) Flush() error {
+	return b.batch.Flush()
+}
+
+func (b *tableBatch) ValueSize() int {
+	return b.batch.ValueSize()
+}
+
+func (b *tableBatch) Reset() {
+	b.batch.Reset()
+}
+
+type tableIterator struct {
+	iterator    Iterator
+	tablePrefix []byte
+}
+
+func (i *tableIterator) Next() bool {
+	return i.iterator.Next()
+}
+
+func (i *tableIterator) Key() []byte {
+	return bytes.TrimPrefix(i.iterator.Key(), i.tablePrefix)
+}
+
+func (i *tableIterator) Value() []byte {
+	return i.iterator.Value()
+}
+
+func (i *tableIterator) Error() error {
+	return i.iterator.Error()
+}
+
+func (i *tableIterator) Release() {
+	i.iterator.Release()
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Table(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDatabase(t, LevelDB)
+	table := NewTable(db, "table")
+
+	err := table.Put([]byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	value, err := db.Get([]byte("tablekey1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	value, err = table.Get([]byte("key1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	batch := table.NewBatch()
+	err = batch.Put([]byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+	err = batch.Del([]byte("key1"))
+	require.NoError(t, err)
+	err = batch.Flush()
+	require.NoError(t, err)
+
+	has, err := table.Has([]byte("key1"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	err = db.Put([]byte("other"), []byte("value"))
+	require.NoError(t, err)
+
+	keyValues := iterateAll(t, table.NewPrefixIterator([]byte("key")))
+	assert.Equal(t, map[string]string{"key2": "value2"}, keyValues)
+
+	err = table.Del([]byte("key2"))
+	require.NoError(t, err)
+
+	_, err = db.Get([]byte("tablekey2"))
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// targetSeparator separates the parent and child parts of a log target,
+// for example in `runtime::system`.
+const targetSeparator = "::"
+
+var (
+	ErrDirectiveMalformed = errors.New("log directive is malformed")
+)
+
+// Directive sets the log level of a log target.
+// A log target is a node package name such as `sync`,
+// or a runtime log target such as `runtime::system`.
+// An empty target applies to all log targets.
+type Directive struct {
+	Target string
+	Level  Level
+}
+
+// ParseDirectives parses a comma separated list of directives
+// of the form `target=level`. A directive with only a level sets
+// the level of all targets, and a directive with only a target
+// sets the level of this target to trace.
+func ParseDirectives(s string) (directives []Directive, err error) {
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		target, levelString, hasLevel := strings.Cut(field, "=")
+		if !hasLevel {
+			level, err := ParseLevel(field)
+			if err == nil {
+				directives = append(directives, Directive{Level: level})
+				continue
+			}
+			directives = append(directives, Directive{Target: field, Level: Trace})
+			continue
+		}
+
+		if target == "" {
+			return nil, fmt.Errorf("%w: %q: target is empty", ErrDirectiveMalformed, field)
+		}
+
+		level, err := ParseLevel(levelString)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrDirectiveMalformed, field, err)
+		}
+		directives = append(directives, Directive{Target: target, Level: level})
+	}
+	return directives, nil
+}
+
+// filter holds log levels by log target which override the
+// level of loggers logging for the target or a child of the target.
+type filter struct {
+	mutex   sync.RWMutex
+	initial map[string]Level
+	levels  map[string]Level
+}
+
+func newFilter() *filter {
+	return &filter{
+		initial: map[string]Level{},
+		levels:  map[string]Level{},
+	}
+}
+
+func (f *filter) set(levels map[string]Level) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.initial = make(map[string]Level, len(levels))
+	f.levels = make(map[string]Level, len(levels))
+	for target, level := range levels {
+		f.initial[target] = level
+		f.levels[target] = level
+	}
+}
+
+func (f *filter) add(directives []Directive) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, directive := range directives {
+		f.levels[directive.Target] = directive.Level
+	}
+}
+
+func (f *filter) reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.levels = make(map[string]Level, len(f.initial))
+	for target, level := range f.initial {
+		f.levels[target] = level
+	}
+}
+
+// level returns the level of the most specific target
+// configured for the target given, going from `a::b::c`
+// to `a::b`, `a` and finally the empty target.
+func (f *filter) level(target string) (level Level, ok bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if len(f.levels) == 0 {
+		return 0, false
+	}
+
+	for {
+		level, ok = f.levels[target]
+		if ok || target == "" {
+			return level, ok
+		}
+
+		i := strings.LastIndex(target, targetSeparator)
+		if i == -1 {
+			target = ""
+		} else {
+			target = target[:i]
+		}
+	}
+}
+
+func (f *filter) maxLevel() (level Level, ok bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for _, targetLevel := range f.levels {
+		if !ok || targetLevel > level {
+			level = targetLevel
+			ok = true
+		}
+	}
+	return level, ok
+}
+
+var globalFilter = newFilter()
+
+// SetFilter sets the log levels by target, overriding
+// the levels of loggers for these targets. These levels
+// are restored when calling ResetFilter.
+func SetFilter(levels map[string]Level) {
+	globalFilter.set(levels)
+}
+
+// AddFilter parses and adds the directives given to the log filter.
+func AddFilter(directives string) (err error) {
+	parsed, err := ParseDirectives(directives)
+	if err != nil {
+		return err
+	}
+	globalFilter.add(parsed)
+	return nil
+}
+
+// ResetFilter restores the log filter to the levels given to SetFilter.
+func ResetFilter() {
+	globalFilter.reset()
+}
+
+// MaxFilterLevel returns the maximum level set in the log filter,
+// and false if the log filter is empty.
+func MaxFilterLevel() (level Level, ok bool) {
+	return globalFilter.maxLevel()
+}
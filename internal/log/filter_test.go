@@ -0,0 +1,177 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package log
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDirectives(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s          string
+		directives []Directive
+		errWrapped error
+		errMessage string
+	}{
+		"empty_string": {},
+		"target_and_level": {
+			s:          "runtime::system=trace",
+			directives: []Directive{{Target: "runtime::system", Level: Trace}},
+		},
+		"multiple_directives": {
+			s: "sync=debug, runtime=2,,",
+			directives: []Directive{
+				{Target: "sync", Level: Debug},
+				{Target: "runtime", Level: Warn},
+			},
+		},
+		"level_only": {
+			s:          "info",
+			directives: []Directive{{Level: Info}},
+		},
+		"target_only": {
+			s:          "runtime::staking",
+			directives: []Directive{{Target: "runtime::staking", Level: Trace}},
+		},
+		"empty_target": {
+			s:          "=info",
+			errWrapped: ErrDirectiveMalformed,
+			errMessage: `log directive is malformed: "=info": target is empty`,
+		},
+		"bad_level": {
+			s:          "sync=loud",
+			errWrapped: ErrDirectiveMalformed,
+			errMessage: `log directive is malformed: "sync=loud": ` +
+				"level is not recognised: loud",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			directives, err := ParseDirectives(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.directives, directives)
+		})
+	}
+}
+
+func Test_filter_level(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		levels map[string]Level
+		target string
+		level  Level
+		ok     bool
+	}{
+		"empty_filter": {
+			target: "sync",
+		},
+		"exact_target": {
+			levels: map[string]Level{"sync": Debug},
+			target: "sync",
+			level:  Debug,
+			ok:     true,
+		},
+		"parent_target": {
+			levels: map[string]Level{"runtime": Warn, "runtime::system": Trace},
+			target: "runtime::staking::slashing",
+			level:  Warn,
+			ok:     true,
+		},
+		"most_specific_target": {
+			levels: map[string]Level{"runtime": Warn, "runtime::system": Trace},
+			target: "runtime::system",
+			level:  Trace,
+			ok:     true,
+		},
+		"empty_target_fallback": {
+			levels: map[string]Level{"": Error, "sync": Debug},
+			target: "network",
+			level:  Error,
+			ok:     true,
+		},
+		"target_not_matched": {
+			levels: map[string]Level{"runtime::system": Trace},
+			target: "runtime",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newFilter()
+			f.set(testCase.levels)
+
+			level, ok := f.level(testCase.target)
+
+			assert.Equal(t, testCase.level, level)
+			assert.Equal(t, testCase.ok, ok)
+		})
+	}
+}
+
+func Test_filter_addReset(t *testing.T) {
+	t.Parallel()
+
+	f := newFilter()
+	f.set(map[string]Level{"sync": Info})
+
+	f.add([]Directive{{Target: "sync", Level: Trace}, {Target: "runtime", Level: Debug}})
+
+	level, ok := f.level("sync")
+	assert.True(t, ok)
+	assert.Equal(t, Trace, level)
+	level, ok = f.maxLevel()
+	assert.True(t, ok)
+	assert.Equal(t, Trace, level)
+
+	f.reset()
+
+	level, ok = f.level("sync")
+	assert.True(t, ok)
+	assert.Equal(t, Info, level)
+	_, ok = f.level("runtime")
+	assert.False(t, ok)
+}
+
+// Test_Logger_filter is not parallel since it modifies the global filter.
+func Test_Logger_filter(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	logger := New(SetWriter(buffer), SetLevel(Info), AddContext("pkg", "runtime"))
+	targetLogger := logger.New(AddContext("target", "runtime::system"))
+
+	err := AddFilter("runtime::system=trace")
+	require.NoError(t, err)
+	t.Cleanup(ResetFilter)
+
+	logger.Debug("package")
+	targetLogger.Debug("target")
+
+	assert.NotContains(t, buffer.String(), "package")
+	assert.Contains(t, buffer.String(), "target")
+	assert.Equal(t, Info, logger.Level())
+	assert.Equal(t, Trace, targetLogger.Level())
+
+	ResetFilter()
+	buffer.Reset()
+	targetLogger.Debug("target")
+
+	assert.Empty(t, buffer.String())
+}
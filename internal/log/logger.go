@@ -51,3 +51,18 @@ func (l *Logger) New(options ...Option) *Logger {
 
 	return newLogger
 }
+
+// Level returns the level the logger logs at, taking into
+// account the log filter level set for the logger target.
+func (l *Logger) Level() (level Level) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.level()
+}
+
+func (l *Logger) level() (level Level) {
+	if filterLevel, ok := globalFilter.level(l.settings.target()); ok {
+		return filterLevel
+	}
+	return *l.settings.level
+}
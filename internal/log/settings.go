@@ -84,3 +84,17 @@ func (s *settings) mergeWith(other settings) {
 		s.context = append(s.context, kvsCopy)
 	}
 }
+
+// target returns the log target of the settings, which is the
+// last value of the `target` context key if set, and otherwise
+// the last value of the `pkg` context key.
+func (s *settings) target() (target string) {
+	for _, key := range [...]string{"target", "pkg"} {
+		for _, kvs := range s.context {
+			if kvs.key == key && len(kvs.values) > 0 {
+				return kvs.values[len(kvs.values)-1]
+			}
+		}
+	}
+	return ""
+}
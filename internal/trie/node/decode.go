@@ -105,7 +105,12 @@ func decodeBranch(reader io.Reader, variant byte, partialKeyLength uint16) (
 		childNode := &Node{
 			MerkleValue: hash,
 		}
-		if len(hash) < hashLength {
+		if len(hash) == 0 {
+			// Omitted child reference, only found in compact proof
+			// node encodings where the child node encoding follows
+			// in the proof. See IsOmittedReference.
+			childNode.MerkleValue = []byte{}
+		} else if len(hash) < hashLength {
 			// Handle inlined nodes
 			reader = bytes.NewReader(hash)
 			childNode, err = Decode(reader)
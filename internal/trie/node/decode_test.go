@@ -188,6 +188,28 @@ func Test_decodeBranch(t *testing.T) {
 				Descendants: 1,
 			},
 		},
+		"success_for_omitted_child_reference": {
+			reader: bytes.NewBuffer(
+				concatByteSlices([][]byte{
+					{9},    // key data
+					{0, 4}, // children bitmap
+					{0},    // empty child reference
+				}),
+			),
+			variant:          branchVariant.bits,
+			partialKeyLength: 1,
+			branch: &Node{
+				PartialKey: []byte{9},
+				Children: padRightChildren([]*Node{
+					nil, nil, nil, nil, nil,
+					nil, nil, nil, nil, nil,
+					{
+						MerkleValue: []byte{},
+					},
+				}),
+				Descendants: 1,
+			},
+		},
 		"value_decoding_error_for_branch_with_value_variant": {
 			reader: bytes.NewBuffer(
 				concatByteSlices([][]byte{
@@ -228,13 +250,16 @@ func Test_decodeBranch(t *testing.T) {
 				{1},                        // key data
 				{0b0000_0001, 0b0000_0000}, // children bitmap
 				scaleEncodeBytes(t, 1),     // branch storage value
-				{0},                        // garbage inlined node
+				scaleEncodeByteSlice(t, []byte{
+					leafVariant.bits | 1, // partial key length of 1
+					// missing key data
+				}),
 			})),
 			variant:          branchWithValueVariant.bits,
 			partialKeyLength: 1,
 			errWrapped:       io.EOF,
 			errMessage: "decoding inlined child at index 0: " +
-				"decoding header: reading header byte: EOF",
+				"cannot decode leaf: cannot decode key: reading from reader: EOF",
 		},
 		"branch_with_inlined_branch_and_leaf": {
 			reader: bytes.NewBuffer(concatByteSlices([][]byte{
@@ -47,6 +47,13 @@ func (n *Node) Kind() Kind {
 	return Leaf
 }
 
+// IsOmittedReference returns true if the node is an omitted
+// child reference, as decoded from a compact proof branch encoding.
+func (n *Node) IsOmittedReference() bool {
+	return n.MerkleValue != nil && len(n.MerkleValue) == 0 &&
+		n.PartialKey == nil && n.StorageValue == nil && n.Children == nil
+}
+
 func (n *Node) String() string {
 	return n.StringNode().String()
 }
@@ -167,3 +167,37 @@ func Test_Node_String(t *testing.T) {
 		})
 	}
 }
+
+func Test_Node_IsOmittedReference(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		node    *Node
+		omitted bool
+	}{
+		"inlined_node": {
+			node: &Node{PartialKey: []byte{1}},
+		},
+		"hash_reference": {
+			node: &Node{MerkleValue: make([]byte, 32)},
+		},
+		"nil_merkle_value": {
+			node: &Node{},
+		},
+		"omitted_reference": {
+			node:    &Node{MerkleValue: []byte{}},
+			omitted: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			omitted := testCase.node.IsOmittedReference()
+
+			assert.Equal(t, testCase.omitted, omitted)
+		})
+	}
+}
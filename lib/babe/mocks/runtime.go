@@ -54,6 +54,21 @@ func (mr *MockRuntimeInstanceMockRecorder) ApplyExtrinsic(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).ApplyExtrinsic), arg0)
 }
 
+// AuthorityDiscoveryAuthorities mocks base method.
+func (m *MockRuntimeInstance) AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityDiscoveryAuthorities")
+	ret0, _ := ret[0].([]types.AuthorityID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityDiscoveryAuthorities indicates an expected call of AuthorityDiscoveryAuthorities.
+func (mr *MockRuntimeInstanceMockRecorder) AuthorityDiscoveryAuthorities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityDiscoveryAuthorities", reflect.TypeOf((*MockRuntimeInstance)(nil).AuthorityDiscoveryAuthorities))
+}
+
 // BabeConfiguration mocks base method.
 func (m *MockRuntimeInstance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	m.ctrl.T.Helper()
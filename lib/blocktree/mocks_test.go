@@ -54,6 +54,21 @@ func (mr *MockRuntimeMockRecorder) ApplyExtrinsic(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyExtrinsic", reflect.TypeOf((*MockRuntime)(nil).ApplyExtrinsic), arg0)
 }
 
+// AuthorityDiscoveryAuthorities mocks base method.
+func (m *MockRuntime) AuthorityDiscoveryAuthorities() ([]types.AuthorityID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorityDiscoveryAuthorities")
+	ret0, _ := ret[0].([]types.AuthorityID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorityDiscoveryAuthorities indicates an expected call of AuthorityDiscoveryAuthorities.
+func (mr *MockRuntimeMockRecorder) AuthorityDiscoveryAuthorities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorityDiscoveryAuthorities", reflect.TypeOf((*MockRuntime)(nil).AuthorityDiscoveryAuthorities))
+}
+
 // BabeConfiguration mocks base method.
 func (m *MockRuntime) BabeConfiguration() (*types.BabeConfiguration, error) {
 	m.ctrl.T.Helper()
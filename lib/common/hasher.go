@@ -97,6 +97,20 @@ func Keccak256(in []byte) (Hash, error) {
 	return buf, nil
 }
 
+// Keccak512 returns the keccak512 hash of the input data
+func Keccak512(in []byte) ([64]byte, error) {
+	h := sha3.NewLegacyKeccak512()
+
+	_, err := h.Write(in)
+	if err != nil {
+		return [64]byte{}, err
+	}
+
+	var buf = [64]byte{}
+	copy(buf[:], h.Sum(nil))
+	return buf, nil
+}
+
 // Twox64 returns the xx64 hash of the input data
 func Twox64(in []byte) ([]byte, error) {
 	hasher := xxhash.NewS64(0)
@@ -72,6 +72,16 @@ func TestKeccak256(t *testing.T) {
 	require.Equal(t, expected, h)
 }
 
+func TestKeccak512_EmptyHash(t *testing.T) {
+	var in []byte
+	h, err := common.Keccak512(in)
+	require.NoError(t, err)
+
+	expected := common.MustHexToBytes("0x0eab42de4c3ceb9235fc91acffe746b29c29a8c366b7c60e4e67c466f36a4304" +
+		"c00fa9caf9d87976ba469bcbe06713b435f091ef2769fb160cdab33d3670680e")
+	require.Equal(t, expected, h[:])
+}
+
 func TestTwox128(t *testing.T) {
 	in := []byte("static")
 	_, err := common.Twox128Hash(in)
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"time"
+
 	ma "github.com/multiformats/go-multiaddr"
 )
 
@@ -26,6 +28,38 @@ type PeerInfo struct {
 	Roles      Roles
 	BestHash   Hash
 	BestNumber uint64
+	Reputation int32
+}
+
+// PeerSyncStats is the statistics of the block requests sent to a peer needed for the rpc server
+type PeerSyncStats struct {
+	PeerID              string
+	Requests            uint64
+	Blocks              uint64
+	AverageResponseTime time.Duration
+	BlocksPerSecond     float64
+	// FailureRate is the rate of requests which failed, such as timeouts
+	FailureRate float64
+	// InvalidResponseRate is the rate of requests answered with an empty or invalid response
+	InvalidResponseRate float64
+	// Score weights the peer when picking a peer to sync from
+	Score float64
+}
+
+// BandwidthStats is the number of bytes sent and received, and
+// the current rates in bytes per second, of a protocol or a peer
+type BandwidthStats struct {
+	TotalIn  int64
+	TotalOut int64
+	RateIn   float64
+	RateOut  float64
+}
+
+// Bandwidth is the bandwidth used by the host needed for the rpc server
+type Bandwidth struct {
+	Total      BandwidthStats
+	ByProtocol map[string]BandwidthStats
+	ByPeer     map[string]BandwidthStats
 }
 
 // Roles is the type of node.
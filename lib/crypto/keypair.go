@@ -4,6 +4,9 @@
 package crypto
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -67,6 +70,32 @@ func publicKeyBytesToAddress(b []byte) common.Address {
 	return common.Address(base58.Encode(append(b, checksum[:2]...)))
 }
 
+// maxSS58Prefix is the highest network prefix which can be encoded in an SS58 address.
+const maxSS58Prefix = 1<<14 - 1
+
+// ErrInvalidSS58Prefix is returned when an SS58 network prefix is too high to be encoded.
+var ErrInvalidSS58Prefix = errors.New("invalid ss58 network prefix")
+
+// SS58Address returns the SS58 address of the public key or account ID bytes given
+// for the network prefix given, which is encoded on two bytes if greater than 63.
+// see: https://docs.substrate.io/reference/address-formats/
+func SS58Address(prefix uint16, b []byte) (common.Address, error) {
+	var prefixBytes []byte
+	switch {
+	case prefix < 64:
+		prefixBytes = []byte{byte(prefix)}
+	case prefix <= maxSS58Prefix:
+		prefixBytes = []byte{
+			byte((prefix&0b1111_1100)>>2) | 0b0100_0000,
+			byte(prefix>>8) | byte((prefix&0b11)<<6),
+		}
+	default:
+		return "", fmt.Errorf("%w: %d is greater than %d", ErrInvalidSS58Prefix, prefix, maxSS58Prefix)
+	}
+
+	return publicKeyBytesToAddress(append(prefixBytes, b...)), nil
+}
+
 // PublicAddressToByteArray returns []byte address for given PublicKey Address
 func PublicAddressToByteArray(add common.Address) []byte {
 	k := base58.Decode(string(add))
@@ -7,8 +7,10 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,3 +24,66 @@ func TestPubkeyToAddress(t *testing.T) {
 	a := pk.Address()
 	require.Equal(t, addr, string(a))
 }
+
+func TestSS58Address(t *testing.T) {
+	t.Parallel()
+
+	// alice sr25519 public key
+	alice := common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+	testCases := map[string]struct {
+		prefix     uint16
+		address    common.Address
+		errWrapped error
+		errMessage string
+	}{
+		"polkadot": {
+			address: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		},
+		"kusama": {
+			prefix:  2,
+			address: "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		},
+		"generic_substrate": {
+			prefix:  42,
+			address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		},
+		"prefix_too_high": {
+			prefix:     1 << 14,
+			errWrapped: crypto.ErrInvalidSS58Prefix,
+			errMessage: "invalid ss58 network prefix: 16384 is greater than 16383",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := crypto.SS58Address(testCase.prefix, alice)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				require.EqualError(t, err, testCase.errMessage)
+			}
+			require.Equal(t, testCase.address, address)
+		})
+	}
+}
+
+func TestSS58Address_twoBytesPrefix(t *testing.T) {
+	t.Parallel()
+
+	alice := common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+	const prefix = 0x1234
+	address, err := crypto.SS58Address(prefix, alice)
+	require.NoError(t, err)
+
+	// decode the network prefix as done by substrate
+	decoded := base58.Decode(string(address))
+	require.Len(t, decoded, 2+len(alice)+2)
+	lower := decoded[0]<<2 | decoded[1]>>6
+	upper := decoded[1] & 0b0011_1111
+	require.Equal(t, uint16(prefix), uint16(lower)|uint16(upper)<<8)
+	require.Equal(t, alice, decoded[2:2+len(alice)])
+}
@@ -4,11 +4,14 @@
 package secp256k1
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 
+	"github.com/ChainSafe/go-schnorrkel"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	secp256k1 "github.com/ethereum/go-ethereum/crypto"
@@ -48,6 +51,68 @@ func VerifySignature(publicKey, signature, message []byte) error {
 		crypto.ErrSignatureVerificationFailed, message, signature, publicKey)
 }
 
+// VerifyRecoverableSignature verifies a 65-byte signature containing the recovery id
+// by recovering the public key that created it and comparing it to the given
+// 33-byte compressed public key. Unlike VerifySignature, signatures with a
+// non-normalised s value are accepted.
+func VerifyRecoverableSignature(publicKey, signature, message []byte) error {
+	if len(signature) != SignatureLengthRecovery {
+		return fmt.Errorf("secp256k1: %w: invalid signature length %d",
+			crypto.ErrSignatureVerificationFailed, len(signature))
+	}
+
+	// copy the signature since the recovery id is updated in place
+	sig := make([]byte, SignatureLengthRecovery)
+	copy(sig, signature)
+
+	recovered, err := RecoverPublicKeyCompressed(message, sig)
+	if err == nil && bytes.Equal(recovered, publicKey) {
+		return nil
+	}
+
+	return fmt.Errorf("secp256k1: %w: for message 0x%x, signature 0x%x and public key 0x%x",
+		crypto.ErrSignatureVerificationFailed, message, signature, publicKey)
+}
+
+// VerifyPrehashedSignature verifies a 65-byte signature of the 32-byte message hash
+// given was created by the 33-byte compressed public key given. The signature must have
+// its r and s values in the range [1, n-1], a low s value and a recovery id between 0 and 3,
+// without the 27 offset accepted by VerifyRecoverableSignature.
+func VerifyPrehashedSignature(publicKey, signature, hash []byte) error {
+	if len(signature) != SignatureLengthRecovery {
+		return fmt.Errorf("secp256k1: %w: invalid signature length %d",
+			crypto.ErrSignatureVerificationFailed, len(signature))
+	} else if len(hash) != MessageLength {
+		return fmt.Errorf("secp256k1: %w: invalid message hash length %d",
+			crypto.ErrSignatureVerificationFailed, len(hash))
+	}
+
+	curveOrder := secp256k1.S256().Params().N
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	recoveryID := signature[64]
+
+	switch {
+	case r.Sign() == 0 || r.Cmp(curveOrder) >= 0:
+		return fmt.Errorf("secp256k1: %w: r value 0x%x is out of range",
+			crypto.ErrSignatureVerificationFailed, signature[:32])
+	case s.Sign() == 0 || s.Cmp(new(big.Int).Rsh(curveOrder, 1)) > 0:
+		return fmt.Errorf("secp256k1: %w: s value 0x%x is not a low s value",
+			crypto.ErrSignatureVerificationFailed, signature[32:64])
+	case recoveryID > 3:
+		return fmt.Errorf("secp256k1: %w: invalid recovery id %d",
+			crypto.ErrSignatureVerificationFailed, recoveryID)
+	}
+
+	recovered, err := secp256k1.SigToPub(hash, signature)
+	if err == nil && bytes.Equal(secp256k1.CompressPubkey(recovered), publicKey) {
+		return nil
+	}
+
+	return fmt.Errorf("secp256k1: %w: for message hash 0x%x, signature 0x%x and public key 0x%x",
+		crypto.ErrSignatureVerificationFailed, hash, signature, publicKey)
+}
+
 // RecoverPublicKey returns the 64-byte uncompressed public key that created the given signature.
 func RecoverPublicKey(msg, sig []byte) ([]byte, error) {
 	// update recovery bit
@@ -127,6 +192,22 @@ func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	return NewKeypairFromPrivate(priv)
 }
 
+// NewKeypairFromMnemonic returns a Keypair using the given mnemonic and password,
+// the private key being the first 32 bytes of the mnemonic seed.
+func NewKeypairFromMnemonic(mnemonic, password string) (*Keypair, error) {
+	seed, err := schnorrkel.SeedFromMnemonic(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := NewPrivateKey(seed[:PrivateKeyLength])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromPrivate(priv)
+}
+
 // GenerateKeypair will generate a Keypair
 func GenerateKeypair() (*Keypair, error) {
 	priv, err := secp256k1.GenerateKey()
@@ -5,11 +5,13 @@
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/stretchr/testify/require"
 )
@@ -201,3 +203,157 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestVerifyRecoverableSignature(t *testing.T) {
+	t.Parallel()
+	keypair, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	otherKeypair, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("a225e8c75da7da319af6335e7642d473")
+
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		publicKey, signature, message []byte
+		errWrapped                    error
+	}{
+		"success": {
+			publicKey: keypair.public.Encode(),
+			signature: signature,
+			message:   message,
+		},
+		"signature_without_recovery_id": {
+			publicKey:  keypair.public.Encode(),
+			signature:  signature[:64],
+			message:    message,
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"other_public_key": {
+			publicKey:  otherKeypair.public.Encode(),
+			signature:  signature,
+			message:    message,
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyRecoverableSignature(testCase.publicKey, testCase.signature, testCase.message)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
+
+func TestVerifyPrehashedSignature(t *testing.T) {
+	t.Parallel()
+	keypair, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	otherKeypair, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	hash := common.MustBlake2bHash([]byte("message"))
+
+	signature, err := keypair.Sign(hash[:])
+	require.NoError(t, err)
+
+	withSignature := func(modify func(signature []byte)) []byte {
+		modified := make([]byte, len(signature))
+		copy(modified, signature)
+		modify(modified)
+		return modified
+	}
+
+	curveOrder := secp256k1.S256().Params().N
+	highS := withSignature(func(signature []byte) {
+		s := new(big.Int).SetBytes(signature[32:64])
+		s.Sub(curveOrder, s).FillBytes(signature[32:64])
+		signature[64] ^= 1
+	})
+	// the high s signature is otherwise valid
+	require.NoError(t, VerifyRecoverableSignature(keypair.public.Encode(), highS, hash[:]))
+
+	testCases := map[string]struct {
+		publicKey, signature, hash []byte
+		errWrapped                 error
+	}{
+		"success": {
+			publicKey: keypair.public.Encode(),
+			signature: signature,
+			hash:      hash[:],
+		},
+		"signature_without_recovery_id": {
+			publicKey:  keypair.public.Encode(),
+			signature:  signature[:64],
+			hash:       hash[:],
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"message_not_hashed": {
+			publicKey:  keypair.public.Encode(),
+			signature:  signature,
+			hash:       []byte("message"),
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"recovery_id_with_offset": {
+			publicKey:  keypair.public.Encode(),
+			signature:  withSignature(func(signature []byte) { signature[64] += 27 }),
+			hash:       hash[:],
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"high_s_value": {
+			publicKey:  keypair.public.Encode(),
+			signature:  highS,
+			hash:       hash[:],
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"r_value_overflow": {
+			publicKey: keypair.public.Encode(),
+			signature: withSignature(func(signature []byte) {
+				curveOrder.FillBytes(signature[:32])
+			}),
+			hash:       hash[:],
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+		"other_public_key": {
+			publicKey:  otherKeypair.public.Encode(),
+			signature:  signature,
+			hash:       hash[:],
+			errWrapped: crypto.ErrSignatureVerificationFailed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyPrehashedSignature(testCase.publicKey, testCase.signature, testCase.hash)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
+
+func TestNewKeypairFromMnemonic(t *testing.T) {
+	t.Parallel()
+	const mnemonic = "twist sausage october vivid neglect swear crumble hawk beauty fabric egg fragile"
+
+	kp, err := NewKeypairFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+
+	again, err := NewKeypairFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+	require.Equal(t, kp.Public().Encode(), again.Public().Encode())
+
+	withPassword, err := NewKeypairFromMnemonic(mnemonic, "password")
+	require.NoError(t, err)
+	require.NotEqual(t, kp.Public().Encode(), withPassword.Public().Encode())
+}
@@ -82,6 +82,7 @@ func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 
 func (*testNetwork) RegisterNotificationsProtocol(
 	_ protocol.ID,
+	_ []protocol.ID,
 	_ byte,
 	_ network.HandshakeGetter,
 	_ network.HandshakeDecoder,
@@ -94,6 +95,10 @@ func (*testNetwork) RegisterNotificationsProtocol(
 	return nil
 }
 
+func (*testNetwork) RegisterGossipValidator(_ byte, _ network.MessageValidator, _ network.RebroadcastPolicy) error {
+	return nil
+}
+
 func (n *testNetwork) SendBlockReqestByHash(_ common.Hash) {}
 
 func setupGrandpa(t *testing.T, kp *ed25519.Keypair) *Service {
@@ -547,6 +547,61 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 	return nil
 }
 
+// VerifyJustification verifies the encoded justification given finalises the
+// block with the hash and number given, and is signed by more than two thirds
+// of the authorities given of the set with the id given. Contrary to
+// VerifyBlockJustification, the blocks voted for do not need to be known, such
+// that it can verify the justification of a block imported without its
+// ancestors and descendants, such as the block of a state snapshot.
+// Note precommits for a block other than the finalised block are only
+// checked to have a higher block number, since their ancestry is unknown.
+func VerifyJustification(justification []byte, hash common.Hash, number uint,
+	setID uint64, authorities []types.GrandpaVoter) error {
+	fj := Justification{}
+	err := scale.Unmarshal(justification, &fj)
+	if err != nil {
+		return fmt.Errorf("decoding justification: %w", err)
+	}
+
+	if fj.Commit.Hash != hash || uint(fj.Commit.Number) != number {
+		return fmt.Errorf("%w: justification for block %s number %d instead of block %s number %d",
+			ErrJustificationMismatch, fj.Commit.Hash.Short(), fj.Commit.Number, hash.Short(), number)
+	}
+
+	authorityKeys := make(map[string]struct{}, len(authorities))
+	for _, authority := range authorities {
+		authorityKeys[string(authority.Key.Encode())] = struct{}{}
+	}
+
+	// voters are counted once, including equivocatory voters
+	voters := make(map[ed25519.PublicKeyBytes]struct{}, len(fj.Commit.Precommits))
+	for i := range fj.Commit.Precommits {
+		signedVote := &fj.Commit.Precommits[i]
+		vote := signedVote.Vote
+		if uint(vote.Number) < number || (uint(vote.Number) == number && vote.Hash != hash) {
+			return fmt.Errorf("%w: precommit for block %s number %d",
+				ErrPrecommitBlockMismatch, vote.Hash.Short(), vote.Number)
+		}
+
+		err = verifyJustification(signedVote, fj.Round, setID, precommit, authorityKeys)
+		if err != nil {
+			return fmt.Errorf("verifying precommit of authority %s: %w", signedVote.AuthorityID, err)
+		}
+
+		voters[signedVote.AuthorityID] = struct{}{}
+	}
+
+	threshold := 2 * len(authorities) / 3
+	if len(voters) <= threshold {
+		return fmt.Errorf("%w: %d voters out of %d authorities",
+			ErrMinVotesNotMet, len(voters), len(authorities))
+	}
+
+	logger.Debugf("verified justification: set id %d, round %d, hash %s, number %d, voters %d",
+		setID, fj.Round, hash, number, len(voters))
+	return nil
+}
+
 func verifyBlockHashAgainstBlockNumber(bs BlockState, hash common.Hash, number uint) error {
 	header, err := bs.GetHeader(hash)
 	if err != nil {
@@ -0,0 +1,136 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VerifyJustification(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	keyPairs := []*ed25519.Keypair{
+		kr.Alice().(*ed25519.Keypair),
+		kr.Bob().(*ed25519.Keypair),
+		kr.Charlie().(*ed25519.Keypair),
+	}
+	authorities := make([]types.GrandpaVoter, len(keyPairs))
+	for i, keyPair := range keyPairs {
+		authorities[i] = types.GrandpaVoter{Key: *keyPair.Public().(*ed25519.PublicKey)}
+	}
+
+	const (
+		round  uint64 = 3
+		setID  uint64 = 1
+		number uint32 = 10
+	)
+	hash := common.Hash{1}
+
+	signedVote := func(keyPair *ed25519.Keypair, vote Vote) SignedVote {
+		message, err := scale.Marshal(FullVote{Stage: precommit, Vote: vote, Round: round, SetID: setID})
+		require.NoError(t, err)
+		signature, err := keyPair.Sign(message)
+		require.NoError(t, err)
+
+		signedVote := SignedVote{
+			Vote:        vote,
+			AuthorityID: keyPair.Public().(*ed25519.PublicKey).AsBytes(),
+		}
+		copy(signedVote.Signature[:], signature)
+		return signedVote
+	}
+
+	encode := func(precommits ...SignedVote) []byte {
+		encoded, err := scale.Marshal(*newJustification(round, hash, number, precommits))
+		require.NoError(t, err)
+		return encoded
+	}
+
+	vote := Vote{Hash: hash, Number: number}
+	descendantVote := Vote{Hash: common.Hash{2}, Number: number + 1}
+	ancestorVote := Vote{Hash: common.Hash{3}, Number: number - 1}
+	invalidSignature := signedVote(keyPairs[2], vote)
+	invalidSignature.Signature[0]++
+
+	testCases := map[string]struct {
+		justification []byte
+		hash          common.Hash
+		errWrapped    error
+		errMessage    string
+	}{
+		"valid": {
+			justification: encode(signedVote(keyPairs[0], vote),
+				signedVote(keyPairs[1], descendantVote), signedVote(keyPairs[2], vote)),
+			hash: hash,
+		},
+		"decoding error": {
+			justification: []byte{1},
+			hash:          hash,
+			errMessage: "decoding justification: decoding struct: " +
+				"unmarshalling field at index 1: decoding struct: " +
+				"unmarshalling field at index 0: EOF",
+		},
+		"other block": {
+			justification: encode(signedVote(keyPairs[0], vote)),
+			hash:          common.Hash{9},
+			errWrapped:    ErrJustificationMismatch,
+			errMessage: "justification does not correspond to given block hash: " +
+				"justification for block 0x01000000...00000000 number 10 " +
+				"instead of block 0x09000000...00000000 number 10",
+		},
+		"precommit for ancestor": {
+			justification: encode(signedVote(keyPairs[0], vote), signedVote(keyPairs[1], ancestorVote)),
+			hash:          hash,
+			errWrapped:    ErrPrecommitBlockMismatch,
+			errMessage: "precommit block is not descendant of committed block: " +
+				"precommit for block 0x03000000...00000000 number 9",
+		},
+		"invalid signature": {
+			justification: encode(signedVote(keyPairs[0], vote), invalidSignature),
+			hash:          hash,
+			errWrapped:    ErrInvalidSignature,
+		},
+		"unknown authority": {
+			justification: encode(signedVote(kr.Dave().(*ed25519.Keypair), vote)),
+			hash:          hash,
+			errWrapped:    ErrVoterNotFound,
+		},
+		"not enough voters": {
+			justification: encode(signedVote(keyPairs[0], vote), signedVote(keyPairs[1], vote),
+				signedVote(keyPairs[1], vote)),
+			hash:       hash,
+			errWrapped: ErrMinVotesNotMet,
+			errMessage: "minimum number of votes not met in a Justification: 2 voters out of 3 authorities",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyJustification(testCase.justification, testCase.hash, uint(number), setID, authorities)
+
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+			}
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			if testCase.errWrapped == nil && testCase.errMessage == "" {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
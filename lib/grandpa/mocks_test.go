@@ -548,18 +548,32 @@ func (mr *MockNetworkMockRecorder) GossipMessage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GossipMessage", reflect.TypeOf((*MockNetwork)(nil).GossipMessage), arg0)
 }
 
+// RegisterGossipValidator mocks base method.
+func (m *MockNetwork) RegisterGossipValidator(arg0 byte, arg1 func(peer.ID, network.NotificationsMessage) network.ValidationResult, arg2 network.RebroadcastPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterGossipValidator", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterGossipValidator indicates an expected call of RegisterGossipValidator.
+func (mr *MockNetworkMockRecorder) RegisterGossipValidator(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterGossipValidator", reflect.TypeOf((*MockNetwork)(nil).RegisterGossipValidator), arg0, arg1, arg2)
+}
+
 // RegisterNotificationsProtocol mocks base method.
-func (m *MockNetwork) RegisterNotificationsProtocol(arg0 protocol.ID, arg1 byte, arg2 func() (network.Handshake, error), arg3 func([]byte) (network.Handshake, error), arg4 func(peer.ID, network.Handshake) error, arg5 func([]byte) (network.NotificationsMessage, error), arg6 func(peer.ID, network.NotificationsMessage) (bool, error), arg7 func(peer.ID, network.NotificationsMessage), arg8 uint64) error {
+func (m *MockNetwork) RegisterNotificationsProtocol(arg0 protocol.ID, arg1 []protocol.ID, arg2 byte, arg3 func() (network.Handshake, error), arg4 func([]byte) (network.Handshake, error), arg5 func(peer.ID, network.Handshake) error, arg6 func([]byte) (network.NotificationsMessage, error), arg7 func(peer.ID, network.NotificationsMessage) (bool, error), arg8 func(peer.ID, network.NotificationsMessage), arg9 uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterNotificationsProtocol", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret := m.ctrl.Call(m, "RegisterNotificationsProtocol", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RegisterNotificationsProtocol indicates an expected call of RegisterNotificationsProtocol.
-func (mr *MockNetworkMockRecorder) RegisterNotificationsProtocol(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+func (mr *MockNetworkMockRecorder) RegisterNotificationsProtocol(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNotificationsProtocol", reflect.TypeOf((*MockNetwork)(nil).RegisterNotificationsProtocol), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNotificationsProtocol", reflect.TypeOf((*MockNetwork)(nil).RegisterNotificationsProtocol), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 }
 
 // SendMessage mocks base method.
@@ -15,7 +15,12 @@
 	"github.com/libp2p/go-libp2p/core/protocol"
 )
 
-const grandpaID1 = "grandpa/1"
+const (
+	grandpaID1 = "grandpa/1"
+	// legacyGrandpaProtocolID is the protocol ID used before
+	// the genesis hash based grandpa protocol ID.
+	legacyGrandpaProtocolID = "/paritytech/grandpa/1"
+)
 
 // NotificationsMessage is an alias for network.NotificationsMessage
 type NotificationsMessage = network.NotificationsMessage
@@ -58,8 +63,9 @@ func (s *Service) registerProtocol() error {
 	genesisHash = strings.TrimPrefix(genesisHash, "0x")
 	grandpaProtocolID := fmt.Sprintf("/%s/%s", genesisHash, grandpaID1)
 
-	return s.network.RegisterNotificationsProtocol(
+	err := s.network.RegisterNotificationsProtocol(
 		protocol.ID(grandpaProtocolID),
+		[]protocol.ID{legacyGrandpaProtocolID},
 		network.ConsensusMsgType,
 		s.getHandshake,
 		s.decodeHandshake,
@@ -69,6 +75,18 @@ func (s *Service) registerProtocol() error {
 		nil,
 		network.MaxGrandpaNotificationSize,
 	)
+	if err != nil {
+		return fmt.Errorf("registering notifications protocol: %w", err)
+	}
+
+	// votes and commits are rebroadcast until they are outdated,
+	// so peers missing them, such as newly connected peers, receive them.
+	err = s.network.RegisterGossipValidator(network.ConsensusMsgType, s.validateMessage, network.RebroadcastPeriodically)
+	if err != nil {
+		return fmt.Errorf("registering gossip validator: %w", err)
+	}
+
+	return nil
 }
 
 func (s *Service) getHandshake() (network.Handshake, error) {
@@ -101,6 +119,51 @@ func (*Service) decodeMessage(in []byte) (NotificationsMessage, error) {
 	return msg, err
 }
 
+// validateMessage validates a GRANDPA message received from the network before it is handled,
+// and discards the messages outdated compared to our current round and set ID.
+// Only votes and commits are kept to be rebroadcast, the other messages are only handled.
+func (s *Service) validateMessage(_ peer.ID, msg NotificationsMessage) network.ValidationResult {
+	cm, ok := msg.(*network.ConsensusMessage)
+	if !ok {
+		return network.ValidationPenalise
+	}
+
+	if len(cm.Data) < 2 {
+		return network.ValidationDiscard
+	}
+
+	m, err := decodeMessage(cm)
+	if err != nil {
+		return network.ValidationPenalise
+	}
+
+	s.roundLock.Lock()
+	round, setID := s.state.round, s.state.setID
+	s.roundLock.Unlock()
+
+	switch m := m.(type) {
+	case *VoteMessage:
+		minRoundAccepted, maxRoundAccepted := acceptedRounds(round)
+		if m.SetID != setID || m.Round < minRoundAccepted || m.Round > maxRoundAccepted {
+			return network.ValidationDiscard
+		}
+	case *CommitMessage:
+		if m.SetID < setID {
+			return network.ValidationDiscard
+		}
+	case *NeighbourPacketV1:
+		minRoundAccepted, _ := acceptedRounds(round)
+		if m.SetID < setID || (m.SetID == setID && m.Round < minRoundAccepted) {
+			return network.ValidationDiscard
+		}
+		return network.ValidationProcess
+	default:
+		return network.ValidationProcess
+	}
+
+	return network.ValidationKeep
+}
+
 func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (bool, error) {
 	if msg == nil {
 		return false, nil
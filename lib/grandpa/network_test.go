@@ -0,0 +1,145 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Service_validateMessage(t *testing.T) {
+	t.Parallel()
+
+	const round, setID = 5, 2
+
+	consensusMessage := func(t *testing.T, m interface {
+		ToConsensusMessage() (*ConsensusMessage, error)
+	}) NotificationsMessage {
+		t.Helper()
+		cm, err := m.ToConsensusMessage()
+		require.NoError(t, err)
+		return cm
+	}
+
+	testCases := map[string]struct {
+		messageBuilder func(t *testing.T) NotificationsMessage
+		result         network.ValidationResult
+	}{
+		"not_consensus_message": {
+			messageBuilder: func(*testing.T) NotificationsMessage {
+				return &network.BlockAnnounceMessage{}
+			},
+			result: network.ValidationPenalise,
+		},
+		"too_short": {
+			messageBuilder: func(*testing.T) NotificationsMessage {
+				return &ConsensusMessage{Data: []byte{1}}
+			},
+			result: network.ValidationDiscard,
+		},
+		"undecodable": {
+			messageBuilder: func(*testing.T) NotificationsMessage {
+				return &ConsensusMessage{Data: []byte{99, 99}}
+			},
+			result: network.ValidationPenalise,
+		},
+		"vote_current_round": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &VoteMessage{Round: round, SetID: setID})
+			},
+			result: network.ValidationKeep,
+		},
+		"vote_lagging_round": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &VoteMessage{Round: round - maxRoundsLag, SetID: setID})
+			},
+			result: network.ValidationKeep,
+		},
+		"vote_outdated_round": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &VoteMessage{Round: round - maxRoundsLag - 1, SetID: setID})
+			},
+			result: network.ValidationDiscard,
+		},
+		"vote_round_too_far_ahead": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &VoteMessage{Round: round + maxRoundsAhead + 1, SetID: setID})
+			},
+			result: network.ValidationDiscard,
+		},
+		"vote_other_set_id": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &VoteMessage{Round: round, SetID: setID + 1})
+			},
+			result: network.ValidationDiscard,
+		},
+		"commit_current_set_id": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &CommitMessage{Round: 1, SetID: setID})
+			},
+			result: network.ValidationKeep,
+		},
+		"commit_outdated_set_id": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &CommitMessage{Round: round, SetID: setID - 1})
+			},
+			result: network.ValidationDiscard,
+		},
+		"neighbour_current_round": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &NeighbourPacketV1{Round: round, SetID: setID})
+			},
+			result: network.ValidationProcess,
+		},
+		"neighbour_outdated_round": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &NeighbourPacketV1{Round: 1, SetID: setID})
+			},
+			result: network.ValidationDiscard,
+		},
+		"neighbour_next_set_id": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, &NeighbourPacketV1{Round: 1, SetID: setID + 1})
+			},
+			result: network.ValidationProcess,
+		},
+		"catch_up_request": {
+			messageBuilder: func(t *testing.T) NotificationsMessage {
+				return consensusMessage(t, newCatchUpRequest(1, setID))
+			},
+			result: network.ValidationProcess,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &Service{
+				state: &State{round: round, setID: setID},
+			}
+			msg := testCase.messageBuilder(t)
+
+			result := service.validateMessage("", msg)
+
+			assert.Equal(t, testCase.result, result)
+		})
+	}
+}
+
+func Test_acceptedRounds(t *testing.T) {
+	t.Parallel()
+
+	minRoundAccepted, maxRoundAccepted := acceptedRounds(0)
+	assert.Equal(t, uint64(0), minRoundAccepted)
+	assert.Equal(t, uint64(maxRoundsAhead), maxRoundAccepted)
+
+	minRoundAccepted, maxRoundAccepted = acceptedRounds(10)
+	assert.Equal(t, uint64(10-maxRoundsLag), minRoundAccepted)
+	assert.Equal(t, uint64(10+maxRoundsAhead), maxRoundAccepted)
+}
@@ -57,6 +57,7 @@ type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
 	RegisterNotificationsProtocol(sub protocol.ID,
+		fallbackSubs []protocol.ID,
 		messageID byte,
 		handshakeGetter network.HandshakeGetter,
 		handshakeDecoder network.HandshakeDecoder,
@@ -66,4 +67,6 @@ type Network interface {
 		batchHandler network.NotificationsMessageBatchHandler,
 		maxSize uint64,
 	) error
+	RegisterGossipValidator(messageID byte, validator network.MessageValidator,
+		policy network.RebroadcastPolicy) error
 }
@@ -22,6 +22,24 @@
 	errEmptyKeyOwnershipProof = errors.New("key ownership proof is nil")
 )
 
+const (
+	// maxRoundsLag is the number of rounds a vote message can be behind our round.
+	maxRoundsLag = 1
+	// maxRoundsAhead is the number of rounds a vote message can be ahead of our round.
+	maxRoundsAhead = 1
+)
+
+// acceptedRounds returns the range of rounds of the vote messages accepted in the given round.
+func acceptedRounds(round uint64) (minRoundAccepted, maxRoundAccepted uint64) {
+	minRoundAccepted = round - maxRoundsLag
+	if minRoundAccepted > round {
+		// we overflowed below 0 so set the minimum to 0.
+		minRoundAccepted = 0
+	}
+
+	return minRoundAccepted, round + maxRoundsAhead
+}
+
 type networkVoteMessage struct {
 	from peer.ID
 	msg  *VoteMessage
@@ -116,16 +134,7 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		return nil, ErrSetIDMismatch
 	}
 
-	const maxRoundsLag = 1
-	minRoundAccepted := s.state.round - maxRoundsLag
-	if minRoundAccepted > s.state.round {
-		// we overflowed below 0 so set the minimum to 0.
-		minRoundAccepted = 0
-	}
-
-	const maxRoundsAhead = 1
-	maxRoundAccepted := s.state.round + maxRoundsAhead
-
+	minRoundAccepted, maxRoundAccepted := acceptedRounds(s.state.round)
 	if m.Round < minRoundAccepted || m.Round > maxRoundAccepted {
 		// Discard message
 		// TODO: affect peer reputation, this is shameful impolite behaviour
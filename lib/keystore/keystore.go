@@ -27,6 +27,7 @@
 	ParaName Name = "para"
 	AsgnName Name = "asgn"
 	AudiName Name = "audi"
+	BeefName Name = "beef"
 	DumyName Name = "dumy"
 )
 
@@ -68,6 +69,7 @@ type GlobalKeystore struct {
 	Asgn Keystore
 	Imon Keystore
 	Audi Keystore
+	Beef Keystore
 	Dumy Keystore
 }
 
@@ -82,6 +84,7 @@ func NewGlobalKeystore() *GlobalKeystore {
 		Asgn: NewBasicKeystore(AsgnName, crypto.Sr25519Type),
 		Imon: NewBasicKeystore(ImonName, crypto.Sr25519Type),
 		Audi: NewBasicKeystore(AudiName, crypto.Sr25519Type),
+		Beef: NewBasicKeystore(BeefName, crypto.Secp256k1Type),
 		Dumy: NewGenericKeystore(DumyName),
 	}
 }
@@ -106,6 +109,8 @@ func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 		return k.Asgn, nil
 	case AudiName:
 		return k.Audi, nil
+	case BeefName:
+		return k.Beef, nil
 	case DumyName:
 		return k.Dumy, nil
 	default:
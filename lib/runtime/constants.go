@@ -45,6 +45,8 @@
 	GrandpaSubmitReportEquivocation = "GrandpaApi_submit_report_equivocation_unsigned_extrinsic"
 	// GrandpaGenerateKeyOwnershipProof is the runtime API call GrandpaApi_generate_key_ownership_proof
 	GrandpaGenerateKeyOwnershipProof = "GrandpaApi_generate_key_ownership_proof"
+	// AuthorityDiscoveryAPIAuthorities is the runtime API call AuthorityDiscoveryApi_authorities
+	AuthorityDiscoveryAPIAuthorities = "AuthorityDiscoveryApi_authorities"
 	// BabeAPIConfiguration is the runtime API call BabeApi_configuration
 	BabeAPIConfiguration = "BabeApi_configuration"
 	// BlockBuilderInherentExtrinsics is the runtime API call BlockBuilder_inherent_extrinsics
@@ -61,4 +63,7 @@
 	TransactionPaymentCallAPIQueryCallInfo = "TransactionPaymentCallApi_query_call_info"
 	// TransactionPaymentCallAPIQueryCallFeeDetails returns call query call fee details
 	TransactionPaymentCallAPIQueryCallFeeDetails = "TransactionPaymentCallApi_query_call_fee_details"
+	// TryRuntimeOnRuntimeUpgrade returns the weights of the runtime upgrade,
+	// and is only available in runtimes built with the try-runtime feature
+	TryRuntimeOnRuntimeUpgrade = "TryRuntime_on_runtime_upgrade"
 )
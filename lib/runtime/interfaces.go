@@ -12,7 +12,7 @@
 // Storage runtime interface.
 type Storage interface {
 	Put(key []byte, value []byte) (err error)
-	Get(key []byte) []byte
+	Get(key []byte) ([]byte, error)
 	Root() (common.Hash, error)
 	SetChild(keyToChild []byte, child *trie.Trie) error
 	SetChildStorage(keyToChild, key, value []byte) error
@@ -22,7 +22,7 @@ type Storage interface {
 	DeleteChildLimit(keyToChild []byte, limit *[]byte) (
 		deleted uint32, allDeleted bool, err error)
 	ClearChildStorage(keyToChild, key []byte) error
-	NextKey([]byte) []byte
+	NextKey([]byte) ([]byte, error)
 	ClearPrefixInChild(keyToChild, prefix []byte) error
 	GetChildNextKey(keyToChild, key []byte) ([]byte, error)
 	GetChild(keyToChild []byte) (*trie.Trie, error)
@@ -32,7 +32,9 @@ type Storage interface {
 	BeginStorageTransaction()
 	CommitStorageTransaction()
 	RollbackStorageTransaction()
-	LoadCode() []byte
+	LoadCode() ([]byte, error)
+	IndexTransaction(extrinsic, size uint32, hash common.Hash)
+	RenewTransactionIndex(extrinsic uint32, hash common.Hash)
 }
 
 // BasicNetwork interface for functions used by runtime network state function
@@ -5,13 +5,16 @@
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // TrieState is a wrapper around a transient trie that is used during the course of executing some runtime call.
@@ -20,6 +23,34 @@ type TrieState struct {
 	t       *trie.Trie
 	oldTrie *trie.Trie // this is the trie before BeginStorageTransaction is called. set to nil if it isn't called
 	lock    sync.RWMutex
+
+	// indexOperations are the transaction storage indexing operations
+	// requested by the runtime during the call.
+	indexOperations []IndexOperation
+
+	// tracer records the storage accesses if it is not nil.
+	tracer *tracing.Tracer
+
+	// changedKeys are the keys of the main trie written since the trie state
+	// was created, excluding the keys only written in a rolled back transaction.
+	changedKeys map[string]struct{}
+	// transactionChangedKeys are the keys of the main trie written since
+	// BeginStorageTransaction was called. It is nil outside a transaction.
+	transactionChangedKeys map[string]struct{}
+}
+
+// IndexOperation is a transaction storage indexing operation requested by the
+// runtime through the transaction index host functions.
+type IndexOperation struct {
+	// Extrinsic is the index of the extrinsic in the block body.
+	Extrinsic uint32
+	// Hash is the hash of the indexed data.
+	Hash common.Hash
+	// Size is the number of bytes at the end of the encoded extrinsic
+	// to index. It is zero for renew operations.
+	Size uint32
+	// Renew is true if the operation renews data indexed in a previous block.
+	Renew bool
 }
 
 // NewTrieState returns a new TrieState with the given trie
@@ -45,6 +76,94 @@ func (s *TrieState) Snapshot() *trie.Trie {
 	return s.t.Snapshot()
 }
 
+// SetTracer sets the tracer recording the storage accesses.
+func (s *TrieState) SetTracer(tracer *tracing.Tracer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tracer = tracer
+}
+
+// trace records a storage access event with the given method, key and
+// optional named value in the tracer, if any. It is NOT THREAD SAFE to use.
+func (s *TrieState) trace(method string, key []byte, valueName string, value []byte) {
+	s.traceChild(method, nil, key, valueName, value)
+}
+
+// traceChild records a storage access event with the given method, child trie key,
+// key and optional named value in the tracer, if any. It is NOT THREAD SAFE to use.
+func (s *TrieState) traceChild(method string, keyToChild, key []byte, valueName string, value []byte) {
+	if s.tracer == nil {
+		return
+	}
+
+	values := map[string]string{
+		"method": method,
+		"key":    hex.EncodeToString(key),
+	}
+
+	if keyToChild != nil {
+		values["child_info"] = hex.EncodeToString(keyToChild)
+	}
+
+	if valueName != "" {
+		values[valueName] = "None"
+		if value != nil {
+			values[valueName] = hex.EncodeToString(value)
+		}
+	}
+
+	s.tracer.Event(tracing.StateTarget, values)
+}
+
+// recordChange records the key given as changed. It is NOT THREAD SAFE to use.
+func (s *TrieState) recordChange(key []byte) {
+	if s.transactionChangedKeys != nil {
+		s.transactionChangedKeys[string(key)] = struct{}{}
+		return
+	}
+
+	if s.changedKeys == nil {
+		s.changedKeys = make(map[string]struct{})
+	}
+	s.changedKeys[string(key)] = struct{}{}
+}
+
+// recordChildChange records the key to the child trie given as changed,
+// since the child trie root stored at this key changes.
+// It is NOT THREAD SAFE to use.
+func (s *TrieState) recordChildChange(keyToChild []byte) {
+	key := make([]byte, 0, len(trie.ChildStorageKeyPrefix)+len(keyToChild))
+	key = append(key, trie.ChildStorageKeyPrefix...)
+	key = append(key, keyToChild...)
+	s.recordChange(key)
+}
+
+// commitTransactionChanges moves the keys changed in the current storage
+// transaction to the changed keys. It is NOT THREAD SAFE to use.
+func (s *TrieState) commitTransactionChanges() {
+	transactionChangedKeys := s.transactionChangedKeys
+	s.transactionChangedKeys = nil
+	for key := range transactionChangedKeys {
+		s.recordChange([]byte(key))
+	}
+}
+
+// ChangedKeys returns the keys of the main trie written since the trie state was
+// created, in lexicographic order. Keys written in rolled back storage transactions
+// are excluded, and the keys cleared by prefix may include keys which did not exist.
+func (s *TrieState) ChangedKeys() (keys [][]byte) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stringKeys := maps.Keys(s.changedKeys)
+	sort.Strings(stringKeys)
+	keys = make([][]byte, len(stringKeys))
+	for i, key := range stringKeys {
+		keys[i] = []byte(key)
+	}
+	return keys
+}
+
 // BeginStorageTransaction begins a new nested storage transaction
 // which will either be committed or rolled back at a later time.
 func (s *TrieState) BeginStorageTransaction() {
@@ -52,6 +171,10 @@ func (s *TrieState) BeginStorageTransaction() {
 	defer s.lock.Unlock()
 	s.oldTrie = s.t
 	s.t = s.t.Snapshot()
+	// The changes of an enclosing transaction are kept since
+	// rolling back only restores the trie as it is now.
+	s.commitTransactionChanges()
+	s.transactionChangedKeys = make(map[string]struct{})
 }
 
 // CommitStorageTransaction commits all storage changes made since BeginStorageTransaction was called.
@@ -59,6 +182,7 @@ func (s *TrieState) CommitStorageTransaction() {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	s.oldTrie = nil
+	s.commitTransactionChanges()
 }
 
 // RollbackStorageTransaction rolls back all storage changes made since BeginStorageTransaction was called.
@@ -67,20 +191,28 @@ func (s *TrieState) RollbackStorageTransaction() {
 	defer s.lock.Unlock()
 	s.t = s.oldTrie
 	s.oldTrie = nil
+	s.transactionChangedKeys = nil
 }
 
 // Put puts a key-value pair in the trie
 func (s *TrieState) Put(key, value []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.trace("Put", key, "value", value)
+	s.recordChange(key)
 	return s.t.Put(key, value)
 }
 
 // Get gets a value from the trie
-func (s *TrieState) Get(key []byte) []byte {
+func (s *TrieState) Get(key []byte) (value []byte, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	return s.t.Get(key)
+	value, err = s.t.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	s.trace("Get", key, "result", value)
+	return value, nil
 }
 
 // MustRoot returns the trie's root hash. It panics if it fails to compute the root.
@@ -94,19 +226,27 @@ func (s *TrieState) Root() (common.Hash, error) {
 }
 
 // Has returns whether or not a key exists
-func (s *TrieState) Has(key []byte) bool {
-	return s.Get(key) != nil
+func (s *TrieState) Has(key []byte) (has bool, err error) {
+	value, err := s.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return value != nil, nil
 }
 
 // Delete deletes a key from the trie
 func (s *TrieState) Delete(key []byte) (err error) {
-	val := s.t.Get(key)
-	if val == nil {
+	val, err := s.t.Get(key)
+	if err != nil {
+		return fmt.Errorf("getting from trie: %w", err)
+	} else if val == nil {
 		return nil
 	}
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.trace("Put", key, "value", nil)
+	s.recordChange(key)
 	err = s.t.Delete(key)
 	if err != nil {
 		return fmt.Errorf("deleting from trie: %w", err)
@@ -116,16 +256,26 @@ func (s *TrieState) Delete(key []byte) (err error) {
 }
 
 // NextKey returns the next key in the trie in lexicographical order. If it does not exist, it returns nil.
-func (s *TrieState) NextKey(key []byte) []byte {
+func (s *TrieState) NextKey(key []byte) (next []byte, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	return s.t.NextKey(key)
+	next, err = s.t.NextKey(key)
+	if err != nil {
+		return nil, err
+	}
+	s.trace("NextKey", key, "result", next)
+	return next, nil
 }
 
 // ClearPrefix deletes all key-value pairs from the trie where the key starts with the given prefix
 func (s *TrieState) ClearPrefix(prefix []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.trace("ClearPrefix", prefix, "", nil)
+	err = s.recordPrefixChange(prefix)
+	if err != nil {
+		return fmt.Errorf("recording prefix change: %w", err)
+	}
 	return s.t.ClearPrefix(prefix)
 }
 
@@ -135,11 +285,30 @@ func (s *TrieState) ClearPrefixLimit(prefix []byte, limit uint32) (
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	s.trace("ClearPrefix", prefix, "", nil)
+	err = s.recordPrefixChange(prefix)
+	if err != nil {
+		return 0, false, fmt.Errorf("recording prefix change: %w", err)
+	}
 	return s.t.ClearPrefixLimit(prefix, limit)
 }
 
+// recordPrefixChange records the keys with the prefix given as changed.
+// It is NOT THREAD SAFE to use.
+func (s *TrieState) recordPrefixChange(prefix []byte) (err error) {
+	keys, err := s.t.GetKeysWithPrefix(prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		s.recordChange(key)
+	}
+	return nil
+}
+
 // TrieEntries returns every key-value pair in the trie
-func (s *TrieState) TrieEntries() map[string][]byte {
+func (s *TrieState) TrieEntries() (entries map[string][]byte, err error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return s.t.Entries()
@@ -149,6 +318,7 @@ func (s *TrieState) TrieEntries() map[string][]byte {
 func (s *TrieState) SetChild(keyToChild []byte, child *trie.Trie) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.recordChildChange(keyToChild)
 	return s.t.SetChild(keyToChild, child)
 }
 
@@ -156,6 +326,8 @@ func (s *TrieState) SetChild(keyToChild []byte, child *trie.Trie) error {
 func (s *TrieState) SetChildStorage(keyToChild, key, value []byte) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.traceChild("ChildPut", keyToChild, key, "value", value)
+	s.recordChildChange(keyToChild)
 	return s.t.PutIntoChild(keyToChild, key, value)
 }
 
@@ -170,13 +342,21 @@ func (s *TrieState) GetChild(keyToChild []byte) (*trie.Trie, error) {
 func (s *TrieState) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	return s.t.GetFromChild(keyToChild, key)
+	value, err := s.t.GetFromChild(keyToChild, key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.traceChild("ChildGet", keyToChild, key, "result", value)
+	return value, nil
 }
 
 // DeleteChild deletes a child trie from the main trie
 func (s *TrieState) DeleteChild(key []byte) (err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.traceChild("KillChild", key, nil, "", nil)
+	s.recordChildChange(key)
 	return s.t.DeleteChild(key)
 }
 
@@ -185,12 +365,17 @@ func (s *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 	deleted uint32, allDeleted bool, err error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.traceChild("KillChild", key, nil, "", nil)
+	s.recordChildChange(key)
 	tr, err := s.t.GetChild(key)
 	if err != nil {
 		return 0, false, err
 	}
 
-	childTrieEntries := tr.Entries()
+	childTrieEntries, err := tr.Entries()
+	if err != nil {
+		return 0, false, fmt.Errorf("getting child trie entries: %w", err)
+	}
 	qtyEntries := uint32(len(childTrieEntries))
 	if limit == nil {
 		err = s.t.DeleteChild(key)
@@ -229,6 +414,8 @@ func (s *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 func (s *TrieState) ClearChildStorage(keyToChild, key []byte) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.traceChild("ChildPut", keyToChild, key, "value", nil)
+	s.recordChildChange(keyToChild)
 	return s.t.ClearFromChild(keyToChild, key)
 }
 
@@ -237,6 +424,8 @@ func (s *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	s.traceChild("ChildClearPrefix", keyToChild, prefix, "", nil)
+	s.recordChildChange(keyToChild)
 	child, err := s.t.GetChild(keyToChild)
 	if err != nil {
 		return err
@@ -264,7 +453,13 @@ func (s *TrieState) GetChildNextKey(keyToChild, key []byte) ([]byte, error) {
 	if child == nil {
 		return nil, nil
 	}
-	return child.NextKey(key), nil
+
+	next, err := child.NextKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("getting next key from child trie located at key 0x%x: %w", keyToChild, err)
+	}
+	s.traceChild("ChildNextKey", keyToChild, key, "result", next)
+	return next, nil
 }
 
 // GetKeysWithPrefixFromChild ...
@@ -276,20 +471,54 @@ func (s *TrieState) GetKeysWithPrefixFromChild(keyToChild, prefix []byte) ([][]b
 	if child == nil {
 		return nil, nil
 	}
-	return child.GetKeysWithPrefix(prefix), nil
+	return child.GetKeysWithPrefix(prefix)
 }
 
 // LoadCode returns the runtime code (located at :code)
-func (s *TrieState) LoadCode() []byte {
+func (s *TrieState) LoadCode() (code []byte, err error) {
 	return s.Get(common.CodeKey)
 }
 
 // LoadCodeHash returns the hash of the runtime code (located at :code)
 func (s *TrieState) LoadCodeHash() (common.Hash, error) {
-	code := s.LoadCode()
+	code, err := s.LoadCode()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("loading code: %w", err)
+	}
 	return common.Blake2bHash(code)
 }
 
+// IndexTransaction records that the last size bytes of the extrinsic at the given
+// index in the block body should be stored in the transaction index under the given hash.
+func (s *TrieState) IndexTransaction(extrinsic, size uint32, hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.indexOperations = append(s.indexOperations, IndexOperation{
+		Extrinsic: extrinsic,
+		Hash:      hash,
+		Size:      size,
+	})
+}
+
+// RenewTransactionIndex records that the data indexed under the given hash is
+// renewed by the extrinsic at the given index in the block body.
+func (s *TrieState) RenewTransactionIndex(extrinsic uint32, hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.indexOperations = append(s.indexOperations, IndexOperation{
+		Extrinsic: extrinsic,
+		Hash:      hash,
+		Renew:     true,
+	})
+}
+
+// IndexOperations returns the transaction index operations recorded so far.
+func (s *TrieState) IndexOperations() []IndexOperation {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return slices.Clone(s.indexOperations)
+}
+
 // GetChangedNodeHashes returns the two sets of hashes for all nodes
 // inserted and deleted in the state trie since the last block produced (trie snapshot).
 func (s *TrieState) GetChangedNodeHashes() (inserted, deleted map[common.Hash]struct{}, err error) {
@@ -6,11 +6,14 @@
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
 
+	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/stretchr/testify/require"
 )
@@ -31,7 +34,8 @@ func TestTrieState_SetGet(t *testing.T) {
 		}
 
 		for _, tc := range testCases {
-			res := ts.Get([]byte(tc))
+			res, err := ts.Get([]byte(tc))
+			require.NoError(t, err)
 			require.Equal(t, []byte(tc), res)
 		}
 	}
@@ -47,7 +51,8 @@ func TestTrieState_Delete(t *testing.T) {
 		}
 
 		ts.Delete([]byte(testCases[0]))
-		has := ts.Has([]byte(testCases[0]))
+		has, err := ts.Has([]byte(testCases[0]))
+		require.NoError(t, err)
 		require.False(t, has)
 	}
 
@@ -85,7 +90,8 @@ func TestTrieState_ClearPrefix(t *testing.T) {
 	ts.ClearPrefix([]byte("noo"))
 
 	for i, key := range keys {
-		val := ts.Get([]byte(key))
+		val, err := ts.Get([]byte(key))
+		require.NoError(t, err)
 		if i < 2 {
 			require.Nil(t, val)
 		} else {
@@ -139,7 +145,8 @@ func TestTrieState_NextKey(t *testing.T) {
 	})
 
 	for i, tc := range testCases {
-		next := ts.NextKey([]byte(tc))
+		next, err := ts.NextKey([]byte(tc))
+		require.NoError(t, err)
 		if i == len(testCases)-1 {
 			require.Nil(t, next)
 		} else {
@@ -160,7 +167,8 @@ func TestTrieState_CommitStorageTransaction(t *testing.T) {
 	ts.Put([]byte(testCases[0]), testValue)
 	ts.CommitStorageTransaction()
 
-	val := ts.Get([]byte(testCases[0]))
+	val, err := ts.Get([]byte(testCases[0]))
+	require.NoError(t, err)
 	require.Equal(t, testValue, val)
 }
 
@@ -176,10 +184,46 @@ func TestTrieState_RollbackStorageTransaction(t *testing.T) {
 	ts.Put([]byte(testCases[0]), testValue)
 	ts.RollbackStorageTransaction()
 
-	val := ts.Get([]byte(testCases[0]))
+	val, err := ts.Get([]byte(testCases[0]))
+	require.NoError(t, err)
 	require.Equal(t, []byte(testCases[0]), val)
 }
 
+func TestTrieState_ChangedKeys(t *testing.T) {
+	ts := NewTrieState(trie.NewEmptyTrie())
+
+	err := ts.Put([]byte("b"), []byte("1"))
+	require.NoError(t, err)
+	err = ts.Put([]byte("pa"), []byte("2"))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("b"), []byte("pa")}, ts.ChangedKeys())
+
+	ts.BeginStorageTransaction()
+	err = ts.Put([]byte("rolled_back"), []byte("3"))
+	require.NoError(t, err)
+	ts.RollbackStorageTransaction()
+
+	ts.BeginStorageTransaction()
+	err = ts.Put([]byte("a"), []byte("4"))
+	require.NoError(t, err)
+	err = ts.SetChild([]byte("child"), trie.NewEmptyTrie())
+	require.NoError(t, err)
+	ts.CommitStorageTransaction()
+
+	err = ts.Delete([]byte("b"))
+	require.NoError(t, err)
+	err = ts.ClearPrefix([]byte("p"))
+	require.NoError(t, err)
+
+	expectedKeys := [][]byte{
+		[]byte(":child_storage:default:child"),
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("pa"),
+	}
+	require.Equal(t, expectedKeys, ts.ChangedKeys())
+}
+
 func TestTrieState_DeleteChildLimit(t *testing.T) {
 	ts := &TrieState{t: trie.NewEmptyTrie()}
 	child := trie.NewEmptyTrie()
@@ -232,3 +276,94 @@ func TestTrieState_DeleteChildLimit(t *testing.T) {
 		require.Equal(t, test.expectedDelAll, all)
 	}
 }
+
+func TestTrieState_IndexOperations(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(nil)
+	ts.IndexTransaction(1, 32, common.Hash{1})
+	ts.BeginStorageTransaction()
+	ts.RenewTransactionIndex(2, common.Hash{2})
+	ts.RollbackStorageTransaction()
+
+	expected := []IndexOperation{
+		{Extrinsic: 1, Hash: common.Hash{1}, Size: 32},
+		{Extrinsic: 2, Hash: common.Hash{2}, Renew: true},
+	}
+	require.Equal(t, expected, ts.IndexOperations())
+}
+
+func TestTrieState_SetTracer(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(nil)
+	tracer := tracing.NewTracer(nil, nil, nil)
+	ts.SetTracer(tracer)
+
+	err := ts.Put([]byte{1, 2}, []byte{3})
+	require.NoError(t, err)
+	value, err := ts.Get([]byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, value)
+	err = ts.Delete([]byte{1, 2})
+	require.NoError(t, err)
+	value, err = ts.Get([]byte{1, 2})
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	expectedEvents := []tracing.Event{
+		{Target: "state", Values: map[string]string{"method": "Put", "key": "0102", "value": "03"}},
+		{Target: "state", Values: map[string]string{"method": "Get", "key": "0102", "result": "03"}},
+		{Target: "state", Values: map[string]string{"method": "Put", "key": "0102", "value": "None"}},
+		{Target: "state", Values: map[string]string{"method": "Get", "key": "0102", "result": "None"}},
+	}
+	require.Equal(t, expectedEvents, tracer.Events())
+}
+
+// rootOnlyDatabase only returns the encoding of the root node
+// of the database given, as if the other nodes were pruned.
+type rootOnlyDatabase struct {
+	chaindb.Database
+	rootHash common.Hash
+}
+
+func (r rootOnlyDatabase) Get(key []byte) (value []byte, err error) {
+	if !bytes.Equal(key, r.rootHash[:]) {
+		return nil, errors.New("not found")
+	}
+	return r.Database.Get(key)
+}
+
+func TestTrieState_lazyTrieNodeMissing(t *testing.T) {
+	t.Parallel()
+
+	tr := trie.NewEmptyTrie()
+	value := bytes.Repeat([]byte{1}, 40)
+	for _, key := range testCases {
+		err := tr.Put([]byte(key), value)
+		require.NoError(t, err)
+	}
+
+	db, err := chaindb.NewBadgerDB(&chaindb.Config{InMemory: true})
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	rootHash := tr.MustHash()
+	lazyTrie, err := trie.NewLazyTrie(rootOnlyDatabase{Database: db, rootHash: rootHash}, rootHash, nil)
+	require.NoError(t, err)
+
+	ts := NewTrieState(lazyTrie)
+	key := []byte(testCases[0])
+
+	_, err = ts.Get(key)
+	require.ErrorContains(t, err, "not found")
+	_, err = ts.Has(key)
+	require.ErrorContains(t, err, "not found")
+	_, err = ts.NextKey(key)
+	require.ErrorContains(t, err, "not found")
+	_, err = ts.TrieEntries()
+	require.ErrorContains(t, err, "not found")
+	err = ts.ClearPrefix(key[:1])
+	require.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tracing
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefaultTargets are the targets traced when no target is given, which are the
+// default targets of Substrate's block tracing and the target of the runtime
+// and host function call spans.
+var DefaultTargets = []string{"pallet", "frame", "state", RuntimeTarget}
+
+const (
+	// StateTarget is the target of the storage access events.
+	StateTarget = "state"
+	// RuntimeTarget is the target of the runtime call spans.
+	RuntimeTarget = "runtime"
+	// HostTarget is the target of the host function call spans.
+	HostTarget = "runtime::host"
+)
+
+// Span is a unit of work recorded during the runtime execution,
+// such as a runtime call or a host function call.
+type Span struct {
+	ID       uint64
+	ParentID *uint64
+	Name     string
+	Target   string
+	Wasm     bool
+}
+
+// Event is a point in time occurrence recorded during the runtime
+// execution, such as a storage access or a runtime log.
+type Event struct {
+	Target   string
+	Values   map[string]string
+	ParentID *uint64
+}
+
+// Tracer records the spans and events of a runtime execution.
+// Spans and events are only recorded if their target matches one of the
+// tracer targets, where a target matches if it starts with a tracer
+// target, such as `runtime::host` for `runtime` or `pallet_balances` for `pallet`.
+// A nil Tracer records nothing.
+type Tracer struct {
+	targets     []string
+	storageKeys []string
+	methods     []string
+
+	mutex      sync.Mutex
+	lastSpanID uint64
+	// openSpans is the stack of the identifiers of the entered spans.
+	// It contains the identifiers of spans not recorded as well,
+	// for which the identifier is zero.
+	openSpans []uint64
+	spans     []Span
+	events    []Event
+}
+
+// NewTracer returns a tracer recording the spans and events matching the
+// given targets, defaulting to DefaultTargets if no target is given.
+// Events with a storage `key` value are only recorded if the key, hex encoded
+// without 0x prefix, starts with one of the given storage keys, if any.
+// Events with a `method` value are only recorded if the method is one of the
+// given methods, if any.
+func NewTracer(targets, storageKeys, methods []string) *Tracer {
+	if len(targets) == 0 {
+		targets = DefaultTargets
+	}
+
+	tracer := &Tracer{
+		targets: make([]string, len(targets)),
+		methods: methods,
+	}
+
+	for i, target := range targets {
+		// Substrate targets can have a log level suffix such as `pallet=trace`
+		target, _, _ = strings.Cut(target, "=")
+		tracer.targets[i] = target
+	}
+
+	tracer.storageKeys = make([]string, len(storageKeys))
+	for i, key := range storageKeys {
+		tracer.storageKeys[i] = strings.ToLower(strings.TrimPrefix(key, "0x"))
+	}
+
+	return tracer
+}
+
+// Targets returns the targets traced.
+func (t *Tracer) Targets() []string {
+	return slices.Clone(t.targets)
+}
+
+// StorageKeys returns the storage key prefixes filtering the events,
+// hex encoded without 0x prefix.
+func (t *Tracer) StorageKeys() []string {
+	return slices.Clone(t.storageKeys)
+}
+
+// Methods returns the methods filtering the events.
+func (t *Tracer) Methods() []string {
+	return slices.Clone(t.methods)
+}
+
+// EnterSpan enters a new span with the given name and target, nested in the
+// current span if any, and returns its identifier. The identifier is zero
+// if the span is not recorded.
+func (t *Tracer) EnterSpan(name, target string, wasm bool) (id uint64) {
+	if t == nil {
+		return 0
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.targetEnabled(target) {
+		t.lastSpanID++
+		id = t.lastSpanID
+		t.spans = append(t.spans, Span{
+			ID:       id,
+			ParentID: t.parentID(),
+			Name:     name,
+			Target:   target,
+			Wasm:     wasm,
+		})
+	}
+
+	t.openSpans = append(t.openSpans, id)
+	return id
+}
+
+// ExitSpan exits the current span, which must be the span
+// returned by the last call to EnterSpan not yet exited.
+func (t *Tracer) ExitSpan() {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.openSpans) == 0 {
+		return
+	}
+	t.openSpans = t.openSpans[:len(t.openSpans)-1]
+}
+
+// ExitSpanID exits the last entered span with the given identifier not yet exited,
+// for spans entered by the runtime which are not necessarily exited in order.
+func (t *Tracer) ExitSpanID(id uint64) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for i := len(t.openSpans) - 1; i >= 0; i-- {
+		if t.openSpans[i] == id {
+			t.openSpans = append(t.openSpans[:i], t.openSpans[i+1:]...)
+			return
+		}
+	}
+}
+
+// Enabled returns true if the spans and events with the given target are recorded.
+func (t *Tracer) Enabled(target string) bool {
+	if t == nil {
+		return false
+	}
+	return t.targetEnabled(target)
+}
+
+// Event records an event with the given target and values
+// in the current span, if it matches the tracer filters.
+func (t *Tracer) Event(target string, values map[string]string) {
+	if t == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.targetEnabled(target) || !t.valuesEnabled(values) {
+		return
+	}
+
+	t.events = append(t.events, Event{
+		Target:   target,
+		Values:   values,
+		ParentID: t.parentID(),
+	})
+}
+
+// Spans returns the spans recorded, in the order they were entered.
+func (t *Tracer) Spans() []Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return slices.Clone(t.spans)
+}
+
+// Events returns the events recorded, in the order they occurred.
+func (t *Tracer) Events() []Event {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return slices.Clone(t.events)
+}
+
+// parentID returns the identifier of the closest recorded open span,
+// or nil if there is none. It is NOT THREAD SAFE to use.
+func (t *Tracer) parentID() *uint64 {
+	for i := len(t.openSpans) - 1; i >= 0; i-- {
+		if t.openSpans[i] != 0 {
+			id := t.openSpans[i]
+			return &id
+		}
+	}
+	return nil
+}
+
+func (t *Tracer) targetEnabled(target string) bool {
+	for _, enabled := range t.targets {
+		if strings.HasPrefix(target, enabled) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tracer) valuesEnabled(values map[string]string) bool {
+	key, ok := values["key"]
+	if ok && len(t.storageKeys) > 0 {
+		matched := false
+		for _, prefix := range t.storageKeys {
+			if strings.HasPrefix(key, prefix) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	method, ok := values["method"]
+	if ok && len(t.methods) > 0 && !slices.Contains(t.methods, method) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uint64Ptr(n uint64) *uint64 { return &n }
+
+func Test_NewTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer(nil, []string{"0x26AA", "3a63"}, nil)
+	assert.Equal(t, DefaultTargets, tracer.Targets())
+	assert.Equal(t, []string{"26aa", "3a63"}, tracer.StorageKeys())
+
+	tracer = NewTracer([]string{"pallet=trace", "runtime"}, nil, nil)
+	assert.Equal(t, []string{"pallet", "runtime"}, tracer.Targets())
+}
+
+func Test_Tracer_spans(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer([]string{"runtime"}, nil, nil)
+
+	id := tracer.EnterSpan("Core_execute_block", RuntimeTarget, false)
+	assert.Equal(t, uint64(1), id)
+
+	id = tracer.EnterSpan("ignored", "frame", false)
+	assert.Equal(t, uint64(0), id)
+
+	id = tracer.EnterSpan("ext_storage_get_version_1", HostTarget, false)
+	assert.Equal(t, uint64(2), id)
+
+	tracer.Event("runtime::system", map[string]string{"message": "hello"})
+	tracer.Event(StateTarget, map[string]string{"method": "Get"})
+
+	tracer.ExitSpan()
+	tracer.ExitSpan()
+	tracer.ExitSpan()
+
+	tracer.Event("runtime", nil)
+
+	expectedSpans := []Span{
+		{ID: 1, Name: "Core_execute_block", Target: RuntimeTarget},
+		{ID: 2, ParentID: uint64Ptr(1), Name: "ext_storage_get_version_1", Target: HostTarget},
+	}
+	assert.Equal(t, expectedSpans, tracer.Spans())
+
+	expectedEvents := []Event{
+		{Target: "runtime::system", Values: map[string]string{"message": "hello"}, ParentID: uint64Ptr(2)},
+		{Target: "runtime"},
+	}
+	assert.Equal(t, expectedEvents, tracer.Events())
+}
+
+func Test_Tracer_defaultTargets(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer(nil, nil, nil)
+
+	tracer.EnterSpan("Core_execute_block", RuntimeTarget, false)
+	tracer.EnterSpan("ext_storage_get_version_1", HostTarget, false)
+	tracer.ExitSpan()
+	tracer.EnterSpan("on_initialize", "frame_executive", true)
+	tracer.ExitSpan()
+	tracer.ExitSpan()
+
+	expectedSpans := []Span{
+		{ID: 1, Name: "Core_execute_block", Target: RuntimeTarget},
+		{ID: 2, ParentID: uint64Ptr(1), Name: "ext_storage_get_version_1", Target: HostTarget},
+		{ID: 3, ParentID: uint64Ptr(1), Name: "on_initialize", Target: "frame_executive", Wasm: true},
+	}
+	assert.Equal(t, expectedSpans, tracer.Spans())
+}
+
+func Test_Tracer_ExitSpanID(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer(nil, nil, nil)
+
+	first := tracer.EnterSpan("first", "pallet", true)
+	second := tracer.EnterSpan("second", "pallet", true)
+	// spans entered by the runtime can be exited out of order
+	tracer.ExitSpanID(first)
+	tracer.Event("pallet", nil)
+	tracer.ExitSpanID(second)
+	tracer.Event("pallet", nil)
+	// exiting an unknown span does nothing
+	tracer.ExitSpanID(100)
+
+	expectedEvents := []Event{
+		{Target: "pallet", ParentID: uint64Ptr(second)},
+		{Target: "pallet"},
+	}
+	assert.Equal(t, expectedEvents, tracer.Events())
+}
+
+func Test_Tracer_Enabled(t *testing.T) {
+	t.Parallel()
+
+	var tracer *Tracer
+	assert.False(t, tracer.Enabled(RuntimeTarget))
+
+	tracer = NewTracer([]string{"pallet"}, nil, nil)
+	assert.True(t, tracer.Enabled("pallet_balances"))
+	assert.False(t, tracer.Enabled(RuntimeTarget))
+}
+
+func Test_Tracer_Event(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tracer   *Tracer
+		target   string
+		values   map[string]string
+		recorded bool
+	}{
+		"nil tracer": {
+			target: StateTarget,
+		},
+		"target not traced": {
+			tracer: NewTracer(nil, nil, nil),
+			target: "offchain",
+		},
+		"nested target": {
+			tracer:   NewTracer([]string{"runtime"}, nil, nil),
+			target:   "runtime::system",
+			recorded: true,
+		},
+		"target prefix": {
+			tracer:   NewTracer(nil, nil, nil),
+			target:   "pallet_balances",
+			recorded: true,
+		},
+		"storage key not matching": {
+			tracer: NewTracer(nil, []string{"26aa"}, nil),
+			target: StateTarget,
+			values: map[string]string{"key": "3a63"},
+		},
+		"storage key matching": {
+			tracer:   NewTracer(nil, []string{"26aa"}, nil),
+			target:   StateTarget,
+			values:   map[string]string{"key": "26aa394eea"},
+			recorded: true,
+		},
+		"event without storage key": {
+			tracer:   NewTracer(nil, []string{"26aa"}, nil),
+			target:   "pallet",
+			recorded: true,
+		},
+		"method not matching": {
+			tracer: NewTracer(nil, nil, []string{"Put"}),
+			target: StateTarget,
+			values: map[string]string{"method": "Get"},
+		},
+		"method matching": {
+			tracer:   NewTracer(nil, nil, []string{"Get", "Put"}),
+			target:   StateTarget,
+			values:   map[string]string{"method": "Put"},
+			recorded: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.tracer.Event(testCase.target, testCase.values)
+
+			if testCase.tracer == nil {
+				return
+			}
+
+			events := testCase.tracer.Events()
+			if testCase.recorded {
+				assert.Len(t, events, 1)
+			} else {
+				assert.Empty(t, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tracing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var errWasmValueTypeUnknown = errors.New("wasm value type unknown")
+
+// WasmMetadata is the metadata of a span or an event entered by the runtime,
+// see `sp_tracing::WasmMetadata`.
+type WasmMetadata struct {
+	Name       []byte
+	Target     []byte
+	Level      uint8
+	File       []byte
+	Line       uint32
+	ModulePath []byte
+	IsSpan     bool
+	Fields     [][]byte
+}
+
+// WasmEntryAttributes are the attributes of a span or an event entered by the runtime,
+// see `sp_tracing::WasmEntryAttributes`. The values are formatted as strings.
+type WasmEntryAttributes struct {
+	ParentID *uint64
+	Metadata WasmMetadata
+	Values   map[string]string
+}
+
+// DecodeWasmMetadata scale decodes the encoded metadata of a span or an event.
+func DecodeWasmMetadata(encoded []byte) (metadata WasmMetadata, err error) {
+	err = scale.Unmarshal(encoded, &metadata)
+	if err != nil {
+		return WasmMetadata{}, err
+	}
+	return metadata, nil
+}
+
+// DecodeWasmEntryAttributes scale decodes the encoded attributes of a span or an event.
+func DecodeWasmEntryAttributes(encoded []byte) (attributes WasmEntryAttributes, err error) {
+	decoder := scale.NewDecoder(bytes.NewReader(encoded))
+
+	err = decoder.Decode(&attributes.ParentID)
+	if err != nil {
+		return WasmEntryAttributes{}, fmt.Errorf("decoding parent id: %w", err)
+	}
+
+	err = decoder.Decode(&attributes.Metadata)
+	if err != nil {
+		return WasmEntryAttributes{}, fmt.Errorf("decoding metadata: %w", err)
+	}
+
+	var length uint
+	err = decoder.Decode(&length)
+	if err != nil {
+		return WasmEntryAttributes{}, fmt.Errorf("decoding values length: %w", err)
+	}
+
+	attributes.Values = make(map[string]string)
+	for i := uint(0); i < length; i++ {
+		var name []byte
+		err = decoder.Decode(&name)
+		if err != nil {
+			return WasmEntryAttributes{}, fmt.Errorf("decoding value name: %w", err)
+		}
+
+		var isSome bool
+		err = decoder.Decode(&isSome)
+		if err != nil {
+			return WasmEntryAttributes{}, fmt.Errorf("decoding value option: %w", err)
+		} else if !isSome {
+			continue
+		}
+
+		var value string
+		value, err = decodeWasmValue(decoder)
+		if err != nil {
+			return WasmEntryAttributes{}, fmt.Errorf("decoding value %s: %w", name, err)
+		}
+		attributes.Values[string(name)] = value
+	}
+
+	return attributes, nil
+}
+
+// decodeWasmValue decodes a `sp_tracing::WasmValue` and formats it as a string.
+func decodeWasmValue(decoder *scale.Decoder) (value string, err error) {
+	var index byte
+	err = decoder.Decode(&index)
+	if err != nil {
+		return "", fmt.Errorf("decoding type: %w", err)
+	}
+
+	switch index {
+	case 0:
+		var v uint8
+		err = decoder.Decode(&v)
+		value = strconv.FormatUint(uint64(v), 10)
+	case 1:
+		var v int8
+		err = decoder.Decode(&v)
+		value = strconv.FormatInt(int64(v), 10)
+	case 2:
+		var v uint32
+		err = decoder.Decode(&v)
+		value = strconv.FormatUint(uint64(v), 10)
+	case 3:
+		var v int32
+		err = decoder.Decode(&v)
+		value = strconv.FormatInt(int64(v), 10)
+	case 4:
+		var v int64
+		err = decoder.Decode(&v)
+		value = strconv.FormatInt(v, 10)
+	case 5:
+		var v uint64
+		err = decoder.Decode(&v)
+		value = strconv.FormatUint(v, 10)
+	case 6:
+		var v bool
+		err = decoder.Decode(&v)
+		value = strconv.FormatBool(v)
+	case 7, 8: // string and formatted value
+		var v []byte
+		err = decoder.Decode(&v)
+		value = string(v)
+	case 9: // scale encoded value
+		var v []byte
+		err = decoder.Decode(&v)
+		value = common.BytesToHex(v)
+	default:
+		return "", fmt.Errorf("%w: %d", errWasmValueTypeUnknown, index)
+	}
+
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeWasmMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := WasmMetadata{
+		Name:       []byte("on_initialize"),
+		Target:     []byte("frame_executive"),
+		Level:      4,
+		File:       []byte("lib.rs"),
+		Line:       10,
+		ModulePath: []byte("frame_executive"),
+		IsSpan:     true,
+		Fields:     [][]byte{[]byte("block")},
+	}
+	encoded, err := scale.Marshal(metadata)
+	require.NoError(t, err)
+
+	decoded, err := DecodeWasmMetadata(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, metadata, decoded)
+
+	_, err = DecodeWasmMetadata(encoded[:3])
+	assert.Error(t, err)
+}
+
+func Test_DecodeWasmEntryAttributes(t *testing.T) {
+	t.Parallel()
+
+	metadata := WasmMetadata{
+		Name:       []byte("event"),
+		Target:     []byte("pallet_balances"),
+		File:       []byte("lib.rs"),
+		ModulePath: []byte("pallet_balances"),
+		Fields:     [][]byte{[]byte("value")},
+	}
+	encodedMetadata, err := scale.Marshal(metadata)
+	require.NoError(t, err)
+
+	encode := func(t *testing.T, values ...interface{}) []byte {
+		t.Helper()
+		encoded := []byte{1, 5, 0, 0, 0, 0, 0, 0, 0} // parent id Some(5)
+		encoded = append(encoded, encodedMetadata...)
+		for _, value := range values {
+			encodedValue, err := scale.Marshal(value)
+			require.NoError(t, err)
+			encoded = append(encoded, encodedValue...)
+		}
+		return encoded
+	}
+
+	parentID := uint64(5)
+
+	testCases := map[string]struct {
+		encoded    []byte
+		attributes WasmEntryAttributes
+		errMessage string
+	}{
+		"values": {
+			encoded: encode(t, uint(11),
+				[]byte("u8"), true, byte(0), uint8(1),
+				[]byte("i8"), true, byte(1), int8(-1),
+				[]byte("u32"), true, byte(2), uint32(2),
+				[]byte("i32"), true, byte(3), int32(-2),
+				[]byte("i64"), true, byte(4), int64(-3),
+				[]byte("u64"), true, byte(5), uint64(3),
+				[]byte("bool"), true, byte(6), true,
+				[]byte("str"), true, byte(7), []byte("hello"),
+				[]byte("formatted"), true, byte(8), []byte("Some(1)"),
+				[]byte("encoded"), true, byte(9), []byte{1, 2},
+				[]byte("none"), false,
+			),
+			attributes: WasmEntryAttributes{
+				ParentID: &parentID,
+				Metadata: metadata,
+				Values: map[string]string{
+					"u8":        "1",
+					"i8":        "-1",
+					"u32":       "2",
+					"i32":       "-2",
+					"i64":       "-3",
+					"u64":       "3",
+					"bool":      "true",
+					"str":       "hello",
+					"formatted": "Some(1)",
+					"encoded":   "0x0102",
+				},
+			},
+		},
+		"unknown value type": {
+			encoded:    encode(t, uint(1), []byte("value"), true, byte(10)),
+			errMessage: "decoding value value: wasm value type unknown: 10",
+		},
+		"missing values": {
+			encoded:    encode(t),
+			errMessage: "decoding values length: reading byte: EOF",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			attributes, err := DecodeWasmEntryAttributes(testCase.encoded)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.attributes, attributes)
+		})
+	}
+}
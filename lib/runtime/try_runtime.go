@@ -0,0 +1,58 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// UpgradeWeights is the result of the TryRuntime_on_runtime_upgrade runtime API call.
+type UpgradeWeights struct {
+	// Weight is the reference time weight consumed by the runtime upgrade.
+	Weight uint64
+	// MaxWeight is the maximum reference time weight of a block.
+	MaxWeight uint64
+	// ProofSize and MaxProofSize are only set for runtimes using
+	// two dimensional weights.
+	ProofSize    uint64
+	MaxProofSize uint64
+}
+
+// DecodeUpgradeWeights decodes the (Weight, Weight) tuple returned by
+// TryRuntime_on_runtime_upgrade, depending on the TryRuntime API version
+// of the runtime. Runtimes with a TryRuntime API version 1 encode each
+// weight as a single u64, whilst runtimes with a later API version encode
+// each weight as a pair of compact encoded reference time and proof size values.
+func DecodeUpgradeWeights(encoded []byte, tryRuntimeVersion uint32) (weights UpgradeWeights, err error) {
+	if tryRuntimeVersion < 2 {
+		var decoded [2]uint64
+		err = scale.Unmarshal(encoded, &decoded)
+		if err != nil {
+			return weights, fmt.Errorf("decoding weights: %w", err)
+		}
+		weights.Weight = decoded[0]
+		weights.MaxWeight = decoded[1]
+		return weights, nil
+	}
+
+	type weightV2 struct {
+		RefTime   *big.Int
+		ProofSize *big.Int
+	}
+	var decoded [2]weightV2
+	err = scale.Unmarshal(encoded, &decoded)
+	if err != nil {
+		return weights, fmt.Errorf("decoding weights: %w", err)
+	}
+
+	return UpgradeWeights{
+		Weight:       decoded[0].RefTime.Uint64(),
+		MaxWeight:    decoded[1].RefTime.Uint64(),
+		ProofSize:    decoded[0].ProofSize.Uint64(),
+		MaxProofSize: decoded[1].ProofSize.Uint64(),
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DecodeUpgradeWeights(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded           []byte
+		tryRuntimeVersion uint32
+		weights           UpgradeWeights
+		errMessage        string
+	}{
+		"v1_weights": {
+			encoded: concatBytes([][]byte{
+				scaleEncode(t, uint64(1000)),
+				scaleEncode(t, uint64(2000)),
+			}),
+			tryRuntimeVersion: 1,
+			weights:           UpgradeWeights{Weight: 1000, MaxWeight: 2000},
+		},
+		"v1_decode_error": {
+			encoded:           []byte{1},
+			tryRuntimeVersion: 1,
+			errMessage:        "decoding weights: EOF",
+		},
+		"v2_weights": {
+			tryRuntimeVersion: 2,
+			encoded: concatBytes([][]byte{
+				scaleEncode(t, big.NewInt(1000)),
+				scaleEncode(t, big.NewInt(10)),
+				scaleEncode(t, big.NewInt(2000000000000)),
+				scaleEncode(t, big.NewInt(5242880)),
+			}),
+			weights: UpgradeWeights{
+				Weight:       1000,
+				MaxWeight:    2000000000000,
+				ProofSize:    10,
+				MaxProofSize: 5242880,
+			},
+		},
+		// v2 weights with the same encoded size as v1 weights
+		"v2_weights_of_v1_size": {
+			tryRuntimeVersion: 2,
+			encoded: concatBytes([][]byte{
+				scaleEncode(t, big.NewInt(1<<20)),
+				scaleEncode(t, big.NewInt(1<<21)),
+				scaleEncode(t, big.NewInt(1<<22)),
+				scaleEncode(t, big.NewInt(1<<23)),
+			}),
+			weights: UpgradeWeights{
+				Weight:       1 << 20,
+				MaxWeight:    1 << 22,
+				ProofSize:    1 << 21,
+				MaxProofSize: 1 << 23,
+			},
+		},
+		"v2_decode_error": {
+			encoded:           []byte{1},
+			tryRuntimeVersion: 2,
+			errMessage:        "decoding weights: decoding struct: unmarshalling field at index 0: EOF",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			weights, err := DecodeUpgradeWeights(testCase.encoded, testCase.tryRuntimeVersion)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.weights, weights)
+		})
+	}
+}
@@ -7,6 +7,7 @@
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 )
 
 // NodeStorageType type to identify offchain storage type
@@ -58,4 +59,5 @@ type Context struct {
 	OffchainHTTPSet *offchain.HTTPSet
 	Version         *Version
 	Memory          Memory
+	Tracer          *tracing.Tracer
 }
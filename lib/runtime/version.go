@@ -35,6 +35,8 @@ type Version struct {
 	ErrDecodingVersionField = errors.New("decoding version field")
 )
 
+var errTryRuntimeAPINotFound = errors.New("TryRuntime API not found")
+
 // TaggedTransactionQueueVersion returns the TaggedTransactionQueue API version
 func (v Version) TaggedTransactionQueueVersion() (txQueueVersion uint32, err error) {
 	encodedTaggedTransactionQueue, err := common.Blake2b8([]byte("TaggedTransactionQueue"))
@@ -49,6 +51,20 @@ func (v Version) TaggedTransactionQueueVersion() (txQueueVersion uint32, err err
 	return 0, errors.New("taggedTransactionQueueAPI not found")
 }
 
+// TryRuntimeVersion returns the TryRuntime API version
+func (v Version) TryRuntimeVersion() (tryRuntimeVersion uint32, err error) {
+	encodedTryRuntime, err := common.Blake2b8([]byte("TryRuntime"))
+	if err != nil {
+		return 0, fmt.Errorf("getting blake2b8: %s", err)
+	}
+	for _, apiItem := range v.APIItems {
+		if apiItem.Name == encodedTryRuntime {
+			return apiItem.Ver, nil
+		}
+	}
+	return 0, errTryRuntimeAPINotFound
+}
+
 // DecodeVersion scale decodes the encoded version data.
 // For older version data with missing fields (such as `transaction_version`)
 // the missing field is set to its zero value (such as `0`).
@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,3 +206,22 @@ func Test_Version_Scale(t *testing.T) {
 		})
 	}
 }
+
+func Test_Version_TryRuntimeVersion(t *testing.T) {
+	t.Parallel()
+
+	version := Version{
+		APIItems: []APIItem{
+			{Name: common.MustBlake2b8([]byte("Core")), Ver: 4},
+			{Name: common.MustBlake2b8([]byte("TryRuntime")), Ver: 2},
+		},
+	}
+
+	tryRuntimeVersion, err := version.TryRuntimeVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), tryRuntimeVersion)
+
+	version.APIItems = version.APIItems[:1]
+	_, err = version.TryRuntimeVersion()
+	assert.ErrorIs(t, err, errTryRuntimeAPINotFound)
+}
@@ -10,6 +10,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 )
 
 // Config is the configuration used to create a Wasmer runtime instance.
@@ -22,6 +23,9 @@ type Config struct {
 	Network     BasicNetwork
 	Transaction TransactionState
 	CodeHash    common.Hash
+	// Tracer, if not nil, records the runtime calls,
+	// host function calls and runtime logs of the instance.
+	Tracer      *tracing.Tracer
 	testVersion *runtime.Version
 }
 
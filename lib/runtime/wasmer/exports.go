@@ -99,6 +99,22 @@ func (in *Instance) GrandpaAuthorities() ([]types.Authority, error) {
 	return types.GrandpaAuthoritiesRawToAuthorities(gar)
 }
 
+// AuthorityDiscoveryAuthorities returns the authority discovery identifiers
+// of the current and next authority sets from the runtime.
+func (in *Instance) AuthorityDiscoveryAuthorities() (authorities []types.AuthorityID, err error) {
+	encodedAuthorities, err := in.Exec(runtime.AuthorityDiscoveryAPIAuthorities, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = scale.Unmarshal(encodedAuthorities, &authorities)
+	if err != nil {
+		return nil, fmt.Errorf("scale decoding authorities: %w", err)
+	}
+
+	return authorities, nil
+}
+
 // BabeGenerateKeyOwnershipProof returns the babe key ownership proof from the runtime.
 func (in *Instance) BabeGenerateKeyOwnershipProof(slot uint64, authorityID [32]byte) (
 	types.OpaqueKeyOwnershipProof, error) {
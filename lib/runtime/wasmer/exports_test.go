@@ -17,6 +17,7 @@
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer/testdata"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/lib/utils"
@@ -278,6 +279,36 @@ func TestInstance_GrandpaAuthorities_PolkadotRuntime(t *testing.T) {
 	require.Equal(t, expected, auths)
 }
 
+func TestInstance_AuthorityDiscoveryAuthorities_WestendRuntime(t *testing.T) {
+	authorityA := types.AuthorityID{1}
+	authorityB := types.AuthorityID{2}
+
+	tt := trie.NewEmptyTrie()
+
+	palletKey, err := common.Twox128Hash([]byte("AuthorityDiscovery"))
+	require.NoError(t, err)
+
+	keysKey, err := common.Twox128Hash([]byte("Keys"))
+	require.NoError(t, err)
+	encodedKeys, err := scale.Marshal([]types.AuthorityID{authorityA})
+	require.NoError(t, err)
+	tt.Put(bytes.Join([][]byte{palletKey, keysKey}, nil), encodedKeys)
+
+	nextKeysKey, err := common.Twox128Hash([]byte("NextKeys"))
+	require.NoError(t, err)
+	encodedNextKeys, err := scale.Marshal([]types.AuthorityID{authorityB, authorityA})
+	require.NoError(t, err)
+	tt.Put(bytes.Join([][]byte{palletKey, nextKeysKey}, nil), encodedNextKeys)
+
+	rt := NewTestInstanceWithTrie(t, runtime.WESTEND_RUNTIME_v0929, tt)
+
+	authorities, err := rt.AuthorityDiscoveryAuthorities()
+	require.NoError(t, err)
+
+	expected := []types.AuthorityID{authorityA, authorityB}
+	require.Equal(t, expected, authorities)
+}
+
 func TestInstance_BabeGenerateKeyOwnershipProof(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -451,6 +482,35 @@ func TestInstance_ExecuteBlock_WestendRuntime(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInstance_ExecuteBlock_WestendRuntime_tracing(t *testing.T) {
+	instance := NewTestInstance(t, runtime.WESTEND_RUNTIME_v0929)
+	block := runtime.InitializeRuntimeToTest(t, instance, &types.Header{})
+
+	// reset state back to parent state before executing
+	parentState := storage.NewTrieState(nil)
+	instance.SetContextStorage(parentState)
+
+	tracer := tracing.NewTracer(nil, nil, nil)
+	instance.ctx.Tracer = tracer
+
+	_, err := instance.ExecuteBlock(block)
+	require.NoError(t, err)
+
+	spans := tracer.Spans()
+	require.Greater(t, len(spans), 1)
+	expectedExecuteBlockSpan := tracing.Span{ID: 1, Name: "Core_execute_block", Target: tracing.RuntimeTarget}
+	assert.Equal(t, expectedExecuteBlockSpan, spans[0])
+
+	hostSpans := 0
+	for _, span := range spans[1:] {
+		require.NotNil(t, span.ParentID)
+		if span.Target == tracing.HostTarget {
+			hostSpans++
+		}
+	}
+	assert.NotZero(t, hostSpans)
+}
+
 func TestInstance_ApplyExtrinsic_WestendRuntime(t *testing.T) {
 	genesisPath := utils.GetWestendDevRawGenesisPath(t)
 	gen := genesisFromRawJSON(t, genesisPath)
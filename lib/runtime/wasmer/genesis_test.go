@@ -72,11 +72,13 @@ func Test_NewTrieFromGenesis(t *testing.T) {
 
 			for hexKey, hexValue := range testCase.expectedKV {
 				key := common.MustHexToBytes(hexKey)
-				value := tr.Get(key)
+				value, err := tr.Get(key)
+				require.NoError(t, err)
 				assert.Equal(t, hexValue, common.BytesToHex(value))
 				tr.Delete(key)
 			}
-			entries := tr.Entries()
+			entries, err := tr.Entries()
+			require.NoError(t, err)
 			assert.Empty(t, entries)
 		})
 	}
@@ -216,7 +216,10 @@ func toKillStorageResultEnum(allRemoved bool, numRemoved uint32) (
 func storageAppend(storage GetSetter, key, valueToAppend []byte) (err error) {
 	// this function assumes the item in storage is a SCALE encoded array of items
 	// the valueToAppend is a new item, so it appends the item and increases the length prefix by 1
-	currentValue := storage.Get(key)
+	currentValue, err := storage.Get(key)
+	if err != nil {
+		return fmt.Errorf("getting value from storage: %w", err)
+	}
 
 	var value []byte
 	if len(currentValue) == 0 {
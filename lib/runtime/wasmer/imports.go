@@ -9,14 +9,17 @@
 	"math/big"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"time"
 
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/lib/trie/proof"
@@ -44,17 +47,23 @@ func ext_logging_log_version_1(env interface{}, args []wasmer.Value) ([]wasmer.V
 	target := string(asMemorySlice(ctx, targetData))
 	msg := string(asMemorySlice(ctx, msgData))
 
-	switch int(level) {
-	case 0:
-		logger.Critical("target=" + target + " message=" + msg)
-	case 1:
-		logger.Warn("target=" + target + " message=" + msg)
-	case 2:
-		logger.Info("target=" + target + " message=" + msg)
-	case 3:
-		logger.Debug("target=" + target + " message=" + msg)
-	case 4:
-		logger.Trace("target=" + target + " message=" + msg)
+	ctx.Tracer.Event(target, map[string]string{
+		"level":   strconv.Itoa(int(level)),
+		"message": msg,
+	})
+
+	targetLogger := runtimeTargetLogger(target)
+	switch log.Level(level) {
+	case log.Error:
+		targetLogger.Error(msg)
+	case log.Warn:
+		targetLogger.Warn(msg)
+	case log.Info:
+		targetLogger.Info(msg)
+	case log.Debug:
+		targetLogger.Debug(msg)
+	case log.Trace:
+		targetLogger.Trace(msg)
 	default:
 		logger.Errorf("level=%d target=%s message=%s", int(level), target, msg)
 	}
@@ -64,20 +73,108 @@ func ext_logging_log_version_1(env interface{}, args []wasmer.Value) ([]wasmer.V
 //export ext_logging_max_level_version_1
 func ext_logging_max_level_version_1(_ interface{}, _ []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Trace("executing...")
-	return []wasmer.Value{wasmer.NewI32(4)}, nil
+
+	// The runtime log level filter values from off (0) to trace (5)
+	// match our log levels from critical (0) to trace (5).
+	maxLevel := logger.Level()
+	filterLevel, ok := log.MaxFilterLevel()
+	if ok && filterLevel > maxLevel {
+		maxLevel = filterLevel
+	}
+	return []wasmer.Value{wasmer.NewI32(int32(maxLevel))}, nil
+}
+
+//export ext_wasm_tracing_enabled_version_1
+func ext_wasm_tracing_enabled_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	ctx := env.(*runtime.Context)
+
+	if ctx.Tracer == nil {
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	metadata, err := tracing.DecodeWasmMetadata(asMemorySlice(ctx, args[0].I64()))
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI32(0)}, fmt.Errorf("decoding metadata: %w", err)
+	}
+
+	if !ctx.Tracer.Enabled(string(metadata.Target)) {
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+	return []wasmer.Value{wasmer.NewI32(1)}, nil
+}
+
+//export ext_wasm_tracing_enter_span_version_1
+func ext_wasm_tracing_enter_span_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	ctx := env.(*runtime.Context)
+
+	attributes, err := tracing.DecodeWasmEntryAttributes(asMemorySlice(ctx, args[0].I64()))
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI64(0)}, fmt.Errorf("decoding span attributes: %w", err)
+	}
+
+	id := ctx.Tracer.EnterSpan(string(attributes.Metadata.Name), string(attributes.Metadata.Target), true)
+	return []wasmer.Value{wasmer.NewI64(int64(id))}, nil
+}
+
+//export ext_wasm_tracing_exit_version_1
+func ext_wasm_tracing_exit_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	ctx := env.(*runtime.Context)
+
+	ctx.Tracer.ExitSpanID(uint64(args[0].I64()))
+	return nil, nil
+}
+
+//export ext_wasm_tracing_event_version_1
+func ext_wasm_tracing_event_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	ctx := env.(*runtime.Context)
+
+	if ctx.Tracer == nil {
+		return nil, nil
+	}
+
+	attributes, err := tracing.DecodeWasmEntryAttributes(asMemorySlice(ctx, args[0].I64()))
+	if err != nil {
+		return nil, fmt.Errorf("decoding event attributes: %w", err)
+	}
+
+	ctx.Tracer.Event(string(attributes.Metadata.Target), attributes.Values)
+	return nil, nil
 }
 
 //export ext_transaction_index_index_version_1
-func ext_transaction_index_index_version_1(_ interface{}, _ []wasmer.Value) ([]wasmer.Value, error) {
+func ext_transaction_index_index_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	ctx := env.(*runtime.Context)
+	memory := ctx.Memory.Data()
+
+	extrinsic := args[0].I32()
+	size := args[1].I32()
+	hashPtr := args[2].I32()
+
+	hash := common.BytesToHash(memory[hashPtr : hashPtr+32])
+	ctx.Storage.IndexTransaction(uint32(extrinsic), uint32(size), hash)
+
+	logger.Debugf("indexing %d bytes of extrinsic %d with hash %s", size, extrinsic, hash)
 	return nil, nil
 }
 
 //export ext_transaction_index_renew_version_1
-func ext_transaction_index_renew_version_1(_ interface{}, _ []wasmer.Value) ([]wasmer.Value, error) {
+func ext_transaction_index_renew_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	ctx := env.(*runtime.Context)
+	memory := ctx.Memory.Data()
+
+	extrinsic := args[0].I32()
+	hashPtr := args[1].I32()
+
+	hash := common.BytesToHash(memory[hashPtr : hashPtr+32])
+	ctx.Storage.RenewTransactionIndex(uint32(extrinsic), hash)
+
+	logger.Debugf("renewing index of extrinsic %d with hash %s", extrinsic, hash)
 	return nil, nil
 }
 
@@ -331,6 +428,12 @@ func ext_crypto_ed25519_verify_version_1(env interface{}, args []wasmer.Value) (
 	return []wasmer.Value{wasmer.NewI32(1)}, nil
 }
 
+//export ext_crypto_ed25519_batch_verify_version_1
+func ext_crypto_ed25519_batch_verify_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	return ext_crypto_ed25519_verify_version_1(env, args)
+}
+
 //export ext_crypto_secp256k1_ecdsa_recover_version_1
 func ext_crypto_secp256k1_ecdsa_recover_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Trace("executing...")
@@ -433,6 +536,280 @@ func ext_crypto_ecdsa_verify_version_2(env interface{}, args []wasmer.Value) ([]
 	return []wasmer.Value{wasmer.NewI32(1)}, nil
 }
 
+//export ext_crypto_ecdsa_generate_version_1
+func ext_crypto_ecdsa_generate_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+
+	runtimeCtx := env.(*runtime.Context)
+	memory := runtimeCtx.Memory.Data()
+
+	keyTypeID := args[0].I32()
+	seedSpan := args[1].I64()
+
+	id := memory[keyTypeID : keyTypeID+4]
+	seedBytes := asMemorySlice(runtimeCtx, seedSpan)
+
+	var seed *[]byte
+	err := scale.Unmarshal(seedBytes, &seed)
+	if err != nil {
+		logger.Warnf("cannot generate key: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	var kp KeyPair
+
+	if seed != nil {
+		kp, err = secp256k1.NewKeypairFromMnemonic(string(*seed), "")
+	} else {
+		kp, err = secp256k1.GenerateKeypair()
+	}
+
+	if err != nil {
+		logger.Warnf("cannot generate key: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	ks, err := runtimeCtx.Keystore.GetKeystore(id)
+	if err != nil {
+		logger.Warnf("error for id 0x%x: %s", id, err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	err = ks.Insert(kp)
+	if err != nil {
+		logger.Warnf("failed to insert key: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	ret, err := toWasmMemorySized(runtimeCtx, kp.Public().Encode())
+	if err != nil {
+		logger.Warnf("failed to allocate memory: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	castedRet, err := safeCastInt32(ret)
+	if err != nil {
+		logger.Errorf("failed to safely cast pointer: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, err
+	}
+
+	logger.Debug("generated ecdsa keypair with public key: " + kp.Public().Hex())
+	return []wasmer.Value{wasmer.NewI32(castedRet)}, nil
+}
+
+//export ext_crypto_ecdsa_public_keys_version_1
+func ext_crypto_ecdsa_public_keys_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	runtimeCtx := env.(*runtime.Context)
+	memory := runtimeCtx.Memory.Data()
+
+	keyTypeID := args[0].I32()
+	id := memory[keyTypeID : keyTypeID+4]
+
+	ks, err := runtimeCtx.Keystore.GetKeystore(id)
+	if err != nil {
+		logger.Warnf("error for id 0x%x: %s", id, err)
+		ret, _ := toWasmMemory(runtimeCtx, []byte{0})
+		return []wasmer.Value{wasmer.NewI64(ret)}, nil
+	}
+
+	if ks.Type() != crypto.Secp256k1Type && ks.Type() != crypto.UnknownType {
+		logger.Warnf(
+			"error for id 0x%x: keystore type is %s and not the expected ecdsa",
+			id, ks.Type())
+		ret, _ := toWasmMemory(runtimeCtx, []byte{0})
+		return []wasmer.Value{wasmer.NewI64(ret)}, nil
+	}
+
+	keys := ks.PublicKeys()
+
+	var encodedKeys []byte
+	for _, key := range keys {
+		encodedKeys = append(encodedKeys, key.Encode()...)
+	}
+
+	prefix, err := scale.Marshal(big.NewInt(int64(len(keys))))
+	if err != nil {
+		logger.Errorf("failed to allocate memory: %s", err)
+		ret, _ := toWasmMemory(runtimeCtx, []byte{0})
+		return []wasmer.Value{wasmer.NewI64(ret)}, nil
+	}
+
+	ret, err := toWasmMemory(runtimeCtx, append(prefix, encodedKeys...))
+	if err != nil {
+		logger.Errorf("failed to allocate memory: %s", err)
+		ret, _ = toWasmMemory(runtimeCtx, []byte{0})
+		return []wasmer.Value{wasmer.NewI64(ret)}, nil
+	}
+
+	return []wasmer.Value{wasmer.NewI64(ret)}, nil
+}
+
+//export ext_crypto_ecdsa_sign_version_1
+func ext_crypto_ecdsa_sign_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+
+	runtimeCtx := env.(*runtime.Context)
+
+	keyTypeID := args[0].I32()
+	key := args[1].I32()
+	msg := args[2].I64()
+
+	hash, err := common.Blake2bHash(asMemorySlice(runtimeCtx, msg))
+	if err != nil {
+		logger.Errorf("failed to hash message: %s", err)
+		return mustToWasmMemoryOptionalNil(runtimeCtx), nil
+	}
+
+	return ecdsaSign(runtimeCtx, keyTypeID, key, hash[:]), nil
+}
+
+//export ext_crypto_ecdsa_sign_prehashed_version_1
+func ext_crypto_ecdsa_sign_prehashed_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+
+	runtimeCtx := env.(*runtime.Context)
+	memory := runtimeCtx.Memory.Data()
+
+	keyTypeID := args[0].I32()
+	key := args[1].I32()
+	msg := args[2].I32()
+
+	hash := memory[msg : msg+32]
+
+	return ecdsaSign(runtimeCtx, keyTypeID, key, hash), nil
+}
+
+// ecdsaSign signs the 32 byte hash with the keypair of the 33 byte compressed
+// public key at the given pointer, found in the keystore identified by the
+// key type id at the given pointer. It returns the pointer size to the scale
+// encoded optional 65 byte signature.
+func ecdsaSign(runtimeCtx *runtime.Context, keyTypeID, key int32, hash []byte) []wasmer.Value {
+	memory := runtimeCtx.Memory.Data()
+	id := memory[keyTypeID : keyTypeID+4]
+
+	pubKey := new(secp256k1.PublicKey)
+	err := pubKey.Decode(memory[key : key+33])
+	if err != nil {
+		logger.Errorf("failed to decode public key: %s", err)
+		return mustToWasmMemoryOptionalNil(runtimeCtx)
+	}
+
+	ks, err := runtimeCtx.Keystore.GetKeystore(id)
+	if err != nil {
+		logger.Warnf("error for id 0x%x: %s", id, err)
+		return mustToWasmMemoryOptionalNil(runtimeCtx)
+	}
+
+	signingKey := ks.GetKeypair(pubKey)
+	if signingKey == nil {
+		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
+		return mustToWasmMemoryOptionalNil(runtimeCtx)
+	}
+
+	sig, err := signingKey.Sign(hash)
+	if err != nil {
+		logger.Errorf("could not sign message: %s", err)
+		return mustToWasmMemoryOptionalNil(runtimeCtx)
+	}
+
+	var signature [secp256k1.SignatureLengthRecovery]byte
+	copy(signature[:], sig)
+	encodedSignature, err := scale.Marshal(&signature)
+	if err != nil {
+		logger.Errorf("failed to scale encode signature: %s", err)
+		return mustToWasmMemoryOptionalNil(runtimeCtx)
+	}
+
+	ret, err := toWasmMemory(runtimeCtx, encodedSignature)
+	if err != nil {
+		logger.Errorf("failed to allocate memory: %s", err)
+		return []wasmer.Value{wasmer.NewI64(0)}
+	}
+
+	return []wasmer.Value{wasmer.NewI64(ret)}
+}
+
+//export ext_crypto_ecdsa_verify_version_1
+func ext_crypto_ecdsa_verify_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+
+	instanceContext := env.(*runtime.Context)
+	memory := instanceContext.Memory.Data()
+	sigVerifier := instanceContext.SigVerifier
+
+	sig := args[0].I32()
+	msg := args[1].I64()
+	key := args[2].I32()
+
+	message := asMemorySlice(instanceContext, msg)
+	signature := memory[sig : sig+65]
+	pubKey := memory[key : key+33]
+
+	logger.Debugf("pub=0x%x, message=0x%x, signature=0x%x",
+		pubKey, message, signature)
+
+	hash, err := common.Blake2bHash(message)
+	if err != nil {
+		logger.Errorf("failed to hash message: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	if sigVerifier.IsStarted() {
+		signature := crypto.SignatureInfo{
+			PubKey:     pubKey,
+			Sign:       signature,
+			Msg:        hash[:],
+			VerifyFunc: secp256k1.VerifyRecoverableSignature,
+		}
+		sigVerifier.Add(&signature)
+		return []wasmer.Value{wasmer.NewI32(1)}, nil
+	}
+
+	err = secp256k1.VerifyRecoverableSignature(pubKey, signature, hash[:])
+	if err != nil {
+		logger.Errorf("%s: %s", validateSignatureFail, err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	logger.Debug("validated signature")
+	return []wasmer.Value{wasmer.NewI32(1)}, nil
+}
+
+//export ext_crypto_ecdsa_verify_prehashed_version_1
+func ext_crypto_ecdsa_verify_prehashed_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+
+	instanceContext := env.(*runtime.Context)
+	memory := instanceContext.Memory.Data()
+
+	sig := args[0].I32()
+	msg := args[1].I32()
+	key := args[2].I32()
+
+	signature := memory[sig : sig+65]
+	hash := memory[msg : msg+32]
+	pubKey := memory[key : key+33]
+
+	logger.Debugf("pub=0x%x, hash=0x%x, signature=0x%x",
+		pubKey, hash, signature)
+
+	err := secp256k1.VerifyPrehashedSignature(pubKey, signature, hash)
+	if err != nil {
+		logger.Errorf("%s: %s", validateSignatureFail, err)
+		return []wasmer.Value{wasmer.NewI32(0)}, nil
+	}
+
+	logger.Debug("validated signature")
+	return []wasmer.Value{wasmer.NewI32(1)}, nil
+}
+
+//export ext_crypto_ecdsa_batch_verify_version_1
+func ext_crypto_ecdsa_batch_verify_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	return ext_crypto_ecdsa_verify_version_1(env, args)
+}
+
 //export ext_crypto_secp256k1_ecdsa_recover_compressed_version_1
 func ext_crypto_secp256k1_ecdsa_recover_compressed_version_1(env interface{},
 	args []wasmer.Value) ([]wasmer.Value, error) {
@@ -735,6 +1112,12 @@ func ext_crypto_sr25519_verify_version_2(env interface{}, args []wasmer.Value) (
 	return []wasmer.Value{wasmer.NewI32(1)}, nil
 }
 
+//export ext_crypto_sr25519_batch_verify_version_1
+func ext_crypto_sr25519_batch_verify_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	return ext_crypto_sr25519_verify_version_2(env, args)
+}
+
 //export ext_crypto_start_batch_verify_version_1
 func ext_crypto_start_batch_verify_version_1(_ interface{}, _ []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Debug("executing...")
@@ -1432,6 +1815,36 @@ func ext_hashing_keccak_256_version_1(env interface{}, args []wasmer.Value) ([]w
 	return []wasmer.Value{wasmer.NewI32(castedOut)}, nil
 }
 
+//export ext_hashing_keccak_512_version_1
+func ext_hashing_keccak_512_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	logger.Trace("executing...")
+	instanceContext := env.(*runtime.Context)
+	dataSpan := args[0].I64()
+	data := asMemorySlice(instanceContext, dataSpan)
+
+	hash, err := common.Keccak512(data)
+	if err != nil {
+		logger.Errorf("failed hashing data: %s", err)
+		return []wasmer.Value{wasmer.NewI32(int32(0))}, nil
+	}
+
+	logger.Debugf("data 0x%x has hash 0x%x", data, hash)
+
+	out, err := toWasmMemorySized(instanceContext, hash[:])
+	if err != nil {
+		logger.Errorf("failed to allocate: %s", err)
+		return []wasmer.Value{wasmer.NewI32(int32(0))}, nil
+	}
+
+	castedOut, err := safeCastInt32(out)
+	if err != nil {
+		logger.Errorf("failed to safely cast pointer: %s", err)
+		return []wasmer.Value{wasmer.NewI32(0)}, err
+	}
+
+	return []wasmer.Value{wasmer.NewI32(castedOut)}, nil
+}
+
 //export ext_hashing_sha2_256_version_1
 func ext_hashing_sha2_256_version_1(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
 	logger.Trace("executing...")
@@ -1910,7 +2323,9 @@ func ext_storage_append_version_1(env interface{}, args []wasmer.Value) ([]wasme
 
 	err := storageAppend(storage, key, cp)
 	if err != nil {
-		logger.Errorf("failed appending to storage: %s", err)
+		// Trap since the storage may not be readable, for example if
+		// a trie node cannot be loaded from the database.
+		return nil, fmt.Errorf("appending to storage: %w", err)
 	}
 	return nil, nil
 }
@@ -2020,8 +2435,10 @@ func ext_storage_exists_version_1(env interface{}, args []wasmer.Value) ([]wasme
 	key := asMemorySlice(instanceContext, keySpan)
 	logger.Debugf("key: 0x%x", key)
 
-	value := storage.Get(key)
-	if value != nil {
+	value, err := storage.Get(key)
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI32(0)}, fmt.Errorf("getting value from storage: %w", err)
+	} else if value != nil {
 		return []wasmer.Value{wasmer.NewI32(int32(1))}, nil
 	}
 
@@ -2039,7 +2456,10 @@ func ext_storage_get_version_1(env interface{}, args []wasmer.Value) ([]wasmer.V
 	key := asMemorySlice(instanceContext, keySpan)
 	logger.Debugf("key: 0x%x", key)
 
-	value := storage.Get(key)
+	value, err := storage.Get(key)
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI64(0)}, fmt.Errorf("getting value from storage: %w", err)
+	}
 	logger.Debugf("value: 0x%x", value)
 
 	valueSpan, err := toWasmMemoryOptional(instanceContext, value)
@@ -2061,7 +2481,10 @@ func ext_storage_next_key_version_1(env interface{}, args []wasmer.Value) ([]was
 
 	key := asMemorySlice(instanceContext, keySpan)
 
-	next := storage.NextKey(key)
+	next, err := storage.NextKey(key)
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI64(0)}, fmt.Errorf("getting next key from storage: %w", err)
+	}
 	logger.Debugf(
 		"key: 0x%x; next key 0x%x",
 		key, next)
@@ -2087,7 +2510,10 @@ func ext_storage_read_version_1(env interface{}, args []wasmer.Value) ([]wasmer.
 	memory := instanceContext.Memory.Data()
 
 	key := asMemorySlice(instanceContext, keySpan)
-	value := storage.Get(key)
+	value, err := storage.Get(key)
+	if err != nil {
+		return []wasmer.Value{wasmer.NewI64(0)}, fmt.Errorf("getting value from storage: %w", err)
+	}
 	logger.Debugf(
 		"key 0x%x has value 0x%x",
 		key, value)
@@ -18,6 +18,7 @@
 
 import (
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/wasmerio/wasmer-go/wasmer"
 )
 
@@ -33,501 +34,610 @@ func importsNodeRuntime(store *wasmer.Store, memory *wasmer.Memory, ctx *runtime
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_logging_log_version_1)
+		), ctx, traced("ext_logging_log_version_1", ext_logging_log_version_1))
 
 	importsMap["ext_logging_max_level_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_logging_max_level_version_1)
+		), ctx, traced("ext_logging_max_level_version_1", ext_logging_max_level_version_1))
 
 	importsMap["ext_transaction_index_index_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(),
-		), ctx, ext_transaction_index_index_version_1)
+		), ctx, traced("ext_transaction_index_index_version_1", ext_transaction_index_index_version_1))
 
 	importsMap["ext_transaction_index_renew_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(),
-		), ctx, ext_transaction_index_renew_version_1)
+		), ctx, traced("ext_transaction_index_renew_version_1", ext_transaction_index_renew_version_1))
 
 	importsMap["ext_sandbox_instance_teardown_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(),
-		), ctx, ext_sandbox_instance_teardown_version_1)
+		), ctx, traced("ext_sandbox_instance_teardown_version_1", ext_sandbox_instance_teardown_version_1))
 
 	importsMap["ext_sandbox_instantiate_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_sandbox_instantiate_version_1)
+		), ctx, traced("ext_sandbox_instantiate_version_1", ext_sandbox_instantiate_version_1))
 
 	importsMap["ext_sandbox_invoke_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64, wasmer.I32, wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_sandbox_invoke_version_1)
+		), ctx, traced("ext_sandbox_invoke_version_1", ext_sandbox_invoke_version_1))
 
 	importsMap["ext_sandbox_memory_get_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_sandbox_memory_get_version_1)
+		), ctx, traced("ext_sandbox_memory_get_version_1", ext_sandbox_memory_get_version_1))
 
 	importsMap["ext_sandbox_memory_new_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_sandbox_memory_new_version_1)
+		), ctx, traced("ext_sandbox_memory_new_version_1", ext_sandbox_memory_new_version_1))
 
 	importsMap["ext_sandbox_memory_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_sandbox_memory_set_version_1)
+		), ctx, traced("ext_sandbox_memory_set_version_1", ext_sandbox_memory_set_version_1))
 
 	importsMap["ext_sandbox_memory_teardown_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(),
-		), ctx, ext_sandbox_memory_teardown_version_1)
+		), ctx, traced("ext_sandbox_memory_teardown_version_1", ext_sandbox_memory_teardown_version_1))
 
 	importsMap["ext_crypto_ed25519_generate_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_ed25519_generate_version_1)
+		), ctx, traced("ext_crypto_ed25519_generate_version_1", ext_crypto_ed25519_generate_version_1))
 
 	importsMap["ext_crypto_ed25519_public_keys_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_ed25519_public_keys_version_1)
+		), ctx, traced("ext_crypto_ed25519_public_keys_version_1", ext_crypto_ed25519_public_keys_version_1))
 
 	importsMap["ext_crypto_ed25519_sign_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_ed25519_sign_version_1)
+		), ctx, traced("ext_crypto_ed25519_sign_version_1", ext_crypto_ed25519_sign_version_1))
 
 	importsMap["ext_crypto_ed25519_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_ed25519_verify_version_1)
+		), ctx, traced("ext_crypto_ed25519_verify_version_1", ext_crypto_ed25519_verify_version_1))
+
+	importsMap["ext_crypto_ed25519_batch_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_ed25519_batch_verify_version_1", ext_crypto_ed25519_batch_verify_version_1))
 
 	importsMap["ext_crypto_secp256k1_ecdsa_recover_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_secp256k1_ecdsa_recover_version_1)
+		), ctx, traced("ext_crypto_secp256k1_ecdsa_recover_version_1", ext_crypto_secp256k1_ecdsa_recover_version_1))
 
 	importsMap["ext_crypto_secp256k1_ecdsa_recover_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_secp256k1_ecdsa_recover_version_2)
+		), ctx, traced("ext_crypto_secp256k1_ecdsa_recover_version_2", ext_crypto_secp256k1_ecdsa_recover_version_2))
 
 	importsMap["ext_crypto_ecdsa_verify_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_ecdsa_verify_version_2)
+		), ctx, traced("ext_crypto_ecdsa_verify_version_2", ext_crypto_ecdsa_verify_version_2))
+
+	importsMap["ext_crypto_ecdsa_generate_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I64),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_ecdsa_generate_version_1", ext_crypto_ecdsa_generate_version_1))
+
+	importsMap["ext_crypto_ecdsa_public_keys_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I64),
+		), ctx, traced("ext_crypto_ecdsa_public_keys_version_1", ext_crypto_ecdsa_public_keys_version_1))
+
+	importsMap["ext_crypto_ecdsa_sign_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I64),
+			wasmer.NewValueTypes(wasmer.I64),
+		), ctx, traced("ext_crypto_ecdsa_sign_version_1", ext_crypto_ecdsa_sign_version_1))
+
+	importsMap["ext_crypto_ecdsa_sign_prehashed_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I64),
+		), ctx, traced("ext_crypto_ecdsa_sign_prehashed_version_1", ext_crypto_ecdsa_sign_prehashed_version_1))
+
+	importsMap["ext_crypto_ecdsa_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_ecdsa_verify_version_1", ext_crypto_ecdsa_verify_version_1))
+
+	importsMap["ext_crypto_ecdsa_verify_prehashed_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_ecdsa_verify_prehashed_version_1", ext_crypto_ecdsa_verify_prehashed_version_1))
+
+	importsMap["ext_crypto_ecdsa_batch_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_ecdsa_batch_verify_version_1", ext_crypto_ecdsa_batch_verify_version_1))
 
 	importsMap["ext_crypto_secp256k1_ecdsa_recover_compressed_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_secp256k1_ecdsa_recover_compressed_version_1)
+		), ctx, traced("ext_crypto_secp256k1_ecdsa_recover_compressed_version_1",
+			ext_crypto_secp256k1_ecdsa_recover_compressed_version_1))
 
 	importsMap["ext_crypto_secp256k1_ecdsa_recover_compressed_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_secp256k1_ecdsa_recover_compressed_version_2)
+		), ctx, traced("ext_crypto_secp256k1_ecdsa_recover_compressed_version_2",
+			ext_crypto_secp256k1_ecdsa_recover_compressed_version_2))
 
 	importsMap["ext_crypto_sr25519_generate_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_sr25519_generate_version_1)
+		), ctx, traced("ext_crypto_sr25519_generate_version_1", ext_crypto_sr25519_generate_version_1))
 
 	importsMap["ext_crypto_sr25519_public_keys_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_sr25519_public_keys_version_1)
+		), ctx, traced("ext_crypto_sr25519_public_keys_version_1", ext_crypto_sr25519_public_keys_version_1))
 
 	importsMap["ext_crypto_sr25519_sign_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_crypto_sr25519_sign_version_1)
+		), ctx, traced("ext_crypto_sr25519_sign_version_1", ext_crypto_sr25519_sign_version_1))
 
 	importsMap["ext_crypto_sr25519_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_sr25519_verify_version_1)
+		), ctx, traced("ext_crypto_sr25519_verify_version_1", ext_crypto_sr25519_verify_version_1))
 
 	importsMap["ext_crypto_sr25519_verify_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_sr25519_verify_version_2)
+		), ctx, traced("ext_crypto_sr25519_verify_version_2", ext_crypto_sr25519_verify_version_2))
+
+	importsMap["ext_crypto_sr25519_batch_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I32),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_crypto_sr25519_batch_verify_version_1", ext_crypto_sr25519_batch_verify_version_1))
 
 	importsMap["ext_crypto_start_batch_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(),
-		), ctx, ext_crypto_start_batch_verify_version_1)
+		), ctx, traced("ext_crypto_start_batch_verify_version_1", ext_crypto_start_batch_verify_version_1))
 
 	importsMap["ext_crypto_finish_batch_verify_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_crypto_finish_batch_verify_version_1)
+		), ctx, traced("ext_crypto_finish_batch_verify_version_1", ext_crypto_finish_batch_verify_version_1))
 
 	importsMap["ext_trie_blake2_256_root_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_trie_blake2_256_root_version_1)
+		), ctx, traced("ext_trie_blake2_256_root_version_1", ext_trie_blake2_256_root_version_1))
 
 	importsMap["ext_trie_blake2_256_ordered_root_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_trie_blake2_256_ordered_root_version_1)
+		), ctx, traced("ext_trie_blake2_256_ordered_root_version_1", ext_trie_blake2_256_ordered_root_version_1))
 
 	importsMap["ext_trie_blake2_256_ordered_root_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_trie_blake2_256_ordered_root_version_2)
+		), ctx, traced("ext_trie_blake2_256_ordered_root_version_2", ext_trie_blake2_256_ordered_root_version_2))
 
 	importsMap["ext_trie_blake2_256_verify_proof_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_trie_blake2_256_verify_proof_version_1)
+		), ctx, traced("ext_trie_blake2_256_verify_proof_version_1", ext_trie_blake2_256_verify_proof_version_1))
 
 	importsMap["ext_misc_print_hex_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_misc_print_hex_version_1)
+		), ctx, traced("ext_misc_print_hex_version_1", ext_misc_print_hex_version_1))
 
 	importsMap["ext_misc_print_num_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_misc_print_num_version_1)
+		), ctx, traced("ext_misc_print_num_version_1", ext_misc_print_num_version_1))
 
 	importsMap["ext_misc_print_utf8_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_misc_print_utf8_version_1)
+		), ctx, traced("ext_misc_print_utf8_version_1", ext_misc_print_utf8_version_1))
 
 	importsMap["ext_misc_runtime_version_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_misc_runtime_version_version_1)
+		), ctx, traced("ext_misc_runtime_version_version_1", ext_misc_runtime_version_version_1))
 
 	importsMap["ext_default_child_storage_read_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_default_child_storage_read_version_1)
+		), ctx, traced("ext_default_child_storage_read_version_1", ext_default_child_storage_read_version_1))
 
 	importsMap["ext_default_child_storage_clear_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_default_child_storage_clear_version_1)
+		), ctx, traced("ext_default_child_storage_clear_version_1", ext_default_child_storage_clear_version_1))
 
 	importsMap["ext_default_child_storage_clear_prefix_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_default_child_storage_clear_prefix_version_1)
+		), ctx, traced("ext_default_child_storage_clear_prefix_version_1",
+			ext_default_child_storage_clear_prefix_version_1))
 
 	importsMap["ext_default_child_storage_exists_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_default_child_storage_exists_version_1)
+		), ctx, traced("ext_default_child_storage_exists_version_1", ext_default_child_storage_exists_version_1))
 
 	importsMap["ext_default_child_storage_get_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_default_child_storage_get_version_1)
+		), ctx, traced("ext_default_child_storage_get_version_1", ext_default_child_storage_get_version_1))
 
 	importsMap["ext_default_child_storage_next_key_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_default_child_storage_next_key_version_1)
+		), ctx, traced("ext_default_child_storage_next_key_version_1", ext_default_child_storage_next_key_version_1))
 
 	importsMap["ext_default_child_storage_root_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_default_child_storage_root_version_1)
+		), ctx, traced("ext_default_child_storage_root_version_1", ext_default_child_storage_root_version_1))
 
 	importsMap["ext_default_child_storage_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_default_child_storage_set_version_1)
+		), ctx, traced("ext_default_child_storage_set_version_1", ext_default_child_storage_set_version_1))
 
 	importsMap["ext_default_child_storage_storage_kill_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_default_child_storage_storage_kill_version_1)
+		), ctx, traced("ext_default_child_storage_storage_kill_version_1",
+			ext_default_child_storage_storage_kill_version_1))
 
 	importsMap["ext_default_child_storage_storage_kill_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_default_child_storage_storage_kill_version_2)
+		), ctx, traced("ext_default_child_storage_storage_kill_version_2",
+			ext_default_child_storage_storage_kill_version_2))
 
 	importsMap["ext_default_child_storage_storage_kill_version_3"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_default_child_storage_storage_kill_version_3)
+		), ctx, traced("ext_default_child_storage_storage_kill_version_3",
+			ext_default_child_storage_storage_kill_version_3))
 
 	importsMap["ext_allocator_free_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(),
-		), ctx, ext_allocator_free_version_1)
+		), ctx, traced("ext_allocator_free_version_1", ext_allocator_free_version_1))
 
 	importsMap["ext_allocator_malloc_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_allocator_malloc_version_1)
+		), ctx, traced("ext_allocator_malloc_version_1", ext_allocator_malloc_version_1))
 
 	importsMap["ext_hashing_blake2_128_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_blake2_128_version_1)
+		), ctx, traced("ext_hashing_blake2_128_version_1", ext_hashing_blake2_128_version_1))
 
 	importsMap["ext_hashing_blake2_256_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_blake2_256_version_1)
+		), ctx, traced("ext_hashing_blake2_256_version_1", ext_hashing_blake2_256_version_1))
 
 	importsMap["ext_hashing_keccak_256_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_keccak_256_version_1)
+		), ctx, traced("ext_hashing_keccak_256_version_1", ext_hashing_keccak_256_version_1))
+
+	importsMap["ext_hashing_keccak_512_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I64),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, traced("ext_hashing_keccak_512_version_1", ext_hashing_keccak_512_version_1))
 
 	importsMap["ext_hashing_sha2_256_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_sha2_256_version_1)
+		), ctx, traced("ext_hashing_sha2_256_version_1", ext_hashing_sha2_256_version_1))
 
 	importsMap["ext_hashing_twox_256_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_twox_256_version_1)
+		), ctx, traced("ext_hashing_twox_256_version_1", ext_hashing_twox_256_version_1))
 
 	importsMap["ext_hashing_twox_128_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_twox_128_version_1)
+		), ctx, traced("ext_hashing_twox_128_version_1", ext_hashing_twox_128_version_1))
 
 	importsMap["ext_hashing_twox_64_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_hashing_twox_64_version_1)
+		), ctx, traced("ext_hashing_twox_64_version_1", ext_hashing_twox_64_version_1))
 
 	importsMap["ext_offchain_index_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_offchain_index_set_version_1)
+		), ctx, traced("ext_offchain_index_set_version_1", ext_offchain_index_set_version_1))
 
 	importsMap["ext_offchain_local_storage_clear_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_offchain_local_storage_clear_version_1)
+		), ctx, traced("ext_offchain_local_storage_clear_version_1", ext_offchain_local_storage_clear_version_1))
 
 	importsMap["ext_offchain_is_validator_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_offchain_is_validator_version_1)
+		), ctx, traced("ext_offchain_is_validator_version_1", ext_offchain_is_validator_version_1))
 
 	importsMap["ext_offchain_local_storage_compare_and_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_offchain_local_storage_compare_and_set_version_1)
+		), ctx, traced("ext_offchain_local_storage_compare_and_set_version_1",
+			ext_offchain_local_storage_compare_and_set_version_1))
 
 	importsMap["ext_offchain_local_storage_get_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_local_storage_get_version_1)
+		), ctx, traced("ext_offchain_local_storage_get_version_1", ext_offchain_local_storage_get_version_1))
 
 	importsMap["ext_offchain_local_storage_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_offchain_local_storage_set_version_1)
+		), ctx, traced("ext_offchain_local_storage_set_version_1", ext_offchain_local_storage_set_version_1))
 
 	importsMap["ext_offchain_network_state_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_network_state_version_1)
+		), ctx, traced("ext_offchain_network_state_version_1", ext_offchain_network_state_version_1))
 
 	importsMap["ext_offchain_random_seed_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_offchain_random_seed_version_1)
+		), ctx, traced("ext_offchain_random_seed_version_1", ext_offchain_random_seed_version_1))
 
 	importsMap["ext_offchain_submit_transaction_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_submit_transaction_version_1)
+		), ctx, traced("ext_offchain_submit_transaction_version_1", ext_offchain_submit_transaction_version_1))
 
 	importsMap["ext_offchain_timestamp_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_timestamp_version_1)
+		), ctx, traced("ext_offchain_timestamp_version_1", ext_offchain_timestamp_version_1))
 
 	importsMap["ext_offchain_sleep_until_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_offchain_sleep_until_version_1)
+		), ctx, traced("ext_offchain_sleep_until_version_1", ext_offchain_sleep_until_version_1))
 
 	importsMap["ext_offchain_http_request_start_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_http_request_start_version_1)
+		), ctx, traced("ext_offchain_http_request_start_version_1", ext_offchain_http_request_start_version_1))
 
 	importsMap["ext_offchain_http_request_add_header_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32, wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_offchain_http_request_add_header_version_1)
+		), ctx, traced("ext_offchain_http_request_add_header_version_1",
+			ext_offchain_http_request_add_header_version_1))
 
 	importsMap["ext_storage_append_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_append_version_1)
+		), ctx, traced("ext_storage_append_version_1", ext_storage_append_version_1))
 
 	importsMap["ext_storage_changes_root_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_changes_root_version_1)
+		), ctx, traced("ext_storage_changes_root_version_1", ext_storage_changes_root_version_1))
 
 	importsMap["ext_storage_clear_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_clear_version_1)
+		), ctx, traced("ext_storage_clear_version_1", ext_storage_clear_version_1))
 
 	importsMap["ext_storage_clear_prefix_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_clear_prefix_version_1)
+		), ctx, traced("ext_storage_clear_prefix_version_1", ext_storage_clear_prefix_version_1))
 
 	importsMap["ext_storage_clear_prefix_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_clear_prefix_version_2)
+		), ctx, traced("ext_storage_clear_prefix_version_2", ext_storage_clear_prefix_version_2))
 
 	importsMap["ext_storage_exists_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I32),
-		), ctx, ext_storage_exists_version_1)
+		), ctx, traced("ext_storage_exists_version_1", ext_storage_exists_version_1))
 
 	importsMap["ext_storage_get_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_get_version_1)
+		), ctx, traced("ext_storage_get_version_1", ext_storage_get_version_1))
 
 	importsMap["ext_storage_next_key_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_next_key_version_1)
+		), ctx, traced("ext_storage_next_key_version_1", ext_storage_next_key_version_1))
 
 	importsMap["ext_storage_read_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64, wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_read_version_1)
+		), ctx, traced("ext_storage_read_version_1", ext_storage_read_version_1))
 
 	importsMap["ext_storage_root_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_root_version_1)
+		), ctx, traced("ext_storage_root_version_1", ext_storage_root_version_1))
 
 	importsMap["ext_storage_root_version_2"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I32),
 			wasmer.NewValueTypes(wasmer.I64),
-		), ctx, ext_storage_root_version_2)
+		), ctx, traced("ext_storage_root_version_2", ext_storage_root_version_2))
 
 	importsMap["ext_storage_set_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(wasmer.I64, wasmer.I64),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_set_version_1)
+		), ctx, traced("ext_storage_set_version_1", ext_storage_set_version_1))
 
 	importsMap["ext_storage_start_transaction_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_start_transaction_version_1)
+		), ctx, traced("ext_storage_start_transaction_version_1", ext_storage_start_transaction_version_1))
 
 	importsMap["ext_storage_rollback_transaction_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_rollback_transaction_version_1)
+		), ctx, traced("ext_storage_rollback_transaction_version_1", ext_storage_rollback_transaction_version_1))
 
 	importsMap["ext_storage_commit_transaction_version_1"] = wasmer.NewFunctionWithEnvironment(store,
 		wasmer.NewFunctionType(
 			wasmer.NewValueTypes(),
 			wasmer.NewValueTypes(),
-		), ctx, ext_storage_commit_transaction_version_1)
+		), ctx, traced("ext_storage_commit_transaction_version_1", ext_storage_commit_transaction_version_1))
+
+	// the tracing host functions are not traced themselves.
+	importsMap["ext_wasm_tracing_enabled_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I64),
+			wasmer.NewValueTypes(wasmer.I32),
+		), ctx, ext_wasm_tracing_enabled_version_1)
+
+	importsMap["ext_wasm_tracing_enter_span_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I64),
+			wasmer.NewValueTypes(wasmer.I64),
+		), ctx, ext_wasm_tracing_enter_span_version_1)
+
+	importsMap["ext_wasm_tracing_exit_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I64),
+			wasmer.NewValueTypes(),
+		), ctx, ext_wasm_tracing_exit_version_1)
+
+	importsMap["ext_wasm_tracing_event_version_1"] = wasmer.NewFunctionWithEnvironment(store,
+		wasmer.NewFunctionType(
+			wasmer.NewValueTypes(wasmer.I64),
+			wasmer.NewValueTypes(),
+		), ctx, ext_wasm_tracing_event_version_1)
 
 	imports := wasmer.NewImportObject()
 	imports.Register("env", importsMap)
 	return imports
 }
+
+// traced wraps the given host function to record its calls
+// in a span of the runtime context tracer, if any.
+func traced(name string, hostFunction func(env interface{}, args []wasmer.Value) ([]wasmer.Value, error)) func(
+	env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+	return func(env interface{}, args []wasmer.Value) ([]wasmer.Value, error) {
+		tracer := env.(*runtime.Context).Tracer
+		if tracer == nil {
+			return hostFunction(env, args)
+		}
+
+		tracer.EnterSpan(name, tracing.HostTarget, false)
+		defer tracer.ExitSpan()
+		return hostFunction(env, args)
+	}
+}
@@ -7,6 +7,7 @@
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"net/http"
 	"sort"
 	"testing"
@@ -26,6 +27,7 @@
 	"github.com/ChainSafe/gossamer/lib/trie/proof"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wasmerio/wasmer-go/wasmer"
@@ -35,6 +37,39 @@
 var testKey = []byte("key")
 var testValue = []byte("value")
 
+// newHostTestContext returns a runtime context using the memory of a
+// minimal wasm instance, to call host functions without a runtime.
+func newHostTestContext(t *testing.T) *runtime.Context {
+	t.Helper()
+
+	instance, err := createInstance(t)
+	require.NoError(t, err)
+	t.Cleanup(instance.Close)
+
+	wasmerMemory, err := instance.Exports.GetMemory("memory")
+	require.NoError(t, err)
+	memory := Memory{memory: wasmerMemory}
+
+	return &runtime.Context{
+		Allocator:   runtime.NewAllocator(memory, 0),
+		Keystore:    keystore.NewGlobalKeystore(),
+		SigVerifier: crypto.NewSignatureVerifier(logger),
+		Memory:      memory,
+	}
+}
+
+// writeHostTestMemory writes the data given to the memory of the context
+// given, and returns its 32 bit pointer and its 64 bit pointer size.
+func writeHostTestMemory(t *testing.T, context *runtime.Context, data []byte) (
+	pointer, pointerSize wasmer.Value) {
+	t.Helper()
+
+	pointerSizeValue, err := toWasmMemory(context, data)
+	require.NoError(t, err)
+	ptr, _ := splitPointerSize(pointerSizeValue)
+	return wasmer.NewI32(int32(ptr)), wasmer.NewI64(pointerSizeValue)
+}
+
 func Test_ext_offchain_timestamp_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
 	runtimeFunc, err := inst.vm.Exports.GetFunction("rtm_ext_offchain_timestamp_version_1")
@@ -124,6 +159,45 @@ func Test_ext_hashing_keccak_256_version_1(t *testing.T) {
 	require.Equal(t, expected[:], hash)
 }
 
+func Test_ext_hashing_keccak_512_version_1(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data []byte
+		hash []byte
+	}{
+		"empty": {
+			hash: common.MustHexToBytes("0x0eab42de4c3ceb9235fc91acffe746b29c29a8c366b7c60e4e67c466f36a4304" +
+				"c00fa9caf9d87976ba469bcbe06713b435f091ef2769fb160cdab33d3670680e"),
+		},
+		"data": {
+			data: []byte("helloworld"),
+			hash: func() []byte {
+				hash, err := common.Keccak512([]byte("helloworld"))
+				require.NoError(t, err)
+				return hash[:]
+			}(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			_, data := writeHostTestMemory(t, context, testCase.data)
+
+			ret, err := ext_hashing_keccak_512_version_1(context, []wasmer.Value{data})
+			require.NoError(t, err)
+
+			pointer := ret[0].I32()
+			require.NotZero(t, pointer)
+			assert.Equal(t, testCase.hash, context.Memory.Data()[pointer:pointer+64])
+		})
+	}
+}
+
 func Test_ext_hashing_twox_128_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
 
@@ -193,7 +267,8 @@ func Test_ext_storage_clear_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_clear_version_1", enc)
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Nil(t, val)
 }
 
@@ -374,10 +449,12 @@ func Test_ext_storage_clear_prefix_version_1_hostAPI(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_clear_prefix_version_1", enc)
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Nil(t, val)
 
-	val = inst.ctx.Storage.Get(testkey2)
+	val, err = inst.ctx.Storage.Get(testkey2)
+	require.NoError(t, err)
 	require.NotNil(t, val)
 }
 
@@ -396,10 +473,12 @@ func Test_ext_storage_clear_prefix_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_clear_prefix_version_1", enc)
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Nil(t, val)
 
-	val = inst.ctx.Storage.Get(testkey2)
+	val, err = inst.ctx.Storage.Get(testkey2)
+	require.NoError(t, err)
 	require.NotNil(t, val)
 }
 
@@ -456,10 +535,12 @@ func Test_ext_storage_clear_prefix_version_2(t *testing.T) {
 	expectedAllDeleted = 1
 	require.Equal(t, expectedAllDeleted, decVal[0])
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.NotNil(t, val)
 
-	val = inst.ctx.Storage.Get(testkey5)
+	val, err = inst.ctx.Storage.Get(testkey5)
+	require.NoError(t, err)
 	require.NotNil(t, val)
 	require.Equal(t, testValue5, val)
 
@@ -476,10 +557,12 @@ func Test_ext_storage_clear_prefix_version_2(t *testing.T) {
 	expectedAllDeleted = 0
 	require.Equal(t, expectedAllDeleted, decVal[0])
 
-	val = inst.ctx.Storage.Get(testkey)
+	val, err = inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Nil(t, val)
 
-	val = inst.ctx.Storage.Get(testkey5)
+	val, err = inst.ctx.Storage.Get(testkey5)
+	require.NoError(t, err)
 	require.NotNil(t, val)
 	require.Equal(t, testValue5, val)
 }
@@ -685,7 +768,8 @@ func Test_ext_storage_set_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_set_version_1", append(encKey, encValue...))
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Equal(t, testvalue, val)
 }
 
@@ -952,6 +1036,567 @@ func Test_ext_crypto_ecdsa_verify_version_2_Table(t *testing.T) {
 	}
 }
 
+// testBatchVerifyHostFunction calls the batch verify host function given with the
+// signature, message and public key given, within a batch verification if batch is
+// true, and checks the value returned and the batch verification result.
+func testBatchVerifyHostFunction(t *testing.T, hostFunction func(interface{}, []wasmer.Value) ([]wasmer.Value, error),
+	signature, message, publicKey []byte, batch bool, verified int32, batchValid bool) {
+	t.Helper()
+
+	context := newHostTestContext(t)
+	if batch {
+		context.SigVerifier.Start()
+	}
+
+	signaturePointer, _ := writeHostTestMemory(t, context, signature)
+	_, messagePointerSize := writeHostTestMemory(t, context, message)
+	publicKeyPointer, _ := writeHostTestMemory(t, context, publicKey)
+
+	ret, err := hostFunction(context, []wasmer.Value{signaturePointer, messagePointerSize, publicKeyPointer})
+	require.NoError(t, err)
+	assert.Equal(t, verified, ret[0].I32())
+
+	if batch {
+		assert.Equal(t, batchValid, context.SigVerifier.Finish())
+	}
+}
+
+func Test_ext_crypto_ed25519_batch_verify_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+	invalidSignature := make([]byte, len(signature))
+
+	testCases := map[string]struct {
+		signature  []byte
+		batch      bool
+		verified   int32
+		batchValid bool
+	}{
+		"valid_signature": {
+			signature: signature,
+			verified:  1,
+		},
+		"invalid_signature": {
+			signature: invalidSignature,
+		},
+		"valid_signature_in_batch": {
+			signature:  signature,
+			batch:      true,
+			verified:   1,
+			batchValid: true,
+		},
+		"invalid_signature_in_batch": {
+			signature: invalidSignature,
+			batch:     true,
+			verified:  1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testBatchVerifyHostFunction(t, ext_crypto_ed25519_batch_verify_version_1,
+				testCase.signature, message, keypair.Public().Encode(),
+				testCase.batch, testCase.verified, testCase.batchValid)
+		})
+	}
+}
+
+func Test_ext_crypto_sr25519_batch_verify_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+	invalidSignature := make([]byte, len(signature))
+
+	testCases := map[string]struct {
+		signature  []byte
+		batch      bool
+		verified   int32
+		batchValid bool
+	}{
+		"valid_signature": {
+			signature: signature,
+			verified:  1,
+		},
+		"invalid_signature": {
+			signature: invalidSignature,
+		},
+		"valid_signature_in_batch": {
+			signature:  signature,
+			batch:      true,
+			verified:   1,
+			batchValid: true,
+		},
+		"invalid_signature_in_batch": {
+			signature: invalidSignature,
+			batch:     true,
+			verified:  1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testBatchVerifyHostFunction(t, ext_crypto_sr25519_batch_verify_version_1,
+				testCase.signature, message, keypair.Public().Encode(),
+				testCase.batch, testCase.verified, testCase.batchValid)
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_batch_verify_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	hash := common.MustBlake2bHash(message)
+	signature, err := keypair.Sign(hash[:])
+	require.NoError(t, err)
+	invalidSignature := make([]byte, len(signature))
+
+	testCases := map[string]struct {
+		signature  []byte
+		batch      bool
+		verified   int32
+		batchValid bool
+	}{
+		"valid_signature": {
+			signature: signature,
+			verified:  1,
+		},
+		"invalid_signature": {
+			signature: invalidSignature,
+		},
+		"valid_signature_in_batch": {
+			signature:  signature,
+			batch:      true,
+			verified:   1,
+			batchValid: true,
+		},
+		"invalid_signature_in_batch": {
+			signature: invalidSignature,
+			batch:     true,
+			verified:  1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testBatchVerifyHostFunction(t, ext_crypto_ecdsa_batch_verify_version_1,
+				testCase.signature, message, keypair.Public().Encode(),
+				testCase.batch, testCase.verified, testCase.batchValid)
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_generate_version_1(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := crypto.NewBIP39Mnemonic()
+	require.NoError(t, err)
+	mnemonicKeypair, err := secp256k1.NewKeypairFromMnemonic(mnemonic, "")
+	require.NoError(t, err)
+
+	mnemonicBytes := []byte(mnemonic)
+	encodedSeed, err := scale.Marshal(&mnemonicBytes)
+	require.NoError(t, err)
+	encodedNoSeed, err := scale.Marshal((*[]byte)(nil))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		keyTypeID   []byte
+		seed        []byte
+		generated   bool
+		publicKey   []byte
+		keystoreLen int
+	}{
+		"seed": {
+			keyTypeID:   []byte(keystore.BeefName),
+			seed:        encodedSeed,
+			generated:   true,
+			publicKey:   mnemonicKeypair.Public().Encode(),
+			keystoreLen: 1,
+		},
+		"no_seed": {
+			keyTypeID:   []byte(keystore.BeefName),
+			seed:        encodedNoSeed,
+			generated:   true,
+			keystoreLen: 1,
+		},
+		"invalid_seed_encoding": {
+			keyTypeID: []byte(keystore.BeefName),
+			seed:      []byte{2},
+		},
+		"unknown_key_type": {
+			keyTypeID: []byte("unkn"),
+			seed:      encodedSeed,
+		},
+		"keystore_of_other_key_type": {
+			keyTypeID: []byte(keystore.GranName),
+			seed:      encodedSeed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			keyTypeID, _ := writeHostTestMemory(t, context, testCase.keyTypeID)
+			_, seed := writeHostTestMemory(t, context, testCase.seed)
+
+			ret, err := ext_crypto_ecdsa_generate_version_1(context, []wasmer.Value{keyTypeID, seed})
+			require.NoError(t, err)
+
+			pointer := ret[0].I32()
+			if !testCase.generated {
+				assert.Zero(t, pointer)
+				return
+			}
+
+			publicKey := context.Memory.Data()[pointer : pointer+33]
+			if testCase.publicKey != nil {
+				assert.Equal(t, testCase.publicKey, publicKey)
+			}
+
+			ks, err := context.Keystore.GetKeystore(testCase.keyTypeID)
+			require.NoError(t, err)
+			require.Equal(t, testCase.keystoreLen, ks.Size())
+			assert.Equal(t, publicKey, ks.PublicKeys()[0].Encode())
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_public_keys_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypairs := make([]*secp256k1.Keypair, 2)
+	for i := range keypairs {
+		keypair, err := secp256k1.GenerateKeypair()
+		require.NoError(t, err)
+		keypairs[i] = keypair
+	}
+
+	testCases := map[string]struct {
+		keyTypeID  []byte
+		keypairs   []*secp256k1.Keypair
+		publicKeys [][33]byte
+	}{
+		"no_key": {
+			keyTypeID:  []byte(keystore.BeefName),
+			publicKeys: [][33]byte{},
+		},
+		"keys": {
+			keyTypeID:  []byte(keystore.BeefName),
+			keypairs:   keypairs,
+			publicKeys: [][33]byte{},
+		},
+		"unknown_key_type": {
+			keyTypeID:  []byte("unkn"),
+			publicKeys: [][33]byte{},
+		},
+		"keystore_of_other_key_type": {
+			keyTypeID:  []byte(keystore.GranName),
+			publicKeys: [][33]byte{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			expectedPublicKeys := testCase.publicKeys
+			if len(testCase.keypairs) > 0 {
+				ks, err := context.Keystore.GetKeystore(testCase.keyTypeID)
+				require.NoError(t, err)
+				for _, keypair := range testCase.keypairs {
+					err = ks.Insert(keypair)
+					require.NoError(t, err)
+
+					var publicKey [33]byte
+					copy(publicKey[:], keypair.Public().Encode())
+					expectedPublicKeys = append(expectedPublicKeys, publicKey)
+				}
+			}
+			keyTypeID, _ := writeHostTestMemory(t, context, testCase.keyTypeID)
+
+			ret, err := ext_crypto_ecdsa_public_keys_version_1(context, []wasmer.Value{keyTypeID})
+			require.NoError(t, err)
+
+			var publicKeys [][33]byte
+			err = scale.Unmarshal(asMemorySlice(context, ret[0].I64()), &publicKeys)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, expectedPublicKeys, publicKeys)
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_sign_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	otherKeypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	hash := common.MustBlake2bHash(message)
+
+	testCases := map[string]struct {
+		keyTypeID []byte
+		publicKey []byte
+		signed    bool
+	}{
+		"signed": {
+			keyTypeID: []byte(keystore.BeefName),
+			publicKey: keypair.Public().Encode(),
+			signed:    true,
+		},
+		"key_not_found": {
+			keyTypeID: []byte(keystore.BeefName),
+			publicKey: otherKeypair.Public().Encode(),
+		},
+		"unknown_key_type": {
+			keyTypeID: []byte("unkn"),
+			publicKey: keypair.Public().Encode(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			err := context.Keystore.Beef.Insert(keypair)
+			require.NoError(t, err)
+
+			keyTypeID, _ := writeHostTestMemory(t, context, testCase.keyTypeID)
+			publicKey, _ := writeHostTestMemory(t, context, testCase.publicKey)
+			_, messagePointerSize := writeHostTestMemory(t, context, message)
+
+			ret, err := ext_crypto_ecdsa_sign_version_1(context,
+				[]wasmer.Value{keyTypeID, publicKey, messagePointerSize})
+			require.NoError(t, err)
+
+			var signature *[65]byte
+			err = scale.Unmarshal(asMemorySlice(context, ret[0].I64()), &signature)
+			require.NoError(t, err)
+			if !testCase.signed {
+				assert.Nil(t, signature)
+				return
+			}
+
+			require.NotNil(t, signature)
+			err = secp256k1.VerifyPrehashedSignature(testCase.publicKey, signature[:], hash[:])
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_sign_prehashed_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	otherKeypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	hash := common.MustBlake2bHash([]byte("message"))
+
+	testCases := map[string]struct {
+		keyTypeID []byte
+		publicKey []byte
+		signed    bool
+	}{
+		"signed": {
+			keyTypeID: []byte(keystore.BeefName),
+			publicKey: keypair.Public().Encode(),
+			signed:    true,
+		},
+		"key_not_found": {
+			keyTypeID: []byte(keystore.BeefName),
+			publicKey: otherKeypair.Public().Encode(),
+		},
+		"unknown_key_type": {
+			keyTypeID: []byte("unkn"),
+			publicKey: keypair.Public().Encode(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			err := context.Keystore.Beef.Insert(keypair)
+			require.NoError(t, err)
+
+			keyTypeID, _ := writeHostTestMemory(t, context, testCase.keyTypeID)
+			publicKey, _ := writeHostTestMemory(t, context, testCase.publicKey)
+			hashPointer, _ := writeHostTestMemory(t, context, hash[:])
+
+			ret, err := ext_crypto_ecdsa_sign_prehashed_version_1(context,
+				[]wasmer.Value{keyTypeID, publicKey, hashPointer})
+			require.NoError(t, err)
+
+			var signature *[65]byte
+			err = scale.Unmarshal(asMemorySlice(context, ret[0].I64()), &signature)
+			require.NoError(t, err)
+			if !testCase.signed {
+				assert.Nil(t, signature)
+				return
+			}
+
+			require.NotNil(t, signature)
+			err = secp256k1.VerifyPrehashedSignature(testCase.publicKey, signature[:], hash[:])
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_verify_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	otherKeypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	hash := common.MustBlake2bHash(message)
+	signature, err := keypair.Sign(hash[:])
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		message   []byte
+		publicKey []byte
+		verified  int32
+	}{
+		"valid_signature": {
+			message:   message,
+			publicKey: keypair.Public().Encode(),
+			verified:  1,
+		},
+		"other_message": {
+			message:   []byte("other message"),
+			publicKey: keypair.Public().Encode(),
+		},
+		"other_public_key": {
+			message:   message,
+			publicKey: otherKeypair.Public().Encode(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			signaturePointer, _ := writeHostTestMemory(t, context, signature)
+			_, messagePointerSize := writeHostTestMemory(t, context, testCase.message)
+			publicKey, _ := writeHostTestMemory(t, context, testCase.publicKey)
+
+			ret, err := ext_crypto_ecdsa_verify_version_1(context,
+				[]wasmer.Value{signaturePointer, messagePointerSize, publicKey})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.verified, ret[0].I32())
+		})
+	}
+}
+
+func Test_ext_crypto_ecdsa_verify_prehashed_version_1(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	otherKeypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	hash := common.MustBlake2bHash([]byte("message"))
+	signature, err := keypair.Sign(hash[:])
+	require.NoError(t, err)
+
+	recoveryIDWithOffset := make([]byte, len(signature))
+	copy(recoveryIDWithOffset, signature)
+	recoveryIDWithOffset[64] += 27
+
+	// the signature with the s value negated and the recovery id parity flipped
+	// recovers the same public key, but its s value is not normalised.
+	highS := make([]byte, len(signature))
+	copy(highS, signature)
+	curveOrder := ethcrypto.S256().Params().N
+	s := new(big.Int).SetBytes(highS[32:64])
+	s.Sub(curveOrder, s).FillBytes(highS[32:64])
+	highS[64] ^= 1
+
+	testCases := map[string]struct {
+		signature []byte
+		publicKey []byte
+		verified  int32
+	}{
+		"valid_signature": {
+			signature: signature,
+			publicKey: keypair.Public().Encode(),
+			verified:  1,
+		},
+		"other_public_key": {
+			signature: signature,
+			publicKey: otherKeypair.Public().Encode(),
+		},
+		"recovery_id_with_offset": {
+			signature: recoveryIDWithOffset,
+			publicKey: keypair.Public().Encode(),
+		},
+		"high_s_value": {
+			signature: highS,
+			publicKey: keypair.Public().Encode(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			context := newHostTestContext(t)
+			signaturePointer, _ := writeHostTestMemory(t, context, testCase.signature)
+			hashPointer, _ := writeHostTestMemory(t, context, hash[:])
+			publicKey, _ := writeHostTestMemory(t, context, testCase.publicKey)
+
+			ret, err := ext_crypto_ecdsa_verify_prehashed_version_1(context,
+				[]wasmer.Value{signaturePointer, hashPointer, publicKey})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.verified, ret[0].I32())
+		})
+	}
+}
+
 func Test_ext_crypto_sr25519_generate_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
 
@@ -1485,7 +2130,9 @@ func Test_ext_default_child_storage_storage_kill_version_2_limit_all(t *testing.
 
 	child, err = inst.ctx.Storage.GetChild(testChildKey)
 	require.NoError(t, err)
-	require.Empty(t, child.Entries())
+	entries, err := child.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
 }
 
 func Test_ext_default_child_storage_storage_kill_version_2_limit_1(t *testing.T) {
@@ -1518,7 +2165,9 @@ func Test_ext_default_child_storage_storage_kill_version_2_limit_1(t *testing.T)
 
 	child, err = inst.ctx.Storage.GetChild(testChildKey)
 	require.NoError(t, err)
-	require.Equal(t, 1, len(child.Entries()))
+	entries, err := child.Entries()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
 }
 
 func Test_ext_default_child_storage_storage_kill_version_2_limit_none(t *testing.T) {
@@ -1623,7 +2272,8 @@ func Test_ext_storage_append_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_append_version_1", append(encKey, doubleEncVal...))
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Equal(t, encArr, val)
 
 	encValueAppend, err := scale.Marshal(testvalueAppend)
@@ -1634,7 +2284,8 @@ func Test_ext_storage_append_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_append_version_1", append(encKey, doubleEncValueAppend...))
 	require.NoError(t, err)
 
-	ret := inst.ctx.Storage.Get(testkey)
+	ret, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.NotNil(t, ret)
 
 	var res [][]byte
@@ -1672,7 +2323,8 @@ func Test_ext_storage_append_version_1_again(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_append_version_1", append(encKey, doubleEncVal...))
 	require.NoError(t, err)
 
-	val := inst.ctx.Storage.Get(testkey)
+	val, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.Equal(t, encArr, val)
 
 	encValueAppend, err := scale.Marshal(testvalueAppend)
@@ -1683,7 +2335,8 @@ func Test_ext_storage_append_version_1_again(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_storage_append_version_1", append(encKey, doubleEncValueAppend...))
 	require.NoError(t, err)
 
-	ret := inst.ctx.Storage.Get(testkey)
+	ret, err := inst.ctx.Storage.Get(testkey)
+	require.NoError(t, err)
 	require.NotNil(t, ret)
 
 	var res [][]byte
@@ -17,6 +17,7 @@
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/tracing"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/klauspost/compress/zstd"
 	"github.com/wasmerio/wasmer-go/wasmer"
@@ -52,8 +53,10 @@ func NewRuntimeFromGenesis(cfg Config) (instance *Instance, err error) {
 		return nil, errors.New("storage is nil")
 	}
 
-	code := cfg.Storage.LoadCode()
-	if len(code) == 0 {
+	code, err := cfg.Storage.LoadCode()
+	if err != nil {
+		return nil, fmt.Errorf("loading code: %w", err)
+	} else if len(code) == 0 {
 		return nil, fmt.Errorf("cannot find :code in state")
 	}
 
@@ -62,8 +65,10 @@ func NewRuntimeFromGenesis(cfg Config) (instance *Instance, err error) {
 
 // NewInstanceFromTrie returns a new runtime instance with the code provided in the given trie
 func NewInstanceFromTrie(t *trie.Trie, cfg Config) (*Instance, error) {
-	code := t.Get(common.CodeKey)
-	if len(code) == 0 {
+	code, err := t.Get(common.CodeKey)
+	if err != nil {
+		return nil, fmt.Errorf("getting code from trie: %w", err)
+	} else if len(code) == 0 {
 		return nil, fmt.Errorf("cannot find :code in trie")
 	}
 
@@ -154,6 +159,7 @@ func NewInstance(code []byte, cfg Config) (*Instance, error) {
 		Transaction:     cfg.Transaction,
 		SigVerifier:     crypto.NewSignatureVerifier(logger),
 		OffchainHTTPSet: offchain.NewHTTPSet(),
+		Tracer:          cfg.Tracer,
 	}
 
 	imports := importsNodeRuntime(store, memory, runtimeCtx)
@@ -256,6 +262,9 @@ func (in *Instance) Exec(function string, data []byte) (result []byte, err error
 		return nil, ErrInstanceIsStopped
 	}
 
+	in.ctx.Tracer.EnterSpan(function, tracing.RuntimeTarget, false)
+	defer in.ctx.Tracer.ExitSpan()
+
 	dataLength := uint32(len(data))
 	inputPtr, err := in.ctx.Allocator.Allocate(dataLength)
 	if err != nil {
@@ -21,7 +21,7 @@ type Storage interface {
 	DeleteChild(keyToChild []byte) (err error)
 	DeleteChildLimit(keyToChild []byte, limit *[]byte) (uint32, bool, error)
 	ClearChildStorage(keyToChild, key []byte) error
-	NextKey([]byte) []byte
+	NextKey([]byte) ([]byte, error)
 	ClearPrefixInChild(keyToChild, prefix []byte) error
 	GetChildNextKey(keyToChild, key []byte) ([]byte, error)
 	GetChild(keyToChild []byte) (*trie.Trie, error)
@@ -31,7 +31,9 @@ type Storage interface {
 	BeginStorageTransaction()
 	CommitStorageTransaction()
 	RollbackStorageTransaction()
-	LoadCode() []byte
+	LoadCode() ([]byte, error)
+	IndexTransaction(extrinsic, size uint32, hash common.Hash)
+	RenewTransactionIndex(extrinsic uint32, hash common.Hash)
 }
 
 // GetSetter gets and sets key values.
@@ -42,7 +44,7 @@ type GetSetter interface {
 
 // Getter gets a value from a key.
 type Getter interface {
-	Get(key []byte) []byte
+	Get(key []byte) ([]byte, error)
 }
 
 // Putter puts a value for a key.
@@ -0,0 +1,26 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wasmer
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+// targetLoggers maps runtime log targets such as `runtime::system`
+// to child loggers of the runtime logger, so the log level of each
+// target can be set using the log filter.
+var targetLoggers sync.Map
+
+func runtimeTargetLogger(target string) *log.Logger {
+	targetLogger, ok := targetLoggers.Load(target)
+	if ok {
+		return targetLogger.(*log.Logger)
+	}
+
+	targetLogger, _ = targetLoggers.LoadOrStore(target,
+		logger.New(log.AddContext("target", target)))
+	return targetLogger.(*log.Logger)
+}
@@ -38,17 +38,33 @@ func (t *Trie) SetChild(keyToChild []byte, child *Trie) error {
 }
 
 // GetChild returns the child trie at key :child_storage:[keyToChild]
-func (t *Trie) GetChild(keyToChild []byte) (*Trie, error) {
+func (t *Trie) GetChild(keyToChild []byte) (child *Trie, err error) {
 	key := make([]byte, len(ChildStorageKeyPrefix)+len(keyToChild))
 	copy(key, ChildStorageKeyPrefix)
 	copy(key[len(ChildStorageKeyPrefix):], keyToChild)
 
-	childHash := t.Get(key)
-	if childHash == nil {
+	childHash, err := t.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("getting child trie root hash: %w", err)
+	} else if childHash == nil {
 		return nil, fmt.Errorf("%w at key 0x%x%x", ErrChildTrieDoesNotExist, ChildStorageKeyPrefix, keyToChild)
 	}
 
-	return t.childTries[common.BytesToHash(childHash)], nil
+	rootHash := common.BytesToHash(childHash)
+	child, ok := t.childTries[rootHash]
+	if ok || t.db == nil {
+		return child, nil
+	}
+
+	// Lazy trie: load the child trie root from the database.
+	// Note the child trie is only kept in the child tries map
+	// once it gets modified, see PutIntoChild.
+	child, err = NewLazyTrie(t.db, rootHash, t.cache)
+	if err != nil {
+		return nil, fmt.Errorf("loading child trie with root hash %s: %w", rootHash, err)
+	}
+	child.generation = t.generation
+	return child, nil
 }
 
 // PutIntoChild puts a key-value pair into the child trie located in the main trie at key :child_storage:[keyToChild]
@@ -81,7 +97,7 @@ func (t *Trie) PutIntoChild(keyToChild, key, value []byte) error {
 
 // GetFromChild retrieves a key-value pair from the child trie located
 // in the main trie at key :child_storage:[keyToChild]
-func (t *Trie) GetFromChild(keyToChild, key []byte) ([]byte, error) {
+func (t *Trie) GetFromChild(keyToChild, key []byte) (value []byte, err error) {
 	child, err := t.GetChild(keyToChild)
 	if err != nil {
 		return nil, err
@@ -91,8 +107,11 @@ func (t *Trie) GetFromChild(keyToChild, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("%w at key 0x%x%x", ErrChildTrieDoesNotExist, ChildStorageKeyPrefix, keyToChild)
 	}
 
-	val := child.Get(key)
-	return val, nil
+	value, err = child.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("getting from child trie located at key 0x%x: %w", keyToChild, err)
+	}
+	return value, nil
 }
 
 // DeleteChild deletes the child storage trie
@@ -123,5 +142,15 @@ func (t *Trie) ClearFromChild(keyToChild, key []byte) error {
 		return fmt.Errorf("deleting from child trie located at key 0x%x: %w", keyToChild, err)
 	}
 
+	if t.db != nil {
+		// Keep the modified lazily loaded child trie in memory.
+		childKey := concatenateSlices(ChildStorageKeyPrefix, keyToChild)
+		childHash, err := t.Get(childKey)
+		if err != nil {
+			return fmt.Errorf("getting child trie root hash: %w", err)
+		}
+		t.childTries[common.BytesToHash(childHash)] = child
+	}
+
 	return nil
 }
@@ -110,11 +110,19 @@ func (t *Trie) loadNode(db Getter, n *Node) error {
 		}
 	}
 
-	for _, key := range t.GetKeysWithPrefix(ChildStorageKeyPrefix) {
+	childTrieKeys, err := t.GetKeysWithPrefix(ChildStorageKeyPrefix)
+	if err != nil {
+		return fmt.Errorf("getting child trie keys: %w", err)
+	}
+
+	for _, key := range childTrieKeys {
 		childTrie := NewEmptyTrie()
-		value := t.Get(key)
+		value, err := t.Get(key)
+		if err != nil {
+			return fmt.Errorf("getting child trie root hash at key 0x%x: %w", key, err)
+		}
 		rootHash := common.BytesToHash(value)
-		err := childTrie.Load(db, rootHash)
+		err = childTrie.Load(db, rootHash)
 		if err != nil {
 			return fmt.Errorf("failed to load child trie with root hash=%s: %w", rootHash, err)
 		}
@@ -281,7 +289,7 @@ func getFromDBAtNode(db Getter, n *Node, key []byte) (
 // WriteDirty writes all dirty nodes to the database and sets them to clean
 func (t *Trie) WriteDirty(db NewBatcher) error {
 	batch := db.NewBatch()
-	err := t.writeDirtyNode(batch, t.root)
+	err := t.PutDirty(batch)
 	if err != nil {
 		batch.Reset()
 		return err
@@ -290,6 +298,12 @@ func (t *Trie) WriteDirty(db NewBatcher) error {
 	return batch.Flush()
 }
 
+// PutDirty puts the dirty nodes of the trie using the given putter,
+// such as a database batch flushed by the caller.
+func (t *Trie) PutDirty(putter Putter) error {
+	return t.writeDirtyNode(putter, t.root)
+}
+
 func (t *Trie) writeDirtyNode(db Putter, n *Node) (err error) {
 	if n == nil || !n.Dirty {
 		return nil
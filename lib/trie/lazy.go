@@ -0,0 +1,189 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/trie/node"
+	"github.com/ChainSafe/gossamer/internal/trie/tracking"
+	"github.com/ChainSafe/gossamer/lib/common"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// NodeCache is a thread safe bounded least recently used cache
+// of encoded trie nodes, keyed by their node hash. Since nodes
+// are content addressed, a single cache can be shared between
+// tries of different state roots.
+type NodeCache struct {
+	encodings *lru.Cache[common.Hash, []byte]
+}
+
+// NewNodeCache creates a node cache holding at most
+// `size` encoded nodes.
+func NewNodeCache(size int) (cache *NodeCache, err error) {
+	encodings, err := lru.New[common.Hash, []byte](size)
+	if err != nil {
+		return nil, fmt.Errorf("creating LRU cache: %w", err)
+	}
+	return &NodeCache{
+		encodings: encodings,
+	}, nil
+}
+
+// Len returns the number of encoded nodes in the cache.
+func (c *NodeCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.encodings.Len()
+}
+
+func (c *NodeCache) get(nodeHash common.Hash) (encoding []byte, ok bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.encodings.Get(nodeHash)
+}
+
+func (c *NodeCache) add(nodeHash common.Hash, encoding []byte) {
+	if c == nil {
+		return
+	}
+	c.encodings.Add(nodeHash, encoding)
+}
+
+// NewLazyTrie creates a trie backed by the database given, where only
+// the root node is loaded. Other nodes are loaded from the database, or
+// from the node cache given if it is not nil, when they are accessed.
+// Loaded nodes are not kept in the trie unless they are modified, so
+// memory usage is bounded by the cache size and the dirty nodes held
+// until WriteDirty is called.
+// Note reading methods such as Get, NextKey or GetKeysWithPrefix
+// return an error if a node cannot be loaded from the database, and
+// the descendants count of lazily loaded branches only accounts for
+// nodes loaded in memory.
+func NewLazyTrie(db Getter, rootHash common.Hash, cache *NodeCache) (
+	trie *Trie, err error) {
+	trie = &Trie{
+		db:         db,
+		cache:      cache,
+		childTries: make(map[common.Hash]*Trie),
+		deltas:     tracking.New(),
+	}
+
+	if rootHash == EmptyHash {
+		return trie, nil
+	}
+
+	root := &Node{MerkleValue: rootHash.ToBytes()}
+	trie.root, err = trie.resolve(root)
+	if err != nil {
+		return nil, fmt.Errorf("loading root node: %w", err)
+	}
+
+	return trie, nil
+}
+
+// isHashReference returns true if the node given is only a reference
+// to a node stored in the database by its node hash, as decoded from
+// its parent branch encoding.
+func isHashReference(n *Node) bool {
+	return n != nil && len(n.MerkleValue) == common.HashLength &&
+		n.PartialKey == nil && n.StorageValue == nil && n.Children == nil
+}
+
+// resolve returns the node given if it is already in memory, or
+// loads it from the cache or the database if it is a hash reference.
+// The node returned is never shared with other tries, such that it
+// can safely be modified.
+func (t *Trie) resolve(n *Node) (resolved *Node, err error) {
+	if t.db == nil || !isHashReference(n) {
+		return n, nil
+	}
+
+	nodeHash := common.NewHash(n.MerkleValue)
+	encoding, cached := t.cache.get(nodeHash)
+	if !cached {
+		encoding, err = t.db.Get(n.MerkleValue)
+		if err != nil {
+			return nil, fmt.Errorf("getting node with hash %s from database: %w", nodeHash, err)
+		}
+	}
+
+	resolved, err = node.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node with hash %s: %w", nodeHash, err)
+	}
+	resolved.MerkleValue = n.MerkleValue
+
+	for _, child := range resolved.Children {
+		if child == nil || len(child.MerkleValue) > 0 {
+			continue
+		}
+		// Inlined child decoded from the branch encoding,
+		// set its Merkle value as it is done in loadNode.
+		_, err = child.CalculateMerkleValue()
+		if err != nil {
+			return nil, fmt.Errorf("calculating Merkle value of inlined child: %w", err)
+		}
+	}
+
+	if !cached {
+		t.cache.add(nodeHash, encoding)
+	}
+
+	return resolved, nil
+}
+
+// resolveAll returns the node given with all its descendants loaded
+// in memory. It is used before operations such as recordAllDeleted
+// which need to visit every node of a subtree.
+// Branches of the subtree loaded are copied so nodes shared with
+// other tries are not modified.
+func (t *Trie) resolveAll(n *Node) (resolved *Node, err error) {
+	resolved, err = t.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.db == nil || resolved == nil || resolved.Kind() != node.Branch {
+		return resolved, nil
+	}
+
+	copySettings := node.DefaultCopySettings
+	copySettings.CopyMerkleValue = true
+	branch := resolved.Copy(copySettings)
+	for i, child := range branch.Children {
+		if child == nil {
+			continue
+		}
+
+		child, err = t.resolveAll(child)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return nil, err
+		}
+		branch.Children[i] = child
+	}
+	branch.Descendants = countDescendants(branch)
+
+	return branch, nil
+}
+
+// countDescendants returns the number of descendants of the branch given
+// from its children, where a child only referenced by its hash counts as a
+// single node, as it does for a branch decoded from the database.
+// It is used instead of subtracting the number of nodes removed below the
+// branch, which can exceed the descendants count of a lazily loaded branch.
+func countDescendants(branch *Node) (descendants uint32) {
+	for _, child := range branch.Children {
+		if child == nil {
+			continue
+		}
+		descendants += 1 + child.Descendants
+	}
+	return descendants
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package trie
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeCache(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewNodeCache(0)
+	require.EqualError(t, err, "creating LRU cache: must provide a positive size")
+
+	var nilCache *NodeCache
+	nilCache.add(common.Hash{1}, []byte{1})
+	_, ok := nilCache.get(common.Hash{1})
+	assert.False(t, ok)
+	assert.Zero(t, nilCache.Len())
+
+	cache, err := NewNodeCache(2)
+	require.NoError(t, err)
+
+	cache.add(common.Hash{1}, []byte{1})
+	cache.add(common.Hash{2}, []byte{2})
+	cache.add(common.Hash{3}, []byte{3})
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.get(common.Hash{1})
+	assert.False(t, ok)
+	encoding, ok := cache.get(common.Hash{3})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{3}, encoding)
+}
+
+func Test_NewLazyTrie(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	trie, err := NewLazyTrie(db, EmptyHash, nil)
+	require.NoError(t, err)
+	assert.Nil(t, trie.root)
+	assert.Equal(t, EmptyHash, trie.MustHash())
+
+	_, err = NewLazyTrie(db, common.Hash{1}, nil)
+	assert.ErrorContains(t, err, "loading root node: getting node with hash "+
+		"0x0100000000000000000000000000000000000000000000000000000000000000 from database")
+}
+
+func Test_LazyTrie_read(t *testing.T) {
+	t.Parallel()
+
+	const size = 1000
+	trie, keyValues := makeSeededTrie(t, size)
+
+	db := newTestDB(t)
+	err := trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	const cacheSize = 100
+	cache, err := NewNodeCache(cacheSize)
+	require.NoError(t, err)
+
+	lazyTrie, err := NewLazyTrie(db, trie.MustHash(), cache)
+	require.NoError(t, err)
+	assert.Equal(t, trie.MustHash(), lazyTrie.MustHash())
+
+	for keyString, value := range keyValues {
+		key := []byte(keyString)
+		lazyValue, err := lazyTrie.Get(key)
+		require.NoError(t, err)
+		assert.Equalf(t, value, lazyValue, "for key 0x%x", key)
+
+		nextKey, err := trie.NextKey(key)
+		require.NoError(t, err)
+		lazyNextKey, err := lazyTrie.NextKey(key)
+		require.NoError(t, err)
+		assert.Equalf(t, nextKey, lazyNextKey, "for key 0x%x", key)
+	}
+	assert.Equal(t, cacheSize, cache.Len())
+
+	prefixes := [][]byte{nil, {}, {1}, {0xf0}, {0x12, 0x34}}
+	for _, prefix := range prefixes {
+		keys, err := trie.GetKeysWithPrefix(prefix)
+		require.NoError(t, err)
+		lazyKeys, err := lazyTrie.GetKeysWithPrefix(prefix)
+		require.NoError(t, err)
+		assert.Equalf(t, keys, lazyKeys, "for prefix 0x%x", prefix)
+	}
+
+	assertEntriesEqual(t, trie, lazyTrie)
+
+	value, err := lazyTrie.Get([]byte("not_a_key"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func assertEntriesEqual(t *testing.T, expected, actual *Trie) {
+	t.Helper()
+
+	expectedEntries, err := expected.Entries()
+	require.NoError(t, err)
+	actualEntries, err := actual.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntries, actualEntries)
+}
+
+func Test_LazyTrie_read_error(t *testing.T) {
+	t.Parallel()
+
+	const size = 100
+	trie, keyValues := makeSeededTrie(t, size)
+
+	db := newTestDB(t)
+	err := trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	lazyTrie, err := NewLazyTrie(errorGetter{}, EmptyHash, nil)
+	require.NoError(t, err)
+	lazyTrie.root = trie.RootNode()
+	for i, child := range lazyTrie.root.Children {
+		if child != nil {
+			lazyTrie.root.Children[i] = &Node{MerkleValue: child.MerkleValue}
+		}
+	}
+
+	key := []byte(pickKeys(keyValues, newGenerator(), 1)[0])
+	childHash := common.NewHash(lazyTrie.root.Children[key[0]>>4].MerkleValue)
+	errMessage := "getting node with hash " + childHash.String() + " from database: test error"
+
+	value, err := lazyTrie.Get(key)
+	assert.EqualError(t, err, errMessage)
+	assert.Nil(t, value)
+
+	nextKey, err := lazyTrie.NextKey(key)
+	assert.EqualError(t, err, errMessage)
+	assert.Nil(t, nextKey)
+
+	keys, err := lazyTrie.GetKeysWithPrefix(key[:1])
+	assert.EqualError(t, err, errMessage)
+	assert.Nil(t, keys)
+
+	entries, err := lazyTrie.Entries()
+	assert.Error(t, err)
+	assert.Nil(t, entries)
+
+	_, err = lazyTrie.GetChild([]byte{1})
+	assert.Error(t, err)
+}
+
+type errorGetter struct{}
+
+func (errorGetter) Get([]byte) ([]byte, error) { return nil, errors.New("test error") }
+
+func Test_LazyTrie_write(t *testing.T) {
+	t.Parallel()
+
+	const size = 1000
+	trie, keyValues := makeSeededTrie(t, size)
+
+	db := newTestDB(t)
+	err := trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	cache, err := NewNodeCache(size)
+	require.NoError(t, err)
+
+	lazyTrie, err := NewLazyTrie(db, trie.MustHash(), cache)
+	require.NoError(t, err)
+
+	trie = trie.Snapshot()
+	lazyTrie = lazyTrie.Snapshot()
+
+	generator := newGenerator()
+	keys := pickKeys(keyValues, generator, size/10)
+	for i, key := range keys {
+		switch i % 4 {
+		case 0:
+			value := []byte{byte(i)}
+			require.NoError(t, trie.Put(key, value))
+			require.NoError(t, lazyTrie.Put(key, value))
+		case 1:
+			require.NoError(t, trie.Delete(key))
+			require.NoError(t, lazyTrie.Delete(key))
+		case 2:
+			prefix := key[:len(key)/2]
+			require.NoError(t, trie.ClearPrefix(prefix))
+			require.NoError(t, lazyTrie.ClearPrefix(prefix))
+		case 3:
+			prefix := key[:len(key)/2]
+			const limit = 3
+			deleted, allDeleted, err := trie.ClearPrefixLimit(prefix, limit)
+			require.NoError(t, err)
+			lazyDeleted, lazyAllDeleted, err := lazyTrie.ClearPrefixLimit(prefix, limit)
+			require.NoError(t, err)
+			assert.Equal(t, deleted, lazyDeleted)
+			assert.Equal(t, allDeleted, lazyAllDeleted)
+		}
+
+		require.Equalf(t, trie.MustHash(), lazyTrie.MustHash(), "at operation %d", i)
+	}
+
+	assertEntriesEqual(t, trie, lazyTrie)
+	assert.Equal(t, trie.deltas.Deleted(), lazyTrie.deltas.Deleted())
+
+	err = lazyTrie.WriteDirty(db)
+	require.NoError(t, err)
+
+	trieFromDB := NewEmptyTrie()
+	err = trieFromDB.Load(db, lazyTrie.MustHash())
+	require.NoError(t, err)
+	assertEntriesEqual(t, trie, trieFromDB)
+}
+
+func Test_LazyTrie_descendants(t *testing.T) {
+	t.Parallel()
+
+	trie := NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		err := trie.Put([]byte{1, byte(i)}, []byte{byte(i)})
+		require.NoError(t, err)
+	}
+	err := trie.Put([]byte{2}, []byte{2})
+	require.NoError(t, err)
+	err = trie.Put([]byte{3}, []byte{3})
+	require.NoError(t, err)
+
+	db := newTestDB(t)
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	// the root branch decoded counts each of its children as one descendant,
+	// whereas clearing the prefix removes the 100 nodes below the child.
+	lazyTrie, err := NewLazyTrie(db, trie.MustHash(), nil)
+	require.NoError(t, err)
+
+	deleted, allDeleted, err := lazyTrie.ClearPrefixLimit([]byte{1}, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), deleted)
+	assert.True(t, allDeleted)
+	assert.Equal(t, uint32(2), lazyTrie.root.Descendants)
+	value, err := lazyTrie.Get([]byte{2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+
+	lazyTrie, err = NewLazyTrie(db, trie.MustHash(), nil)
+	require.NoError(t, err)
+
+	err = lazyTrie.ClearPrefix([]byte{1, 5})
+	require.NoError(t, err)
+	assert.Equal(t, countDescendants(lazyTrie.root), lazyTrie.root.Descendants)
+	assert.Less(t, lazyTrie.root.Descendants, uint32(200))
+}
+
+func Test_LazyTrie_child(t *testing.T) {
+	t.Parallel()
+
+	const size = 100
+	trie, _ := makeSeededTrie(t, size)
+
+	const childTrieSize = 10
+	childTrie, childKeyValues := makeSeededTrie(t, childTrieSize)
+
+	keyToChild := []byte("child")
+	err := trie.SetChild(keyToChild, childTrie)
+	require.NoError(t, err)
+
+	db := newTestDB(t)
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	lazyTrie, err := NewLazyTrie(db, trie.MustHash(), nil)
+	require.NoError(t, err)
+
+	for keyString, value := range childKeyValues {
+		valueFromChild, err := lazyTrie.GetFromChild(keyToChild, []byte(keyString))
+		require.NoError(t, err)
+		assert.Equal(t, value, valueFromChild)
+	}
+
+	err = trie.PutIntoChild(keyToChild, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = lazyTrie.PutIntoChild(keyToChild, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+	assert.Equal(t, trie.MustHash(), lazyTrie.MustHash())
+
+	value, err := lazyTrie.GetFromChild(keyToChild, []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
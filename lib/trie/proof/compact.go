@@ -0,0 +1,174 @@
+// Copyright 2023 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/trie/node"
+)
+
+var (
+	ErrCompactProofNodesLeft    = errors.New("compact proof has unused nodes")
+	ErrCompactProofNodesMissing = errors.New("compact proof is missing nodes")
+	ErrRootHashMismatch         = errors.New("root hash mismatch")
+)
+
+// EncodeCompact encodes the proof nodes given to a compact proof,
+// for the trie corresponding to the root hash given.
+// In a compact proof, nodes are ordered depth first starting from the
+// root node, and the hash reference of a child node present in the proof
+// is omitted from its parent encoding, since it can be recomputed when
+// decoding the proof. Nodes not reachable from the root node are dropped.
+func EncodeCompact(encodedProofNodes [][]byte, rootHash []byte) (
+	compactProof [][]byte, err error) {
+	if len(encodedProofNodes) == 0 {
+		return nil, nil
+	}
+
+	root, digestToEncoding, err := decodeProof(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, fmt.Errorf("decoding proof: %w", err)
+	}
+
+	compactProof = make([][]byte, 0, len(encodedProofNodes))
+	compactProof, err = encodeCompact(root, digestToEncoding, compactProof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding compact proof: %w", err)
+	}
+
+	return compactProof, nil
+}
+
+// encodeCompact appends the compact encoding of the node given
+// and of its descendants present in the proof to the compact proof
+// slice given, in a depth first order.
+func encodeCompact(n *node.Node, digestToEncoding map[string][]byte,
+	compactProof [][]byte) (newCompactProof [][]byte, err error) {
+	if n.Kind() == node.Leaf {
+		buffer := bytes.NewBuffer(nil)
+		err = n.Encode(buffer)
+		if err != nil {
+			return nil, fmt.Errorf("encoding leaf: %w", err)
+		}
+		return append(compactProof, buffer.Bytes()), nil
+	}
+
+	branch := n.Copy(node.DefaultCopySettings)
+	var childrenInProof []*node.Node
+	for i, child := range n.Children {
+		if child == nil || !isHashReference(child) {
+			continue
+		}
+
+		encoding, ok := digestToEncoding[string(child.MerkleValue)]
+		if !ok {
+			continue
+		}
+
+		child, err = node.Decode(bytes.NewReader(encoding))
+		if err != nil {
+			return nil, fmt.Errorf("decoding child node at index %d: %w", i, err)
+		}
+		childrenInProof = append(childrenInProof, child)
+		branch.Children[i] = &node.Node{MerkleValue: []byte{}}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = branch.Encode(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("encoding branch: %w", err)
+	}
+	compactProof = append(compactProof, buffer.Bytes())
+
+	for _, child := range childrenInProof {
+		compactProof, err = encodeCompact(child, digestToEncoding, compactProof)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return nil, err
+		}
+	}
+
+	return compactProof, nil
+}
+
+// DecodeCompact decodes the compact proof given to the proof encoded
+// nodes it was created from, and verifies the root node hash matches
+// the root hash given. The root node encoding is the first encoded
+// proof node returned.
+func DecodeCompact(compactProof [][]byte, rootHash []byte) (
+	encodedProofNodes [][]byte, err error) {
+	if len(compactProof) == 0 {
+		return nil, nil
+	}
+
+	var nodesInProof []*node.Node
+	nextIndex := 0
+	_, err = decodeCompact(compactProof, &nextIndex, &nodesInProof)
+	if err != nil {
+		return nil, fmt.Errorf("decoding compact proof: %w", err)
+	}
+
+	if nextIndex < len(compactProof) {
+		return nil, fmt.Errorf("%w: %d nodes decoded out of %d",
+			ErrCompactProofNodesLeft, nextIndex, len(compactProof))
+	}
+
+	// Encode nodes in reverse order such that the Merkle value
+	// of each child is set before its parent is encoded.
+	encodedProofNodes = make([][]byte, len(nodesInProof))
+	for i := len(nodesInProof) - 1; i >= 1; i-- {
+		encodedProofNodes[i], _, err = nodesInProof[i].EncodeAndHash()
+		if err != nil {
+			return nil, fmt.Errorf("encoding node: %w", err)
+		}
+	}
+
+	encodedProofNodes[0], _, err = nodesInProof[0].EncodeAndHashRoot()
+	if err != nil {
+		return nil, fmt.Errorf("encoding root node: %w", err)
+	}
+
+	if !bytes.Equal(nodesInProof[0].MerkleValue, rootHash) {
+		return nil, fmt.Errorf("%w: expected 0x%x but got 0x%x from compact proof",
+			ErrRootHashMismatch, rootHash, nodesInProof[0].MerkleValue)
+	}
+
+	return encodedProofNodes, nil
+}
+
+// decodeCompact decodes the compact proof node at the index given,
+// replacing its omitted children references with the next nodes
+// of the compact proof. Decoded nodes are appended to the nodes slice
+// pointed to, in the same depth first order as the compact proof.
+func decodeCompact(compactProof [][]byte, index *int, nodes *[]*node.Node) (
+	decoded *node.Node, err error) {
+	if *index >= len(compactProof) {
+		return nil, fmt.Errorf("%w: expected node at index %d",
+			ErrCompactProofNodesMissing, *index)
+	}
+
+	decoded, err = node.Decode(bytes.NewReader(compactProof[*index]))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node at index %d: %w", *index, err)
+	}
+	*index++
+	*nodes = append(*nodes, decoded)
+
+	for i, child := range decoded.Children {
+		if child == nil || !child.IsOmittedReference() {
+			continue
+		}
+
+		decoded.Children[i], err = decodeCompact(compactProof, index, nodes)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return nil, err
+		}
+	}
+
+	return decoded, nil
+}
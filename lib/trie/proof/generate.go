@@ -5,7 +5,6 @@
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/internal/trie/codec"
@@ -15,10 +14,6 @@
 	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
-var (
-	ErrKeyNotFound = errors.New("key not found")
-)
-
 // Database defines a key value Get method used
 // for proof generation.
 type Database interface {
@@ -28,11 +23,15 @@ type Database interface {
 // Generate generates and deduplicates the encoded proof nodes
 // for the trie corresponding to the root hash given, and for
 // the slice of (Little Endian) full keys given. The database given
-// is used to load the trie using the root hash given.
+// is used to load the trie nodes on the path to each key, starting
+// from the root hash given.
+// Keys not found in the trie get the proof nodes proving their absence.
+// If any key is given, the root node encoding is the first encoded
+// proof node returned.
 func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
 	encodedProofNodes [][]byte, err error) {
-	trie := trie.NewEmptyTrie()
-	if err := trie.Load(database, common.BytesToHash(rootHash)); err != nil {
+	trie, err := trie.NewLazyTrie(database, common.BytesToHash(rootHash), nil)
+	if err != nil {
 		return nil, fmt.Errorf("loading trie: %w", err)
 	}
 	rootNode := trie.RootNode()
@@ -43,7 +42,7 @@ func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
 	nodeHashesSeen := make(map[common.Hash]struct{})
 	for _, fullKey := range fullKeys {
 		fullKeyNibbles := codec.KeyLEToNibbles(fullKey)
-		newEncodedProofNodes, err := walkRoot(rootNode, fullKeyNibbles)
+		newEncodedProofNodes, err := walkRoot(rootNode, fullKeyNibbles, database)
 		if err != nil {
 			// Note we wrap the full key context here since walk is recursive and
 			// may not be aware of the initial full key.
@@ -74,13 +73,11 @@ func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
 	return encodedProofNodes, nil
 }
 
-func walkRoot(root *node.Node, fullKey []byte) (
+func walkRoot(root *node.Node, fullKey []byte, database Database) (
 	encodedProofNodes [][]byte, err error) {
 	if root == nil {
-		if len(fullKey) == 0 {
-			return nil, nil
-		}
-		return nil, ErrKeyNotFound
+		// Empty trie: the empty proof proves the absence of any key.
+		return nil, nil
 	}
 
 	// Note we do not use sync.Pool buffers since we would have
@@ -97,20 +94,19 @@ func walkRoot(root *node.Node, fullKey []byte) (
 		return encodedProofNodes, nil
 	}
 
-	if root.Kind() == node.Leaf && !nodeFound {
-		return nil, ErrKeyNotFound
-	}
-
 	nodeIsDeeper := len(fullKey) > len(root.PartialKey)
-	if !nodeIsDeeper {
-		return nil, ErrKeyNotFound
+	if root.Kind() == node.Leaf || !nodeIsDeeper ||
+		!bytes.HasPrefix(fullKey, root.PartialKey) {
+		// The key is not in the trie, and the node encoding
+		// added to the proof proves its absence.
+		return encodedProofNodes, nil
 	}
 
 	commonLength := lenCommonPrefix(root.PartialKey, fullKey)
 	childIndex := fullKey[commonLength]
 	nextChild := root.Children[childIndex]
 	nextFullKey := fullKey[commonLength+1:]
-	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey)
+	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey, database)
 	if err != nil {
 		return nil, err // note: do not wrap since this is recursive
 	}
@@ -119,13 +115,17 @@ func walkRoot(root *node.Node, fullKey []byte) (
 	return encodedProofNodes, nil
 }
 
-func walk(parent *node.Node, fullKey []byte) (
+func walk(parent *node.Node, fullKey []byte, database Database) (
 	encodedProofNodes [][]byte, err error) {
 	if parent == nil {
-		if len(fullKey) == 0 {
-			return nil, nil
-		}
-		return nil, ErrKeyNotFound
+		// The parent branch encoding already in the proof
+		// proves the absence of the key.
+		return nil, nil
+	}
+
+	parent, err = loadNode(parent, database)
+	if err != nil {
+		return nil, fmt.Errorf("loading node: %w", err)
 	}
 
 	// Note we do not use sync.Pool buffers since we would have
@@ -149,20 +149,19 @@ func walk(parent *node.Node, fullKey []byte) (
 		return encodedProofNodes, nil
 	}
 
-	if parent.Kind() == node.Leaf && !nodeFound {
-		return nil, ErrKeyNotFound
-	}
-
 	nodeIsDeeper := len(fullKey) > len(parent.PartialKey)
-	if !nodeIsDeeper {
-		return nil, ErrKeyNotFound
+	if parent.Kind() == node.Leaf || !nodeIsDeeper ||
+		!bytes.HasPrefix(fullKey, parent.PartialKey) {
+		// The key is not in the trie, and the node encoding
+		// added to the proof proves its absence.
+		return encodedProofNodes, nil
 	}
 
 	commonLength := lenCommonPrefix(parent.PartialKey, fullKey)
 	childIndex := fullKey[commonLength]
 	nextChild := parent.Children[childIndex]
 	nextFullKey := fullKey[commonLength+1:]
-	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey)
+	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey, database)
 	if err != nil {
 		return nil, err // note: do not wrap since this is recursive
 	}
@@ -171,6 +170,35 @@ func walk(parent *node.Node, fullKey []byte) (
 	return encodedProofNodes, nil
 }
 
+// loadNode returns the node given if it is loaded in memory, or loads
+// and decodes it from the database if it is only a hash reference
+// as decoded from its parent branch encoding.
+func loadNode(n *node.Node, database Database) (loaded *node.Node, err error) {
+	if !isHashReference(n) {
+		return n, nil
+	}
+
+	encoding, err := database.Get(n.MerkleValue)
+	if err != nil {
+		return nil, fmt.Errorf("getting node with hash 0x%x from database: %w", n.MerkleValue, err)
+	}
+
+	loaded, err = node.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node with hash 0x%x: %w", n.MerkleValue, err)
+	}
+	loaded.MerkleValue = n.MerkleValue
+
+	return loaded, nil
+}
+
+// isHashReference returns true if the node given is only a reference
+// to a node by its hash, as decoded from its parent branch encoding.
+func isHashReference(n *node.Node) bool {
+	return len(n.MerkleValue) == common.HashLength &&
+		n.PartialKey == nil && n.StorageValue == nil && n.Children == nil
+}
+
 // lenCommonPrefix returns the length of the
 // common prefix between two byte slices.
 func lenCommonPrefix(a, b []byte) (length int) {
@@ -45,12 +45,45 @@ func Test_Generate(t *testing.T) {
 				return mockDatabase
 			},
 			errWrapped: errTest,
-			errMessage: "loading trie: " +
-				"failed to find root key " +
-				"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f: " +
-				"test error",
+			errMessage: "loading trie: loading root node: " +
+				"getting node with hash " +
+				"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f " +
+				"from database: test error",
 		},
-		"walk_error": {
+		"failed_loading_child": {
+			rootHash:        someHash,
+			fullKeysNibbles: [][]byte{{1, 2, 3, 4}},
+			databaseBuilder: func(ctrl *gomock.Controller) Database {
+				mockDatabase := NewMockDatabase(ctrl)
+
+				rootNode := node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{2},
+					Children: padRightChildren([]*node.Node{
+						nil, nil, nil,
+						{ // full key 1, 2, 3, 4
+							PartialKey:   []byte{4},
+							StorageValue: largeValue,
+						},
+					}),
+				}
+
+				mockDatabase.EXPECT().Get(someHash).
+					Return(encodeNode(t, rootNode), nil)
+
+				encodedChild := encodeNode(t, *rootNode.Children[3])
+				mockDatabase.EXPECT().Get(blake2b(t, encodedChild)).
+					Return(nil, errTest)
+
+				return mockDatabase
+			},
+			errWrapped: errTest,
+			errMessage: "walking to node at key 0x1234: loading node: " +
+				"getting node with hash " +
+				"0x40f4310a13e306318d42e21d0ebd59eae1890ffabb9728f2c3a4139253a88d1a " +
+				"from database: test error",
+		},
+		"key_not_found": {
 			rootHash:        someHash,
 			fullKeysNibbles: [][]byte{{1}},
 			databaseBuilder: func(ctrl *gomock.Controller) Database {
@@ -63,8 +96,12 @@ func Test_Generate(t *testing.T) {
 					Return(encodedRoot, nil)
 				return mockDatabase
 			},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "walking to node at key 0x01: key not found",
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1},
+					StorageValue: []byte{2},
+				}),
+			},
 		},
 		"leaf_root": {
 			rootHash:        someHash,
@@ -281,9 +318,7 @@ func Test_walkRoot(t *testing.T) {
 	}{
 		"nil_parent_and_empty_full_key": {},
 		"nil_parent_and_non_empty_full_key": {
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
 		},
 		// The parent encode error cannot be triggered here
 		// since it can only be caused by a buffer.Write error.
@@ -302,27 +337,39 @@ func Test_walkRoot(t *testing.T) {
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{1},
+				}),
+			},
 		},
 		"parent_leaf_and_mismatching_full_key": {
 			parent: &node.Node{
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 3},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{1},
+				}),
+			},
 		},
 		"parent_leaf_and_longer_full_key": {
 			parent: &node.Node{
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1, 2, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 2, 3},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{1},
+				}),
+			},
 		},
 		"branch_and_empty_search_key": {
 			parent: &node.Node{
@@ -359,9 +406,19 @@ func Test_walkRoot(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{3},
+					Children: padRightChildren([]*node.Node{
+						{
+							PartialKey:   []byte{4},
+							StorageValue: []byte{5},
+						},
+					}),
+				}),
+			},
 		},
 		"branch_and_mismatching_full_key": {
 			parent: &node.Node{
@@ -374,9 +431,19 @@ func Test_walkRoot(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 3},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{3},
+					Children: padRightChildren([]*node.Node{
+						{
+							PartialKey:   []byte{4},
+							StorageValue: []byte{5},
+						},
+					}),
+				}),
+			},
 		},
 		"branch_and_matching_search_key": {
 			parent: &node.Node{
@@ -470,9 +537,19 @@ func Test_walkRoot(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1, 2, 0x04, 4},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 2, 0x04, 4},
+			encodedProofNodes: [][]byte{
+				encodeNode(t, node.Node{
+					PartialKey:   []byte{1, 2},
+					StorageValue: []byte{3},
+					Children: padRightChildren([]*node.Node{
+						{
+							PartialKey:   []byte{4, 5},
+							StorageValue: []byte{5},
+						},
+					}),
+				}),
+			},
 		},
 		"found_leaf_at_deeper_level": {
 			parent: &node.Node{
@@ -506,7 +583,7 @@ func Test_walkRoot(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encodedProofNodes, err := walkRoot(testCase.parent, testCase.fullKey)
+			encodedProofNodes, err := walkRoot(testCase.parent, testCase.fullKey, nil)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -532,9 +609,7 @@ func Test_walk(t *testing.T) {
 	}{
 		"nil_parent_and_empty_full_key": {},
 		"nil_parent_and_non_empty_full_key": {
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
 		},
 		// The parent encode error cannot be triggered here
 		// since it can only be caused by a buffer.Write error.
@@ -553,27 +628,21 @@ func Test_walk(t *testing.T) {
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
 		},
 		"parent_leaf_and_mismatching_full_key": {
 			parent: &node.Node{
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 3},
 		},
 		"parent_leaf_and_longer_full_key": {
 			parent: &node.Node{
 				PartialKey:   []byte{1, 2},
 				StorageValue: []byte{1},
 			},
-			fullKey:    []byte{1, 2, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 2, 3},
 		},
 		"branch_and_empty_search_key": {
 			parent: &node.Node{
@@ -610,9 +679,7 @@ func Test_walk(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1},
 		},
 		"branch_and_mismatching_full_key": {
 			parent: &node.Node{
@@ -625,9 +692,7 @@ func Test_walk(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1, 3},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 3},
 		},
 		"branch_and_matching_search_key": {
 			parent: &node.Node{
@@ -721,9 +786,7 @@ func Test_walk(t *testing.T) {
 					},
 				}),
 			},
-			fullKey:    []byte{1, 2, 0x04, 4},
-			errWrapped: ErrKeyNotFound,
-			errMessage: "key not found",
+			fullKey: []byte{1, 2, 0x04, 4},
 		},
 		"found_leaf_at_deeper_level": {
 			parent: &node.Node{
@@ -757,7 +820,7 @@ func Test_walk(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encodedProofNodes, err := walk(testCase.parent, testCase.fullKey)
+			encodedProofNodes, err := walk(testCase.parent, testCase.fullKey, nil)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -845,12 +908,12 @@ func Benchmark_walkRoot(b *testing.B) {
 	longestKeyNibbles := codec.KeyLEToNibbles(longestKeyLE)
 
 	rootNode := trie.RootNode()
-	encodedProofNodes, err := walkRoot(rootNode, longestKeyNibbles)
+	encodedProofNodes, err := walkRoot(rootNode, longestKeyNibbles, nil)
 	require.NoError(b, err)
 	require.Equal(b, len(encodedProofNodes), trieDepth)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = walkRoot(rootNode, longestKeyNibbles)
+		_, _ = walkRoot(rootNode, longestKeyNibbles, nil)
 	}
 }
@@ -50,3 +50,79 @@ func Test_Generate_Verify(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func Test_Generate_VerifyMultiple(t *testing.T) {
+	t.Parallel()
+
+	keyValues := map[string][]byte{
+		"cat":       generateBytes(t, 40),
+		"catapulta": []byte("catapulta"),
+		"catapora":  generateBytes(t, 64),
+		"dog":       []byte("dog"),
+		"doguinho":  generateBytes(t, 33),
+	}
+
+	trie := trie.NewEmptyTrie()
+	for key, value := range keyValues {
+		trie.Put([]byte(key), value)
+	}
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	database, err := chaindb.NewBadgerDB(&chaindb.Config{
+		InMemory: true,
+	})
+	require.NoError(t, err)
+	err = trie.WriteDirty(database)
+	require.NoError(t, err)
+
+	fullKeys := [][]byte{[]byte("cat"), []byte("catapora"), []byte("doguinho"), []byte("cow")}
+	proof, err := Generate(rootHash.ToBytes(), fullKeys, database)
+	require.NoError(t, err)
+
+	err = VerifyMultiple(proof, rootHash.ToBytes(), map[string][]byte{
+		"cat":      keyValues["cat"],
+		"catapora": keyValues["catapora"],
+		"doguinho": keyValues["doguinho"],
+		"cow":      nil,
+	})
+	require.NoError(t, err)
+
+	err = VerifyMultiple(proof, rootHash.ToBytes(), map[string][]byte{"cat": nil})
+	require.ErrorIs(t, err, ErrKeyFoundInProofTrie)
+
+	err = VerifyMultiple(proof, rootHash.ToBytes(), map[string][]byte{"cow": []byte("cow")})
+	require.ErrorIs(t, err, ErrKeyNotFoundInProofTrie)
+
+	err = VerifyMultiple(proof, rootHash.ToBytes(), map[string][]byte{"catapora": []byte("x")})
+	require.ErrorIs(t, err, ErrValueMismatchProofTrie)
+
+	proofWithoutLeaves := [][]byte{proof[0]}
+	err = VerifyMultiple(proofWithoutLeaves, rootHash.ToBytes(), map[string][]byte{"doguinho": nil})
+	require.ErrorIs(t, err, ErrIncompleteProof)
+
+	compactProof, err := EncodeCompact(proof, rootHash.ToBytes())
+	require.NoError(t, err)
+	require.Len(t, compactProof, len(proof))
+	var compactSize, proofSize int
+	for i := range proof {
+		compactSize += len(compactProof[i])
+		proofSize += len(proof[i])
+	}
+	require.Less(t, compactSize, proofSize)
+
+	decodedProof, err := DecodeCompact(compactProof, rootHash.ToBytes())
+	require.NoError(t, err)
+	require.ElementsMatch(t, proof, decodedProof)
+	require.Equal(t, proof[0], decodedProof[0])
+
+	_, err = DecodeCompact(compactProof[:len(compactProof)-1], rootHash.ToBytes())
+	require.ErrorIs(t, err, ErrCompactProofNodesMissing)
+
+	_, err = DecodeCompact(append(compactProof, compactProof[0]), rootHash.ToBytes())
+	require.ErrorIs(t, err, ErrCompactProofNodesLeft)
+
+	_, err = DecodeCompact(compactProof, []byte{1})
+	require.ErrorIs(t, err, ErrRootHashMismatch)
+}
@@ -7,12 +7,15 @@
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/ChainSafe/gossamer/internal/trie/codec"
 	"github.com/ChainSafe/gossamer/internal/trie/node"
 	"github.com/ChainSafe/gossamer/internal/trie/pools"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/trie"
+	"golang.org/x/exp/maps"
 )
 
 var (
@@ -31,8 +34,10 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 		return fmt.Errorf("building trie from proof encoded nodes: %w", err)
 	}
 
-	proofTrieValue := proofTrie.Get(key)
-	if proofTrieValue == nil {
+	proofTrieValue, err := proofTrie.Get(key)
+	if err != nil {
+		return fmt.Errorf("getting value from proof trie: %w", err)
+	} else if proofTrieValue == nil {
 		return fmt.Errorf("%w: %s in proof trie for root hash 0x%x",
 			ErrKeyNotFoundInProofTrie, bytesToString(key), rootHash)
 	}
@@ -46,6 +51,99 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 	return nil
 }
 
+var (
+	ErrKeyFoundInProofTrie = errors.New("key found in proof trie")
+	ErrIncompleteProof     = errors.New("proof is incomplete")
+)
+
+// VerifyMultiple verifies the given keys and values against the trie with
+// the given root hash, using the encoded proof nodes given. The order of
+// proofs is ignored. Keys are in Little Endian format, and a nil value
+// verifies the key is absent from the trie.
+// A nil error is returned on success.
+func VerifyMultiple(encodedProofNodes [][]byte, rootHash []byte,
+	keyValues map[string][]byte) (err error) {
+	var root *node.Node
+	if !bytes.Equal(rootHash, trie.EmptyHash[:]) {
+		root, _, err = decodeProof(encodedProofNodes, rootHash)
+		if err != nil {
+			return fmt.Errorf("decoding proof: %w", err)
+		}
+	}
+
+	digestToEncoding := make(map[string][]byte, len(encodedProofNodes))
+	for _, encodedProofNode := range encodedProofNodes {
+		digest, err := common.Blake2bHash(encodedProofNode)
+		if err != nil {
+			return fmt.Errorf("hashing proof node: %w", err)
+		}
+		digestToEncoding[string(digest[:])] = encodedProofNode
+	}
+
+	keys := maps.Keys(keyValues)
+	sort.Strings(keys)
+	for _, key := range keys {
+		expectedValue := keyValues[key]
+		value, err := lookup(root, codec.KeyLEToNibbles([]byte(key)), digestToEncoding)
+		if err != nil {
+			return fmt.Errorf("looking up key %s: %w", bytesToString([]byte(key)), err)
+		}
+
+		switch {
+		case expectedValue == nil && value != nil:
+			return fmt.Errorf("%w: %s with value %s for root hash 0x%x",
+				ErrKeyFoundInProofTrie, bytesToString([]byte(key)), bytesToString(value), rootHash)
+		case expectedValue != nil && value == nil:
+			return fmt.Errorf("%w: %s in proof trie for root hash 0x%x",
+				ErrKeyNotFoundInProofTrie, bytesToString([]byte(key)), rootHash)
+		case !bytes.Equal(expectedValue, value):
+			return fmt.Errorf("%w: expected value %s but got value %s from proof trie for key %s",
+				ErrValueMismatchProofTrie, bytesToString(expectedValue), bytesToString(value),
+				bytesToString([]byte(key)))
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the value at the key given in nibbles, walking down from
+// the node given and decoding child nodes from the map of node hash digest
+// to node encoding. It returns a nil value if the proof nodes show the key
+// is absent, and an error if a node needed is missing from the proof.
+func lookup(n *node.Node, key []byte, digestToEncoding map[string][]byte) (
+	value []byte, err error) {
+	for n != nil {
+		if isHashReference(n) {
+			encoding, ok := digestToEncoding[string(n.MerkleValue)]
+			if !ok {
+				return nil, fmt.Errorf("%w: node with hash 0x%x is missing",
+					ErrIncompleteProof, n.MerkleValue)
+			}
+
+			n, err = node.Decode(bytes.NewReader(encoding))
+			if err != nil {
+				return nil, fmt.Errorf("decoding node: %w", err)
+			}
+		}
+
+		if bytes.Equal(n.PartialKey, key) {
+			return n.StorageValue, nil
+		}
+
+		if n.Kind() == node.Leaf ||
+			len(key) <= len(n.PartialKey) ||
+			!bytes.HasPrefix(key, n.PartialKey) {
+			return nil, nil
+		}
+
+		childIndex := key[len(n.PartialKey)]
+		key = key[len(n.PartialKey)+1:]
+		n = n.Children[childIndex]
+	}
+
+	return nil, nil
+}
+
 var (
 	ErrEmptyProof       = errors.New("proof slice empty")
 	ErrRootNodeNotFound = errors.New("root node not found in proof")
@@ -53,12 +151,30 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 
 // buildTrie sets a partial trie based on the proof slice of encoded nodes.
 func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err error) {
+	root, digestToEncoding, err := decodeProof(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadProof(digestToEncoding, root)
+	if err != nil {
+		return nil, fmt.Errorf("loading proof: %w", err)
+	}
+
+	return trie.NewTrie(root), nil
+}
+
+// decodeProof finds and decodes the root node from the proof slice of
+// encoded nodes, and returns a map of node hash digest to node encoding
+// for the other encoded proof nodes.
+func decodeProof(encodedProofNodes [][]byte, rootHash []byte) (
+	root *node.Node, digestToEncoding map[string][]byte, err error) {
 	if len(encodedProofNodes) == 0 {
-		return nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
+		return nil, nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
 			ErrEmptyProof, rootHash)
 	}
 
-	digestToEncoding := make(map[string][]byte, len(encodedProofNodes))
+	digestToEncoding = make(map[string][]byte, len(encodedProofNodes))
 
 	// note we can use a buffer from the pool since
 	// the calculated root hash digest is not used after
@@ -71,7 +187,6 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 	// 2. It stores other encoded nodes in a mapping from their encoding digest to
 	//    their encoding. They are only decoded later if the root or one of its
 	//    descendant nodes reference their hash digest.
-	var root *node.Node
 	for _, encodedProofNode := range encodedProofNodes {
 		// Note all encoded proof nodes are one of the following:
 		// - trie root node
@@ -83,7 +198,7 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 		buffer.Reset()
 		err = node.MerkleValueRoot(encodedProofNode, buffer)
 		if err != nil {
-			return nil, fmt.Errorf("calculating node hash: %w", err)
+			return nil, nil, fmt.Errorf("calculating node hash: %w", err)
 		}
 		digest := buffer.Bytes()
 
@@ -96,7 +211,7 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 
 		root, err = node.Decode(bytes.NewReader(encodedProofNode))
 		if err != nil {
-			return nil, fmt.Errorf("decoding root node: %w", err)
+			return nil, nil, fmt.Errorf("decoding root node: %w", err)
 		}
 		// The built proof trie is not used with a database, but just in case
 		// it becomes used with a database in the future, we set the dirty flag
@@ -110,16 +225,11 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 			hashDigestHex := common.BytesToHex([]byte(hashDigestString))
 			proofHashDigests = append(proofHashDigests, hashDigestHex)
 		}
-		return nil, fmt.Errorf("%w: for root hash 0x%x in proof hash digests %s",
+		return nil, nil, fmt.Errorf("%w: for root hash 0x%x in proof hash digests %s",
 			ErrRootNodeNotFound, rootHash, strings.Join(proofHashDigests, ", "))
 	}
 
-	err = loadProof(digestToEncoding, root)
-	if err != nil {
-		return nil, fmt.Errorf("loading proof: %w", err)
-	}
-
-	return trie.NewTrie(root), nil
+	return root, digestToEncoding, nil
 }
 
 // loadProof is a recursive function that will create all the trie paths based
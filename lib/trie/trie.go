@@ -21,6 +21,10 @@ type Trie struct {
 	generation uint64
 	root       *Node
 	childTries map[common.Hash]*Trie
+	// db and cache are set for lazy tries, to load nodes
+	// from the database on demand, see NewLazyTrie.
+	db    Getter
+	cache *NodeCache
 	// deltas stores trie deltas since the last trie snapshot.
 	// For example node hashes that were deleted since
 	// the last snapshot. These are used by the online
@@ -57,6 +61,8 @@ func (t *Trie) Snapshot() (newTrie *Trie) {
 		childTries[rootHash] = &Trie{
 			generation: childTrie.generation + 1,
 			root:       childTrie.root.Copy(rootCopySettings),
+			db:         childTrie.db,
+			cache:      childTrie.cache,
 			deltas:     tracking.New(),
 		}
 	}
@@ -65,6 +71,8 @@ func (t *Trie) Snapshot() (newTrie *Trie) {
 		generation: t.generation + 1,
 		root:       t.root,
 		childTries: childTries,
+		db:         t.db,
+		cache:      t.cache,
 		deltas:     tracking.New(),
 	}
 }
@@ -137,6 +145,8 @@ func (t *Trie) DeepCopy() (trieCopy *Trie) {
 
 	trieCopy = &Trie{
 		generation: t.generation,
+		db:         t.db,
+		cache:      t.cache,
 	}
 
 	if t.deltas != nil {
@@ -194,15 +204,23 @@ func (t *Trie) Hash() (rootHash common.Hash, err error) {
 
 // Entries returns all the key-value pairs in the trie as a map of keys to values
 // where the keys are encoded in Little Endian.
-func (t *Trie) Entries() (keyValueMap map[string][]byte) {
+// It returns an error if a node of a lazy trie cannot be loaded.
+func (t *Trie) Entries() (keyValueMap map[string][]byte, err error) {
 	keyValueMap = make(map[string][]byte)
-	entries(t.root, nil, keyValueMap)
-	return keyValueMap
+	err = t.entries(t.root, nil, keyValueMap)
+	if err != nil {
+		return nil, err
+	}
+	return keyValueMap, nil
 }
 
-func entries(parent *Node, prefix []byte, kv map[string][]byte) {
+func (t *Trie) entries(parent *Node, prefix []byte, kv map[string][]byte) (err error) {
 	if parent == nil {
-		return
+		return nil
+	}
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return err
 	}
 
 	if parent.Kind() == node.Leaf {
@@ -210,7 +228,7 @@ func entries(parent *Node, prefix []byte, kv map[string][]byte) {
 		fullKeyNibbles := concatenateSlices(prefix, parentKey)
 		keyLE := string(codec.NibblesToKeyLE(fullKeyNibbles))
 		kv[keyLE] = parent.StorageValue
-		return
+		return nil
 	}
 
 	branch := parent
@@ -222,34 +240,47 @@ func entries(parent *Node, prefix []byte, kv map[string][]byte) {
 
 	for i, child := range branch.Children {
 		childPrefix := concatenateSlices(prefix, branch.PartialKey, intToByteSlice(i))
-		entries(child, childPrefix, kv)
+		err = t.entries(child, childPrefix, kv)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return err
+		}
 	}
+
+	return nil
 }
 
 // NextKey returns the next key in the trie in lexicographic order.
-// It returns nil if no next key is found.
-func (t *Trie) NextKey(keyLE []byte) (nextKeyLE []byte) {
+// It returns nil if no next key is found, and an error if a node
+// of a lazy trie cannot be loaded.
+func (t *Trie) NextKey(keyLE []byte) (nextKeyLE []byte, err error) {
 	prefix := []byte(nil)
 	key := codec.KeyLEToNibbles(keyLE)
 
-	nextKey := findNextKey(t.root, prefix, key)
-	if nextKey == nil {
-		return nil
+	nextKey, err := t.findNextKey(t.root, prefix, key)
+	if err != nil {
+		return nil, err
+	} else if nextKey == nil {
+		return nil, nil
 	}
 
 	nextKeyLE = codec.NibblesToKeyLE(nextKey)
-	return nextKeyLE
+	return nextKeyLE, nil
 }
 
-func findNextKey(parent *Node, prefix, searchKey []byte) (nextKey []byte) {
+func (t *Trie) findNextKey(parent *Node, prefix, searchKey []byte) (nextKey []byte, err error) {
 	if parent == nil {
-		return nil
+		return nil, nil
+	}
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, err
 	}
 
 	if parent.Kind() == node.Leaf {
-		return findNextKeyLeaf(parent, prefix, searchKey)
+		return findNextKeyLeaf(parent, prefix, searchKey), nil
 	}
-	return findNextKeyBranch(parent, prefix, searchKey)
+	return t.findNextKeyBranch(parent, prefix, searchKey)
 }
 
 func findNextKeyLeaf(leaf *Node, prefix, searchKey []byte) (nextKey []byte) {
@@ -263,30 +294,30 @@ func findNextKeyLeaf(leaf *Node, prefix, searchKey []byte) (nextKey []byte) {
 	return fullKey
 }
 
-func findNextKeyBranch(parentBranch *Node, prefix, searchKey []byte) (nextKey []byte) {
+func (t *Trie) findNextKeyBranch(parentBranch *Node, prefix, searchKey []byte) (nextKey []byte, err error) {
 	fullKey := concatenateSlices(prefix, parentBranch.PartialKey)
 
 	if bytes.Equal(searchKey, fullKey) {
 		const startChildIndex = 0
-		return findNextKeyChild(parentBranch.Children, startChildIndex, fullKey, searchKey)
+		return t.findNextKeyChild(parentBranch.Children, startChildIndex, fullKey, searchKey)
 	}
 
 	if keyIsLexicographicallyBigger(searchKey, fullKey) {
 		if len(searchKey) < len(fullKey) {
-			return nil
+			return nil, nil
 		} else if len(searchKey) > len(fullKey) {
 			startChildIndex := searchKey[len(fullKey)]
-			return findNextKeyChild(parentBranch.Children,
+			return t.findNextKeyChild(parentBranch.Children,
 				startChildIndex, fullKey, searchKey)
 		}
 	}
 
 	// search key is smaller than full key
 	if parentBranch.StorageValue != nil {
-		return fullKey
+		return fullKey, nil
 	}
 	const startChildIndex = 0
-	return findNextKeyChild(parentBranch.Children, startChildIndex,
+	return t.findNextKeyChild(parentBranch.Children, startChildIndex,
 		fullKey, searchKey)
 }
 
@@ -299,8 +330,8 @@ func keyIsLexicographicallyBigger(key, key2 []byte) (bigger bool) {
 
 // findNextKeyChild searches for a next key in the children
 // given and returns a next key or nil if no next key is found.
-func findNextKeyChild(children []*Node, startIndex byte,
-	fullKey, key []byte) (nextKey []byte) {
+func (t *Trie) findNextKeyChild(children []*Node, startIndex byte,
+	fullKey, key []byte) (nextKey []byte, err error) {
 	for i := startIndex; i < node.ChildrenCapacity; i++ {
 		child := children[i]
 		if child == nil {
@@ -308,13 +339,16 @@ func findNextKeyChild(children []*Node, startIndex byte,
 		}
 
 		childFullKey := concatenateSlices(fullKey, []byte{i})
-		next := findNextKey(child, childFullKey, key)
-		if len(next) > 0 {
-			return next
+		next, err := t.findNextKey(child, childFullKey, key)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return nil, err
+		} else if len(next) > 0 {
+			return next, nil
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
 // Put inserts a value into the trie at the
@@ -360,6 +394,11 @@ func (t *Trie) insert(parent *Node, key, value []byte,
 		}, mutated, nodesCreated, nil
 	}
 
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, false, 0, fmt.Errorf("resolving node: %w", err)
+	}
+
 	if parent.Kind() == node.Branch {
 		newParent, mutated, nodesCreated, err = t.insertInBranch(
 			parent, key, value, pendingDeltas)
@@ -611,7 +650,8 @@ func LoadFromMap(data map[string]string) (trie Trie, err error) {
 // GetKeysWithPrefix returns all keys in little Endian
 // format from nodes in the trie that have the given little
 // Endian formatted prefix in their key.
-func (t *Trie) GetKeysWithPrefix(prefixLE []byte) (keysLE [][]byte) {
+// It returns an error if a node of a lazy trie cannot be loaded.
+func (t *Trie) GetKeysWithPrefix(prefixLE []byte) (keysLE [][]byte, err error) {
 	var prefixNibbles []byte
 	if len(prefixLE) > 0 {
 		prefixNibbles = codec.KeyLEToNibbles(prefixLE)
@@ -620,24 +660,28 @@ func (t *Trie) GetKeysWithPrefix(prefixLE []byte) (keysLE [][]byte) {
 
 	prefix := []byte(nil)
 	key := prefixNibbles
-	return getKeysWithPrefix(t.root, prefix, key, keysLE)
+	return t.getKeysWithPrefix(t.root, prefix, key, keysLE)
 }
 
 // getKeysWithPrefix returns all keys in little Endian format that have the
 // prefix given. The prefix and key byte slices are in nibbles format.
 // TODO pass in map of keysLE if order is not needed.
 // TODO do all processing on nibbles keys and then convert to LE.
-func getKeysWithPrefix(parent *Node, prefix, key []byte,
-	keysLE [][]byte) (newKeysLE [][]byte) {
+func (t *Trie) getKeysWithPrefix(parent *Node, prefix, key []byte,
+	keysLE [][]byte) (newKeysLE [][]byte, err error) {
 	if parent == nil {
-		return keysLE
+		return keysLE, nil
+	}
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, err
 	}
 
 	if parent.Kind() == node.Leaf {
-		return getKeysWithPrefixFromLeaf(parent, prefix, key, keysLE)
+		return getKeysWithPrefixFromLeaf(parent, prefix, key, keysLE), nil
 	}
 
-	return getKeysWithPrefixFromBranch(parent, prefix, key, keysLE)
+	return t.getKeysWithPrefixFromBranch(parent, prefix, key, keysLE)
 }
 
 func getKeysWithPrefixFromLeaf(parent *Node, prefix, key []byte,
@@ -649,17 +693,16 @@ func getKeysWithPrefixFromLeaf(parent *Node, prefix, key []byte,
 	return keysLE
 }
 
-func getKeysWithPrefixFromBranch(parent *Node, prefix, key []byte,
-	keysLE [][]byte) (newKeysLE [][]byte) {
+func (t *Trie) getKeysWithPrefixFromBranch(parent *Node, prefix, key []byte,
+	keysLE [][]byte) (newKeysLE [][]byte, err error) {
 	if len(key) == 0 || bytes.HasPrefix(parent.PartialKey, key) {
-		return addAllKeys(parent, prefix, keysLE)
+		return t.addAllKeys(parent, prefix, keysLE)
 	}
 
-	noPossiblePrefixedKeys :=
-		len(parent.PartialKey) > len(key) &&
-			!bytes.HasPrefix(parent.PartialKey, key)
+	noPossiblePrefixedKeys := len(parent.PartialKey) >= len(key) ||
+		!bytes.HasPrefix(key, parent.PartialKey)
 	if noPossiblePrefixedKeys {
-		return keysLE
+		return keysLE, nil
 	}
 
 	key = key[len(parent.PartialKey):]
@@ -667,22 +710,27 @@ func getKeysWithPrefixFromBranch(parent *Node, prefix, key []byte,
 	child := parent.Children[childIndex]
 	childPrefix := makeChildPrefix(prefix, parent.PartialKey, int(childIndex))
 	childKey := key[1:]
-	return getKeysWithPrefix(child, childPrefix, childKey, keysLE)
+	return t.getKeysWithPrefix(child, childPrefix, childKey, keysLE)
 }
 
 // addAllKeys appends all keys of descendant nodes of the parent node
 // to the slice of keys given and returns this slice.
 // It uses the prefix in nibbles format to determine the full key.
 // The slice of keys has its keys formatted in little Endian.
-func addAllKeys(parent *Node, prefix []byte, keysLE [][]byte) (newKeysLE [][]byte) {
+func (t *Trie) addAllKeys(parent *Node, prefix []byte, keysLE [][]byte) (
+	newKeysLE [][]byte, err error) {
 	if parent == nil {
-		return keysLE
+		return keysLE, nil
+	}
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, err
 	}
 
 	if parent.Kind() == node.Leaf {
 		keyLE := makeFullKeyLE(prefix, parent.PartialKey)
 		keysLE = append(keysLE, keyLE)
-		return keysLE
+		return keysLE, nil
 	}
 
 	if parent.StorageValue != nil {
@@ -692,10 +740,14 @@ func addAllKeys(parent *Node, prefix []byte, keysLE [][]byte) (newKeysLE [][]byt
 
 	for i, child := range parent.Children {
 		childPrefix := makeChildPrefix(prefix, parent.PartialKey, i)
-		keysLE = addAllKeys(child, childPrefix, keysLE)
+		keysLE, err = t.addAllKeys(child, childPrefix, keysLE)
+		if err != nil {
+			// Note: do not wrap error since this is recursive.
+			return nil, err
+		}
 	}
 
-	return keysLE
+	return keysLE, nil
 }
 
 func makeFullKeyLE(prefix, nodeKey []byte) (fullKeyLE []byte) {
@@ -713,20 +765,25 @@ func makeChildPrefix(branchPrefix, branchKey []byte,
 // Get returns the value in the node of the trie
 // which matches its key with the key given.
 // Note the key argument is given in little Endian format.
-func (t *Trie) Get(keyLE []byte) (value []byte) {
+// It returns an error if a node of a lazy trie cannot be loaded.
+func (t *Trie) Get(keyLE []byte) (value []byte, err error) {
 	keyNibbles := codec.KeyLEToNibbles(keyLE)
-	return retrieve(t.root, keyNibbles)
+	return t.retrieve(t.root, keyNibbles)
 }
 
-func retrieve(parent *Node, key []byte) (value []byte) {
+func (t *Trie) retrieve(parent *Node, key []byte) (value []byte, err error) {
 	if parent == nil {
-		return nil
+		return nil, nil
+	}
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, err
 	}
 
 	if parent.Kind() == node.Leaf {
-		return retrieveFromLeaf(parent, key)
+		return retrieveFromLeaf(parent, key), nil
 	}
-	return retrieveFromBranch(parent, key)
+	return t.retrieveFromBranch(parent, key)
 }
 
 func retrieveFromLeaf(leaf *Node, key []byte) (value []byte) {
@@ -736,20 +793,20 @@ func retrieveFromLeaf(leaf *Node, key []byte) (value []byte) {
 	return nil
 }
 
-func retrieveFromBranch(branch *Node, key []byte) (value []byte) {
+func (t *Trie) retrieveFromBranch(branch *Node, key []byte) (value []byte, err error) {
 	if len(key) == 0 || bytes.Equal(branch.PartialKey, key) {
-		return branch.StorageValue
+		return branch.StorageValue, nil
 	}
 
 	if len(branch.PartialKey) > len(key) && bytes.HasPrefix(branch.PartialKey, key) {
-		return nil
+		return nil, nil
 	}
 
 	commonPrefixLength := lenCommonPrefix(branch.PartialKey, key)
 	childIndex := key[commonPrefixLength]
 	childKey := key[commonPrefixLength+1:]
 	child := branch.Children[childIndex]
-	return retrieve(child, childKey)
+	return t.retrieve(child, childKey)
 }
 
 // ClearPrefixLimit deletes the keys having the prefix given in little
@@ -791,6 +848,11 @@ func (t *Trie) clearPrefixLimitAtNode(parent *Node, prefix []byte,
 		return nil, 0, 0, true, nil
 	}
 
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, 0, 0, false, fmt.Errorf("resolving node: %w", err)
+	}
+
 	if parent.Kind() == node.Leaf {
 		// if prefix is not found, it's also all deleted.
 		// TODO check this is the same behaviour as in substrate
@@ -861,7 +923,7 @@ func (t *Trie) clearPrefixLimitBranch(branch *Node, prefix []byte, limit uint32,
 	}
 
 	branch.Children[childIndex] = child
-	branch.Descendants -= nodesRemoved
+	branch.Descendants = countDescendants(branch)
 	newParent, branchChildMerged, err := t.handleDeletion(branch, prefix, pendingDeltas)
 	if err != nil {
 		return nil, 0, 0, false, fmt.Errorf("handling deletion: %w", err)
@@ -909,7 +971,7 @@ func (t *Trie) clearPrefixLimitChild(branch *Node, prefix []byte, limit uint32,
 	}
 
 	branch.Children[childIndex] = child
-	branch.Descendants -= nodesRemoved
+	branch.Descendants = countDescendants(branch)
 
 	newParent, branchChildMerged, err := t.handleDeletion(branch, prefix, pendingDeltas)
 	if err != nil {
@@ -937,6 +999,11 @@ func (t *Trie) deleteNodesLimit(parent *Node, limit uint32,
 		return nil, valuesDeleted, nodesRemoved, nil
 	}
 
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("resolving node: %w", err)
+	}
+
 	if parent.Kind() == node.Leaf {
 		err = t.registerDeletedNodeHash(parent, pendingDeltas)
 		if err != nil {
@@ -981,7 +1048,7 @@ func (t *Trie) deleteNodesLimit(parent *Node, limit uint32,
 		limit -= newDeleted
 		valuesDeleted += newDeleted
 		nodesRemoved += newNodesRemoved
-		branch.Descendants -= newNodesRemoved
+		branch.Descendants = countDescendants(branch)
 
 		newParent, branchChildMerged, err = t.handleDeletion(branch, branch.PartialKey, pendingDeltas)
 		if err != nil {
@@ -1020,6 +1087,11 @@ func (t *Trie) ClearPrefix(prefixLE []byte) (err error) {
 	}()
 
 	if len(prefixLE) == 0 {
+		t.root, err = t.resolveAll(t.root)
+		if err != nil {
+			return fmt.Errorf("loading all nodes: %w", err)
+		}
+
 		err = t.ensureMerkleValueIsCalculated(t.root)
 		if err != nil {
 			return fmt.Errorf("ensuring Merkle values are calculated: %w", err)
@@ -1050,7 +1122,17 @@ func (t *Trie) clearPrefixAtNode(parent *Node, prefix []byte,
 		return nil, nodesRemoved, nil
 	}
 
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, 0, fmt.Errorf("resolving node: %w", err)
+	}
+
 	if bytes.HasPrefix(parent.PartialKey, prefix) {
+		parent, err = t.resolveAll(parent)
+		if err != nil {
+			return nil, 0, fmt.Errorf("loading all nodes: %w", err)
+		}
+
 		err = t.ensureMerkleValueIsCalculated(parent)
 		if err != nil {
 			nodesRemoved = 0
@@ -1092,7 +1174,7 @@ func (t *Trie) clearPrefixAtNode(parent *Node, prefix []byte,
 		}
 
 		branch.Children[childIndex] = nil
-		branch.Descendants -= nodesRemoved
+		branch.Descendants = countDescendants(branch)
 		var branchChildMerged bool
 		newParent, branchChildMerged, err = t.handleDeletion(branch, prefix, pendingDeltas)
 		if err != nil {
@@ -1131,8 +1213,8 @@ func (t *Trie) clearPrefixAtNode(parent *Node, prefix []byte,
 		return nil, 0, fmt.Errorf("preparing branch for mutation: %w", err)
 	}
 
-	branch.Descendants -= nodesRemoved
 	branch.Children[childIndex] = child
+	branch.Descendants = countDescendants(branch)
 	newParent, branchChildMerged, err := t.handleDeletion(branch, prefix, pendingDeltas)
 	if err != nil {
 		return nil, 0, fmt.Errorf("handling deletion: %w", err)
@@ -1172,6 +1254,11 @@ func (t *Trie) deleteAtNode(parent *Node, key []byte,
 		return nil, false, nodesRemoved, nil
 	}
 
+	parent, err = t.resolve(parent)
+	if err != nil {
+		return nil, false, 0, fmt.Errorf("resolving node: %w", err)
+	}
+
 	if parent.Kind() == node.Leaf {
 		newParent, err = t.deleteLeaf(parent, key, pendingDeltas)
 		if err != nil {
@@ -1265,8 +1352,8 @@ func (t *Trie) deleteBranch(branch *Node, key []byte,
 		return nil, false, 0, fmt.Errorf("preparing branch for mutation: %w", err)
 	}
 
-	branch.Descendants -= nodesRemoved
 	branch.Children[childIndex] = newChild
+	branch.Descendants = countDescendants(branch)
 
 	newParent, branchChildMerged, err := t.handleDeletion(branch, key, pendingDeltas)
 	if err != nil {
@@ -1323,7 +1410,12 @@ func (t *Trie) handleDeletion(branch *Node, key []byte,
 		// pending deltas.
 		const branchChildMerged = true
 		childIndex := firstChildIndex
-		child := branch.Children[firstChildIndex]
+		var child *Node
+		child, err = t.resolve(branch.Children[firstChildIndex])
+		if err != nil {
+			return nil, false, fmt.Errorf("resolving child: %w", err)
+		}
+
 		err = t.registerDeletedNodeHash(child, pendingDeltas)
 		if err != nil {
 			return nil, false, fmt.Errorf("registering deleted node hash: %w", err)
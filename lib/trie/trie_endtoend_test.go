@@ -52,12 +52,14 @@ func runTests(t *testing.T, trie *Trie, tests []keyValues) {
 		case put:
 			trie.Put(test.key, test.value)
 		case get:
-			val := trie.Get(test.key)
+			val, err := trie.Get(test.key)
+			require.NoError(t, err)
 			assert.Equal(t, test.value, val)
 		case del:
 			trie.Delete(test.key)
 		case getLeaf:
-			value := trie.Get(test.key)
+			value, err := trie.Get(test.key)
+			require.NoError(t, err)
 			assert.Equal(t, test.value, value)
 		}
 	}
@@ -105,7 +107,8 @@ func Fuzz_Trie_PutAndGet_Single(f *testing.F) {
 	f.Fuzz(func(t *testing.T, key, value []byte) {
 		trie := NewEmptyTrie()
 		trie.Put(key, value)
-		retrievedValue := trie.Get(key)
+		retrievedValue, err := trie.Get(key)
+		require.NoError(t, err)
 		assert.Equal(t, value, retrievedValue)
 	})
 }
@@ -122,7 +125,8 @@ func Test_Trie_PutAndGet_Multiple(t *testing.T) {
 		trie.Put(key, value)
 
 		// Check value is inserted correctly.
-		retrievedValue := trie.Get(key)
+		retrievedValue, err := trie.Get(key)
+		require.NoError(t, err)
 		require.Equalf(t, retrievedValue, value,
 			"for key (nibbles) 0x%x", codec.KeyLEToNibbles(key))
 	}
@@ -130,7 +134,8 @@ func Test_Trie_PutAndGet_Multiple(t *testing.T) {
 	// Check values were not mismoved in the trie.
 	for keyString, value := range keyValues {
 		key := []byte(keyString)
-		retrievedValue := trie.Get(key)
+		retrievedValue, err := trie.Get(key)
+		require.NoError(t, err)
 		require.Equalf(t, retrievedValue, value,
 			"for key (nibbles) 0x%x", codec.KeyLEToNibbles(key))
 	}
@@ -376,10 +381,12 @@ func TestDelete(t *testing.T) {
 		switch generator.Int31n(2) {
 		case 0:
 			ssTrie.Delete(key)
-			retrievedValue := ssTrie.Get(key)
+			retrievedValue, err := ssTrie.Get(key)
+			require.NoError(t, err)
 			assert.Nil(t, retrievedValue, "for key %x", key)
 		case 1:
-			retrievedValue := ssTrie.Get(key)
+			retrievedValue, err := ssTrie.Get(key)
+			require.NoError(t, err)
 			assert.Equal(t, value, retrievedValue, "for key %x", key)
 		}
 	}
@@ -461,7 +468,8 @@ func TestClearPrefix(t *testing.T) {
 		}
 
 		for _, test := range tests {
-			res := ssTrie.Get(test.key)
+			res, err := ssTrie.Get(test.key)
+			require.NoError(t, err)
 
 			keyNibbles := codec.KeyLEToNibbles(test.key)
 			length := lenCommonPrefix(keyNibbles, prefixNibbles)
@@ -602,7 +610,8 @@ func TestTrie_ClearPrefixVsDelete(t *testing.T) {
 				trieClearPrefix.Put(test.key, test.value)
 			}
 
-			prefixedKeys := trieDelete.GetKeysWithPrefix(prefix)
+			prefixedKeys, err := trieDelete.GetKeysWithPrefix(prefix)
+			require.NoError(t, err)
 			for _, key := range prefixedKeys {
 				trieDelete.Delete(key)
 			}
@@ -672,7 +681,8 @@ func Test_Trie_NextKey_Random(t *testing.T) {
 
 	for i, key := range sortedKeys {
 
-		nextKey := trie.NextKey(key)
+		nextKey, err := trie.NextKey(key)
+		require.NoError(t, err)
 
 		var expectedNextKey []byte
 		isLastKey := i == len(sortedKeys)-1
@@ -853,7 +863,8 @@ func TestTrie_ClearPrefixLimit(t *testing.T) {
 			isAllDeleted := true
 
 			for _, test := range testCase {
-				val := trieClearPrefix.Get(test.key)
+				val, err := trieClearPrefix.Get(test.key)
+				require.NoError(t, err)
 
 				keyNibbles := codec.KeyLEToNibbles(test.key)
 				length := lenCommonPrefix(keyNibbles, prefixNibbles)
@@ -978,7 +989,8 @@ func TestTrie_ClearPrefixLimitSnapshot(t *testing.T) {
 				isAllDeleted := true
 
 				for _, test := range testCase {
-					val := ssTrie.Get(test.key)
+					val, err := ssTrie.Get(test.key)
+					require.NoError(t, err)
 
 					keyNibbles := codec.KeyLEToNibbles(test.key)
 					length := lenCommonPrefix(keyNibbles, prefixNibbles)
@@ -1038,7 +1050,8 @@ func Test_encodeRoot_fuzz(t *testing.T) {
 			key := []byte(keyString)
 			trie.Put(key, value)
 
-			retrievedValue := trie.Get(key)
+			retrievedValue, err := trie.Get(key)
+			require.NoError(t, err)
 			assert.Equal(t, value, retrievedValue)
 		}
 		buffer := bytes.NewBuffer(nil)
@@ -621,7 +621,8 @@ func Test_Trie_Entries(t *testing.T) {
 
 		trie := NewTrie(root)
 
-		entries := trie.Entries()
+		entries, err := trie.Entries()
+		require.NoError(t, err)
 
 		expectedEntries := map[string][]byte{
 			string([]byte{0x0a}):       []byte("root"),
@@ -675,7 +676,8 @@ func Test_Trie_Entries(t *testing.T) {
 
 		trie := NewTrie(root)
 
-		entries := trie.Entries()
+		entries, err := trie.Entries()
+		require.NoError(t, err)
 
 		expectedEntries := map[string][]byte{
 			string([]byte{0xab}):             []byte("root"),
@@ -708,7 +710,8 @@ func Test_Trie_Entries(t *testing.T) {
 			trie.Put([]byte(k), v)
 		}
 
-		entries := trie.Entries()
+		entries, err := trie.Entries()
+		require.NoError(t, err)
 
 		assert.Equal(t, kv, entries)
 	})
@@ -752,7 +755,8 @@ func Test_Trie_NextKey(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			nextKey := testCase.trie.NextKey(testCase.key)
+			nextKey, err := testCase.trie.NextKey(testCase.key)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.nextKey, nextKey)
 		})
@@ -1038,7 +1042,8 @@ func Test_nextKey(t *testing.T) {
 
 			originalTrie := testCase.trie.DeepCopy()
 
-			nextKey := findNextKey(testCase.trie.root, nil, testCase.key)
+			nextKey, err := testCase.trie.findNextKey(testCase.trie.root, nil, testCase.key)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.nextKey, nextKey)
 			assert.Equal(t, *originalTrie, testCase.trie) // ensure no mutation
@@ -1794,7 +1799,8 @@ func Test_Trie_GetKeysWithPrefix(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			keys := testCase.trie.GetKeysWithPrefix(testCase.prefix)
+			keys, err := testCase.trie.GetKeysWithPrefix(testCase.prefix)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.keys, keys)
 		})
@@ -1860,6 +1866,32 @@ func Test_getKeysWithPrefix(t *testing.T) {
 			keys:         [][]byte{{1}, {2}},
 			expectedKeys: [][]byte{{1}, {2}},
 		},
+		"search_key_same_length_as_branch_key_with_no_full_common_prefix": {
+			parent: &Node{
+				PartialKey:  []byte{1, 2},
+				Descendants: 2,
+				Children: padRightChildren([]*Node{
+					{PartialKey: []byte{4}, StorageValue: []byte{1}},
+					{PartialKey: []byte{5}, StorageValue: []byte{1}},
+				}),
+			},
+			key:          []byte{1, 3},
+			keys:         [][]byte{{1}, {2}},
+			expectedKeys: [][]byte{{1}, {2}},
+		},
+		"search_key_longer_than_branch_key_with_no_full_common_prefix": {
+			parent: &Node{
+				PartialKey:  []byte{1, 2},
+				Descendants: 2,
+				Children: padRightChildren([]*Node{
+					{PartialKey: []byte{4}, StorageValue: []byte{1}},
+					{PartialKey: []byte{5}, StorageValue: []byte{1}},
+				}),
+			},
+			key:          []byte{1, 3, 0},
+			keys:         [][]byte{{1}, {2}},
+			expectedKeys: [][]byte{{1}, {2}},
+		},
 		"common_prefix_smaller_tan_search_key": {
 			parent: &Node{
 				PartialKey:  []byte{1, 2},
@@ -1948,8 +1980,10 @@ func Test_getKeysWithPrefix(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			keys := getKeysWithPrefix(testCase.parent,
+			trie := NewEmptyTrie()
+			keys, err := trie.getKeysWithPrefix(testCase.parent,
 				testCase.prefix, testCase.key, testCase.keys)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.expectedKeys, keys)
 		})
@@ -2018,8 +2052,10 @@ func Test_addAllKeys(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			keys := addAllKeys(testCase.parent,
+			trie := NewEmptyTrie()
+			keys, err := trie.addAllKeys(testCase.parent,
 				testCase.prefix, testCase.keys)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.expectedKeys, keys)
 		})
@@ -2066,7 +2102,8 @@ func Test_Trie_Get(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			value := testCase.trie.Get(testCase.key)
+			value, err := testCase.trie.Get(testCase.key)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.value, value)
 		})
@@ -2171,7 +2208,9 @@ func Test_retrieve(t *testing.T) {
 				expectedParent = testCase.parent.Copy(copySettings)
 			}
 
-			value := retrieve(testCase.parent, testCase.key)
+			trie := NewEmptyTrie()
+			value, err := trie.retrieve(testCase.parent, testCase.key)
+			require.NoError(t, err)
 
 			assert.Equal(t, testCase.value, value)
 			assert.Equal(t, expectedParent, testCase.parent)
@@ -14,7 +14,7 @@
 	"strings"
 	"testing"
 
-	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
 )
@@ -22,12 +22,17 @@
 // DefaultDatabaseDir directory inside basepath where database contents are stored
 const DefaultDatabaseDir = "db"
 
-// SetupDatabase will return an instance of database based on basepath
-func SetupDatabase(basepath string, inMemory bool) (*chaindb.BadgerDB, error) {
-	return chaindb.NewBadgerDB(&chaindb.Config{
-		DataDir:  filepath.Join(basepath, DefaultDatabaseDir),
-		InMemory: inMemory,
-	})
+// SetupDatabase will return an instance of database based on basepath,
+// opened with the backend the database was created with.
+func SetupDatabase(basepath string, inMemory bool) (database.Database, error) {
+	return database.Load(filepath.Join(basepath, DefaultDatabaseDir), inMemory)
+}
+
+// SetupDatabaseWithBackend will return an instance of database based on
+// basepath using the given backend.
+func SetupDatabaseWithBackend(basepath string, backend database.Backend,
+	inMemory bool) (database.Database, error) {
+	return database.New(backend, filepath.Join(basepath, DefaultDatabaseDir), inMemory)
 }
 
 // PathExists returns true if the named file or directory exists, otherwise false
@@ -254,14 +259,10 @@ func GetProjectRootPath() (rootPath string, err error) {
 	return rootPath, nil
 }
 
-// LoadChainDB load the db at the given path.
-func LoadChainDB(basePath string) (*chaindb.BadgerDB, error) {
-	cfg := &chaindb.Config{
-		DataDir: basePath,
-	}
-
+// LoadChainDB load the badger db at the given path.
+func LoadChainDB(basePath string) (database.Database, error) {
 	// Open already existing DB
-	db, err := chaindb.NewBadgerDB(cfg)
+	db, err := database.New(database.Badger, basePath, false)
 	if err != nil {
 		return nil, err
 	}